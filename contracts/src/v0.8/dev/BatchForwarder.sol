@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.6;
+
+import "../ConfirmedOwner.sol";
+import "./AuthorizedReceiver.sol";
+
+/**
+ * @title BatchForwarder
+ * @notice Forwards batches of calls on behalf of authorized senders, so that a
+ * node can coalesce several of its transactions into a single one.
+ * @dev A failed call does not revert the batch. The outcome of each call is
+ * reported by a CallForwarded event, emitted right after the logs of the call.
+ * Each call is limited to its own gas limit, so that a call running out of gas
+ * can't use up the gas of the calls after it.
+ */
+contract BatchForwarder is ConfirmedOwner, AuthorizedReceiver {
+  event CallForwarded(uint256 indexed index, bool success);
+
+  error LengthMismatch();
+  error InsufficientGas(uint256 index);
+
+  // Gas spent by a call besides the gas forwarded to its target
+  uint256 private constant CALL_GAS_OVERHEAD = 5_000;
+
+  constructor(address owner) ConfirmedOwner(owner) {}
+
+  /**
+   * @notice The type and version of this contract
+   * @return Type and version string
+   */
+  function typeAndVersion() external pure virtual returns (string memory) {
+    return "BatchForwarder 1.0.0";
+  }
+
+  /**
+   * @notice Forward a batch of calls, in order
+   * @dev Only callable by an authorized sender. Calls to addresses without
+   * code are reported as failed. The batch reverts if there is not enough gas
+   * left to give a call its whole gas limit.
+   * @param targets addresses to call
+   * @param data to forward to each target
+   * @param gasLimits of each call
+   */
+  function forwardBatch(
+    address[] calldata targets,
+    bytes[] calldata data,
+    uint256[] calldata gasLimits
+  ) external validateAuthorizedSender {
+    if (targets.length != data.length || targets.length != gasLimits.length) {
+      revert LengthMismatch();
+    }
+    for (uint256 i = 0; i < targets.length; i++) {
+      bool success = false;
+      if (targets[i].code.length > 0) {
+        // At most 63/64 of the remaining gas can be forwarded (EIP-150)
+        uint256 gasLeft = gasleft();
+        if (gasLeft < CALL_GAS_OVERHEAD || ((gasLeft - CALL_GAS_OVERHEAD) * 63) / 64 < gasLimits[i]) {
+          revert InsufficientGas(i);
+        }
+        (success, ) = targets[i].call{gas: gasLimits[i]}(data[i]);
+      }
+      emit CallForwarded(i, success);
+    }
+  }
+
+  /**
+   * @notice concrete implementation of AuthorizedReceiver
+   * @return bool of whether sender is authorized
+   */
+  function _canSetAuthorizedSenders() internal view override returns (bool) {
+    return owner() == msg.sender;
+  }
+}
@@ -0,0 +1,119 @@
+import { ethers } from 'hardhat'
+import { expect } from 'chai'
+import { Contract, Signer } from 'ethers'
+
+describe('BatchForwarder', () => {
+  let forwarder: Contract
+  let counter: Contract
+  let owner: Signer
+  let sender: Signer
+  let stranger: Signer
+
+  beforeEach(async () => {
+    const accounts = await ethers.getSigners()
+    owner = accounts[0]
+    sender = accounts[1]
+    stranger = accounts[2]
+
+    const forwarderFactory = await ethers.getContractFactory(
+      'src/v0.8/dev/BatchForwarder.sol:BatchForwarder',
+      owner,
+    )
+    forwarder = await forwarderFactory.deploy(await owner.getAddress())
+    await forwarder.setAuthorizedSenders([await sender.getAddress()])
+
+    const counterFactory = await ethers.getContractFactory(
+      'src/v0.8/tests/Counter.sol:Counter',
+      owner,
+    )
+    counter = await counterFactory.deploy()
+  })
+
+  it('#typeAndVersion', async () => {
+    expect(await forwarder.typeAndVersion()).to.equal('BatchForwarder 1.0.0')
+  })
+
+  describe('#forwardBatch', () => {
+    it('forwards every call and reports its outcome', async () => {
+      const increment = counter.interface.encodeFunctionData('increment')
+      const revert = counter.interface.encodeFunctionData('alwaysRevert')
+
+      await expect(
+        forwarder
+          .connect(sender)
+          .forwardBatch(
+            [counter.address, counter.address, counter.address],
+            [increment, revert, increment],
+            [100_000, 100_000, 100_000],
+          ),
+      )
+        .to.emit(forwarder, 'CallForwarded')
+        .withArgs(0, true)
+        .and.to.emit(forwarder, 'CallForwarded')
+        .withArgs(1, false)
+        .and.to.emit(forwarder, 'CallForwarded')
+        .withArgs(2, true)
+
+      expect(await counter.count()).to.equal(2)
+    })
+
+    it('reports calls to addresses without code as failed', async () => {
+      await expect(
+        forwarder
+          .connect(sender)
+          .forwardBatch([await stranger.getAddress()], ['0x'], [100_000]),
+      )
+        .to.emit(forwarder, 'CallForwarded')
+        .withArgs(0, false)
+    })
+
+    it('limits each call to its gas limit', async () => {
+      const increment = counter.interface.encodeFunctionData('increment')
+
+      await expect(
+        forwarder
+          .connect(sender)
+          .forwardBatch(
+            [counter.address, counter.address],
+            [increment, increment],
+            [1_000, 100_000],
+          ),
+      )
+        .to.emit(forwarder, 'CallForwarded')
+        .withArgs(0, false)
+        .and.to.emit(forwarder, 'CallForwarded')
+        .withArgs(1, true)
+
+      expect(await counter.count()).to.equal(1)
+    })
+
+    it('reverts if there is not enough gas left for a call', async () => {
+      const increment = counter.interface.encodeFunctionData('increment')
+
+      await expect(
+        forwarder
+          .connect(sender)
+          .forwardBatch([counter.address], [increment], [1_000_000], {
+            gasLimit: 500_000,
+          }),
+      ).to.be.revertedWith('InsufficientGas(0)')
+    })
+
+    it('reverts if the arguments have different lengths', async () => {
+      await expect(
+        forwarder.connect(sender).forwardBatch([counter.address], [], []),
+      ).to.be.revertedWith('LengthMismatch')
+      await expect(
+        forwarder
+          .connect(sender)
+          .forwardBatch([counter.address], ['0x'], []),
+      ).to.be.revertedWith('LengthMismatch')
+    })
+
+    it('reverts if the sender is not authorized', async () => {
+      await expect(
+        forwarder.connect(stranger).forwardBatch([], [], []),
+      ).to.be.revertedWith('UnauthorizedSender')
+    })
+  })
+})
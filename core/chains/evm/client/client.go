@@ -268,10 +268,20 @@ func (client *client) HeadByHash(ctx context.Context, hash common.Hash) (head *e
 	return
 }
 
+// ToBlockNumArg returns the block argument of a JSON-RPC call for number:
+// latest if it is nil, the tag of the pending, finalized and safe
+// rpc.BlockNumber values, or its hex encoding
 func ToBlockNumArg(number *big.Int) string {
 	if number == nil {
 		return "latest"
 	}
+	if number.Sign() < 0 && number.IsInt64() {
+		switch bn := rpc.BlockNumber(number.Int64()); bn {
+		case rpc.PendingBlockNumber, rpc.FinalizedBlockNumber, rpc.SafeBlockNumber:
+			tag, _ := bn.MarshalText()
+			return string(tag)
+		}
+	}
 	return hexutil.EncodeBig(number)
 }
 
@@ -222,9 +222,10 @@ func (c *SimulatedBackendClient) blockNumber(number interface{}) (blockNumber *b
 	panic("can never reach here")
 }
 
-// HeadByNumber returns our own header type.
+// HeadByNumber returns our own header type. Block tags such as finalized
+// return the latest block, since the simulated chain is final immediately.
 func (c *SimulatedBackendClient) HeadByNumber(ctx context.Context, n *big.Int) (*evmtypes.Head, error) {
-	if n == nil {
+	if n == nil || n.Sign() < 0 {
 		n = c.currentBlockNumber()
 	}
 	header, err := c.b.HeaderByNumber(ctx, n)
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"math/big"
+	"net/url"
 	"os"
 	"sync"
 	"time"
@@ -38,11 +39,16 @@ type ChainScopedOnlyConfig interface {
 	BlockHistoryEstimatorEIP1559FeeCapBufferBlocks() uint16
 	BlockHistoryEstimatorTransactionPercentile() uint16
 	ChainID() *big.Int
+	CompositeEstimatorBandPercent() uint16
+	CompositeEstimatorHistorySize() uint16
+	CompositeEstimatorModes() []string
+	CompositeEstimatorPolicy() string
 	EvmEIP1559DynamicFees() bool
 	EthTxReaperInterval() time.Duration
 	EthTxReaperThreshold() time.Duration
 	EthTxResendAfterThreshold() time.Duration
 	EvmFinalityDepth() uint32
+	EvmFinalityTagEnabled() bool
 	EvmGasBumpPercent() uint16
 	EvmGasBumpThreshold() uint64
 	EvmGasBumpTxDepth() uint16
@@ -63,20 +69,36 @@ type ChainScopedOnlyConfig interface {
 	EvmHeadTrackerHistoryDepth() uint32
 	EvmHeadTrackerMaxBufferSize() uint32
 	EvmHeadTrackerSamplingInterval() time.Duration
+	EvmJobSpendLimitDaily() *assets.Wei
+	EvmJobSpendLimitHourly() *assets.Wei
 	EvmLogBackfillBatchSize() uint32
 	EvmLogKeepBlocksDepth() uint32
 	EvmLogPollInterval() time.Duration
 	EvmMaxGasPriceWei() *assets.Wei
 	EvmMaxInFlightTransactions() uint32
 	EvmMaxQueuedTransactions() uint64
+	EvmMaxQueuedTransactionsPriority(priority string) uint64
 	EvmMinGasPriceWei() *assets.Wei
 	EvmNonceAutoSync() bool
+	EvmNonceGapsEnabled() bool
+	EvmNonceGapsFill() bool
+	EvmNonceGapsResync() bool
+	EvmPrivateRelayAllTransactions() bool
+	EvmPrivateRelayFallbackBlocks() uint32
+	EvmPrivateRelayMethod() string
+	EvmPrivateRelayURL() *url.URL
+	EvmSimulateBeforeBroadcast() bool
 	EvmUseForwarders() bool
 	EvmRPCDefaultBatchSize() uint32
+	FeeHistoryEstimatorBlockCount() uint16
+	FeeHistoryEstimatorRewardPercentile() uint16
 	FlagsContractAddress() string
 	GasEstimatorMode() string
 	ChainType() config.ChainType
 	KeySpecificMaxGasPriceWei(addr gethcommon.Address) *assets.Wei
+	KeySpecificSignerURL(addr gethcommon.Address) *url.URL
+	KeySpecificSpendLimitDaily(addr gethcommon.Address) *assets.Wei
+	KeySpecificSpendLimitHourly(addr gethcommon.Address) *assets.Wei
 	LinkContractAddress() string
 	OperatorFactoryAddress() string
 	MinIncomingConfirmations() uint32
@@ -379,6 +401,108 @@ func (c *chainScopedConfig) EvmMaxQueuedTransactions() uint64 {
 	return c.defaultSet.maxQueuedTransactions
 }
 
+// EvmMaxQueuedTransactionsPriority always returns 0: the queues of priority
+// lanes can only be capped with TOML
+func (c *chainScopedConfig) EvmMaxQueuedTransactionsPriority(priority string) uint64 {
+	return 0
+}
+
+// EvmPrivateRelayURL always returns nil: private relays can only be
+// configured with TOML
+func (c *chainScopedConfig) EvmPrivateRelayURL() *url.URL {
+	return nil
+}
+
+// EvmPrivateRelayMethod always returns eth_sendPrivateTransaction: private
+// relays can only be configured with TOML
+func (c *chainScopedConfig) EvmPrivateRelayMethod() string {
+	return "eth_sendPrivateTransaction"
+}
+
+// EvmPrivateRelayFallbackBlocks always returns 25: private relays can only be
+// configured with TOML
+func (c *chainScopedConfig) EvmPrivateRelayFallbackBlocks() uint32 {
+	return 25
+}
+
+// EvmPrivateRelayAllTransactions always returns false: private relays can
+// only be configured with TOML
+func (c *chainScopedConfig) EvmPrivateRelayAllTransactions() bool {
+	return false
+}
+
+// EvmJobSpendLimitHourly always returns 0: spend limits can only be configured
+// with TOML
+func (c *chainScopedConfig) EvmJobSpendLimitHourly() *assets.Wei {
+	return assets.NewWeiI(0)
+}
+
+// EvmJobSpendLimitDaily always returns 0: spend limits can only be configured
+// with TOML
+func (c *chainScopedConfig) EvmJobSpendLimitDaily() *assets.Wei {
+	return assets.NewWeiI(0)
+}
+
+// CompositeEstimatorModes always returns nil: the composite estimator can
+// only be configured with TOML
+func (c *chainScopedConfig) CompositeEstimatorModes() []string {
+	return nil
+}
+
+// CompositeEstimatorPolicy always returns Median: the composite estimator can
+// only be configured with TOML
+func (c *chainScopedConfig) CompositeEstimatorPolicy() string {
+	return "Median"
+}
+
+// CompositeEstimatorBandPercent always returns 0: the composite estimator can
+// only be configured with TOML
+func (c *chainScopedConfig) CompositeEstimatorBandPercent() uint16 {
+	return 0
+}
+
+// CompositeEstimatorHistorySize always returns 10: the composite estimator
+// can only be configured with TOML
+func (c *chainScopedConfig) CompositeEstimatorHistorySize() uint16 {
+	return 10
+}
+
+// FeeHistoryEstimatorBlockCount always returns 20: the fee history estimator
+// can only be configured with TOML
+func (c *chainScopedConfig) FeeHistoryEstimatorBlockCount() uint16 {
+	return 20
+}
+
+// FeeHistoryEstimatorRewardPercentile always returns 60: the fee history
+// estimator can only be configured with TOML
+func (c *chainScopedConfig) FeeHistoryEstimatorRewardPercentile() uint16 {
+	return 60
+}
+
+// EvmNonceGapsEnabled always returns false: nonce gap detection can only be
+// enabled with TOML
+func (c *chainScopedConfig) EvmNonceGapsEnabled() bool {
+	return false
+}
+
+// EvmNonceGapsFill always returns false: nonce gap detection can only be
+// enabled with TOML
+func (c *chainScopedConfig) EvmNonceGapsFill() bool {
+	return false
+}
+
+// EvmNonceGapsResync always returns false: nonce gap detection can only be
+// enabled with TOML
+func (c *chainScopedConfig) EvmNonceGapsResync() bool {
+	return false
+}
+
+// EvmSimulateBeforeBroadcast always returns false: simulating transactions
+// before broadcast can only be enabled with TOML
+func (c *chainScopedConfig) EvmSimulateBeforeBroadcast() bool {
+	return false
+}
+
 // EvmMinGasPriceWei is the minimum amount in Wei that a transaction may be priced.
 // Chainlink will never send a transaction priced below this amount.
 func (c *chainScopedConfig) EvmMinGasPriceWei() *assets.Wei {
@@ -585,6 +709,12 @@ func (c *chainScopedConfig) EvmFinalityDepth() uint32 {
 	return c.defaultSet.finalityDepth
 }
 
+// EvmFinalityTagEnabled always returns false: using the finalized block tag as
+// the finality source can only be enabled with TOML
+func (c *chainScopedConfig) EvmFinalityTagEnabled() bool {
+	return false
+}
+
 // EvmHeadTrackerHistoryDepth tracks the top N block numbers to keep in the `heads` database table.
 // Note that this can easily result in MORE than N records since in the case of re-orgs we keep multiple heads for a particular block height.
 // This number should be at least as large as `EvmFinalityDepth`.
@@ -827,6 +957,24 @@ func (c *chainScopedConfig) KeySpecificMaxGasPriceWei(addr gethcommon.Address) *
 	return c.EvmMaxGasPriceWei()
 }
 
+// KeySpecificSignerURL always returns nil: remote signers can only be
+// configured with TOML
+func (c *chainScopedConfig) KeySpecificSignerURL(addr gethcommon.Address) *url.URL {
+	return nil
+}
+
+// KeySpecificSpendLimitHourly always returns 0: spend limits can only be
+// configured with TOML
+func (c *chainScopedConfig) KeySpecificSpendLimitHourly(addr gethcommon.Address) *assets.Wei {
+	return assets.NewWeiI(0)
+}
+
+// KeySpecificSpendLimitDaily always returns 0: spend limits can only be
+// configured with TOML
+func (c *chainScopedConfig) KeySpecificSpendLimitDaily(addr gethcommon.Address) *assets.Wei {
+	return assets.NewWeiI(0)
+}
+
 func (c *chainScopedConfig) ChainType() config.ChainType {
 	val, ok := c.GeneralConfig.GlobalChainType()
 	if ok {
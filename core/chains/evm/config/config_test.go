@@ -178,6 +178,38 @@ func TestChainScopedConfig(t *testing.T) {
 		})
 	})
 
+	t.Run("KeySpecificSpendLimit", func(t *testing.T) {
+		addr := testutils.NewAddress()
+		unsetAddr := testutils.NewAddress()
+		gcfg3 := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+			c.EVM[0].Transactions.SpendLimits.KeyHourly = assets.GWei(10)
+			c.EVM[0].Transactions.SpendLimits.KeyDaily = assets.GWei(100)
+			c.EVM[0].KeySpecific = v2.KeySpecificConfig{
+				{Key: ptr(ethkey.EIP55AddressFromAddress(addr)),
+					SpendLimits: v2.KeySpecificSpendLimits{
+						Daily: assets.GWei(50),
+					},
+				},
+			}
+		})
+		cfg3 := evmtest.NewChainScopedConfig(t, gcfg3)
+
+		t.Run("uses key-specific override value when set", func(t *testing.T) {
+			assert.Equal(t, assets.GWei(50).String(), cfg3.KeySpecificSpendLimitDaily(addr).String())
+		})
+		t.Run("uses chain-specific value when there is no key-specific limit", func(t *testing.T) {
+			assert.Equal(t, assets.GWei(10).String(), cfg3.KeySpecificSpendLimitHourly(addr).String())
+			assert.Equal(t, assets.GWei(10).String(), cfg3.KeySpecificSpendLimitHourly(unsetAddr).String())
+			assert.Equal(t, assets.GWei(100).String(), cfg3.KeySpecificSpendLimitDaily(unsetAddr).String())
+		})
+		t.Run("is disabled by default", func(t *testing.T) {
+			assert.True(t, cfg.KeySpecificSpendLimitHourly(addr).IsZero())
+			assert.True(t, cfg.KeySpecificSpendLimitDaily(addr).IsZero())
+			assert.True(t, cfg.EvmJobSpendLimitHourly().IsZero())
+			assert.True(t, cfg.EvmJobSpendLimitDaily().IsZero())
+		})
+	})
+
 	t.Run("LinkContractAddress", func(t *testing.T) {
 		t.Run("uses chain-specific default value when nothing is set", func(t *testing.T) {
 			assert.Equal(t, "", cfg.LinkContractAddress())
@@ -616,6 +616,64 @@ func (_m *ChainScopedConfig) ChainType() coreconfig.ChainType {
 	return r0
 }
 
+// CompositeEstimatorBandPercent provides a mock function with given fields:
+func (_m *ChainScopedConfig) CompositeEstimatorBandPercent() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
+// CompositeEstimatorHistorySize provides a mock function with given fields:
+func (_m *ChainScopedConfig) CompositeEstimatorHistorySize() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
+// CompositeEstimatorModes provides a mock function with given fields:
+func (_m *ChainScopedConfig) CompositeEstimatorModes() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// CompositeEstimatorPolicy provides a mock function with given fields:
+func (_m *ChainScopedConfig) CompositeEstimatorPolicy() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // Configure provides a mock function with given fields: _a0
 func (_m *ChainScopedConfig) Configure(_a0 types.ChainCfg) {
 	_m.Called(_a0)
@@ -1021,6 +1079,20 @@ func (_m *ChainScopedConfig) EvmFinalityDepth() uint32 {
 	return r0
 }
 
+// EvmFinalityTagEnabled provides a mock function with given fields:
+func (_m *ChainScopedConfig) EvmFinalityTagEnabled() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // EvmGasBumpPercent provides a mock function with given fields:
 func (_m *ChainScopedConfig) EvmGasBumpPercent() uint16 {
 	ret := _m.Called()
@@ -1321,6 +1393,38 @@ func (_m *ChainScopedConfig) EvmHeadTrackerSamplingInterval() time.Duration {
 	return r0
 }
 
+// EvmJobSpendLimitDaily provides a mock function with given fields:
+func (_m *ChainScopedConfig) EvmJobSpendLimitDaily() *assets.Wei {
+	ret := _m.Called()
+
+	var r0 *assets.Wei
+	if rf, ok := ret.Get(0).(func() *assets.Wei); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	return r0
+}
+
+// EvmJobSpendLimitHourly provides a mock function with given fields:
+func (_m *ChainScopedConfig) EvmJobSpendLimitHourly() *assets.Wei {
+	ret := _m.Called()
+
+	var r0 *assets.Wei
+	if rf, ok := ret.Get(0).(func() *assets.Wei); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	return r0
+}
+
 // EvmLogBackfillBatchSize provides a mock function with given fields:
 func (_m *ChainScopedConfig) EvmLogBackfillBatchSize() uint32 {
 	ret := _m.Called()
@@ -1407,6 +1511,20 @@ func (_m *ChainScopedConfig) EvmMaxQueuedTransactions() uint64 {
 	return r0
 }
 
+// EvmMaxQueuedTransactionsPriority provides a mock function with given fields: priority
+func (_m *ChainScopedConfig) EvmMaxQueuedTransactionsPriority(priority string) uint64 {
+	ret := _m.Called(priority)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(string) uint64); ok {
+		r0 = rf(priority)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
 // EvmMinGasPriceWei provides a mock function with given fields:
 func (_m *ChainScopedConfig) EvmMinGasPriceWei() *assets.Wei {
 	ret := _m.Called()
@@ -1437,6 +1555,106 @@ func (_m *ChainScopedConfig) EvmNonceAutoSync() bool {
 	return r0
 }
 
+// EvmNonceGapsEnabled provides a mock function with given fields:
+func (_m *ChainScopedConfig) EvmNonceGapsEnabled() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EvmNonceGapsFill provides a mock function with given fields:
+func (_m *ChainScopedConfig) EvmNonceGapsFill() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EvmNonceGapsResync provides a mock function with given fields:
+func (_m *ChainScopedConfig) EvmNonceGapsResync() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EvmPrivateRelayAllTransactions provides a mock function with given fields:
+func (_m *ChainScopedConfig) EvmPrivateRelayAllTransactions() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EvmPrivateRelayFallbackBlocks provides a mock function with given fields:
+func (_m *ChainScopedConfig) EvmPrivateRelayFallbackBlocks() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// EvmPrivateRelayMethod provides a mock function with given fields:
+func (_m *ChainScopedConfig) EvmPrivateRelayMethod() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// EvmPrivateRelayURL provides a mock function with given fields:
+func (_m *ChainScopedConfig) EvmPrivateRelayURL() *url.URL {
+	ret := _m.Called()
+
+	var r0 *url.URL
+	if rf, ok := ret.Get(0).(func() *url.URL); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*url.URL)
+		}
+	}
+
+	return r0
+}
+
 // EvmRPCDefaultBatchSize provides a mock function with given fields:
 func (_m *ChainScopedConfig) EvmRPCDefaultBatchSize() uint32 {
 	ret := _m.Called()
@@ -1451,6 +1669,20 @@ func (_m *ChainScopedConfig) EvmRPCDefaultBatchSize() uint32 {
 	return r0
 }
 
+// EvmSimulateBeforeBroadcast provides a mock function with given fields:
+func (_m *ChainScopedConfig) EvmSimulateBeforeBroadcast() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // EvmUseForwarders provides a mock function with given fields:
 func (_m *ChainScopedConfig) EvmUseForwarders() bool {
 	ret := _m.Called()
@@ -1607,6 +1839,20 @@ func (_m *ChainScopedConfig) FeatureOffchainReporting2() bool {
 	return r0
 }
 
+// FeatureTxEvents provides a mock function with given fields:
+func (_m *ChainScopedConfig) FeatureTxEvents() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // FeatureUICSAKeys provides a mock function with given fields:
 func (_m *ChainScopedConfig) FeatureUICSAKeys() bool {
 	ret := _m.Called()
@@ -1621,6 +1867,34 @@ func (_m *ChainScopedConfig) FeatureUICSAKeys() bool {
 	return r0
 }
 
+// FeeHistoryEstimatorBlockCount provides a mock function with given fields:
+func (_m *ChainScopedConfig) FeeHistoryEstimatorBlockCount() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
+// FeeHistoryEstimatorRewardPercentile provides a mock function with given fields:
+func (_m *ChainScopedConfig) FeeHistoryEstimatorRewardPercentile() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
 // FlagsContractAddress provides a mock function with given fields:
 func (_m *ChainScopedConfig) FlagsContractAddress() string {
 	ret := _m.Called()
@@ -1719,6 +1993,34 @@ func (_m *ChainScopedConfig) JSONConsole() bool {
 	return r0
 }
 
+// JobPipelineHTTPCircuitBreakerCooldown provides a mock function with given fields:
+func (_m *ChainScopedConfig) JobPipelineHTTPCircuitBreakerCooldown() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// JobPipelineHTTPCircuitBreakerFailureThreshold provides a mock function with given fields:
+func (_m *ChainScopedConfig) JobPipelineHTTPCircuitBreakerFailureThreshold() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
 // JobPipelineMaxRunDuration provides a mock function with given fields:
 func (_m *ChainScopedConfig) JobPipelineMaxRunDuration() time.Duration {
 	ret := _m.Called()
@@ -1973,6 +2275,54 @@ func (_m *ChainScopedConfig) KeySpecificMaxGasPriceWei(addr common.Address) *ass
 	return r0
 }
 
+// KeySpecificSignerURL provides a mock function with given fields: addr
+func (_m *ChainScopedConfig) KeySpecificSignerURL(addr common.Address) *url.URL {
+	ret := _m.Called(addr)
+
+	var r0 *url.URL
+	if rf, ok := ret.Get(0).(func(common.Address) *url.URL); ok {
+		r0 = rf(addr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*url.URL)
+		}
+	}
+
+	return r0
+}
+
+// KeySpecificSpendLimitDaily provides a mock function with given fields: addr
+func (_m *ChainScopedConfig) KeySpecificSpendLimitDaily(addr common.Address) *assets.Wei {
+	ret := _m.Called(addr)
+
+	var r0 *assets.Wei
+	if rf, ok := ret.Get(0).(func(common.Address) *assets.Wei); ok {
+		r0 = rf(addr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	return r0
+}
+
+// KeySpecificSpendLimitHourly provides a mock function with given fields: addr
+func (_m *ChainScopedConfig) KeySpecificSpendLimitHourly(addr common.Address) *assets.Wei {
+	ret := _m.Called(addr)
+
+	var r0 *assets.Wei
+	if rf, ok := ret.Get(0).(func(common.Address) *assets.Wei); ok {
+		r0 = rf(addr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	return r0
+}
+
 // KeystorePassword provides a mock function with given fields:
 func (_m *ChainScopedConfig) KeystorePassword() string {
 	ret := _m.Called()
@@ -3588,6 +3938,36 @@ func (_m *ChainScopedConfig) TriggerFallbackDBPollInterval() time.Duration {
 	return r0
 }
 
+// TxWebhookSecret provides a mock function with given fields:
+func (_m *ChainScopedConfig) TxWebhookSecret() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TxWebhookURL provides a mock function with given fields:
+func (_m *ChainScopedConfig) TxWebhookURL() *url.URL {
+	ret := _m.Called()
+
+	var r0 *url.URL
+	if rf, ok := ret.Get(0).(func() *url.URL); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*url.URL)
+		}
+	}
+
+	return r0
+}
+
 // UnAuthenticatedRateLimit provides a mock function with given fields:
 func (_m *ChainScopedConfig) UnAuthenticatedRateLimit() int64 {
 	ret := _m.Called()
@@ -2,6 +2,7 @@ package v2
 
 import (
 	"math/big"
+	"net/url"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -103,6 +104,33 @@ func (c *ChainScoped) BlockHistoryEstimatorTransactionPercentile() uint16 {
 	return *c.cfg.GasEstimator.BlockHistory.TransactionPercentile
 }
 
+func (c *ChainScoped) CompositeEstimatorModes() []string {
+	if c.cfg.GasEstimator.Composite.Modes == nil {
+		return nil
+	}
+	return *c.cfg.GasEstimator.Composite.Modes
+}
+
+func (c *ChainScoped) CompositeEstimatorPolicy() string {
+	return *c.cfg.GasEstimator.Composite.Policy
+}
+
+func (c *ChainScoped) CompositeEstimatorBandPercent() uint16 {
+	return *c.cfg.GasEstimator.Composite.BandPercent
+}
+
+func (c *ChainScoped) CompositeEstimatorHistorySize() uint16 {
+	return *c.cfg.GasEstimator.Composite.HistorySize
+}
+
+func (c *ChainScoped) FeeHistoryEstimatorBlockCount() uint16 {
+	return *c.cfg.GasEstimator.FeeHistory.BlockCount
+}
+
+func (c *ChainScoped) FeeHistoryEstimatorRewardPercentile() uint16 {
+	return *c.cfg.GasEstimator.FeeHistory.RewardPercentile
+}
+
 func (c *ChainScoped) EvmEIP1559DynamicFees() bool {
 	return *c.cfg.GasEstimator.EIP1559DynamicFees
 }
@@ -123,6 +151,10 @@ func (c *ChainScoped) EvmFinalityDepth() uint32 {
 	return *c.cfg.FinalityDepth
 }
 
+func (c *ChainScoped) EvmFinalityTagEnabled() bool {
+	return *c.cfg.FinalityTagEnabled
+}
+
 func (c *ChainScoped) EvmGasBumpPercent() uint16 {
 	return *c.cfg.GasEstimator.BumpPercent
 }
@@ -232,10 +264,70 @@ func (c *ChainScoped) EvmMaxQueuedTransactions() uint64 {
 	return uint64(*c.cfg.Transactions.MaxQueued)
 }
 
+func (c *ChainScoped) EvmMaxQueuedTransactionsPriority(priority string) uint64 {
+	var max *uint32
+	switch priority {
+	case "high":
+		max = c.cfg.Transactions.MaxQueuedPriority.High
+	case "normal":
+		max = c.cfg.Transactions.MaxQueuedPriority.Normal
+	case "low":
+		max = c.cfg.Transactions.MaxQueuedPriority.Low
+	}
+	if max == nil {
+		return 0
+	}
+	return uint64(*max)
+}
+
+func (c *ChainScoped) EvmSimulateBeforeBroadcast() bool {
+	return *c.cfg.Transactions.SimulateBeforeBroadcast
+}
+
+func (c *ChainScoped) EvmJobSpendLimitHourly() *assets.Wei {
+	return c.cfg.Transactions.SpendLimits.JobHourly
+}
+
+func (c *ChainScoped) EvmJobSpendLimitDaily() *assets.Wei {
+	return c.cfg.Transactions.SpendLimits.JobDaily
+}
+
+func (c *ChainScoped) EvmPrivateRelayURL() *url.URL {
+	u := c.cfg.Transactions.PrivateRelay.URL
+	if u == nil || u.IsZero() {
+		return nil
+	}
+	return u.URL()
+}
+
+func (c *ChainScoped) EvmPrivateRelayMethod() string {
+	return *c.cfg.Transactions.PrivateRelay.Method
+}
+
+func (c *ChainScoped) EvmPrivateRelayFallbackBlocks() uint32 {
+	return *c.cfg.Transactions.PrivateRelay.FallbackBlocks
+}
+
+func (c *ChainScoped) EvmPrivateRelayAllTransactions() bool {
+	return *c.cfg.Transactions.PrivateRelay.AllTransactions
+}
+
 func (c *ChainScoped) EvmNonceAutoSync() bool {
 	return *c.cfg.NonceAutoSync
 }
 
+func (c *ChainScoped) EvmNonceGapsEnabled() bool {
+	return *c.cfg.Transactions.NonceGaps.Enabled
+}
+
+func (c *ChainScoped) EvmNonceGapsFill() bool {
+	return *c.cfg.Transactions.NonceGaps.Fill
+}
+
+func (c *ChainScoped) EvmNonceGapsResync() bool {
+	return *c.cfg.Transactions.NonceGaps.Resync
+}
+
 func (c *ChainScoped) EvmUseForwarders() bool {
 	return *c.cfg.Transactions.ForwardersEnabled
 }
@@ -272,6 +364,36 @@ func (c *ChainScoped) KeySpecificMaxGasPriceWei(addr common.Address) *assets.Wei
 	return c.EvmMaxGasPriceWei()
 }
 
+func (c *ChainScoped) KeySpecificSpendLimitHourly(addr common.Address) *assets.Wei {
+	for i := range c.cfg.KeySpecific {
+		ks := c.cfg.KeySpecific[i]
+		if ks.Key.Address() == addr && ks.SpendLimits.Hourly != nil {
+			return ks.SpendLimits.Hourly
+		}
+	}
+	return c.cfg.Transactions.SpendLimits.KeyHourly
+}
+
+func (c *ChainScoped) KeySpecificSpendLimitDaily(addr common.Address) *assets.Wei {
+	for i := range c.cfg.KeySpecific {
+		ks := c.cfg.KeySpecific[i]
+		if ks.Key.Address() == addr && ks.SpendLimits.Daily != nil {
+			return ks.SpendLimits.Daily
+		}
+	}
+	return c.cfg.Transactions.SpendLimits.KeyDaily
+}
+
+func (c *ChainScoped) KeySpecificSignerURL(addr common.Address) *url.URL {
+	for i := range c.cfg.KeySpecific {
+		ks := c.cfg.KeySpecific[i]
+		if ks.Key.Address() == addr {
+			return ks.Signer.URL.URL()
+		}
+	}
+	return nil
+}
+
 func (c *ChainScoped) LinkContractAddress() string {
 	if c.cfg.LinkContractAddress == nil {
 		return ""
@@ -280,6 +280,7 @@ type Chain struct {
 	BlockBackfillSkip        *bool
 	ChainType                *string
 	FinalityDepth            *uint32
+	FinalityTagEnabled       *bool
 	FlagsContractAddress     *ethkey.EIP55Address
 	LinkContractAddress      *ethkey.EIP55Address
 	LogBackfillBatchSize     *uint32
@@ -431,6 +432,13 @@ type Transactions struct {
 	ReaperInterval       *models.Duration
 	ReaperThreshold      *models.Duration
 	ResendAfterThreshold *models.Duration
+	// SimulateBeforeBroadcast simulates every transaction before it is first broadcast
+	SimulateBeforeBroadcast *bool
+
+	MaxQueuedPriority TransactionsMaxQueuedPriority `toml:",omitempty"`
+	PrivateRelay      TransactionsPrivateRelay      `toml:",omitempty"`
+	SpendLimits       TransactionsSpendLimits       `toml:",omitempty"`
+	NonceGaps         TransactionsNonceGaps         `toml:",omitempty"`
 }
 
 func (t *Transactions) setFrom(f *Transactions) {
@@ -452,6 +460,120 @@ func (t *Transactions) setFrom(f *Transactions) {
 	if v := f.ResendAfterThreshold; v != nil {
 		t.ResendAfterThreshold = v
 	}
+	if v := f.SimulateBeforeBroadcast; v != nil {
+		t.SimulateBeforeBroadcast = v
+	}
+	t.MaxQueuedPriority.setFrom(&f.MaxQueuedPriority)
+	t.PrivateRelay.setFrom(&f.PrivateRelay)
+	t.SpendLimits.setFrom(&f.SpendLimits)
+	t.NonceGaps.setFrom(&f.NonceGaps)
+}
+
+type TransactionsMaxQueuedPriority struct {
+	High   *uint32
+	Normal *uint32
+	Low    *uint32
+}
+
+func (p *TransactionsMaxQueuedPriority) setFrom(f *TransactionsMaxQueuedPriority) {
+	if v := f.High; v != nil {
+		p.High = v
+	}
+	if v := f.Normal; v != nil {
+		p.Normal = v
+	}
+	if v := f.Low; v != nil {
+		p.Low = v
+	}
+}
+
+type TransactionsPrivateRelay struct {
+	URL             *models.URL
+	Method          *string
+	FallbackBlocks  *uint32
+	AllTransactions *bool
+}
+
+func (r *TransactionsPrivateRelay) setFrom(f *TransactionsPrivateRelay) {
+	if v := f.URL; v != nil {
+		r.URL = v
+	}
+	if v := f.Method; v != nil {
+		r.Method = v
+	}
+	if v := f.FallbackBlocks; v != nil {
+		r.FallbackBlocks = v
+	}
+	if v := f.AllTransactions; v != nil {
+		r.AllTransactions = v
+	}
+}
+
+func (r *TransactionsPrivateRelay) ValidateConfig() (err error) {
+	if r.Method != nil {
+		switch *r.Method {
+		case "eth_sendPrivateTransaction", "eth_sendBundle":
+		default:
+			err = multierr.Append(err, v2.ErrInvalid{Name: "Method", Value: *r.Method,
+				Msg: "must be eth_sendPrivateTransaction or eth_sendBundle"})
+		}
+	}
+	if r.FallbackBlocks != nil && *r.FallbackBlocks == 0 {
+		err = multierr.Append(err, v2.ErrInvalid{Name: "FallbackBlocks", Value: *r.FallbackBlocks,
+			Msg: "must be greater than 0"})
+	}
+	if r.URL == nil || r.URL.IsZero() {
+		if r.AllTransactions != nil && *r.AllTransactions {
+			err = multierr.Append(err, v2.ErrMissing{Name: "URL", Msg: "must be set to send all transactions privately"})
+		}
+		return
+	}
+	switch r.URL.Scheme {
+	case "http", "https":
+	default:
+		err = multierr.Append(err, v2.ErrInvalid{Name: "URL", Value: r.URL.Scheme, Msg: "must be http or https"})
+	}
+	return
+}
+
+type TransactionsSpendLimits struct {
+	KeyHourly *assets.Wei
+	KeyDaily  *assets.Wei
+	JobHourly *assets.Wei
+	JobDaily  *assets.Wei
+}
+
+func (l *TransactionsSpendLimits) setFrom(f *TransactionsSpendLimits) {
+	if v := f.KeyHourly; v != nil {
+		l.KeyHourly = v
+	}
+	if v := f.KeyDaily; v != nil {
+		l.KeyDaily = v
+	}
+	if v := f.JobHourly; v != nil {
+		l.JobHourly = v
+	}
+	if v := f.JobDaily; v != nil {
+		l.JobDaily = v
+	}
+}
+
+type TransactionsNonceGaps struct {
+	Enabled *bool
+	Fill    *bool
+	Resync  *bool
+}
+
+func (g *TransactionsNonceGaps) setFrom(f *TransactionsNonceGaps) {
+	if v := f.Enabled; v != nil {
+		g.Enabled = v
+	}
+	if v := f.Fill; v != nil {
+		g.Fill = v
+	}
+	if v := f.Resync; v != nil {
+		g.Resync = v
+	}
 }
 
 type OCR2 struct {
@@ -507,6 +629,8 @@ type GasEstimator struct {
 	TipCapMin     *assets.Wei
 
 	BlockHistory BlockHistoryEstimator `toml:",omitempty"`
+	FeeHistory   FeeHistoryEstimator   `toml:",omitempty"`
+	Composite    CompositeEstimator    `toml:",omitempty"`
 }
 
 func (e *GasEstimator) ValidateConfig() (err error) {
@@ -543,6 +667,15 @@ func (e *GasEstimator) ValidateConfig() (err error) {
 		err = multierr.Append(err, v2.ErrInvalid{Name: "BlockHistory.BlockHistorySize", Value: *e.BlockHistory.BlockHistorySize,
 			Msg: "must be greater than or equal to 1 with BlockHistory Mode"})
 	}
+	if *e.Mode == "FeeHistory" && *e.FeeHistory.BlockCount <= 0 {
+		err = multierr.Append(err, v2.ErrInvalid{Name: "FeeHistory.BlockCount", Value: *e.FeeHistory.BlockCount,
+			Msg: "must be greater than or equal to 1 with FeeHistory Mode"})
+	}
+	if *e.FeeHistory.RewardPercentile > 100 {
+		err = multierr.Append(err, v2.ErrInvalid{Name: "FeeHistory.RewardPercentile", Value: *e.FeeHistory.RewardPercentile,
+			Msg: "must be less than or equal to 100"})
+	}
+	err = multierr.Append(err, e.Composite.validateConfig(*e.Mode))
 
 	return
 }
@@ -598,6 +731,8 @@ func (e *GasEstimator) setFrom(f *GasEstimator) {
 	}
 	e.LimitJobType.setFrom(&f.LimitJobType)
 	e.BlockHistory.setFrom(&f.BlockHistory)
+	e.FeeHistory.setFrom(&f.FeeHistory)
+	e.Composite.setFrom(&f.Composite)
 }
 
 type GasLimitJobType struct {
@@ -656,6 +791,67 @@ func (e *BlockHistoryEstimator) setFrom(f *BlockHistoryEstimator) {
 	}
 }
 
+type FeeHistoryEstimator struct {
+	BlockCount       *uint16
+	RewardPercentile *uint16
+}
+
+func (e *FeeHistoryEstimator) setFrom(f *FeeHistoryEstimator) {
+	if v := f.BlockCount; v != nil {
+		e.BlockCount = v
+	}
+	if v := f.RewardPercentile; v != nil {
+		e.RewardPercentile = v
+	}
+}
+
+type CompositeEstimator struct {
+	Modes       *[]string
+	Policy      *string
+	BandPercent *uint16
+	HistorySize *uint16
+}
+
+func (e *CompositeEstimator) validateConfig(mode string) (err error) {
+	switch *e.Policy {
+	case "Max", "Median", "FirstHealthy":
+	default:
+		err = multierr.Append(err, v2.ErrInvalid{Name: "Composite.Policy", Value: *e.Policy,
+			Msg: "must be one of Max, Median or FirstHealthy"})
+	}
+	if mode != "Composite" {
+		return
+	}
+	if e.Modes == nil || len(*e.Modes) == 0 {
+		err = multierr.Append(err, v2.ErrMissing{Name: "Composite.Modes", Msg: "must be set with Composite Mode"})
+		return
+	}
+	for _, m := range *e.Modes {
+		switch m {
+		case "Arbitrum", "BlockHistory", "FeeHistory", "FixedPrice", "L2Suggested", "Optimism2":
+		default:
+			err = multierr.Append(err, v2.ErrInvalid{Name: "Composite.Modes", Value: m,
+				Msg: "must be one of Arbitrum, BlockHistory, FeeHistory, FixedPrice, L2Suggested or Optimism2"})
+		}
+	}
+	return
+}
+
+func (e *CompositeEstimator) setFrom(f *CompositeEstimator) {
+	if v := f.Modes; v != nil {
+		e.Modes = v
+	}
+	if v := f.Policy; v != nil {
+		e.Policy = v
+	}
+	if v := f.BandPercent; v != nil {
+		e.BandPercent = v
+	}
+	if v := f.HistorySize; v != nil {
+		e.HistorySize = v
+	}
+}
+
 type KeySpecificConfig []KeySpecific
 
 func (ks KeySpecificConfig) ValidateConfig() (err error) {
@@ -674,6 +870,8 @@ func (ks KeySpecificConfig) ValidateConfig() (err error) {
 type KeySpecific struct {
 	Key          *ethkey.EIP55Address
 	GasEstimator KeySpecificGasEstimator `toml:",omitempty"`
+	Signer       KeySpecificSigner       `toml:",omitempty"`
+	SpendLimits  KeySpecificSpendLimits  `toml:",omitempty"`
 }
 
 type KeySpecificGasEstimator struct {
@@ -686,6 +884,46 @@ func (e *KeySpecificGasEstimator) setFrom(f *KeySpecificGasEstimator) {
 	}
 }
 
+type KeySpecificSigner struct {
+	URL *models.URL
+}
+
+func (s *KeySpecificSigner) setFrom(f *KeySpecificSigner) {
+	if v := f.URL; v != nil {
+		s.URL = v
+	}
+}
+
+func (s *KeySpecificSigner) ValidateConfig() (err error) {
+	if s.URL == nil {
+		return
+	}
+	if s.URL.IsZero() {
+		err = multierr.Append(err, v2.ErrEmpty{Name: "URL", Msg: "must be set to use a remote signer"})
+		return
+	}
+	switch s.URL.Scheme {
+	case "http", "https":
+	default:
+		err = multierr.Append(err, v2.ErrInvalid{Name: "URL", Value: s.URL.Scheme, Msg: "must be http or https"})
+	}
+	return
+}
+
+type KeySpecificSpendLimits struct {
+	Hourly *assets.Wei
+	Daily  *assets.Wei
+}
+
+func (l *KeySpecificSpendLimits) setFrom(f *KeySpecificSpendLimits) {
+	if v := f.Hourly; v != nil {
+		l.Hourly = v
+	}
+	if v := f.Daily; v != nil {
+		l.Daily = v
+	}
+}
+
 type HeadTracker struct {
 	HistoryDepth     *uint32
 	MaxBufferSize    *uint32
@@ -116,6 +116,9 @@ func (c *Chain) SetFrom(f *Chain) {
 	if v := f.FinalityDepth; v != nil {
 		c.FinalityDepth = v
 	}
+	if v := f.FinalityTagEnabled; v != nil {
+		c.FinalityTagEnabled = v
+	}
 	if v := f.FlagsContractAddress; v != nil {
 		c.FlagsContractAddress = v
 	}
@@ -163,6 +166,8 @@ func (c *Chain) SetFrom(f *Chain) {
 				c.KeySpecific = append(c.KeySpecific, v)
 			} else {
 				c.KeySpecific[i].GasEstimator.setFrom(&v.GasEstimator)
+				c.KeySpecific[i].Signer.setFrom(&v.Signer)
+				c.KeySpecific[i].SpendLimits.setFrom(&v.SpendLimits)
 			}
 		}
 	}
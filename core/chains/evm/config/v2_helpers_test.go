@@ -4,6 +4,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/shopspring/decimal"
 
+	"github.com/smartcontractkit/chainlink/core/assets"
 	v2 "github.com/smartcontractkit/chainlink/core/chains/evm/config/v2"
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -29,6 +30,7 @@ func (set chainSpecificConfigDefaultSet) asV2() v2.Chain {
 
 		ChainType:                ptr(string(set.chainType)),
 		FinalityDepth:            ptr(set.finalityDepth),
+		FinalityTagEnabled:       ptr(false), // TOML only
 		FlagsContractAddress:     asEIP155Address(set.flagsContractAddress),
 		LinkContractAddress:      asEIP155Address(set.linkContractAddress),
 		LogBackfillBatchSize:     ptr(set.logBackfillBatchSize),
@@ -48,6 +50,25 @@ func (set chainSpecificConfigDefaultSet) asV2() v2.Chain {
 			ReaperInterval:       models.MustNewDuration(set.ethTxReaperInterval),
 			ReaperThreshold:      models.MustNewDuration(set.ethTxReaperThreshold),
 			ResendAfterThreshold: models.MustNewDuration(set.ethTxResendAfterThreshold),
+
+			// TOML only
+			SimulateBeforeBroadcast: ptr(false),
+			PrivateRelay: v2.TransactionsPrivateRelay{
+				Method:          ptr("eth_sendPrivateTransaction"),
+				FallbackBlocks:  ptr[uint32](25),
+				AllTransactions: ptr(false),
+			},
+			SpendLimits: v2.TransactionsSpendLimits{
+				KeyHourly: assets.NewWeiI(0),
+				KeyDaily:  assets.NewWeiI(0),
+				JobHourly: assets.NewWeiI(0),
+				JobDaily:  assets.NewWeiI(0),
+			},
+			NonceGaps: v2.TransactionsNonceGaps{
+				Enabled: ptr(false),
+				Fill:    ptr(false),
+				Resync:  ptr(false),
+			},
 		},
 		BalanceMonitor: v2.BalanceMonitor{
 			Enabled: ptr(set.balanceMonitorEnabled),
@@ -83,6 +104,16 @@ func (set chainSpecificConfigDefaultSet) asV2() v2.Chain {
 				CheckInclusionPercentile: ptr(set.blockHistoryEstimatorCheckInclusionPercentile),
 				TransactionPercentile:    ptr(set.blockHistoryEstimatorTransactionPercentile),
 			},
+			// TOML only
+			FeeHistory: v2.FeeHistoryEstimator{
+				BlockCount:       ptr[uint16](20),
+				RewardPercentile: ptr[uint16](60),
+			},
+			Composite: v2.CompositeEstimator{
+				Policy:      ptr("Median"),
+				BandPercent: ptr[uint16](0),
+				HistorySize: ptr[uint16](10),
+			},
 		},
 		HeadTracker: v2.HeadTracker{
 			HistoryDepth:     ptr(set.headTrackerHistoryDepth),
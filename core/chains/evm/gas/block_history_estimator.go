@@ -255,6 +255,70 @@ func (b *BlockHistoryEstimator) getTipCap() *assets.Wei {
 	return b.tipCap
 }
 
+// BlockHistoryWindow is the window of recent blocks that the
+// BlockHistoryEstimator currently derives its prices from
+type BlockHistoryWindow struct {
+	// Percentile is the configured percentile of the transaction prices
+	Percentile uint16
+	// Size is the configured maximum number of blocks in the window
+	Size uint16
+	// BlockNumbers are the blocks in the window, in ascending order
+	BlockNumbers []int64
+	// GasPrice and TipCap are the percentile prices of the window, or nil if
+	// they have not been calculated yet
+	GasPrice *assets.Wei
+	TipCap   *assets.Wei
+}
+
+// Window returns the window of blocks that prices are currently derived from
+func (b *BlockHistoryEstimator) Window() BlockHistoryWindow {
+	w := BlockHistoryWindow{
+		Percentile: b.config.BlockHistoryEstimatorTransactionPercentile(),
+		Size:       b.config.BlockHistoryEstimatorBlockHistorySize(),
+		GasPrice:   b.getGasPrice(),
+		TipCap:     b.getTipCap(),
+	}
+	for _, block := range b.getWindowBlocks() {
+		w.BlockNumbers = append(w.BlockNumbers, block.Number)
+	}
+	return w
+}
+
+func (b *BlockHistoryEstimator) getWindowBlocks() []evmtypes.Block {
+	blocks := b.getBlocks()
+	l := mathutil.Min(len(blocks), int(b.config.BlockHistoryEstimatorBlockHistorySize()))
+	return blocks[:l]
+}
+
+// BasePercentile returns the BlockHistoryEstimatorTransactionPercentile
+func (b *BlockHistoryEstimator) BasePercentile() uint16 {
+	return b.config.BlockHistoryEstimatorTransactionPercentile()
+}
+
+// PercentilePrices returns the gas price and tip cap paid by percentile of
+// the transactions in the window
+func (b *BlockHistoryEstimator) PercentilePrices(percentile uint16) (gasPrice, tipCap *assets.Wei, err error) {
+	blocks := b.getWindowBlocks()
+	if len(blocks) == 0 {
+		return nil, nil, errors.New("no blocks in history")
+	}
+	return b.calculatePercentilePrices(blocks, int(percentile), b.config.EvmEIP1559DynamicFees(), nil, nil)
+}
+
+// AverageBlockTime returns the average time between the blocks in the
+// window, or zero if there are not enough of them to tell
+func (b *BlockHistoryEstimator) AverageBlockTime() time.Duration {
+	blocks := b.getWindowBlocks()
+	if len(blocks) < 2 {
+		return 0
+	}
+	first, last := blocks[0], blocks[len(blocks)-1]
+	if last.Number <= first.Number || !last.Timestamp.After(first.Timestamp) {
+		return 0
+	}
+	return last.Timestamp.Sub(first.Timestamp) / time.Duration(last.Number-first.Number)
+}
+
 func (b *BlockHistoryEstimator) BumpLegacyGas(_ context.Context, originalGasPrice *assets.Wei, gasLimit uint32, maxGasPriceWei *assets.Wei, attempts []PriorAttempt) (bumpedGasPrice *assets.Wei, chainSpecificGasLimit uint32, err error) {
 	if b.config.BlockHistoryEstimatorCheckInclusionBlocks() > 0 {
 		if err = b.checkConnectivity(attempts); err != nil {
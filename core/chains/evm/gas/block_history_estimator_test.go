@@ -1589,6 +1589,64 @@ func TestBlockHistoryEstimator_GetLegacyGas(t *testing.T) {
 	})
 }
 
+func TestBlockHistoryEstimator_PercentilePrices(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfigWithEIP1559DynamicFeesDisabled(t)
+	cfg.BlockHistoryEstimatorBlockHistorySizeF = uint16(3)
+	cfg.EvmMinGasPriceWeiF = assets.NewWeiI(0)
+
+	bhe := newBlockHistoryEstimator(t, nil, cfg)
+
+	t.Run("fails without blocks", func(t *testing.T) {
+		_, _, err := bhe.PercentilePrices(50)
+		require.EqualError(t, err, "no blocks in history")
+		assert.Zero(t, bhe.AverageBlockTime())
+	})
+
+	start := time.Unix(1600000000, 0)
+	gas.SetRollingBlockHistory(bhe, []evmtypes.Block{
+		{
+			Number:       10,
+			Hash:         utils.NewHash(),
+			Timestamp:    start,
+			Transactions: cltest.LegacyTransactionsFromGasPrices(10, 20, 30),
+		},
+		{
+			Number:       12,
+			Hash:         utils.NewHash(),
+			Timestamp:    start.Add(24 * time.Second),
+			Transactions: cltest.LegacyTransactionsFromGasPrices(40, 50),
+		},
+		{
+			Number:       13,
+			Hash:         utils.NewHash(),
+			Timestamp:    start.Add(36 * time.Second),
+			Transactions: cltest.LegacyTransactionsFromGasPrices(60),
+		},
+		{
+			// Outside of the window
+			Number:       14,
+			Hash:         utils.NewHash(),
+			Timestamp:    start.Add(60 * time.Second),
+			Transactions: cltest.LegacyTransactionsFromGasPrices(1000),
+		},
+	})
+
+	t.Run("prices transactions at any percentile of the window", func(t *testing.T) {
+		for percentile, expected := range map[uint16]int64{0: 10, 50: 30, 80: 50, 100: 60} {
+			gasPrice, tipCap, err := bhe.PercentilePrices(percentile)
+			require.NoError(t, err)
+			assert.Equal(t, assets.NewWeiI(expected), gasPrice, "percentile %d", percentile)
+			assert.Nil(t, tipCap)
+		}
+	})
+
+	t.Run("averages the time between the blocks of the window", func(t *testing.T) {
+		assert.Equal(t, 12*time.Second, bhe.AverageBlockTime())
+	})
+}
+
 func TestBlockHistoryEstimator_UseDefaultPriceAsFallback(t *testing.T) {
 	t.Parallel()
 
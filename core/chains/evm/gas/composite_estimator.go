@@ -0,0 +1,348 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	evmclient "github.com/smartcontractkit/chainlink/core/chains/evm/client"
+	evmtypes "github.com/smartcontractkit/chainlink/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+var (
+	promCompositeEstimatorWinner = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gas_estimator_composite_winner",
+		Help: "Counter is incremented every time the estimate of an estimator is used by the composite estimator",
+	},
+		[]string{"evmChainID", "estimator", "method"},
+	)
+	promCompositeEstimatorRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gas_estimator_composite_rejected",
+		Help: "Counter is incremented every time the estimate of an estimator is rejected by the composite estimator for being outside the sanity band",
+	},
+		[]string{"evmChainID", "estimator", "method"},
+	)
+)
+
+var _ Estimator = &CompositeEstimator{}
+
+// NamedEstimator is an estimator of a CompositeEstimator, named after its mode
+type NamedEstimator struct {
+	Name string
+	Estimator
+}
+
+// estimate is the result of an estimator of a CompositeEstimator
+type estimate struct {
+	name string
+	// value is what estimates are compared by: the gas price of legacy
+	// transactions, or the tip cap of EIP-1559 transactions
+	value    *assets.Wei
+	gasPrice *assets.Wei
+	fee      DynamicFee
+	gasLimit uint32
+}
+
+// estimateHistory holds the recent estimates used by a CompositeEstimator
+type estimateHistory struct {
+	values []*assets.Wei
+	// rejected counts the consecutive estimations whose estimates were all
+	// outside the sanity band
+	rejected int
+}
+
+// median returns the median of the history, or nil if it is empty
+func (h *estimateHistory) median() *assets.Wei {
+	if len(h.values) == 0 {
+		return nil
+	}
+	values := make([]*assets.Wei, len(h.values))
+	copy(values, h.values)
+	sort.Slice(values, func(i, j int) bool { return values[i].Cmp(values[j]) < 0 })
+	return values[(len(values)-1)/2]
+}
+
+func (h *estimateHistory) clone() estimateHistory {
+	values := make([]*assets.Wei, len(h.values))
+	copy(values, h.values)
+	return estimateHistory{values: values, rejected: h.rejected}
+}
+
+func (h *estimateHistory) add(value *assets.Wei, size int) {
+	h.rejected = 0
+	h.values = append(h.values, value)
+	if len(h.values) > size {
+		h.values = h.values[len(h.values)-size:]
+	}
+}
+
+// CompositeEstimator is an Estimator which queries several estimators and
+// combines their estimates with a policy:
+//
+//   - `Max` uses the highest estimate.
+//   - `Median` uses the median estimate.
+//   - `FirstHealthy` uses the estimate of the first estimator that did not fail.
+//
+// New estimates that are outside of a band around the median of the recent
+// estimates are rejected, so that an estimator returning nonsense is ignored.
+// Estimators that fail to start are not used.
+type CompositeEstimator struct {
+	utils.StartStopOnce
+	estimators []NamedEstimator
+	active     []NamedEstimator
+	config     Config
+	chainID    big.Int
+	logger     logger.SugaredLogger
+
+	historyMu sync.Mutex
+	legacy    estimateHistory
+	dynamic   estimateHistory
+	// dryRun is set on snapshots, whose estimations are not counted in the
+	// metrics
+	dryRun bool
+}
+
+func newCompositeEstimator(lggr logger.Logger, ethClient evmclient.Client, cfg Config) Estimator {
+	var estimators []NamedEstimator
+	for _, mode := range cfg.CompositeEstimatorModes() {
+		if mode == "Composite" {
+			lggr.Warn("GasEstimator: composite estimators cannot be nested, ignoring Composite mode")
+			continue
+		}
+		estimators = append(estimators, NamedEstimator{mode, newEstimator(lggr, ethClient, cfg, mode)})
+	}
+	if len(estimators) == 0 {
+		lggr.Warn("GasEstimator: no modes configured for composite estimator, falling back to FixedPriceEstimator")
+		return NewFixedPriceEstimator(cfg, lggr)
+	}
+	return NewCompositeEstimator(lggr, cfg, *ethClient.ChainID(), estimators)
+}
+
+// NewCompositeEstimator returns a new CompositeEstimator that combines the
+// estimates of estimators, in order of preference
+func NewCompositeEstimator(lggr logger.Logger, cfg Config, chainID big.Int, estimators []NamedEstimator) Estimator {
+	return &CompositeEstimator{
+		estimators: estimators,
+		config:     cfg,
+		chainID:    chainID,
+		logger:     logger.Sugared(lggr.Named("CompositeEstimator")),
+	}
+}
+
+// snapshot returns a copy of c with a copy of its history, which estimates
+// with the same estimators without changing the history of c or its metrics
+func (c *CompositeEstimator) snapshot() *CompositeEstimator {
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+	return &CompositeEstimator{
+		estimators: c.estimators,
+		active:     c.active,
+		config:     c.config,
+		chainID:    c.chainID,
+		logger:     c.logger,
+		legacy:     c.legacy.clone(),
+		dynamic:    c.dynamic.clone(),
+		dryRun:     true,
+	}
+}
+
+// Start starts all the estimators. It fails only if none of them could be
+// started.
+func (c *CompositeEstimator) Start(ctx context.Context) error {
+	return c.StartOnce("CompositeEstimator", func() (merr error) {
+		for _, e := range c.estimators {
+			if err := e.Start(ctx); err != nil {
+				c.logger.Errorw(fmt.Sprintf("Failed to start %s estimator, it will not be used", e.Name), "estimator", e.Name, "err", err)
+				merr = multierr.Append(merr, errors.Wrapf(err, "failed to start %s estimator", e.Name))
+				continue
+			}
+			c.active = append(c.active, e)
+		}
+		if len(c.active) > 0 {
+			return nil
+		} else if merr == nil {
+			return errors.New("CompositeEstimator has no estimators")
+		}
+		return merr
+	})
+}
+
+func (c *CompositeEstimator) Close() error {
+	return c.StopOnce("CompositeEstimator", func() (merr error) {
+		for _, e := range c.active {
+			merr = multierr.Append(merr, e.Close())
+		}
+		return
+	})
+}
+
+func (c *CompositeEstimator) OnNewLongestChain(ctx context.Context, head *evmtypes.Head) {
+	for _, e := range c.active {
+		e.OnNewLongestChain(ctx, head)
+	}
+}
+
+func (c *CompositeEstimator) GetLegacyGas(ctx context.Context, calldata []byte, gasLimit uint32, maxGasPriceWei *assets.Wei, opts ...Opt) (gasPrice *assets.Wei, chainSpecificGasLimit uint32, err error) {
+	winner, err := c.estimate("GetLegacyGas", &c.legacy, func(e Estimator) (est estimate, err error) {
+		est.gasPrice, est.gasLimit, err = e.GetLegacyGas(ctx, calldata, gasLimit, maxGasPriceWei, opts...)
+		est.value = est.gasPrice
+		return
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return winner.gasPrice, winner.gasLimit, nil
+}
+
+func (c *CompositeEstimator) BumpLegacyGas(ctx context.Context, originalGasPrice *assets.Wei, gasLimit uint32, maxGasPriceWei *assets.Wei, attempts []PriorAttempt) (bumpedGasPrice *assets.Wei, chainSpecificGasLimit uint32, err error) {
+	winner, err := c.estimate("BumpLegacyGas", nil, func(e Estimator) (est estimate, err error) {
+		est.gasPrice, est.gasLimit, err = e.BumpLegacyGas(ctx, originalGasPrice, gasLimit, maxGasPriceWei, attempts)
+		est.value = est.gasPrice
+		return
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return winner.gasPrice, winner.gasLimit, nil
+}
+
+func (c *CompositeEstimator) GetDynamicFee(ctx context.Context, gasLimit uint32, maxGasPriceWei *assets.Wei) (fee DynamicFee, chainSpecificGasLimit uint32, err error) {
+	winner, err := c.estimate("GetDynamicFee", &c.dynamic, func(e Estimator) (est estimate, err error) {
+		est.fee, est.gasLimit, err = e.GetDynamicFee(ctx, gasLimit, maxGasPriceWei)
+		est.value = est.fee.TipCap
+		return
+	})
+	if err != nil {
+		return fee, 0, err
+	}
+	return winner.fee, winner.gasLimit, nil
+}
+
+func (c *CompositeEstimator) BumpDynamicFee(ctx context.Context, original DynamicFee, gasLimit uint32, maxGasPriceWei *assets.Wei, attempts []PriorAttempt) (bumped DynamicFee, chainSpecificGasLimit uint32, err error) {
+	winner, err := c.estimate("BumpDynamicFee", nil, func(e Estimator) (est estimate, err error) {
+		est.fee, est.gasLimit, err = e.BumpDynamicFee(ctx, original, gasLimit, maxGasPriceWei, attempts)
+		est.value = est.fee.TipCap
+		return
+	})
+	if err != nil {
+		return bumped, 0, err
+	}
+	return winner.fee, winner.gasLimit, nil
+}
+
+// estimate queries all the active estimators with f, and picks the winning
+// estimate with the configured policy. Estimates outside of the sanity band
+// around history are rejected, unless history is nil.
+func (c *CompositeEstimator) estimate(method string, history *estimateHistory, f func(Estimator) (estimate, error)) (winner estimate, err error) {
+	if len(c.active) == 0 {
+		return winner, errors.New("CompositeEstimator is not started; cannot estimate gas")
+	}
+	var estimates []estimate
+	var firstErr error
+	for _, e := range c.active {
+		est, err := f(e.Estimator)
+		if err != nil {
+			c.logger.Debugw(fmt.Sprintf("%s estimator failed to estimate gas", e.Name), "estimator", e.Name, "method", method, "err", err)
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "%s estimator failed", e.Name)
+			}
+			continue
+		}
+		est.name = e.Name
+		estimates = append(estimates, est)
+	}
+	if len(estimates) == 0 {
+		return winner, errors.Wrapf(firstErr, "all %d gas estimators failed", len(c.active))
+	}
+
+	if history != nil {
+		c.historyMu.Lock()
+		defer c.historyMu.Unlock()
+		if estimates, err = c.filterBand(method, history, estimates); err != nil {
+			return winner, err
+		}
+	}
+
+	switch c.config.CompositeEstimatorPolicy() {
+	case "Max":
+		winner = estimates[0]
+		for _, est := range estimates[1:] {
+			if est.value.Cmp(winner.value) > 0 {
+				winner = est
+			}
+		}
+	case "FirstHealthy":
+		winner = estimates[0]
+	default:
+		sorted := make([]estimate, len(estimates))
+		copy(sorted, estimates)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].value.Cmp(sorted[j].value) < 0 })
+		winner = sorted[(len(sorted)-1)/2]
+	}
+
+	if history != nil {
+		history.add(winner.value, int(c.config.CompositeEstimatorHistorySize()))
+	}
+	if !c.dryRun {
+		promCompositeEstimatorWinner.WithLabelValues(c.chainID.String(), winner.name, method).Inc()
+	}
+	candidates := make(map[string]string, len(estimates))
+	for _, est := range estimates {
+		candidates[est.name] = est.value.String()
+	}
+	c.logger.Debugw(fmt.Sprintf("Using %s estimate of %s from %s estimator", method, winner.value, winner.name),
+		"estimator", winner.name, "method", method, "policy", c.config.CompositeEstimatorPolicy(), "candidates", candidates)
+	return winner, nil
+}
+
+// filterBand rejects the estimates that are more than
+// CompositeEstimatorBandPercent above or below the median of history. If all
+// the estimates of CompositeEstimatorHistorySize consecutive estimations are
+// rejected, the market is assumed to have moved and history is reset.
+func (c *CompositeEstimator) filterBand(method string, history *estimateHistory, estimates []estimate) ([]estimate, error) {
+	percent := c.config.CompositeEstimatorBandPercent()
+	ref := history.median()
+	if percent == 0 || ref == nil {
+		return estimates, nil
+	}
+	upper := ref.AddPercentage(percent)
+	lower := assets.NewWeiI(0)
+	if percent < 100 {
+		lower = assets.NewWei(new(big.Int).Div(new(big.Int).Mul(ref.ToInt(), big.NewInt(100-int64(percent))), big.NewInt(100)))
+	}
+
+	var accepted []estimate
+	for _, est := range estimates {
+		if est.value.Cmp(lower) < 0 || est.value.Cmp(upper) > 0 {
+			c.logger.Warnw(fmt.Sprintf("Rejecting %s estimate of %s from %s estimator, which is outside of the band of %s to %s around the median of recent estimates", method, est.value, est.name, lower, upper),
+				"estimator", est.name, "method", method, "estimate", est.value, "median", ref, "bandPercent", percent)
+			if !c.dryRun {
+				promCompositeEstimatorRejected.WithLabelValues(c.chainID.String(), est.name, method).Inc()
+			}
+			continue
+		}
+		accepted = append(accepted, est)
+	}
+	if len(accepted) > 0 {
+		return accepted, nil
+	}
+
+	history.rejected++
+	if history.rejected < int(c.config.CompositeEstimatorHistorySize()) {
+		return nil, errors.Errorf("all %d gas estimates were rejected for being outside of the band of %s to %s around the median of recent estimates", len(estimates), lower, upper)
+	}
+	c.logger.Warnw(fmt.Sprintf("All %s estimates were rejected %d times in a row, resetting the history of recent estimates", method, history.rejected),
+		"method", method, "median", ref, "bandPercent", percent)
+	history.values = nil
+	return estimates, nil
+}
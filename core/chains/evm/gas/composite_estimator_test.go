@@ -0,0 +1,216 @@
+package gas_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas/mocks"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+func TestCompositeEstimator(t *testing.T) {
+	t.Parallel()
+
+	const gasLimit uint32 = 80000
+	maxGasPrice := assets.NewWeiI(5000)
+
+	newEstimators := func(t *testing.T, n int) (estimators []gas.NamedEstimator, ms []*mocks.Estimator) {
+		for i := 0; i < n; i++ {
+			m := mocks.NewEstimator(t)
+			m.On("Start", mock.Anything).Return(nil).Once()
+			m.On("Close").Return(nil).Once()
+			ms = append(ms, m)
+			estimators = append(estimators, gas.NamedEstimator{Name: string(rune('A' + i)), Estimator: m})
+		}
+		return
+	}
+	newComposite := func(t *testing.T, cfg gas.Config, estimators []gas.NamedEstimator) gas.Estimator {
+		c := gas.NewCompositeEstimator(logger.TestLogger(t), cfg, cltest.FixtureChainID, estimators)
+		require.NoError(t, c.Start(testutils.Context(t)))
+		t.Cleanup(func() { assert.NoError(t, c.Close()) })
+		return c
+	}
+	mockLegacyGas := func(m *mocks.Estimator, gasPrice int64, limit uint32) *mock.Call {
+		return m.On("GetLegacyGas", mock.Anything, mock.Anything, gasLimit, maxGasPrice).Return(assets.NewWeiI(gasPrice), limit, nil)
+	}
+
+	t.Run("uses the median estimate and ignores estimators that failed", func(t *testing.T) {
+		cfg := gas.NewMockConfig()
+		cfg.CompositeEstimatorPolicyF = "Median"
+		estimators, ms := newEstimators(t, 4)
+		c := newComposite(t, cfg, estimators)
+
+		mockLegacyGas(ms[0], 10, 1)
+		mockLegacyGas(ms[1], 30, 2)
+		ms[2].On("GetLegacyGas", mock.Anything, mock.Anything, gasLimit, maxGasPrice).Return(nil, uint32(0), errors.New("boom"))
+		mockLegacyGas(ms[3], 20, 3)
+
+		gasPrice, chainSpecificGasLimit, err := c.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(20), gasPrice)
+		assert.Equal(t, uint32(3), chainSpecificGasLimit)
+	})
+
+	t.Run("uses the highest estimate", func(t *testing.T) {
+		cfg := gas.NewMockConfig()
+		cfg.CompositeEstimatorPolicyF = "Max"
+		estimators, ms := newEstimators(t, 2)
+		c := newComposite(t, cfg, estimators)
+
+		ms[0].On("GetDynamicFee", mock.Anything, gasLimit, maxGasPrice).Return(gas.DynamicFee{TipCap: assets.NewWeiI(5), FeeCap: assets.NewWeiI(500)}, gasLimit, nil)
+		ms[1].On("GetDynamicFee", mock.Anything, gasLimit, maxGasPrice).Return(gas.DynamicFee{TipCap: assets.NewWeiI(7), FeeCap: assets.NewWeiI(100)}, gasLimit, nil)
+
+		fee, _, err := c.GetDynamicFee(testutils.Context(t), gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, gas.DynamicFee{TipCap: assets.NewWeiI(7), FeeCap: assets.NewWeiI(100)}, fee)
+	})
+
+	t.Run("uses the first estimator that did not fail", func(t *testing.T) {
+		cfg := gas.NewMockConfig()
+		cfg.CompositeEstimatorPolicyF = "FirstHealthy"
+		estimators, ms := newEstimators(t, 3)
+		c := newComposite(t, cfg, estimators)
+
+		ms[0].On("BumpLegacyGas", mock.Anything, assets.NewWeiI(10), gasLimit, maxGasPrice, mock.Anything).Return(nil, uint32(0), errors.New("bump gas is not supported"))
+		ms[1].On("BumpLegacyGas", mock.Anything, assets.NewWeiI(10), gasLimit, maxGasPrice, mock.Anything).Return(assets.NewWeiI(12), gasLimit, nil)
+		ms[2].On("BumpLegacyGas", mock.Anything, assets.NewWeiI(10), gasLimit, maxGasPrice, mock.Anything).Return(assets.NewWeiI(15), gasLimit, nil)
+
+		gasPrice, _, err := c.BumpLegacyGas(testutils.Context(t), assets.NewWeiI(10), gasLimit, maxGasPrice, nil)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(12), gasPrice)
+	})
+
+	t.Run("returns the first error if all estimators failed", func(t *testing.T) {
+		cfg := gas.NewMockConfig()
+		cfg.CompositeEstimatorPolicyF = "Median"
+		estimators, ms := newEstimators(t, 2)
+		c := newComposite(t, cfg, estimators)
+
+		ms[0].On("BumpLegacyGas", mock.Anything, assets.NewWeiI(10), gasLimit, maxGasPrice, mock.Anything).Return(nil, uint32(0), gas.ErrBumpGasExceedsLimit)
+		ms[1].On("BumpLegacyGas", mock.Anything, assets.NewWeiI(10), gasLimit, maxGasPrice, mock.Anything).Return(nil, uint32(0), errors.New("boom"))
+
+		_, _, err := c.BumpLegacyGas(testutils.Context(t), assets.NewWeiI(10), gasLimit, maxGasPrice, nil)
+		require.Error(t, err)
+		assert.EqualError(t, err, "all 2 gas estimators failed: A estimator failed: gas bump exceeds limit")
+		assert.True(t, gas.IsBumpErr(err))
+	})
+
+	t.Run("does not use estimators that failed to start", func(t *testing.T) {
+		cfg := gas.NewMockConfig()
+		cfg.CompositeEstimatorPolicyF = "Median"
+		failing := mocks.NewEstimator(t)
+		failing.On("Start", mock.Anything).Return(errors.New("no blocks")).Once()
+		estimators, ms := newEstimators(t, 1)
+		c := newComposite(t, cfg, append([]gas.NamedEstimator{{Name: "failing", Estimator: failing}}, estimators...))
+
+		mockLegacyGas(ms[0], 10, gasLimit)
+		gasPrice, _, err := c.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(10), gasPrice)
+	})
+
+	t.Run("fails to start if all estimators failed to start", func(t *testing.T) {
+		failing := mocks.NewEstimator(t)
+		failing.On("Start", mock.Anything).Return(errors.New("no blocks")).Once()
+		c := gas.NewCompositeEstimator(logger.TestLogger(t), gas.NewMockConfig(), cltest.FixtureChainID, []gas.NamedEstimator{{Name: "failing", Estimator: failing}})
+		assert.EqualError(t, c.Start(testutils.Context(t)), "failed to start failing estimator: no blocks")
+	})
+
+	t.Run("rejects estimates outside of the band around recent estimates", func(t *testing.T) {
+		cfg := gas.NewMockConfig()
+		cfg.CompositeEstimatorPolicyF = "Max"
+		cfg.CompositeEstimatorBandPercentF = 50
+		cfg.CompositeEstimatorHistorySizeF = 2
+		estimators, ms := newEstimators(t, 2)
+		c := newComposite(t, cfg, estimators)
+
+		mockLegacyGas(ms[0], 100, gasLimit).Once()
+		mockLegacyGas(ms[1], 90, gasLimit).Once()
+		gasPrice, _, err := c.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(100), gasPrice)
+
+		// 1000 is above the band of 50 to 150 around 100
+		mockLegacyGas(ms[0], 1000, gasLimit).Once()
+		mockLegacyGas(ms[1], 120, gasLimit).Once()
+		gasPrice, _, err = c.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(120), gasPrice)
+
+		// Median of 100 and 120 is 100
+		mockLegacyGas(ms[0], 1000, gasLimit).Once()
+		mockLegacyGas(ms[1], 49, gasLimit).Once()
+		_, _, err = c.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		assert.EqualError(t, err, "all 2 gas estimates were rejected for being outside of the band of 50 wei to 150 wei around the median of recent estimates")
+
+		// History is reset after HistorySize estimations in a row were rejected
+		mockLegacyGas(ms[0], 1000, gasLimit).Once()
+		mockLegacyGas(ms[1], 900, gasLimit).Once()
+		gasPrice, _, err = c.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(1000), gasPrice)
+	})
+	t.Run("rejects estimates as far below as above recent estimates", func(t *testing.T) {
+		cfg := gas.NewMockConfig()
+		cfg.CompositeEstimatorPolicyF = "Max"
+		cfg.CompositeEstimatorBandPercentF = 50
+		cfg.CompositeEstimatorHistorySizeF = 2
+		estimators, ms := newEstimators(t, 2)
+		c := newComposite(t, cfg, estimators)
+
+		mockLegacyGas(ms[0], 100, gasLimit).Once()
+		mockLegacyGas(ms[1], 100, gasLimit).Once()
+		_, _, err := c.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		require.NoError(t, err)
+
+		// 50 is at the lower bound of the band, 151 is above it
+		mockLegacyGas(ms[0], 151, gasLimit).Once()
+		mockLegacyGas(ms[1], 50, gasLimit).Once()
+		gasPrice, _, err := c.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(50).String(), gasPrice.String())
+
+		// Bands of 100% or more have no lower bound
+		cfg.CompositeEstimatorBandPercentF = 100
+		mockLegacyGas(ms[0], 1, gasLimit).Once()
+		mockLegacyGas(ms[1], 1, gasLimit).Once()
+		gasPrice, _, err = c.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(1).String(), gasPrice.String())
+	})
+
+	t.Run("finds the first active percentile estimator", func(t *testing.T) {
+		estimators, _ := newEstimators(t, 1)
+		assert.Nil(t, gas.GetPercentileEstimator(newComposite(t, gas.NewMockConfig(), estimators)))
+
+		failing := &percentileEstimator{mocks.NewEstimator(t)}
+		failing.On("Start", mock.Anything).Return(errors.New("no blocks")).Once()
+		active := &percentileEstimator{mocks.NewEstimator(t)}
+		active.On("Start", mock.Anything).Return(nil).Once()
+		active.On("Close").Return(nil).Once()
+		estimators, _ = newEstimators(t, 1)
+		c := newComposite(t, gas.NewMockConfig(), append(estimators, gas.NamedEstimator{Name: "failing", Estimator: failing}, gas.NamedEstimator{Name: "active", Estimator: active}))
+		assert.Same(t, active, gas.GetPercentileEstimator(c))
+	})
+}
+
+type percentileEstimator struct {
+	*mocks.Estimator
+}
+
+func (*percentileEstimator) PercentilePrices(uint16) (gasPrice, tipCap *assets.Wei, err error) {
+	return nil, nil, nil
+}
+
+func (*percentileEstimator) AverageBlockTime() time.Duration { return 0 }
+
+func (*percentileEstimator) BasePercentile() uint16 { return 0 }
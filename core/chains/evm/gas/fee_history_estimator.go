@@ -0,0 +1,530 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	evmtypes "github.com/smartcontractkit/chainlink/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/utils"
+	"github.com/smartcontractkit/chainlink/core/utils/mathutil"
+)
+
+var (
+	promFeeHistoryEstimatorConnectivityFailureCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fee_history_estimator_connectivity_failure_count",
+		Help: "Counter is incremented every time a gas bump is prevented due to a detected network propagation/connectivity issue",
+	},
+		[]string{"evmChainID", "mode"},
+	)
+)
+
+var (
+	_ Estimator           = &FeeHistoryEstimator{}
+	_ PercentileEstimator = &FeeHistoryEstimator{}
+)
+
+// feeHistory is the result of eth_feeHistory
+type feeHistory struct {
+	OldestBlock hexutil.Big `json:"oldestBlock"`
+	// BaseFeePerGas holds one more entry than the other fields: the base fee
+	// of the block after the newest one
+	BaseFeePerGas []hexutil.Big   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64       `json:"gasUsedRatio"`
+	Reward        [][]hexutil.Big `json:"reward"`
+}
+
+// FeeHistoryBlock holds the fees paid in a block, as returned by eth_feeHistory
+type FeeHistoryBlock struct {
+	Number  int64
+	BaseFee *assets.Wei
+	// Reward is the tip paid at the FeeHistoryEstimatorRewardPercentile of
+	// the transactions of the block, weighted by gas used
+	Reward *assets.Wei
+	// InclusionReward is the tip paid at the
+	// BlockHistoryEstimatorCheckInclusionPercentile of the transactions of
+	// the block, weighted by gas used
+	InclusionReward *assets.Wei
+	// Empty blocks report a reward of zero, which must not be used for
+	// estimation
+	Empty bool
+}
+
+// FeeHistoryEstimator is an Estimator which derives prices from the tips paid
+// in recent blocks and from the base fee of the next block, as reported by
+// eth_feeHistory. Unlike the BlockHistoryEstimator, it does not need to
+// download full blocks, which makes it much lighter on RPC nodes.
+type FeeHistoryEstimator struct {
+	utils.StartStopOnce
+	client    rpcClient
+	chainID   big.Int
+	config    Config
+	mb        *utils.Mailbox[*evmtypes.Head]
+	wg        *sync.WaitGroup
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	// NOTE: blocks are kept sorted by block number ascending
+	blocks       []FeeHistoryBlock
+	gasPrice     *assets.Wei
+	tipCap       *assets.Wei
+	baseFee      *assets.Wei
+	priceMu      sync.RWMutex
+	initialFetch atomic.Bool
+
+	logger logger.SugaredLogger
+}
+
+// NewFeeHistoryEstimator returns a new FeeHistoryEstimator that fetches the
+// fee history of the chain on every new head
+func NewFeeHistoryEstimator(lggr logger.Logger, client rpcClient, cfg Config, chainID big.Int) Estimator {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &FeeHistoryEstimator{
+		client:    client,
+		chainID:   chainID,
+		config:    cfg,
+		mb:        utils.NewSingleMailbox[*evmtypes.Head](),
+		wg:        new(sync.WaitGroup),
+		ctx:       ctx,
+		ctxCancel: cancel,
+		logger:    logger.Sugared(lggr.Named("FeeHistoryEstimator")),
+	}
+}
+
+// OnNewLongestChain schedules a refresh of the fee history up to head
+func (f *FeeHistoryEstimator) OnNewLongestChain(_ context.Context, head *evmtypes.Head) {
+	f.mb.Deliver(head)
+}
+
+// Start starts FeeHistoryEstimator service.
+// The provided context can be used to terminate Start sequence.
+func (f *FeeHistoryEstimator) Start(ctx context.Context) error {
+	return f.StartOnce("FeeHistoryEstimator", func() error {
+		f.logger.Trace("Starting")
+
+		if f.config.BlockHistoryEstimatorCheckInclusionBlocks() > 0 {
+			f.logger.Infof("Inclusion checking enabled, bumping will be prevented on transactions that have been priced above the %d percentile for %d blocks", f.config.BlockHistoryEstimatorCheckInclusionPercentile(), f.config.BlockHistoryEstimatorCheckInclusionBlocks())
+		}
+		if f.config.FeeHistoryEstimatorBlockCount() == 0 {
+			return errors.New("FeeHistoryEstimatorBlockCount must be set to a value greater than 0")
+		}
+
+		fetchCtx, cancel := context.WithTimeout(ctx, MaxStartTime)
+		defer cancel()
+		f.FetchAndRecalculate(fetchCtx, nil)
+
+		// NOTE: This only checks the start context, not the fetch context
+		if ctx.Err() != nil {
+			return errors.Wrap(ctx.Err(), "failed to start FeeHistoryEstimator due to main context error")
+		}
+
+		f.wg.Add(1)
+		go f.runLoop()
+
+		f.logger.Trace("Started")
+		return nil
+	})
+}
+
+func (f *FeeHistoryEstimator) Close() error {
+	return f.StopOnce("FeeHistoryEstimator", func() error {
+		f.ctxCancel()
+		f.wg.Wait()
+		return nil
+	})
+}
+
+func (f *FeeHistoryEstimator) runLoop() {
+	defer f.wg.Done()
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-f.mb.Notify():
+			head, exists := f.mb.Retrieve()
+			if !exists {
+				f.logger.Debug("No head to retrieve")
+				continue
+			}
+			f.FetchAndRecalculate(f.ctx, head)
+		}
+	}
+}
+
+// FetchAndRecalculate fetches the fee history leading up to head, or to the
+// latest block if head is nil, and recalculates the prices.
+func (f *FeeHistoryEstimator) FetchAndRecalculate(ctx context.Context, head *evmtypes.Head) {
+	blocks, nextBaseFee, err := f.FetchFeeHistory(ctx, head)
+	if err != nil {
+		f.logger.Warnw("Error fetching fee history", "head", head, "err", err)
+		return
+	}
+	f.initialFetch.Store(true)
+	f.Recalculate(blocks, nextBaseFee)
+}
+
+// FetchFeeHistory returns the fees paid in the blocks leading up to head, or
+// to the latest block if head is nil, and the base fee of the block after them.
+func (f *FeeHistoryEstimator) FetchFeeHistory(ctx context.Context, head *evmtypes.Head) (blocks []FeeHistoryBlock, nextBaseFee *assets.Wei, err error) {
+	newestBlock := "latest"
+	if head != nil {
+		newestBlock = Int64ToHex(mathutil.Max(head.Number-int64(f.config.BlockHistoryEstimatorBlockDelay()), 0))
+	}
+	// Must have enough blocks for both estimator and connectivity checker
+	blockCount := mathutil.Max(f.config.FeeHistoryEstimatorBlockCount(), f.config.BlockHistoryEstimatorCheckInclusionBlocks())
+	rewardPercentile := float64(f.config.FeeHistoryEstimatorRewardPercentile())
+	inclusionPercentile := float64(f.config.BlockHistoryEstimatorCheckInclusionPercentile())
+	// eth_feeHistory requires the percentiles in increasing order
+	percentiles := []float64{rewardPercentile}
+	rewardIdx, inclusionIdx := 0, 0
+	if inclusionPercentile > rewardPercentile {
+		percentiles = append(percentiles, inclusionPercentile)
+		inclusionIdx = 1
+	} else if inclusionPercentile < rewardPercentile {
+		percentiles = []float64{inclusionPercentile, rewardPercentile}
+		rewardIdx = 1
+	}
+
+	var res feeHistory
+	if err = f.client.CallContext(ctx, &res, "eth_feeHistory", hexutil.Uint(blockCount), newestBlock, percentiles); err != nil {
+		return nil, nil, errors.Wrap(err, "eth_feeHistory failed")
+	}
+	n := len(res.GasUsedRatio)
+	if n == 0 {
+		return nil, nil, errors.New("eth_feeHistory returned no blocks")
+	} else if len(res.BaseFeePerGas) != n+1 {
+		return nil, nil, errors.Errorf("eth_feeHistory returned %d base fees for %d blocks, expected %d", len(res.BaseFeePerGas), n, n+1)
+	} else if len(res.Reward) != n {
+		return nil, nil, errors.Errorf("eth_feeHistory returned %d rewards for %d blocks", len(res.Reward), n)
+	}
+	oldest := res.OldestBlock.ToInt().Int64()
+	for i := 0; i < n; i++ {
+		if len(res.Reward[i]) != len(percentiles) {
+			return nil, nil, errors.Errorf("eth_feeHistory returned %d rewards for block %d, expected %d", len(res.Reward[i]), oldest+int64(i), len(percentiles))
+		}
+		blocks = append(blocks, FeeHistoryBlock{
+			Number:          oldest + int64(i),
+			BaseFee:         assets.NewWei(res.BaseFeePerGas[i].ToInt()),
+			Reward:          assets.NewWei(res.Reward[i][rewardIdx].ToInt()),
+			InclusionReward: assets.NewWei(res.Reward[i][inclusionIdx].ToInt()),
+			Empty:           res.GasUsedRatio[i] == 0,
+		})
+	}
+	return blocks, assets.NewWei(res.BaseFeePerGas[n].ToInt()), nil
+}
+
+// Recalculate sets the fee history and recalculates the prices from it.
+//
+// The tip cap is the FeeHistoryEstimatorRewardPercentile of the tips paid in
+// the last FeeHistoryEstimatorBlockCount non-empty blocks, each of which is
+// itself the tip paid at that percentile of the transactions of the block.
+// The legacy gas price is that tip on top of the base fee of the next block,
+// which the RPC node derives from how full the latest block was. On chains
+// without EIP-1559 the base fee is zero, and tips are the full gas prices.
+func (f *FeeHistoryEstimator) Recalculate(blocks []FeeHistoryBlock, nextBaseFee *assets.Wei) {
+	f.priceMu.Lock()
+	f.blocks = blocks
+	f.baseFee = nextBaseFee
+	f.priceMu.Unlock()
+
+	rewards := f.recentRewards(blocks)
+	if len(rewards) == 0 {
+		f.logger.Debug("No non-empty blocks in fee history, cannot set gas price")
+		return
+	}
+	percentile := int(f.config.FeeHistoryEstimatorRewardPercentile())
+	tipCap := rewards[((len(rewards)-1)*percentile)/100]
+	gasPrice := nextBaseFee.Add(tipCap)
+
+	f.logger.Debugw(fmt.Sprintf("Setting new default prices, GasPrice: %s, TipCap: %s", gasPrice, tipCap),
+		"gasPriceWei", gasPrice,
+		"tipCapWei", tipCap,
+		"baseFeeWei", nextBaseFee,
+		"maxGasPriceWei", f.config.EvmMaxGasPriceWei(),
+		"oldestBlock", blocks[0].Number,
+		"newestBlock", blocks[len(blocks)-1].Number,
+	)
+	f.setGasPrice(gasPrice)
+	f.setTipCap(tipCap)
+}
+
+// recentRewards returns the rewards of the last FeeHistoryEstimatorBlockCount
+// non-empty blocks, sorted ascending
+func (f *FeeHistoryEstimator) recentRewards(blocks []FeeHistoryBlock) (rewards []*assets.Wei) {
+	l := mathutil.Min(len(blocks), int(f.config.FeeHistoryEstimatorBlockCount()))
+	for _, block := range blocks[len(blocks)-l:] {
+		if !block.Empty {
+			rewards = append(rewards, block.Reward)
+		}
+	}
+	sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+	return
+}
+
+func (f *FeeHistoryEstimator) setGasPrice(gasPrice *assets.Wei) {
+	max := f.config.EvmMaxGasPriceWei()
+	min := f.config.EvmMinGasPriceWei()
+
+	f.priceMu.Lock()
+	defer f.priceMu.Unlock()
+	if gasPrice.Cmp(max) > 0 {
+		f.logger.Warnw(fmt.Sprintf("Calculated gas price of %s exceeds EVM.GasEstimator.PriceMax=%[2]s, setting gas price to the maximum allowed value of %[2]s instead", gasPrice.String(), max.String()), "gasPriceWei", gasPrice, "maxGasPriceWei", max)
+		f.gasPrice = max
+	} else if gasPrice.Cmp(min) < 0 {
+		f.logger.Warnw(fmt.Sprintf("Calculated gas price of %s falls below EVM.GasEstimator.PriceMin=%[2]s, setting gas price to the minimum allowed value of %[2]s instead", gasPrice.String(), min.String()), "gasPriceWei", gasPrice, "minGasPriceWei", min)
+		f.gasPrice = min
+	} else {
+		f.gasPrice = gasPrice
+	}
+}
+
+func (f *FeeHistoryEstimator) setTipCap(tipCap *assets.Wei) {
+	max := f.config.EvmMaxGasPriceWei()
+	min := f.config.EvmGasTipCapMinimum()
+
+	f.priceMu.Lock()
+	defer f.priceMu.Unlock()
+	if tipCap.Cmp(max) > 0 {
+		f.logger.Warnw(fmt.Sprintf("Calculated gas tip cap of %s exceeds EVM.GasEstimator.PriceMax=%[2]s, setting gas tip cap to the maximum allowed value of %[2]s instead", tipCap.String(), max.String()), "tipCapWei", tipCap, "minTipCapWei", min, "maxTipCapWei", max)
+		f.tipCap = max
+	} else if tipCap.Cmp(min) < 0 {
+		f.logger.Warnw(fmt.Sprintf("Calculated gas tip cap of %s falls below EVM.GasEstimator.TipCapMin=%[2]s, setting gas tip cap to the minimum allowed value of %[2]s instead", tipCap.String(), min.String()), "tipCapWei", tipCap, "minTipCapWei", min, "maxTipCapWei", max)
+		f.tipCap = min
+	} else {
+		f.tipCap = tipCap
+	}
+}
+
+func (f *FeeHistoryEstimator) getGasPrice() *assets.Wei {
+	f.priceMu.RLock()
+	defer f.priceMu.RUnlock()
+	return f.gasPrice
+}
+
+func (f *FeeHistoryEstimator) getTipCap() *assets.Wei {
+	f.priceMu.RLock()
+	defer f.priceMu.RUnlock()
+	return f.tipCap
+}
+
+func (f *FeeHistoryEstimator) getBaseFee() *assets.Wei {
+	f.priceMu.RLock()
+	defer f.priceMu.RUnlock()
+	return f.baseFee
+}
+
+func (f *FeeHistoryEstimator) getBlocks() []FeeHistoryBlock {
+	f.priceMu.RLock()
+	defer f.priceMu.RUnlock()
+	return f.blocks
+}
+
+// BasePercentile returns the FeeHistoryEstimatorRewardPercentile
+func (f *FeeHistoryEstimator) BasePercentile() uint16 {
+	return f.config.FeeHistoryEstimatorRewardPercentile()
+}
+
+// PercentilePrices returns the prices at percentile of the tips paid in the
+// last FeeHistoryEstimatorBlockCount non-empty blocks, as Recalculate does for
+// the FeeHistoryEstimatorRewardPercentile. The tips of each block are those
+// paid at the FeeHistoryEstimatorRewardPercentile of its transactions, since
+// eth_feeHistory does not return all of them.
+func (f *FeeHistoryEstimator) PercentilePrices(percentile uint16) (gasPrice, tipCap *assets.Wei, err error) {
+	f.priceMu.RLock()
+	blocks, baseFee := f.blocks, f.baseFee
+	f.priceMu.RUnlock()
+
+	rewards := f.recentRewards(blocks)
+	if len(rewards) == 0 {
+		return nil, nil, errors.New("no non-empty blocks in fee history")
+	}
+	tip := rewards[((len(rewards)-1)*int(mathutil.Min(percentile, 100)))/100]
+	if f.config.EvmEIP1559DynamicFees() {
+		tipCap = tip
+	}
+	return baseFee.Add(tip), tipCap, nil
+}
+
+// AverageBlockTime always returns zero, since eth_feeHistory does not return
+// the timestamps of the blocks
+func (f *FeeHistoryEstimator) AverageBlockTime() time.Duration {
+	return 0
+}
+
+func (f *FeeHistoryEstimator) GetLegacyGas(_ context.Context, _ []byte, gasLimit uint32, maxGasPriceWei *assets.Wei, _ ...Opt) (gasPrice *assets.Wei, chainSpecificGasLimit uint32, err error) {
+	ok := f.IfStarted(func() {
+		chainSpecificGasLimit = applyMultiplier(gasLimit, f.config.EvmGasLimitMultiplier())
+		gasPrice = f.getGasPrice()
+	})
+	if !ok {
+		return nil, 0, errors.New("FeeHistoryEstimator is not started; cannot estimate gas")
+	}
+	if gasPrice == nil {
+		if !f.initialFetch.Load() {
+			return nil, 0, errors.New("FeeHistoryEstimator has not finished the first gas estimation yet, likely because a failure on start")
+		}
+		f.logger.Warn("Failed to estimate gas price. This is likely because all the blocks in the fee history are empty." +
+			"Using EvmGasPriceDefault as fallback.")
+		gasPrice = f.config.EvmGasPriceDefault()
+	}
+	gasPrice = capGasPrice(gasPrice, maxGasPriceWei, f.config)
+	return
+}
+
+func (f *FeeHistoryEstimator) BumpLegacyGas(_ context.Context, originalGasPrice *assets.Wei, gasLimit uint32, maxGasPriceWei *assets.Wei, attempts []PriorAttempt) (bumpedGasPrice *assets.Wei, chainSpecificGasLimit uint32, err error) {
+	if f.config.BlockHistoryEstimatorCheckInclusionBlocks() > 0 {
+		if err = f.checkConnectivity(attempts); err != nil {
+			if errors.Is(err, ErrConnectivity) {
+				f.logger.Criticalw("Gas bumping is being prevented due to a detected connectivity issue; this requires immediate action to fix", "err", err)
+				promFeeHistoryEstimatorConnectivityFailureCount.WithLabelValues(f.chainID.String(), "legacy").Inc()
+			}
+			return nil, 0, err
+		}
+	}
+	return BumpLegacyGasPriceOnly(f.config, f.logger, f.getGasPrice(), originalGasPrice, gasLimit, maxGasPriceWei)
+}
+
+func (f *FeeHistoryEstimator) GetDynamicFee(_ context.Context, gasLimit uint32, maxGasPriceWei *assets.Wei) (fee DynamicFee, chainSpecificGasLimit uint32, err error) {
+	if !f.config.EvmEIP1559DynamicFees() {
+		return fee, 0, errors.New("Can't get dynamic fee, EIP1559 is disabled")
+	}
+
+	ok := f.IfStarted(func() {
+		chainSpecificGasLimit = applyMultiplier(gasLimit, f.config.EvmGasLimitMultiplier())
+		fee.TipCap = f.getTipCap()
+		if fee.TipCap == nil {
+			if !f.initialFetch.Load() {
+				err = errors.New("FeeHistoryEstimator has not finished the first gas estimation yet, likely because a failure on start")
+				return
+			}
+			f.logger.Warn("Failed to estimate gas price. This is likely because all the blocks in the fee history are empty." +
+				"Using EvmGasTipCapDefault as fallback.")
+			fee.TipCap = f.config.EvmGasTipCapDefault()
+		}
+		maxGasPrice := getMaxGasPrice(maxGasPriceWei, f.config)
+		if f.config.EvmGasBumpThreshold() == 0 {
+			// just use the max gas price if gas bumping is disabled
+			fee.FeeCap = maxGasPrice
+		} else if baseFee := f.getBaseFee(); baseFee != nil {
+			// HACK: due to a flaw of how EIP-1559 is implemented we have to
+			// set a much lower FeeCap than the actual maximum we are willing
+			// to pay in order to give ourselves headroom for bumping
+			// See: https://github.com/ethereum/go-ethereum/issues/24284
+			fee.FeeCap = calcFeeCap(baseFee, f.config, fee.TipCap, maxGasPrice)
+		} else {
+			err = errors.New("FeeHistoryEstimator: no value for next block base fee; cannot estimate EIP-1559 base fee")
+		}
+	})
+	if !ok {
+		return fee, 0, errors.New("FeeHistoryEstimator is not started; cannot estimate gas")
+	}
+	if err != nil {
+		return DynamicFee{}, 0, err
+	}
+	return
+}
+
+func (f *FeeHistoryEstimator) BumpDynamicFee(_ context.Context, originalFee DynamicFee, originalGasLimit uint32, maxGasPriceWei *assets.Wei, attempts []PriorAttempt) (bumped DynamicFee, chainSpecificGasLimit uint32, err error) {
+	if f.config.BlockHistoryEstimatorCheckInclusionBlocks() > 0 {
+		if err = f.checkConnectivity(attempts); err != nil {
+			if errors.Is(err, ErrConnectivity) {
+				f.logger.Criticalw("Gas bumping is being prevented due to a detected connectivity issue; this requires immediate action to fix", "err", err)
+				promFeeHistoryEstimatorConnectivityFailureCount.WithLabelValues(f.chainID.String(), "eip1559").Inc()
+			}
+			return bumped, 0, err
+		}
+	}
+	return BumpDynamicFeeOnly(f.config, f.logger, f.getTipCap(), f.getBaseFee(), originalFee, originalGasLimit, maxGasPriceWei)
+}
+
+// checkConnectivity detects if the transaction is not being included due to
+// some kind of mempool propagation or connectivity issue rather than
+// insufficiently high pricing and returns error if so. That is the case when
+// an attempt has been pending for BlockHistoryEstimatorCheckInclusionBlocks,
+// while its tip was higher than the tip paid at the
+// BlockHistoryEstimatorCheckInclusionPercentile of every non-empty block
+// since it was broadcast.
+func (f *FeeHistoryEstimator) checkConnectivity(attempts []PriorAttempt) error {
+	percentile := f.config.BlockHistoryEstimatorCheckInclusionPercentile()
+	expectInclusionWithinBlocks := int(f.config.BlockHistoryEstimatorCheckInclusionBlocks())
+	blockHistory := f.getBlocks()
+	if len(blockHistory) == 0 {
+		f.logger.Warn("Latest block is unknown; skipping inclusion check")
+		return nil
+	} else if len(blockHistory) < expectInclusionWithinBlocks {
+		f.logger.Warnf("Fee history with length %d is insufficient to determine whether transaction should have been included within the past %d blocks", len(blockHistory), expectInclusionWithinBlocks)
+		return nil
+	}
+	latestBlockNum := blockHistory[len(blockHistory)-1].Number
+	for _, attempt := range attempts {
+		if attempt.GetBroadcastBeforeBlockNum() == nil {
+			// this shouldn't happen; any broadcast attempt ought to have a
+			// BroadcastBeforeBlockNum otherwise its an assumption violation
+			return errors.Errorf("BroadcastBeforeBlockNum was unexpectedly nil for attempt %s", attempt.GetHash().Hex())
+		}
+		broadcastBeforeBlockNum := *attempt.GetBroadcastBeforeBlockNum()
+		blocksSinceBroadcast := latestBlockNum - broadcastBeforeBlockNum
+		if blocksSinceBroadcast < int64(expectInclusionWithinBlocks) {
+			// only check attempts that have been waiting around longer than
+			// BlockHistoryEstimatorCheckInclusionBlocks
+			continue
+		}
+		switch attempt.GetTxType() {
+		case 0x0, 0x1, 0x2:
+		default:
+			return errors.Errorf("attempt %s has unknown transaction type 0x%d", attempt.GetHash(), attempt.GetTxType())
+		}
+		var checked int
+		aboveAll := true
+		// highest -> lowest block number, so we can bail out early
+		for i := len(blockHistory) - 1; i >= len(blockHistory)-expectInclusionWithinBlocks; i-- {
+			block := blockHistory[i]
+			if block.Number < broadcastBeforeBlockNum {
+				break
+			} else if block.Empty {
+				continue
+			}
+			checked++
+			tip := effectiveTip(attempt, block.BaseFee)
+			if tip == nil || tip.Cmp(block.InclusionReward) <= 0 {
+				aboveAll = false
+				break
+			}
+		}
+		if checked == 0 {
+			f.logger.Warnf("no suitable blocks found to verify if transaction %s has been included within expected inclusion blocks of %d", attempt.GetHash().Hex(), expectInclusionWithinBlocks)
+			return nil
+		}
+		if aboveAll {
+			return errors.Wrapf(ErrConnectivity, "transaction %s has a tip above percentile=%d%% of the tips paid in every block from %d thru %d (checking %d blocks)", attempt.GetHash(), percentile, broadcastBeforeBlockNum, latestBlockNum, expectInclusionWithinBlocks)
+		}
+	}
+	return nil
+}
+
+// effectiveTip returns the tip that attempt would pay in a block with baseFee,
+// or nil if it could not be included in that block
+func effectiveTip(attempt PriorAttempt, baseFee *assets.Wei) *assets.Wei {
+	if attempt.GetTxType() == 0x2 {
+		fee := attempt.DynamicFee()
+		if fee.FeeCap.Cmp(fee.TipCap.Add(baseFee)) < 0 {
+			return nil
+		}
+		return fee.TipCap
+	}
+	if attempt.GetGasPrice().Cmp(baseFee) < 0 {
+		return nil
+	}
+	return attempt.GetGasPrice().Sub(baseFee)
+}
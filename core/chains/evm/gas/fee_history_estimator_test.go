@@ -0,0 +1,254 @@
+package gas_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas/mocks"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// Blocks 100 thru 103 with base fees 100 thru 130, and 140 for the next block.
+// Block 101 is empty.
+const testFeeHistory = `{
+	"oldestBlock": "0x64",
+	"baseFeePerGas": ["0x64", "0x6e", "0x78", "0x82", "0x8c"],
+	"gasUsedRatio": [0.5, 0, 0.6, 0.9],
+	"reward": [["0xa", "0x14"], ["0x0", "0x0"], ["0x1e", "0x28"], ["0x14", "0x32"]]
+}`
+
+func newFeeHistoryConfig() *gas.MockConfig {
+	cfg := gas.NewMockConfig()
+	cfg.EvmEIP1559DynamicFeesF = true
+	cfg.FeeHistoryEstimatorBlockCountF = 4
+	cfg.FeeHistoryEstimatorRewardPercentileF = 50
+	cfg.BlockHistoryEstimatorCheckInclusionPercentileF = 90
+	cfg.EvmGasBumpPercentF = 20
+	cfg.EvmGasBumpThresholdF = 3
+	cfg.EvmGasBumpWeiF = assets.NewWeiI(5)
+	cfg.EvmGasLimitMultiplierF = 1
+	cfg.EvmGasPriceDefaultF = assets.NewWeiI(42)
+	cfg.EvmGasTipCapDefaultF = assets.NewWeiI(7)
+	cfg.EvmGasTipCapMinimumF = assets.NewWeiI(1)
+	cfg.EvmMaxGasPriceWeiF = assets.NewWeiI(1000)
+	cfg.EvmMinGasPriceWeiF = assets.NewWeiI(1)
+	return cfg
+}
+
+func mockFeeHistory(client *mocks.RPCClient, blockCount uint, newestBlock string, feeHistory string) *mock.Call {
+	return client.On("CallContext", mock.Anything, mock.Anything, "eth_feeHistory", hexutil.Uint(blockCount), newestBlock, []float64{50, 90}).Return(nil).Run(func(args mock.Arguments) {
+		if err := json.Unmarshal([]byte(feeHistory), args.Get(1)); err != nil {
+			panic(err)
+		}
+	})
+}
+
+func TestFeeHistoryEstimator(t *testing.T) {
+	t.Parallel()
+
+	const gasLimit uint32 = 80000
+	maxGasPrice := assets.NewWeiI(500)
+
+	t.Run("calling GetLegacyGas on unstarted estimator returns error", func(t *testing.T) {
+		client := mocks.NewRPCClient(t)
+		f := gas.NewFeeHistoryEstimator(logger.TestLogger(t), client, newFeeHistoryConfig(), cltest.FixtureChainID)
+		_, _, err := f.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		assert.EqualError(t, err, "FeeHistoryEstimator is not started; cannot estimate gas")
+	})
+
+	t.Run("estimates prices from the fee history on start", func(t *testing.T) {
+		client := mocks.NewRPCClient(t)
+		mockFeeHistory(client, 4, "latest", testFeeHistory).Once()
+
+		f := gas.NewFeeHistoryEstimator(logger.TestLogger(t), client, newFeeHistoryConfig(), cltest.FixtureChainID)
+		require.NoError(t, f.Start(testutils.Context(t)))
+		t.Cleanup(func() { assert.NoError(t, f.Close()) })
+
+		// Non-empty block rewards are 10, 30 and 20, so the median is 20,
+		// on top of the base fee of 140 of the next block
+		gasPrice, chainSpecificGasLimit, err := f.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(160), gasPrice)
+		assert.Equal(t, gasLimit, chainSpecificGasLimit)
+
+		fee, chainSpecificGasLimit, err := f.GetDynamicFee(testutils.Context(t), gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(20), fee.TipCap)
+		assert.Equal(t, assets.NewWeiI(160), fee.FeeCap)
+		assert.Equal(t, gasLimit, chainSpecificGasLimit)
+
+		gasPrice, _, err = f.GetLegacyGas(testutils.Context(t), nil, gasLimit, assets.NewWeiI(150))
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(150), gasPrice)
+	})
+
+	t.Run("fetches the fee history up to the head minus the block delay", func(t *testing.T) {
+		client := mocks.NewRPCClient(t)
+		cfg := newFeeHistoryConfig()
+		cfg.BlockHistoryEstimatorBlockDelayF = 1
+		cfg.BlockHistoryEstimatorCheckInclusionBlocksF = 6
+		mockFeeHistory(client, 6, "0x6d", testFeeHistory).Once()
+
+		f := gas.FeeHistoryEstimatorFromInterface(gas.NewFeeHistoryEstimator(logger.TestLogger(t), client, cfg, cltest.FixtureChainID))
+		blocks, nextBaseFee, err := f.FetchFeeHistory(testutils.Context(t), cltest.Head(110))
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(140), nextBaseFee)
+		require.Len(t, blocks, 4)
+		assert.Equal(t, gas.FeeHistoryBlock{Number: 100, BaseFee: assets.NewWeiI(100), Reward: assets.NewWeiI(10), InclusionReward: assets.NewWeiI(20)}, blocks[0])
+		assert.Equal(t, int64(101), blocks[1].Number)
+		assert.Equal(t, assets.NewWeiI(110).String(), blocks[1].BaseFee.String())
+		require.NotNil(t, blocks[1].Reward)
+		assert.Equal(t, assets.NewWeiI(0).String(), blocks[1].Reward.String())
+		require.NotNil(t, blocks[1].InclusionReward)
+		assert.Equal(t, assets.NewWeiI(0).String(), blocks[1].InclusionReward.String())
+		assert.True(t, blocks[1].Empty)
+	})
+
+	t.Run("returns error on a malformed fee history", func(t *testing.T) {
+		client := mocks.NewRPCClient(t)
+		mockFeeHistory(client, 4, "latest", `{"oldestBlock": "0x64", "baseFeePerGas": ["0x64"], "gasUsedRatio": [0.5], "reward": [["0xa", "0x14"]]}`).Once()
+		client.On("CallContext", mock.Anything, mock.Anything, "eth_feeHistory", hexutil.Uint(4), "latest", []float64{50, 90}).Return(errors.New("method not found")).Once()
+
+		f := gas.FeeHistoryEstimatorFromInterface(gas.NewFeeHistoryEstimator(logger.TestLogger(t), client, newFeeHistoryConfig(), cltest.FixtureChainID))
+		_, _, err := f.FetchFeeHistory(testutils.Context(t), nil)
+		assert.EqualError(t, err, "eth_feeHistory returned 1 base fees for 1 blocks, expected 2")
+		_, _, err = f.FetchFeeHistory(testutils.Context(t), nil)
+		assert.EqualError(t, err, "eth_feeHistory failed: method not found")
+	})
+
+	t.Run("falls back to the default prices if all the blocks are empty", func(t *testing.T) {
+		client := mocks.NewRPCClient(t)
+		mockFeeHistory(client, 4, "latest", `{"oldestBlock": "0x64", "baseFeePerGas": ["0x64", "0x64"], "gasUsedRatio": [0], "reward": [["0x0", "0x0"]]}`).Once()
+
+		f := gas.NewFeeHistoryEstimator(logger.TestLogger(t), client, newFeeHistoryConfig(), cltest.FixtureChainID)
+		require.NoError(t, f.Start(testutils.Context(t)))
+		t.Cleanup(func() { assert.NoError(t, f.Close()) })
+
+		gasPrice, _, err := f.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(42), gasPrice)
+
+		fee, _, err := f.GetDynamicFee(testutils.Context(t), gasLimit, maxGasPrice)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(7), fee.TipCap)
+		assert.Equal(t, assets.NewWeiI(107), fee.FeeCap)
+	})
+
+	t.Run("bumps from the current prices", func(t *testing.T) {
+		client := mocks.NewRPCClient(t)
+		mockFeeHistory(client, 4, "latest", testFeeHistory).Once()
+
+		f := gas.NewFeeHistoryEstimator(logger.TestLogger(t), client, newFeeHistoryConfig(), cltest.FixtureChainID)
+		require.NoError(t, f.Start(testutils.Context(t)))
+		t.Cleanup(func() { assert.NoError(t, f.Close()) })
+
+		// The current price of 160 is above the bumped price of 120
+		gasPrice, _, err := f.BumpLegacyGas(testutils.Context(t), assets.NewWeiI(100), gasLimit, maxGasPrice, nil)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(160), gasPrice)
+
+		bumped, _, err := f.BumpDynamicFee(testutils.Context(t), gas.DynamicFee{TipCap: assets.NewWeiI(20), FeeCap: assets.NewWeiI(200)}, gasLimit, maxGasPrice, nil)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(25), bumped.TipCap)
+		assert.Equal(t, assets.NewWeiI(240), bumped.FeeCap)
+	})
+}
+
+func TestFeeHistoryEstimator_PercentilePrices(t *testing.T) {
+	t.Parallel()
+
+	cfg := newFeeHistoryConfig()
+	f := gas.FeeHistoryEstimatorFromInterface(gas.NewFeeHistoryEstimator(logger.TestLogger(t), mocks.NewRPCClient(t), cfg, cltest.FixtureChainID))
+	assert.Equal(t, uint16(50), f.BasePercentile())
+	assert.Zero(t, f.AverageBlockTime())
+
+	t.Run("fails without non-empty blocks", func(t *testing.T) {
+		_, _, err := f.PercentilePrices(50)
+		require.EqualError(t, err, "no non-empty blocks in fee history")
+	})
+
+	// The blocks of testFeeHistory
+	blocks := []gas.FeeHistoryBlock{
+		{Number: 100, BaseFee: assets.NewWeiI(100), Reward: assets.NewWeiI(10)},
+		{Number: 101, BaseFee: assets.NewWeiI(110), Reward: assets.NewWeiI(0), Empty: true},
+		{Number: 102, BaseFee: assets.NewWeiI(120), Reward: assets.NewWeiI(30)},
+		{Number: 103, BaseFee: assets.NewWeiI(130), Reward: assets.NewWeiI(20)},
+	}
+	f.Recalculate(blocks, assets.NewWeiI(140))
+
+	t.Run("prices transactions at any percentile of the tips of non-empty blocks", func(t *testing.T) {
+		// Non-empty block rewards are 10, 20 and 30, on top of the base fee of
+		// 140 of the next block
+		for percentile, expected := range map[uint16]int64{0: 10, 50: 20, 100: 30} {
+			gasPrice, tipCap, err := f.PercentilePrices(percentile)
+			require.NoError(t, err)
+			assert.Equal(t, assets.NewWeiI(140+expected), gasPrice, "percentile %d", percentile)
+			assert.Equal(t, assets.NewWeiI(expected), tipCap, "percentile %d", percentile)
+		}
+	})
+
+	t.Run("returns no tip cap if EIP-1559 is disabled", func(t *testing.T) {
+		cfg.EvmEIP1559DynamicFeesF = false
+		t.Cleanup(func() { cfg.EvmEIP1559DynamicFeesF = true })
+		gasPrice, tipCap, err := f.PercentilePrices(100)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(170), gasPrice)
+		assert.Nil(t, tipCap)
+	})
+}
+
+func TestFeeHistoryEstimator_CheckConnectivity(t *testing.T) {
+	t.Parallel()
+
+	cfg := newFeeHistoryConfig()
+	cfg.BlockHistoryEstimatorCheckInclusionBlocksF = 2
+	f := gas.FeeHistoryEstimatorFromInterface(gas.NewFeeHistoryEstimator(logger.TestLogger(t), nil, cfg, cltest.FixtureChainID))
+
+	attempt := func(broadcastBeforeBlockNum int64, gasPrice int64) gas.PriorAttempt {
+		return &MockAttempt{TxType: 0x0, Hash: utils.NewHash(), BroadcastBeforeBlockNum: &broadcastBeforeBlockNum, GasPrice: assets.NewWeiI(gasPrice)}
+	}
+
+	t.Run("skips connectivity check if fee history is unknown", func(t *testing.T) {
+		require.NoError(t, f.CheckConnectivity([]gas.PriorAttempt{attempt(10, 200)}))
+	})
+
+	block := func(n int64) gas.FeeHistoryBlock {
+		return gas.FeeHistoryBlock{Number: n, BaseFee: assets.NewWeiI(100), Reward: assets.NewWeiI(30), InclusionReward: assets.NewWeiI(50)}
+	}
+	f.Recalculate([]gas.FeeHistoryBlock{block(10), block(11), block(12)}, assets.NewWeiI(100))
+
+	t.Run("returns error if an attempt paid more than the percentile tip of every block since broadcast", func(t *testing.T) {
+		err := f.CheckConnectivity([]gas.PriorAttempt{attempt(10, 200)})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, gas.ErrConnectivity))
+		assert.Contains(t, err.Error(), "has a tip above percentile=90% of the tips paid in every block from 10 thru 12 (checking 2 blocks)")
+	})
+
+	t.Run("does not return error if an attempt paid less", func(t *testing.T) {
+		require.NoError(t, f.CheckConnectivity([]gas.PriorAttempt{attempt(10, 140)}))
+	})
+
+	t.Run("does not check attempts that were recently broadcast", func(t *testing.T) {
+		require.NoError(t, f.CheckConnectivity([]gas.PriorAttempt{attempt(11, 200)}))
+	})
+
+	t.Run("does not return error if a dynamic fee attempt could not be included", func(t *testing.T) {
+		n := int64(10)
+		a := &MockAttempt{TxType: 0x2, Hash: utils.NewHash(), BroadcastBeforeBlockNum: &n, GasTipCap: assets.NewWeiI(60), GasFeeCap: assets.NewWeiI(150)}
+		require.NoError(t, f.CheckConnectivity([]gas.PriorAttempt{a}))
+
+		a.GasFeeCap = assets.NewWeiI(160)
+		assert.True(t, errors.Is(f.CheckConnectivity([]gas.PriorAttempt{a}), gas.ErrConnectivity))
+	})
+}
@@ -71,6 +71,14 @@ func SimulateStart(t *testing.T, b *BlockHistoryEstimator) {
 	require.NoError(t, b.StartOnce("BlockHistoryEstimatorSimulatedStart", func() error { return nil }))
 }
 
+func (f *FeeHistoryEstimator) CheckConnectivity(attempts []PriorAttempt) error {
+	return f.checkConnectivity(attempts)
+}
+
+func FeeHistoryEstimatorFromInterface(fhe Estimator) *FeeHistoryEstimator {
+	return fhe.(*FeeHistoryEstimator)
+}
+
 type MockConfig struct {
 	BlockHistoryEstimatorBatchSizeF                 uint32
 	BlockHistoryEstimatorBlockDelayF                uint16
@@ -80,6 +88,10 @@ type MockConfig struct {
 	BlockHistoryEstimatorEIP1559FeeCapBufferBlocksF uint16
 	BlockHistoryEstimatorTransactionPercentileF     uint16
 	ChainTypeF                                      string
+	CompositeEstimatorBandPercentF                  uint16
+	CompositeEstimatorHistorySizeF                  uint16
+	CompositeEstimatorModesF                        []string
+	CompositeEstimatorPolicyF                       string
 	EvmEIP1559DynamicFeesF                          bool
 	EvmGasBumpPercentF                              uint16
 	EvmGasBumpThresholdF                            uint64
@@ -90,6 +102,8 @@ type MockConfig struct {
 	EvmMaxGasPriceWeiF                              *assets.Wei
 	EvmMinGasPriceWeiF                              *assets.Wei
 	EvmGasPriceDefaultF                             *assets.Wei
+	FeeHistoryEstimatorBlockCountF                  uint16
+	FeeHistoryEstimatorRewardPercentileF            uint16
 }
 
 func NewMockConfig() *MockConfig {
@@ -128,6 +142,22 @@ func (m *MockConfig) ChainType() config.ChainType {
 	return config.ChainType(m.ChainTypeF)
 }
 
+func (m *MockConfig) CompositeEstimatorBandPercent() uint16 {
+	return m.CompositeEstimatorBandPercentF
+}
+
+func (m *MockConfig) CompositeEstimatorHistorySize() uint16 {
+	return m.CompositeEstimatorHistorySizeF
+}
+
+func (m *MockConfig) CompositeEstimatorModes() []string {
+	return m.CompositeEstimatorModesF
+}
+
+func (m *MockConfig) CompositeEstimatorPolicy() string {
+	return m.CompositeEstimatorPolicyF
+}
+
 func (m *MockConfig) EvmEIP1559DynamicFees() bool {
 	return m.EvmEIP1559DynamicFeesF
 }
@@ -180,6 +210,14 @@ func (m *MockConfig) EvmMinGasPriceWei() *assets.Wei {
 	return m.EvmMinGasPriceWeiF
 }
 
+func (m *MockConfig) FeeHistoryEstimatorBlockCount() uint16 {
+	return m.FeeHistoryEstimatorBlockCountF
+}
+
+func (m *MockConfig) FeeHistoryEstimatorRewardPercentile() uint16 {
+	return m.FeeHistoryEstimatorRewardPercentileF
+}
+
 func (m *MockConfig) GasEstimatorMode() string {
 	panic("not implemented") // TODO: Implement
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -37,6 +38,10 @@ func NewEstimator(lggr logger.Logger, ethClient evmclient.Client, cfg Config) Es
 		"blockHistorySize", cfg.BlockHistoryEstimatorBlockHistorySize(),
 		"eip1559FeeCapBufferBlocks", cfg.BlockHistoryEstimatorEIP1559FeeCapBufferBlocks(),
 		"transactionPercentile", cfg.BlockHistoryEstimatorTransactionPercentile(),
+		"feeHistoryBlockCount", cfg.FeeHistoryEstimatorBlockCount(),
+		"feeHistoryRewardPercentile", cfg.FeeHistoryEstimatorRewardPercentile(),
+		"compositeModes", cfg.CompositeEstimatorModes(),
+		"compositePolicy", cfg.CompositeEstimatorPolicy(),
 		"eip1559DynamicFees", cfg.EvmEIP1559DynamicFees(),
 		"gasBumpPercent", cfg.EvmGasBumpPercent(),
 		"gasBumpThreshold", cfg.EvmGasBumpThreshold(),
@@ -49,11 +54,20 @@ func NewEstimator(lggr logger.Logger, ethClient evmclient.Client, cfg Config) Es
 		"maxGasPriceWei", cfg.EvmMaxGasPriceWei(),
 		"minGasPriceWei", cfg.EvmMinGasPriceWei(),
 	)
+	if s == "Composite" {
+		return newCompositeEstimator(lggr, ethClient, cfg)
+	}
+	return newEstimator(lggr, ethClient, cfg, s)
+}
+
+func newEstimator(lggr logger.Logger, ethClient evmclient.Client, cfg Config, s string) Estimator {
 	switch s {
 	case "Arbitrum":
 		return NewArbitrumEstimator(lggr, cfg, ethClient, ethClient)
 	case "BlockHistory":
 		return NewBlockHistoryEstimator(lggr, ethClient, cfg, *ethClient.ChainID())
+	case "FeeHistory":
+		return NewFeeHistoryEstimator(lggr, ethClient, cfg, *ethClient.ChainID())
 	case "FixedPrice":
 		return NewFixedPriceEstimator(cfg, lggr)
 	case "Optimism2", "L2Suggested":
@@ -106,6 +120,39 @@ type Estimator interface {
 	BumpDynamicFee(ctx context.Context, original DynamicFee, gasLimit uint32, maxGasPriceWei *assets.Wei, attempts []PriorAttempt) (bumped DynamicFee, chainSpecificGasLimit uint32, err error)
 }
 
+// PercentileEstimator is implemented by estimators that keep the prices paid
+// by the transactions in recent blocks, so that transactions can be priced at
+// any percentile of them.
+type PercentileEstimator interface {
+	// BasePercentile is the percentile of the prices in recent blocks that
+	// new transactions are priced at
+	BasePercentile() uint16
+	// PercentilePrices returns the gas price and tip cap paid by percentile
+	// of the transactions in recent blocks. The tip cap is nil unless
+	// EIP-1559 is enabled.
+	PercentilePrices(percentile uint16) (gasPrice, tipCap *assets.Wei, err error)
+	// AverageBlockTime returns the average time between recent blocks, or
+	// zero if it is not known
+	AverageBlockTime() time.Duration
+}
+
+// GetPercentileEstimator returns estimator as a PercentileEstimator, or the
+// first active estimator of a CompositeEstimator that is one. It returns nil
+// if there is none.
+func GetPercentileEstimator(estimator Estimator) PercentileEstimator {
+	switch e := estimator.(type) {
+	case PercentileEstimator:
+		return e
+	case *CompositeEstimator:
+		for _, n := range e.active {
+			if pe := GetPercentileEstimator(n.Estimator); pe != nil {
+				return pe
+			}
+		}
+	}
+	return nil
+}
+
 // Opt is an option for a gas estimator
 type Opt int
 
@@ -130,6 +177,10 @@ type Config interface {
 	BlockHistoryEstimatorEIP1559FeeCapBufferBlocks() uint16
 	BlockHistoryEstimatorTransactionPercentile() uint16
 	ChainType() config.ChainType
+	CompositeEstimatorBandPercent() uint16
+	CompositeEstimatorHistorySize() uint16
+	CompositeEstimatorModes() []string
+	CompositeEstimatorPolicy() string
 	EvmEIP1559DynamicFees() bool
 	EvmFinalityDepth() uint32
 	EvmGasBumpPercent() uint16
@@ -143,6 +194,8 @@ type Config interface {
 	EvmGasTipCapMinimum() *assets.Wei
 	EvmMaxGasPriceWei() *assets.Wei
 	EvmMinGasPriceWei() *assets.Wei
+	FeeHistoryEstimatorBlockCount() uint16
+	FeeHistoryEstimatorRewardPercentile() uint16
 	GasEstimatorMode() string
 }
 
@@ -0,0 +1,91 @@
+package gas
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+)
+
+// Prices is a snapshot of what an estimator currently believes gas costs on
+// its chain: the prices a new transaction would be sent with, and the prices
+// a pending attempt sent with those would be bumped to.
+type Prices struct {
+	EIP1559DynamicFees bool
+	// LegacyGasPrice and BumpedLegacyGasPrice are nil if they could not be
+	// estimated
+	LegacyGasPrice       *assets.Wei
+	BumpedLegacyGasPrice *assets.Wei
+	// DynamicFee and BumpedDynamicFee are only estimated for chains with
+	// EIP-1559 dynamic fees enabled
+	DynamicFee       *DynamicFee
+	BumpedDynamicFee *DynamicFee
+	// BlockHistory is only set for the BlockHistory estimator, including as
+	// part of a Composite estimator
+	BlockHistory *BlockHistoryWindow
+}
+
+// GetPrices returns the current prices of estimator for a transaction with
+// gasLimit. Bumps are calculated for a hypothetical attempt, and nothing is
+// sent. Estimating has no side effects on estimator: a Composite estimator is
+// queried through a snapshot, so its history of recent estimates and its
+// metrics are left untouched. Prices that could not be estimated are left nil,
+// and the errors are returned alongside them.
+func GetPrices(ctx context.Context, estimator Estimator, cfg Config, gasLimit uint32) (prices Prices, merr error) {
+	if estimator == nil {
+		return prices, errors.New("no gas estimator is running for this chain")
+	}
+	maxGasPrice := cfg.EvmMaxGasPriceWei()
+	prices.EIP1559DynamicFees = cfg.EvmEIP1559DynamicFees()
+	prices.BlockHistory = blockHistoryWindow(estimator)
+	if c, ok := estimator.(*CompositeEstimator); ok {
+		estimator = c.snapshot()
+	}
+
+	gasPrice, _, err := estimator.GetLegacyGas(ctx, nil, gasLimit, maxGasPrice)
+	if err != nil {
+		merr = multierr.Append(merr, errors.Wrap(err, "failed to estimate legacy gas price"))
+	} else {
+		prices.LegacyGasPrice = gasPrice
+		bumped, _, err := estimator.BumpLegacyGas(ctx, gasPrice, gasLimit, maxGasPrice, nil)
+		if err != nil {
+			merr = multierr.Append(merr, errors.Wrap(err, "failed to bump legacy gas price"))
+		} else {
+			prices.BumpedLegacyGasPrice = bumped
+		}
+	}
+
+	if !prices.EIP1559DynamicFees {
+		return
+	}
+	fee, _, err := estimator.GetDynamicFee(ctx, gasLimit, maxGasPrice)
+	if err != nil {
+		merr = multierr.Append(merr, errors.Wrap(err, "failed to estimate dynamic fee"))
+		return
+	}
+	prices.DynamicFee = &fee
+	bumped, _, err := estimator.BumpDynamicFee(ctx, fee, gasLimit, maxGasPrice, nil)
+	if err != nil {
+		merr = multierr.Append(merr, errors.Wrap(err, "failed to bump dynamic fee"))
+		return
+	}
+	prices.BumpedDynamicFee = &bumped
+	return
+}
+
+func blockHistoryWindow(estimator Estimator) *BlockHistoryWindow {
+	switch e := estimator.(type) {
+	case *BlockHistoryEstimator:
+		w := e.Window()
+		return &w
+	case *CompositeEstimator:
+		for _, n := range e.active {
+			if w := blockHistoryWindow(n.Estimator); w != nil {
+				return w
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package gas_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas/mocks"
+	evmtypes "github.com/smartcontractkit/chainlink/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/evmtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+func TestGetPrices(t *testing.T) {
+	t.Parallel()
+
+	const gasLimit uint32 = 500000
+	maxGasPrice := assets.GWei(100)
+
+	t.Run("returns the legacy gas price and its bump", func(t *testing.T) {
+		cfg := gas.NewMockConfig()
+		cfg.EvmMaxGasPriceWeiF = maxGasPrice
+		estimator := mocks.NewEstimator(t)
+		estimator.On("GetLegacyGas", mock.Anything, []byte(nil), gasLimit, maxGasPrice).Return(assets.GWei(10), gasLimit, nil)
+		estimator.On("BumpLegacyGas", mock.Anything, assets.GWei(10), gasLimit, maxGasPrice, []gas.PriorAttempt(nil)).Return(assets.GWei(12), gasLimit, nil)
+
+		prices, err := gas.GetPrices(testutils.Context(t), estimator, cfg, gasLimit)
+		require.NoError(t, err)
+		assert.False(t, prices.EIP1559DynamicFees)
+		assert.Equal(t, assets.GWei(10), prices.LegacyGasPrice)
+		assert.Equal(t, assets.GWei(12), prices.BumpedLegacyGasPrice)
+		assert.Nil(t, prices.DynamicFee)
+		assert.Nil(t, prices.BumpedDynamicFee)
+		assert.Nil(t, prices.BlockHistory)
+	})
+
+	t.Run("returns the dynamic fee and its bump, and the prices that failed", func(t *testing.T) {
+		cfg := gas.NewMockConfig()
+		cfg.EvmMaxGasPriceWeiF = maxGasPrice
+		cfg.EvmEIP1559DynamicFeesF = true
+		estimator := mocks.NewEstimator(t)
+		fee := gas.DynamicFee{TipCap: assets.GWei(2), FeeCap: assets.GWei(40)}
+		bumped := gas.DynamicFee{TipCap: assets.GWei(3), FeeCap: assets.GWei(44)}
+		estimator.On("GetLegacyGas", mock.Anything, []byte(nil), gasLimit, maxGasPrice).Return(nil, uint32(0), errors.New("boom"))
+		estimator.On("GetDynamicFee", mock.Anything, gasLimit, maxGasPrice).Return(fee, gasLimit, nil)
+		estimator.On("BumpDynamicFee", mock.Anything, fee, gasLimit, maxGasPrice, []gas.PriorAttempt(nil)).Return(bumped, gasLimit, nil)
+
+		prices, err := gas.GetPrices(testutils.Context(t), estimator, cfg, gasLimit)
+		require.EqualError(t, err, "failed to estimate legacy gas price: boom")
+		assert.True(t, prices.EIP1559DynamicFees)
+		assert.Nil(t, prices.LegacyGasPrice)
+		assert.Nil(t, prices.BumpedLegacyGasPrice)
+		assert.Equal(t, &fee, prices.DynamicFee)
+		assert.Equal(t, &bumped, prices.BumpedDynamicFee)
+	})
+
+	t.Run("returns the window of the block history estimator", func(t *testing.T) {
+		cfg := gas.NewMockConfig()
+		cfg.EvmMaxGasPriceWeiF = maxGasPrice
+		cfg.EvmGasBumpPercentF = 10
+		cfg.EvmGasBumpWeiF = assets.NewWeiI(1)
+		cfg.EvmGasLimitMultiplierF = 1
+		cfg.BlockHistoryEstimatorBlockHistorySizeF = 2
+		cfg.BlockHistoryEstimatorTransactionPercentileF = 35
+		bhe := newBlockHistoryEstimator(t, evmtest.NewEthClientMockWithDefaultChain(t), cfg)
+		gas.SimulateStart(t, bhe)
+		gas.SetRollingBlockHistory(bhe, []evmtypes.Block{{Number: 41}, {Number: 42}, {Number: 43}})
+		gas.SetGasPrice(bhe, assets.GWei(10))
+
+		prices, err := gas.GetPrices(testutils.Context(t), bhe, cfg, gasLimit)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(10), prices.LegacyGasPrice)
+		assert.Equal(t, assets.GWei(11), prices.BumpedLegacyGasPrice)
+		require.NotNil(t, prices.BlockHistory)
+		assert.Equal(t, uint16(35), prices.BlockHistory.Percentile)
+		assert.Equal(t, uint16(2), prices.BlockHistory.Size)
+		assert.Equal(t, []int64{41, 42}, prices.BlockHistory.BlockNumbers)
+		assert.Equal(t, assets.GWei(10), prices.BlockHistory.GasPrice)
+		assert.Nil(t, prices.BlockHistory.TipCap)
+	})
+
+	t.Run("does not change the history of the composite estimator", func(t *testing.T) {
+		cfg := gas.NewMockConfig()
+		cfg.EvmMaxGasPriceWeiF = maxGasPrice
+		cfg.CompositeEstimatorPolicyF = "Max"
+		cfg.CompositeEstimatorBandPercentF = 50
+		cfg.CompositeEstimatorHistorySizeF = 2
+		m := mocks.NewEstimator(t)
+		m.On("Start", mock.Anything).Return(nil).Once()
+		m.On("Close").Return(nil).Once()
+		c := gas.NewCompositeEstimator(logger.TestLogger(t), cfg, cltest.FixtureChainID, []gas.NamedEstimator{{Name: "A", Estimator: m}})
+		require.NoError(t, c.Start(testutils.Context(t)))
+		t.Cleanup(func() { assert.NoError(t, c.Close()) })
+
+		m.On("GetLegacyGas", mock.Anything, []byte(nil), gasLimit, maxGasPrice).Return(assets.GWei(10), gasLimit, nil).Once()
+		_, _, err := c.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		require.NoError(t, err)
+
+		// Rejecting HistorySize estimations in a row would reset the history
+		m.On("GetLegacyGas", mock.Anything, []byte(nil), gasLimit, maxGasPrice).Return(assets.GWei(50), gasLimit, nil)
+		for i := 0; i < 2; i++ {
+			prices, err := gas.GetPrices(testutils.Context(t), c, cfg, gasLimit)
+			require.Error(t, err)
+			assert.Nil(t, prices.LegacyGasPrice)
+		}
+
+		_, _, err = c.GetLegacyGas(testutils.Context(t), nil, gasLimit, maxGasPrice)
+		assert.EqualError(t, err, "all 1 gas estimates were rejected for being outside of the band of 5 gwei to 15 gwei around the median of recent estimates")
+	})
+
+	t.Run("fails without an estimator", func(t *testing.T) {
+		_, err := gas.GetPrices(testutils.Context(t), nil, gas.NewMockConfig(), gasLimit)
+		assert.EqualError(t, err, "no gas estimator is running for this chain")
+	})
+}
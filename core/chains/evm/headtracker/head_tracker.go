@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -18,6 +19,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/config"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/utils"
+	"github.com/smartcontractkit/chainlink/core/utils/mathutil"
 )
 
 var (
@@ -185,6 +187,12 @@ func (ht *headTracker) handleNewHead(ctx context.Context, head *evmtypes.Head) e
 		"parentHeadHash", head.ParentHash,
 	)
 
+	if prevHead == nil || head.Number > prevHead.Number {
+		ht.setFinalized(ctx, head)
+	} else if head.Hash == prevHead.Hash {
+		head.Finalized = prevHead.Finalized
+	}
+
 	err := ht.headSaver.Save(ctx, head)
 	if ctx.Err() != nil {
 		return nil
@@ -209,7 +217,7 @@ func (ht *headTracker) handleNewHead(ctx context.Context, head *evmtypes.Head) e
 		}
 	} else {
 		ht.log.Debugw("Got out of order head", "blockNum", head.Number, "head", head.Hash.Hex(), "prevHead", prevHead.Number)
-		if head.Number < prevHead.Number-int64(ht.config.EvmFinalityDepth()) {
+		if head.Number < prevHead.LatestFinalizedBlockNumber(ht.config.EvmFinalityDepth()) {
 			promOldHead.WithLabelValues(ht.chainID.String()).Inc()
 			ht.log.Criticalf("Got very old block with number %d (highest seen was %d). This is a problem and either means a very deep re-org occurred, one of the RPC nodes has gotten far out of sync, or the chain went backwards in block numbers. This node may not function correctly without manual intervention.", head.Number, prevHead.Number)
 		}
@@ -217,6 +225,40 @@ func (ht *headTracker) handleNewHead(ctx context.Context, head *evmtypes.Head) e
 	return nil
 }
 
+// setFinalized sets the latest finalized block of head if the finality tag
+// is enabled. If the finalized block cannot be fetched, head is left without
+// one and FinalityDepth is used instead.
+func (ht *headTracker) setFinalized(ctx context.Context, head *evmtypes.Head) {
+	if !ht.config.EvmFinalityTagEnabled() {
+		return
+	}
+	finalized, err := ht.ethClient.HeadByNumber(ctx, big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+	if err == nil && finalized == nil {
+		err = errors.New("got nil head")
+	}
+	if ctx.Err() != nil {
+		return
+	} else if err != nil {
+		ht.log.Warnw("Failed to fetch the finalized block, falling back to EvmFinalityDepth", "blockNumber", head.Number, "err", err)
+		return
+	} else if finalized.Number > head.Number {
+		ht.log.Warnw("Finalized block is above the head, falling back to EvmFinalityDepth", "blockNumber", head.Number, "finalizedBlockNumber", finalized.Number)
+		return
+	}
+	finalized.Parent = nil
+	head.Finalized = finalized
+}
+
+// backfillDepth returns the number of heads to keep in the chain of head,
+// which reaches at least its finalized block
+func (ht *headTracker) backfillDepth(head *evmtypes.Head) uint {
+	depth := int64(ht.config.EvmFinalityDepth())
+	if f := head.Finalized; f != nil {
+		depth = mathutil.Max(depth, head.Number-f.Number+1)
+	}
+	return uint(mathutil.Min(depth, int64(ht.config.EvmHeadTrackerHistoryDepth())))
+}
+
 func (ht *headTracker) broadcastLoop() {
 	defer ht.wgDone.Done()
 
@@ -273,7 +315,7 @@ func (ht *headTracker) backfillLoop() {
 					break
 				}
 				{
-					err := ht.Backfill(ctx, head, uint(ht.config.EvmFinalityDepth()))
+					err := ht.Backfill(ctx, head, ht.backfillDepth(head))
 					if err != nil {
 						ht.log.Warnw("Unexpected error while backfilling heads", "err", err)
 					} else if ctx.Err() != nil {
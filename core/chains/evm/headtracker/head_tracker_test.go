@@ -17,11 +17,13 @@ import (
 	"github.com/ethereum/go-ethereum"
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/onsi/gomega"
 	"github.com/smartcontractkit/sqlx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 
 	evmclient "github.com/smartcontractkit/chainlink/core/chains/evm/client"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/headtracker"
@@ -260,6 +262,66 @@ func TestHeadTracker_CallsHeadTrackableCallbacks(t *testing.T) {
 	assert.Equal(t, int32(1), checker.OnNewLongestChainCount())
 }
 
+func TestHeadTracker_FinalityTagEnabled(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	db := pgtest.NewSqlxDB(t)
+	logger := logger.TestLogger(t)
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].FinalityDepth = ptr[uint32](1)
+		c.EVM[0].FinalityTagEnabled = ptr(true)
+	})
+	config := evmtest.NewChainScopedConfig(t, cfg)
+	orm := headtracker.NewORM(db, logger, config, cltest.FixtureChainID)
+
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	chchHeaders := make(chan evmtest.RawSub[*evmtypes.Head], 1)
+	mockEth := &evmtest.MockEth{EthClient: ethClient}
+	ethClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Return(
+			func(ctx context.Context, ch chan<- *evmtypes.Head) ethereum.Subscription {
+				sub := mockEth.NewSub(t)
+				chchHeaders <- evmtest.NewRawSub(ch, sub.Err())
+				return sub
+			},
+			func(ctx context.Context, ch chan<- *evmtypes.Head) error { return nil },
+		)
+	var lowestBackfilled atomic.Int64
+	lowestBackfilled.Store(10)
+	ethClient.On("HeadByNumber", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, n *big.Int) *evmtypes.Head {
+			switch {
+			case n == nil:
+				return cltest.Head(0)
+			case n.Int64() == rpc.FinalizedBlockNumber.Int64():
+				return cltest.Head(4)
+			}
+			if n.Int64() < lowestBackfilled.Load() {
+				lowestBackfilled.Store(n.Int64())
+			}
+			return cltest.Head(n.Int64())
+		},
+		func(ctx context.Context, n *big.Int) error { return nil },
+	)
+
+	ht := createHeadTracker(t, ethClient, config, orm)
+	ht.Start(t)
+
+	headers := <-chchHeaders
+	headers.TrySend(&evmtypes.Head{Number: 10, Hash: utils.NewHash(), EVMChainID: utils.NewBig(&cltest.FixtureChainID)})
+
+	g.Eventually(func() int64 {
+		latest := ht.headSaver.LatestChain()
+		if latest == nil || latest.Number != 10 || latest.Finalized == nil {
+			return -1
+		}
+		return latest.Finalized.Number
+	}).Should(gomega.Equal(int64(4)))
+	// Heads are backfilled down to the finalized block, not only FinalityDepth
+	g.Eventually(lowestBackfilled.Load).Should(gomega.Equal(int64(4)))
+}
+
 func TestHeadTracker_ReconnectOnError(t *testing.T) {
 	t.Parallel()
 	g := gomega.NewWithT(t)
@@ -30,6 +30,10 @@ func (disabled) RegisterFilter(filter Filter) (int, error) { return -1, ErrDisab
 
 func (disabled) UnregisterFilter(filterID int) error { return ErrDisabled }
 
+func (disabled) IndexedFrom(eventSig common.Hash, address common.Address) (int64, bool) {
+	return 0, false
+}
+
 func (disabled) LatestBlock(qopts ...pg.QOpt) (int64, error) { return -1, ErrDisabled }
 
 func (disabled) GetBlocksRange(ctx context.Context, numbers []uint64, qopts ...pg.QOpt) ([]LogPollerBlock, error) {
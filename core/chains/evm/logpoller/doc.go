@@ -11,7 +11,9 @@
 // - Queries always return the logs from the _current_ canonical chain (same as eth_getLogs). In particular
 // that means that querying unfinalized logs may change between queries but finalized logs remain stable.
 // The threshold between unfinalized and finalized logs is the finalityDepth parameter, chosen such that with
-// exceedingly high probability logs finalityDepth deep cannot be reorged.
+// exceedingly high probability logs finalityDepth deep cannot be reorged. On chains reporting the finalized
+// block tag, the poller can use the finalized block instead, and queries can pass Finalized as their confs
+// to only return finalized logs.
 // - After calling RegisterFilter with a particular event, it will never miss logs for that event
 // despite node crashes and reorgs. The granularity of the filter is always at least one block (more when backfilling).
 // - After calling Replay(fromBlock), all blocks including that one to the latest chain tip will be polled
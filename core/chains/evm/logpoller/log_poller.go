@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	evmtypes "github.com/smartcontractkit/chainlink/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -32,6 +33,10 @@ type LogPoller interface {
 	Replay(ctx context.Context, fromBlock int64) error
 	RegisterFilter(filter Filter) (int, error)
 	UnregisterFilter(filterID int) error
+	// IndexedFrom returns the block from which the logs of eventSig emitted
+	// by address have been indexed, or false if no registered filter has
+	// captured them yet.
+	IndexedFrom(eventSig common.Hash, address common.Address) (fromBlock int64, ok bool)
 	LatestBlock(qopts ...pg.QOpt) (int64, error)
 	GetBlocksRange(ctx context.Context, numbers []uint64, qopts ...pg.QOpt) ([]LogPollerBlock, error)
 	// General querying
@@ -48,6 +53,10 @@ type LogPoller interface {
 	LogsDataWordGreaterThan(eventSig common.Hash, address common.Address, wordIndex int, wordValueMin common.Hash, confs int, qopts ...pg.QOpt) ([]Log, error)
 }
 
+// Finalized can be passed as the confs of queries to only return logs in or
+// below the latest finalized block.
+const Finalized = -1
+
 type Client interface {
 	HeadByNumber(ctx context.Context, n *big.Int) (*evmtypes.Head, error)
 	HeadByHash(ctx context.Context, n common.Hash) (*evmtypes.Head, error)
@@ -69,6 +78,7 @@ type logPoller struct {
 	lggr              logger.Logger
 	pollPeriod        time.Duration // poll period set by block production rate
 	finalityDepth     int64         // finality depth is taken to mean that block (head - finality) is finalized
+	useFinalityTag    bool          // use the finalized block tag instead of finality depth to find the latest finalized block
 	keepBlocksDepth   int64         // the number of blocks behind the head for which we keep the blocks. Must be greater than finality depth + 1.
 	backfillBatchSize int64         // batch size to use when backfilling finalized logs
 	rpcBatchSize      int64         // batch size to use for fallback RPC calls made in GetBlocks
@@ -76,6 +86,7 @@ type logPoller struct {
 	filterMu        sync.RWMutex
 	currentFilterID int
 	filters         map[int]Filter
+	filterFrom      map[int]int64 // the first block polled with each filter
 	filterDirty     bool
 	cachedAddresses []common.Address
 	cachedEventSigs []common.Hash
@@ -101,7 +112,9 @@ type ReplayRequest struct {
 // - 1 db tx including block write and logs write to logs.
 // How fast that can be done depends largely on network speed and DB, but even for the fastest
 // support chain, polygon, which has 2s block times, we need RPCs roughly with <= 500ms latency
-func NewLogPoller(orm *ORM, ec Client, lggr logger.Logger, pollPeriod time.Duration, finalityDepth int64, backfillBatchSize int64, rpcBatchSize int64, keepBlocksDepth int64) *logPoller {
+// If useFinalityTag is set, the latest finalized block is fetched with the finalized block tag
+// (one more eth_getBlockByNumber call per poll) instead of assumed to be finalityDepth blocks behind the latest.
+func NewLogPoller(orm *ORM, ec Client, lggr logger.Logger, pollPeriod time.Duration, finalityDepth int64, useFinalityTag bool, backfillBatchSize int64, rpcBatchSize int64, keepBlocksDepth int64) *logPoller {
 	return &logPoller{
 		ec:                ec,
 		orm:               orm,
@@ -111,10 +124,12 @@ func NewLogPoller(orm *ORM, ec Client, lggr logger.Logger, pollPeriod time.Durat
 		done:              make(chan struct{}),
 		pollPeriod:        pollPeriod,
 		finalityDepth:     finalityDepth,
+		useFinalityTag:    useFinalityTag,
 		backfillBatchSize: backfillBatchSize,
 		rpcBatchSize:      rpcBatchSize,
 		keepBlocksDepth:   keepBlocksDepth,
 		filters:           make(map[int]Filter),
+		filterFrom:        make(map[int]int64),
 		filterDirty:       true, // Always build filter on first call to cache an empty filter if nothing registered yet.
 	}
 }
@@ -169,10 +184,42 @@ func (lp *logPoller) UnregisterFilter(filterID int) error {
 		return errors.Errorf("filter %d doesn't exist", filterID)
 	}
 	delete(lp.filters, filterID)
+	delete(lp.filterFrom, filterID)
 	lp.filterDirty = true
 	return nil
 }
 
+// IndexedFrom returns the earliest block from which a registered filter
+// capturing the logs of eventSig emitted by address has been polled. Logs in
+// blocks before that may be missing, e.g. if the filter was registered after
+// they were polled.
+func (lp *logPoller) IndexedFrom(eventSig common.Hash, address common.Address) (fromBlock int64, ok bool) {
+	lp.filterMu.Lock()
+	defer lp.filterMu.Unlock()
+	for id, filter := range lp.filters {
+		from, polled := lp.filterFrom[id]
+		if !polled || !slices.Contains(filter.EventSigs, eventSig) || !slices.Contains(filter.Addresses, address) {
+			continue
+		}
+		if !ok || from < fromBlock {
+			fromBlock, ok = from, true
+		}
+	}
+	return
+}
+
+// markFiltersPolledFrom records that the filters that were not polled yet
+// are polled from block on
+func (lp *logPoller) markFiltersPolledFrom(block int64) {
+	lp.filterMu.Lock()
+	defer lp.filterMu.Unlock()
+	for id := range lp.filters {
+		if _, polled := lp.filterFrom[id]; !polled {
+			lp.filterFrom[id] = block
+		}
+	}
+}
+
 func (lp *logPoller) filter(from, to *big.Int, bh *common.Hash) ethereum.FilterQuery {
 	lp.filterMu.Lock()
 	defer lp.filterMu.Unlock()
@@ -333,16 +380,20 @@ func (lp *logPoller) run() {
 					lp.lggr.Warnw("unable to get latest for first poll", "err", err)
 					continue
 				}
-				latestNum := latest.Number
+				latestFinalized, err := lp.latestFinalizedBlockNumber(lp.ctx, latest)
+				if err != nil {
+					lp.lggr.Warnw("unable to get latest finalized block for first poll", "err", err)
+					continue
+				}
 				// Do not support polling chains with don't even have finality depth worth of blocks.
 				// Could conceivably support this but not worth the effort.
 				// Need finality depth + 1, no block 0.
-				if latestNum <= lp.finalityDepth {
-					lp.lggr.Warnw("insufficient number of blocks on chain, waiting for finality depth", "err", err, "latest", latestNum, "finality", lp.finalityDepth)
+				if latestFinalized <= 0 {
+					lp.lggr.Warnw("insufficient number of blocks on chain, waiting for finality depth", "err", err, "latest", latest.Number, "finality", lp.finalityDepth)
 					continue
 				}
 				// Starting at the first finalized block. We do not backfill the first finalized block.
-				start = latestNum - lp.finalityDepth
+				start = latestFinalized
 			} else {
 				start = lastProcessed.BlockNumber + 1
 			}
@@ -529,13 +580,22 @@ func (lp *logPoller) pollAndSaveLogs(ctx context.Context, currentBlockNumber int
 		return
 	}
 	currentBlockNumber = currentBlock.Number
+	// Every filter registered by now is applied to the blocks from
+	// currentBlockNumber on, by the backfill and the polling below
+	lp.markFiltersPolledFrom(currentBlockNumber)
 
 	// Backfill finalized blocks if we can for performance. If we crash during backfill, we may reprocess logs.
 	// Log insertion is idempotent so this is ok.
 	// E.g. 1<-2<-3(currentBlockNumber)<-4<-5<-6<-7(latestBlockNumber), finality is 2. So 3,4 can be batched.
 	// Although 5 is finalized, we still need to save it to the db for reorg detection if 6 is a reorg.
 	// start = currentBlockNumber = 3, end = latestBlockNumber - finality - 1 = 7-2-1 = 4 (inclusive range).
-	lastSafeBackfillBlock := latestBlockNumber - lp.finalityDepth - 1
+	// With the finality tag, end is the block before the finalized block reported by the chain.
+	latestFinalizedBlockNumber, err := lp.latestFinalizedBlockNumber(ctx, latestBlock)
+	if err != nil {
+		lp.lggr.Warnw("Unable to get latest finalized block", "err", err, "currentBlockNumber", currentBlockNumber)
+		return
+	}
+	lastSafeBackfillBlock := latestFinalizedBlockNumber - 1
 	if lastSafeBackfillBlock >= currentBlockNumber {
 		lp.lggr.Infow("Backfilling logs", "start", currentBlockNumber, "end", lastSafeBackfillBlock)
 		if err = lp.backfill(ctx, currentBlockNumber, lastSafeBackfillBlock); err != nil {
@@ -566,7 +626,7 @@ func (lp *logPoller) pollAndSaveLogs(ctx context.Context, currentBlockNumber int
 		}
 		lp.lggr.Debugw("Unfinalized log query", "logs", len(logs), "currentBlockNumber", currentBlockNumber, "blockHash", currentBlock.Hash)
 		err = lp.orm.q.WithOpts(pg.WithParentCtx(ctx)).Transaction(func(tx pg.Queryer) error {
-			if err2 := lp.orm.InsertBlock(h, currentBlockNumber, pg.WithQueryer(tx)); err2 != nil {
+			if err2 := lp.orm.InsertBlock(h, currentBlockNumber, mathutil.Min(mathutil.Max(latestFinalizedBlockNumber, 0), currentBlockNumber), pg.WithQueryer(tx)); err2 != nil {
 				return err2
 			}
 			if len(logs) == 0 {
@@ -605,12 +665,16 @@ func (lp *logPoller) findBlockAfterLCA(ctx context.Context, current *evmtypes.He
 		return nil, err
 	}
 	blockAfterLCA := *current
-	reorgStart := parent.Number
 	// We expect reorgs up to the block after (current - finalityDepth),
 	// since the block at (current - finalityDepth) is finalized.
+	// With the finality tag, we expect them up to the block after the finalized block instead.
 	// We loop via parent instead of current so current always holds the LCA+1.
 	// If the parent block number becomes < the first finalized block our reorg is too deep.
-	for parent.Number >= (reorgStart - lp.finalityDepth) {
+	finalized, err := lp.latestFinalizedBlockNumber(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	for parent.Number >= finalized {
 		ourParentBlockHash, err := lp.orm.SelectBlockByNumber(parent.Number, pg.WithParentCtx(ctx))
 		if err != nil {
 			return nil, err
@@ -626,7 +690,7 @@ func (lp *logPoller) findBlockAfterLCA(ctx context.Context, current *evmtypes.He
 			return nil, err
 		}
 	}
-	lp.lggr.Criticalw("Reorg greater than finality depth detected", "max reorg depth", lp.finalityDepth-1)
+	lp.lggr.Criticalw("Reorg greater than finality depth detected", "max reorg depth", lp.finalityDepth-1, "finalizedBlockNumber", finalized)
 	return nil, errors.New("Reorg greater than finality depth")
 }
 
@@ -645,7 +709,34 @@ func (lp *logPoller) pruneOldBlocks(ctx context.Context) error {
 	}
 	// 1-2-3-4-5(latest), keepBlocksDepth=3
 	// Remove <= 2
-	return lp.orm.DeleteBlocksBefore(latest.Number-lp.keepBlocksDepth, pg.WithParentCtx(ctx))
+	end := latest.Number - lp.keepBlocksDepth
+	if lp.useFinalityTag {
+		// Always keep the finalized block for reorg detection on the first unfinalized block,
+		// even if it is more than keepBlocksDepth behind.
+		finalized, err := lp.latestFinalizedBlockNumber(ctx, latest)
+		if err != nil {
+			return err
+		}
+		end = mathutil.Min(end, finalized-1)
+	}
+	return lp.orm.DeleteBlocksBefore(end, pg.WithParentCtx(ctx))
+}
+
+// latestFinalizedBlockNumber returns the number of the latest finalized block as of latest: the block
+// reported with the finalized block tag if useFinalityTag is set, or finalityDepth blocks behind it.
+func (lp *logPoller) latestFinalizedBlockNumber(ctx context.Context, latest *evmtypes.Head) (int64, error) {
+	if !lp.useFinalityTag {
+		return latest.Number - lp.finalityDepth, nil
+	}
+	finalized, err := lp.ec.HeadByNumber(ctx, big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+	if err != nil {
+		return 0, err
+	}
+	if finalized == nil {
+		return 0, errors.New("received nil finalized block from RPC")
+	}
+	// The RPC may be ahead of latest, which is finalized then.
+	return mathutil.Min(finalized.Number, latest.Number), nil
 }
 
 // Logs returns logs matching topics and address (exactly) in the given block range,
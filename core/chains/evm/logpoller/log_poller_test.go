@@ -109,7 +109,7 @@ func TestLogPoller_SynchronizedWithGeth(t *testing.T) {
 		}, 10e6)
 		_, _, emitter1, err := log_emitter.DeployLogEmitter(owner, ec)
 		require.NoError(t, err)
-		lp := NewLogPoller(orm, client.NewSimulatedBackendClient(t, ec, chainID), lggr, 15*time.Second, int64(finalityDepth), 3, 2, 1000)
+		lp := NewLogPoller(orm, client.NewSimulatedBackendClient(t, ec, chainID), lggr, 15*time.Second, int64(finalityDepth), false, 3, 2, 1000)
 		for i := 0; i < finalityDepth; i++ { // Have enough blocks that we could reorg the full finalityDepth-1.
 			ec.Commit()
 		}
@@ -419,7 +419,7 @@ func TestLogPoller_Logs(t *testing.T) {
 }
 
 func TestLogPoller_RegisterFilter(t *testing.T) {
-	lp := NewLogPoller(nil, nil, nil, 15*time.Second, 1, 1, 2, 1000)
+	lp := NewLogPoller(nil, nil, nil, 15*time.Second, 1, false, 1, 2, 1000)
 	a1 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbb")
 	a2 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
 
@@ -428,10 +428,24 @@ func TestLogPoller_RegisterFilter(t *testing.T) {
 	require.Equal(t, 1, len(f.Addresses))
 	assert.Equal(t, common.HexToAddress("0x0000000000000000000000000000000000000000"), f.Addresses[0])
 
+	_, ok := lp.IndexedFrom(EmitterABI.Events["Log1"].ID, a1)
+	assert.False(t, ok)
+
 	_, err := lp.RegisterFilter(Filter{[]common.Hash{EmitterABI.Events["Log1"].ID}, []common.Address{a1}})
 	require.NoError(t, err)
 	assert.Equal(t, []common.Address{a1}, lp.Filter().Addresses)
 	assert.Equal(t, [][]common.Hash{{EmitterABI.Events["Log1"].ID}}, lp.Filter().Topics)
+	// Logs are only indexed once the filter is polled
+	_, ok = lp.IndexedFrom(EmitterABI.Events["Log1"].ID, a1)
+	assert.False(t, ok)
+	lp.markFiltersPolledFrom(10)
+	from, ok := lp.IndexedFrom(EmitterABI.Events["Log1"].ID, a1)
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), from)
+	_, ok = lp.IndexedFrom(EmitterABI.Events["Log2"].ID, a1)
+	assert.False(t, ok)
+	_, ok = lp.IndexedFrom(EmitterABI.Events["Log1"].ID, a2)
+	assert.False(t, ok)
 
 	// Should de-dupe EventSigs
 	_, err = lp.RegisterFilter(Filter{[]common.Hash{EmitterABI.Events["Log1"].ID, EmitterABI.Events["Log2"].ID}, []common.Address{a2}})
@@ -439,6 +453,15 @@ func TestLogPoller_RegisterFilter(t *testing.T) {
 	assert.Equal(t, []common.Address{a1, a2}, lp.Filter().Addresses)
 	assert.Equal(t, [][]common.Hash{{EmitterABI.Events["Log1"].ID, EmitterABI.Events["Log2"].ID}}, lp.Filter().Topics)
 
+	// Filters that were already polled keep their first block
+	lp.markFiltersPolledFrom(20)
+	from, ok = lp.IndexedFrom(EmitterABI.Events["Log1"].ID, a1)
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), from)
+	from, ok = lp.IndexedFrom(EmitterABI.Events["Log2"].ID, a2)
+	assert.True(t, ok)
+	assert.Equal(t, int64(20), from)
+
 	// Should de-dupe Addresses
 	_, err = lp.RegisterFilter(Filter{[]common.Hash{EmitterABI.Events["Log1"].ID, EmitterABI.Events["Log2"].ID}, []common.Address{a2}})
 	require.NoError(t, err)
@@ -622,7 +645,7 @@ func TestGetReplayFromBlock(t *testing.T) {
 
 func benchmarkFilter(b *testing.B, nFilters, nAddresses, nEvents int) {
 	lggr := logger.TestLogger(b)
-	lp := NewLogPoller(nil, nil, lggr, 1*time.Hour, 2, 3, 2, 1000)
+	lp := NewLogPoller(nil, nil, lggr, 1*time.Hour, 2, false, 3, 2, 1000)
 	for i := 0; i < nFilters; i++ {
 		var addresses []common.Address
 		var events []common.Hash
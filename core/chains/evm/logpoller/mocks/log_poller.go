@@ -77,6 +77,27 @@ func (_m *LogPoller) Healthy() error {
 	return r0
 }
 
+// IndexedFrom provides a mock function with given fields: eventSig, address
+func (_m *LogPoller) IndexedFrom(eventSig common.Hash, address common.Address) (int64, bool) {
+	ret := _m.Called(eventSig, address)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(common.Hash, common.Address) int64); ok {
+		r0 = rf(eventSig, address)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(common.Hash, common.Address) bool); ok {
+		r1 = rf(eventSig, address)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // IndexedLogs provides a mock function with given fields: eventSig, address, topicIndex, topicValues, confs, qopts
 func (_m *LogPoller) IndexedLogs(eventSig common.Hash, address common.Address, topicIndex int, topicValues []common.Hash, confs int, qopts ...pg.QOpt) ([]logpoller.Log, error) {
 	_va := make([]interface{}, len(qopts))
@@ -2,6 +2,7 @@ package logpoller
 
 import (
 	"database/sql"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -29,11 +30,12 @@ func NewORM(chainID *big.Int, db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig) *
 	}
 }
 
-// InsertBlock is idempotent to support replays.
-func (o *ORM) InsertBlock(h common.Hash, n int64, qopts ...pg.QOpt) error {
+// InsertBlock is idempotent to support replays. finalizedBlockNumber is the
+// latest finalized block as of the block.
+func (o *ORM) InsertBlock(h common.Hash, n int64, finalizedBlockNumber int64, qopts ...pg.QOpt) error {
 	q := o.q.WithOpts(qopts...)
-	err := q.ExecQ(`INSERT INTO log_poller_blocks (evm_chain_id, block_hash, block_number, created_at) 
-      VALUES ($1, $2, $3, NOW()) ON CONFLICT DO NOTHING`, utils.NewBig(o.chainID), h[:], n)
+	err := q.ExecQ(`INSERT INTO log_poller_blocks (evm_chain_id, block_hash, block_number, finalized_block_number, created_at) 
+      VALUES ($1, $2, $3, $4, NOW()) ON CONFLICT DO NOTHING`, utils.NewBig(o.chainID), h[:], n, finalizedBlockNumber)
 	return err
 }
 
@@ -71,13 +73,21 @@ func (o *ORM) SelectLatestLogEventSigWithConfs(eventSig common.Hash, address com
          WHERE evm_chain_id = $1 
             AND event_sig = $2 
             AND address = $3 
-            AND (block_number + $4) <= (SELECT COALESCE(block_number, 0) FROM log_poller_blocks WHERE evm_chain_id = $1 ORDER BY block_number DESC LIMIT 1)
+            AND block_number <= `+nestedBlockNumberQuery("$4")+`
         ORDER BY (block_number, log_index) DESC LIMIT 1`, utils.NewBig(o.chainID), eventSig, address, confs); err != nil {
 		return nil, err
 	}
 	return &l, nil
 }
 
+// nestedBlockNumberQuery returns a subquery for the highest block number whose
+// logs have confs confirmations, or the latest finalized block if confs is
+// Finalized. The chain ID must be the first query argument.
+func nestedBlockNumberQuery(confs string) string {
+	return fmt.Sprintf(`(SELECT CASE WHEN %[1]s = %[2]d THEN finalized_block_number ELSE block_number - %[1]s END
+			FROM log_poller_blocks WHERE evm_chain_id = $1 ORDER BY block_number DESC LIMIT 1)`, confs, Finalized)
+}
+
 // DeleteBlocksAfter delete all blocks after and including start.
 func (o *ORM) DeleteBlocksAfter(start int64, qopts ...pg.QOpt) error {
 	q := o.q.WithOpts(qopts...)
@@ -225,7 +235,7 @@ func (o *ORM) SelectLatestLogEventSigsAddrsWithConfs(fromBlock int64, addresses
 				    event_sig = ANY($2) AND
 					address = ANY($3) AND
 		   			block_number > $4 AND
-					(block_number + $5) <= (SELECT COALESCE(block_number, 0) FROM log_poller_blocks WHERE evm_chain_id = $1 ORDER BY block_number DESC LIMIT 1)
+					block_number <= `+nestedBlockNumberQuery("$5")+`
 			GROUP BY event_sig, address
 		)
 		ORDER BY block_number ASC
@@ -245,7 +255,7 @@ func (o *ORM) SelectDataWordRange(address common.Address, eventSig common.Hash,
 			AND address = $2 AND event_sig = $3
 			AND substring(data from 32*$4+1 for 32) >= $5
 			AND substring(data from 32*$4+1 for 32) <= $6
-			AND (block_number + $7) <= (SELECT COALESCE(block_number, 0) FROM log_poller_blocks WHERE evm_chain_id = $1 ORDER BY block_number DESC LIMIT 1)
+			AND block_number <= `+nestedBlockNumberQuery("$7")+`
 			ORDER BY (logs.block_number, logs.log_index)`, utils.NewBig(o.chainID), address, eventSig.Bytes(), wordIndex, wordValueMin.Bytes(), wordValueMax.Bytes(), confs)
 	if err != nil {
 		return nil, err
@@ -261,7 +271,7 @@ func (o *ORM) SelectDataWordGreaterThan(address common.Address, eventSig common.
 			WHERE logs.evm_chain_id = $1
 			AND address = $2 AND event_sig = $3
 			AND substring(data from 32*$4+1 for 32) >= $5
-			AND (block_number + $6) <= (SELECT COALESCE(block_number, 0) FROM log_poller_blocks WHERE evm_chain_id = $1 ORDER BY block_number DESC LIMIT 1)
+			AND block_number <= `+nestedBlockNumberQuery("$6")+`
 			ORDER BY (logs.block_number, logs.log_index)`, utils.NewBig(o.chainID), address, eventSig.Bytes(), wordIndex, wordValueMin.Bytes(), confs)
 	if err != nil {
 		return nil, err
@@ -277,7 +287,7 @@ func (o *ORM) SelectIndexLogsTopicGreaterThan(address common.Address, eventSig c
 			WHERE logs.evm_chain_id = $1
 			AND address = $2 AND event_sig = $3
 			AND topics[$4] >= $5
-			AND (block_number + $6) <= (SELECT COALESCE(block_number, 0) FROM log_poller_blocks WHERE evm_chain_id = $1 ORDER BY block_number DESC LIMIT 1)
+			AND block_number <= `+nestedBlockNumberQuery("$6")+`
 			ORDER BY (logs.block_number, logs.log_index)`, utils.NewBig(o.chainID), address, eventSig.Bytes(), topicIndex+1, topicValueMin.Bytes(), confs)
 	if err != nil {
 		return nil, err
@@ -294,7 +304,7 @@ func (o *ORM) SelectIndexLogsTopicRange(address common.Address, eventSig common.
 			AND address = $2 AND event_sig = $3
 			AND topics[$4] >= $5
 			AND topics[$4] <= $6
-			AND (block_number + $7) <= (SELECT COALESCE(block_number, 0) FROM log_poller_blocks WHERE evm_chain_id = $1 ORDER BY block_number DESC LIMIT 1)
+			AND block_number <= `+nestedBlockNumberQuery("$7")+`
 			ORDER BY (logs.block_number, logs.log_index)`, utils.NewBig(o.chainID), address, eventSig.Bytes(), topicIndex+1, topicValueMin.Bytes(), topicValueMax.Bytes(), confs)
 	if err != nil {
 		return nil, err
@@ -315,7 +325,7 @@ func (o *ORM) SelectIndexedLogs(address common.Address, eventSig common.Hash, to
 			WHERE logs.evm_chain_id = $1
 			AND address = $2 AND event_sig = $3
 			AND topics[$4] = ANY($5)
-			AND (block_number + $6) <= (SELECT COALESCE(block_number, 0) FROM log_poller_blocks WHERE evm_chain_id = $1 ORDER BY block_number DESC LIMIT 1)
+			AND block_number <= `+nestedBlockNumberQuery("$6")+`
 			ORDER BY (logs.block_number, logs.log_index)`, utils.NewBig(o.chainID), address, eventSig.Bytes(), topicIndex+1, pq.ByteaArray(topicValuesBytes), confs)
 	if err != nil {
 		return nil, err
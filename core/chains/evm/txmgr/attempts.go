@@ -2,7 +2,10 @@ package txmgr
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"math/big"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -10,46 +13,233 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/core/logger"
 )
 
-func (c *ChainKeyStore) NewDynamicFeeAttempt(etx EthTx, fee gas.DynamicFee, gasLimit uint32) (attempt EthTxAttempt, err error) {
-	if err = validateDynamicFeeGas(c.config, fee, gasLimit, etx); err != nil {
-		return attempt, errors.Wrap(err, "error validating gas")
+// The EIP-2718 transaction types that attempts can be built for
+const (
+	LegacyTxType     = int(types.LegacyTxType)
+	AccessListTxType = int(types.AccessListTxType)
+	DynamicFeeTxType = int(types.DynamicFeeTxType)
+)
+
+// AttemptFee is the fee paid by an attempt: a GasPrice for legacy and access
+// list transactions, or a DynamicFee.
+type AttemptFee struct {
+	GasPrice   *assets.Wei
+	DynamicFee gas.DynamicFee
+}
+
+func (f AttemptFee) String() string {
+	if f.GasPrice != nil {
+		return fmt.Sprintf("gas price %s", f.GasPrice)
+	}
+	return fmt.Sprintf("tip cap %s, fee cap %s", f.DynamicFee.TipCap, f.DynamicFee.FeeCap)
+}
+
+// MaxPrice returns the highest price per gas that can be paid with f: its gas
+// price or fee cap
+func (f AttemptFee) MaxPrice() *assets.Wei {
+	if f.GasPrice != nil {
+		return f.GasPrice
+	}
+	return f.DynamicFee.FeeCap
+}
+
+// reachedBumpCeiling returns true if f, bumped from previous, is no higher
+// than previous or has reached max
+func (f AttemptFee) reachedBumpCeiling(previous AttemptFee, max *assets.Wei) bool {
+	if f.GasPrice != nil {
+		return f.GasPrice.Cmp(previous.GasPrice) == 0 || f.GasPrice.Cmp(max) >= 0
 	}
+	return f.DynamicFee.TipCap.Cmp(previous.DynamicFee.TipCap) == 0 || f.DynamicFee.FeeCap.Cmp(previous.DynamicFee.FeeCap) == 0 || f.DynamicFee.TipCap.Cmp(max) >= 0
+}
+
+// AttemptBuilder builds the attempts of one EIP-2718 transaction type. It
+// decides how the fee of an attempt is estimated, bumped and validated, and
+// how its transaction is assembled for signing.
+type AttemptBuilder interface {
+	// TxType is the type of the transactions built
+	TxType() int
+	// EstimateFee returns the fee and gas limit of the first attempt of etx
+	EstimateFee(ctx context.Context, estimator gas.Estimator, etx EthTx, maxGasPrice *assets.Wei, opts ...gas.Opt) (fee AttemptFee, gasLimit uint32, err error)
+	// BumpFee returns the fee and gas limit of an attempt replacing previousAttempt
+	BumpFee(ctx context.Context, estimator gas.Estimator, etx EthTx, previousAttempt EthTxAttempt, maxGasPrice *assets.Wei, priorAttempts []gas.PriorAttempt) (fee AttemptFee, gasLimit uint32, err error)
+	// ManualBumpFee returns the fee and gas limit of an attempt replacing
+	// previousAttempt that pays gasPrice, or an error if gasPrice is too low
+	// to replace it
+	ManualBumpFee(cfg gas.Config, lggr logger.SugaredLogger, etx EthTx, previousAttempt EthTxAttempt, gasPrice, maxGasPrice *assets.Wei) (fee AttemptFee, gasLimit uint32, err error)
+	// PercentileFee returns the fee of an attempt paying the gasPrice or
+	// tipCap paid at a percentile of recent transactions, capped at
+	// maxGasPrice. Only the tip cap of a dynamic fee is set. It returns false
+	// if the fee cannot be paid with those prices.
+	PercentileFee(gasPrice, tipCap, maxGasPrice *assets.Wei) (fee AttemptFee, ok bool)
+	// ValidateFee is a sanity check - we have other checks elsewhere, but
+	// this makes sure we _never_ create an invalid attempt
+	ValidateFee(cfg Config, etx EthTx, fee AttemptFee) error
+	// NewTx returns the unsigned transaction of an attempt of etx paying fee
+	NewTx(etx EthTx, fee AttemptFee, gasLimit uint32, chainID *big.Int) *types.Transaction
+}
 
-	var al types.AccessList
-	if etx.AccessList.Valid {
-		al = etx.AccessList.AccessList
-	}
-	d := newDynamicFeeTransaction(
-		uint64(*etx.Nonce),
-		etx.ToAddress,
-		&etx.Value,
-		gasLimit,
-		&c.chainID,
-		fee.TipCap,
-		fee.FeeCap,
-		etx.EncodedPayload,
-		al,
-	)
-	tx := types.NewTx(&d)
-	attempt, err = c.newSignedAttempt(etx, tx)
+var attemptBuilders = map[int]AttemptBuilder{
+	LegacyTxType:     legacyAttemptBuilder{},
+	AccessListTxType: accessListAttemptBuilder{},
+	DynamicFeeTxType: dynamicFeeAttemptBuilder{},
+}
+
+// AttemptBuilderFor returns the AttemptBuilder of txType
+func AttemptBuilderFor(txType int) (AttemptBuilder, error) {
+	b, ok := attemptBuilders[txType]
+	if !ok {
+		return nil, errors.Errorf("unsupported transaction type 0x%x", txType)
+	}
+	return b, nil
+}
+
+// ParseEthTxType parses an EIP-2718 transaction type, in decimal or as 0x
+// prefixed hex, e.g. "0x1". Only the types that attempts can be built for are
+// accepted.
+func ParseEthTxType(s string) (int, error) {
+	t, err := strconv.ParseUint(s, 0, 8)
 	if err != nil {
-		return attempt, err
+		return 0, errors.Errorf("invalid transaction type %q, must be one of 0x0, 0x1 or 0x2", s)
 	}
-	attempt.GasTipCap = fee.TipCap
-	attempt.GasFeeCap = fee.FeeCap
-	attempt.ChainSpecificGasLimit = gasLimit
-	attempt.TxType = 2
-	return attempt, nil
+	if _, err = AttemptBuilderFor(int(t)); err != nil {
+		return 0, err
+	}
+	return int(t), nil
+}
+
+// EthTxType returns the type of the first attempt of etx: its TxType if it
+// has one, and otherwise dynamic fee if the chain is configured for EIP-1559,
+// or legacy. Later attempts keep the type of the attempt they replace.
+func EthTxType(cfg Config, etx EthTx) int {
+	switch {
+	case etx.TxType.Valid:
+		return int(etx.TxType.Int64)
+	case cfg.EvmEIP1559DynamicFees():
+		return DynamicFeeTxType
+	default:
+		return LegacyTxType
+	}
+}
+
+type legacyAttemptBuilder struct{}
+
+func (legacyAttemptBuilder) TxType() int { return LegacyTxType }
+
+func (legacyAttemptBuilder) EstimateFee(ctx context.Context, estimator gas.Estimator, etx EthTx, maxGasPrice *assets.Wei, opts ...gas.Opt) (AttemptFee, uint32, error) {
+	gasPrice, gasLimit, err := estimator.GetLegacyGas(ctx, etx.EncodedPayload, etx.GasLimit, maxGasPrice, opts...)
+	return AttemptFee{GasPrice: gasPrice}, gasLimit, err
+}
+
+func (legacyAttemptBuilder) BumpFee(ctx context.Context, estimator gas.Estimator, etx EthTx, previousAttempt EthTxAttempt, maxGasPrice *assets.Wei, priorAttempts []gas.PriorAttempt) (AttemptFee, uint32, error) {
+	gasPrice, gasLimit, err := estimator.BumpLegacyGas(ctx, previousAttempt.GasPrice, etx.GasLimit, maxGasPrice, priorAttempts)
+	return AttemptFee{GasPrice: gasPrice}, gasLimit, err
+}
+
+func (legacyAttemptBuilder) ManualBumpFee(cfg gas.Config, lggr logger.SugaredLogger, etx EthTx, previousAttempt EthTxAttempt, gasPrice, maxGasPrice *assets.Wei) (fee AttemptFee, gasLimit uint32, err error) {
+	minGasPrice, gasLimit, err := gas.BumpLegacyGasPriceOnly(cfg, lggr, nil, previousAttempt.GasPrice, etx.GasLimit, maxGasPrice)
+	if err != nil {
+		return fee, 0, errors.Wrap(err, "error bumping gas")
+	}
+	if gasPrice.Cmp(minGasPrice) < 0 {
+		return fee, 0, errors.Errorf("gas price of %s is too low to replace the previous attempt, it must be at least %s", gasPrice, minGasPrice)
+	}
+	return AttemptFee{GasPrice: gasPrice}, gasLimit, nil
+}
+
+func (legacyAttemptBuilder) PercentileFee(gasPrice, _, maxGasPrice *assets.Wei) (AttemptFee, bool) {
+	return AttemptFee{GasPrice: assets.WeiMin(gasPrice, maxGasPrice)}, true
+}
+
+func (legacyAttemptBuilder) ValidateFee(cfg Config, etx EthTx, fee AttemptFee) error {
+	gasPrice := fee.GasPrice
+	if gasPrice == nil {
+		panic("gas price missing")
+	}
+	max := cfg.KeySpecificMaxGasPriceWei(etx.FromAddress)
+	if gasPrice.Cmp(max) > 0 {
+		return errors.Errorf("cannot create tx attempt: specified gas price of %s would exceed max configured gas price of %s for key %s", gasPrice.String(), max.String(), etx.FromAddress.Hex())
+	}
+	min := cfg.EvmMinGasPriceWei()
+	if gasPrice.Cmp(min) < 0 {
+		return errors.Errorf("cannot create tx attempt: specified gas price of %s is below min configured gas price of %s for key %s", gasPrice.String(), min.String(), etx.FromAddress.Hex())
+	}
+	return nil
+}
+
+func (legacyAttemptBuilder) NewTx(etx EthTx, fee AttemptFee, gasLimit uint32, _ *big.Int) *types.Transaction {
+	to, value, data := attemptPayload(etx)
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    uint64(*etx.Nonce),
+		To:       &to,
+		Value:    value.ToInt(),
+		Gas:      uint64(gasLimit),
+		GasPrice: fee.GasPrice.ToInt(),
+		Data:     data,
+	})
+}
+
+// accessListAttemptBuilder builds EIP-2930 transactions, which are priced
+// like legacy transactions
+type accessListAttemptBuilder struct {
+	legacyAttemptBuilder
+}
+
+func (accessListAttemptBuilder) TxType() int { return AccessListTxType }
+
+func (accessListAttemptBuilder) NewTx(etx EthTx, fee AttemptFee, gasLimit uint32, chainID *big.Int) *types.Transaction {
+	to, value, data := attemptPayload(etx)
+	return types.NewTx(&types.AccessListTx{
+		ChainID:    chainID,
+		Nonce:      uint64(*etx.Nonce),
+		GasPrice:   fee.GasPrice.ToInt(),
+		Gas:        uint64(gasLimit),
+		To:         &to,
+		Value:      value.ToInt(),
+		Data:       data,
+		AccessList: attemptAccessList(etx),
+	})
+}
+
+// dynamicFeeAttemptBuilder builds EIP-1559 transactions
+type dynamicFeeAttemptBuilder struct{}
+
+func (dynamicFeeAttemptBuilder) TxType() int { return DynamicFeeTxType }
+
+func (dynamicFeeAttemptBuilder) EstimateFee(ctx context.Context, estimator gas.Estimator, etx EthTx, maxGasPrice *assets.Wei, _ ...gas.Opt) (AttemptFee, uint32, error) {
+	fee, gasLimit, err := estimator.GetDynamicFee(ctx, etx.GasLimit, maxGasPrice)
+	return AttemptFee{DynamicFee: fee}, gasLimit, err
+}
+
+func (dynamicFeeAttemptBuilder) BumpFee(ctx context.Context, estimator gas.Estimator, etx EthTx, previousAttempt EthTxAttempt, maxGasPrice *assets.Wei, priorAttempts []gas.PriorAttempt) (AttemptFee, uint32, error) {
+	fee, gasLimit, err := estimator.BumpDynamicFee(ctx, previousAttempt.DynamicFee(), etx.GasLimit, maxGasPrice, priorAttempts)
+	return AttemptFee{DynamicFee: fee}, gasLimit, err
+}
+
+func (dynamicFeeAttemptBuilder) ManualBumpFee(cfg gas.Config, lggr logger.SugaredLogger, etx EthTx, previousAttempt EthTxAttempt, gasPrice, maxGasPrice *assets.Wei) (fee AttemptFee, gasLimit uint32, err error) {
+	minFee, gasLimit, err := gas.BumpDynamicFeeOnly(cfg, lggr, nil, nil, previousAttempt.DynamicFee(), etx.GasLimit, maxGasPrice)
+	if err != nil {
+		return fee, 0, errors.Wrap(err, "error bumping gas")
+	}
+	if gasPrice.Cmp(minFee.FeeCap) < 0 {
+		return fee, 0, errors.Errorf("fee cap of %s is too low to replace the previous attempt, it must be at least %s", gasPrice, minFee.FeeCap)
+	}
+	return AttemptFee{DynamicFee: gas.DynamicFee{FeeCap: gasPrice, TipCap: minFee.TipCap}}, gasLimit, nil
+}
+
+func (dynamicFeeAttemptBuilder) PercentileFee(_, tipCap, maxGasPrice *assets.Wei) (AttemptFee, bool) {
+	if tipCap == nil {
+		return AttemptFee{}, false
+	}
+	return AttemptFee{DynamicFee: gas.DynamicFee{TipCap: assets.WeiMin(tipCap, maxGasPrice)}}, true
 }
 
 var Max256BitUInt = big.NewInt(0).Exp(big.NewInt(2), big.NewInt(256), nil)
 
-// validateDynamicFeeGas is a sanity check - we have other checks elsewhere, but this
-// makes sure we _never_ create an invalid attempt
-func validateDynamicFeeGas(cfg Config, fee gas.DynamicFee, gasLimit uint32, etx EthTx) error {
-	gasTipCap, gasFeeCap := fee.TipCap, fee.FeeCap
+func (dynamicFeeAttemptBuilder) ValidateFee(cfg Config, etx EthTx, fee AttemptFee) error {
+	gasTipCap, gasFeeCap := fee.DynamicFee.TipCap, fee.DynamicFee.FeeCap
 
 	if gasTipCap == nil {
 		panic("gas tip cap missing")
@@ -83,67 +273,48 @@ func validateDynamicFeeGas(cfg Config, fee gas.DynamicFee, gasLimit uint32, etx
 	return nil
 }
 
-func newDynamicFeeTransaction(nonce uint64, to common.Address, value *assets.Eth, gasLimit uint32, chainID *big.Int, gasTipCap, gasFeeCap *assets.Wei, data []byte, accessList types.AccessList) types.DynamicFeeTx {
-	return types.DynamicFeeTx{
+func (dynamicFeeAttemptBuilder) NewTx(etx EthTx, fee AttemptFee, gasLimit uint32, chainID *big.Int) *types.Transaction {
+	to, value, data := attemptPayload(etx)
+	return types.NewTx(&types.DynamicFeeTx{
 		ChainID:    chainID,
-		Nonce:      nonce,
-		GasTipCap:  gasTipCap.ToInt(),
-		GasFeeCap:  gasFeeCap.ToInt(),
+		Nonce:      uint64(*etx.Nonce),
+		GasTipCap:  fee.DynamicFee.TipCap.ToInt(),
+		GasFeeCap:  fee.DynamicFee.FeeCap.ToInt(),
 		Gas:        uint64(gasLimit),
 		To:         &to,
 		Value:      value.ToInt(),
 		Data:       data,
-		AccessList: accessList,
-	}
+		AccessList: attemptAccessList(etx),
+	})
 }
 
-func (c *ChainKeyStore) NewLegacyAttempt(etx EthTx, gasPrice *assets.Wei, gasLimit uint32) (attempt EthTxAttempt, err error) {
-	if err = validateLegacyGas(c.config, gasPrice, gasLimit, etx); err != nil {
+// NewAttempt builds an attempt of etx of type txType paying fee, signed by
+// the sending key of etx
+func (c *ChainKeyStore) NewAttempt(txType int, etx EthTx, fee AttemptFee, gasLimit uint32) (attempt EthTxAttempt, err error) {
+	builder, err := AttemptBuilderFor(txType)
+	if err != nil {
+		return attempt, err
+	}
+	if err = builder.ValidateFee(c.config, etx, fee); err != nil {
 		return attempt, errors.Wrap(err, "error validating gas")
 	}
 
-	tx := newLegacyTransaction(
-		uint64(*etx.Nonce),
-		etx.ToAddress,
-		etx.Value.ToInt(),
-		gasLimit,
-		gasPrice,
-		etx.EncodedPayload,
-	)
-
-	transaction := types.NewTx(&tx)
-	hash, signedTxBytes, err := c.SignTx(etx.FromAddress, transaction)
+	attempt, err = c.newSignedAttempt(etx, builder.NewTx(etx, fee, gasLimit, &c.chainID))
 	if err != nil {
-		return attempt, errors.Wrapf(err, "error using account %s to sign transaction %v", etx.FromAddress.String(), etx.ID)
+		return attempt, err
 	}
-
-	attempt.State = EthTxAttemptInProgress
-	attempt.SignedRawTx = signedTxBytes
-	attempt.EthTxID = etx.ID
-	attempt.GasPrice = gasPrice
-	attempt.Hash = hash
-	attempt.TxType = 0
+	attempt.setFee(fee)
 	attempt.ChainSpecificGasLimit = gasLimit
-	attempt.EthTx = etx
-
+	attempt.TxType = txType
 	return attempt, nil
 }
 
-// validateLegacyGas is a sanity check - we have other checks elsewhere, but this
-// makes sure we _never_ create an invalid attempt
-func validateLegacyGas(cfg Config, gasPrice *assets.Wei, gasLimit uint32, etx EthTx) error {
-	if gasPrice == nil {
-		panic("gas price missing")
-	}
-	max := cfg.KeySpecificMaxGasPriceWei(etx.FromAddress)
-	if gasPrice.Cmp(max) > 0 {
-		return errors.Errorf("cannot create tx attempt: specified gas price of %s would exceed max configured gas price of %s for key %s", gasPrice.String(), max.String(), etx.FromAddress.Hex())
-	}
-	min := cfg.EvmMinGasPriceWei()
-	if gasPrice.Cmp(min) < 0 {
-		return errors.Errorf("cannot create tx attempt: specified gas price of %s is below min configured gas price of %s for key %s", gasPrice.String(), min.String(), etx.FromAddress.Hex())
-	}
-	return nil
+func (c *ChainKeyStore) NewDynamicFeeAttempt(etx EthTx, fee gas.DynamicFee, gasLimit uint32) (attempt EthTxAttempt, err error) {
+	return c.NewAttempt(DynamicFeeTxType, etx, AttemptFee{DynamicFee: fee}, gasLimit)
+}
+
+func (c *ChainKeyStore) NewLegacyAttempt(etx EthTx, gasPrice *assets.Wei, gasLimit uint32) (attempt EthTxAttempt, err error) {
+	return c.NewAttempt(LegacyTxType, etx, AttemptFee{GasPrice: gasPrice}, gasLimit)
 }
 
 func (c *ChainKeyStore) newSignedAttempt(etx EthTx, tx *types.Transaction) (attempt EthTxAttempt, err error) {
@@ -161,19 +332,26 @@ func (c *ChainKeyStore) newSignedAttempt(etx EthTx, tx *types.Transaction) (atte
 	return attempt, nil
 }
 
-func newLegacyTransaction(nonce uint64, to common.Address, value *big.Int, gasLimit uint32, gasPrice *assets.Wei, data []byte) types.LegacyTx {
-	return types.LegacyTx{
-		Nonce:    nonce,
-		To:       &to,
-		Value:    value,
-		Gas:      uint64(gasLimit),
-		GasPrice: gasPrice.ToInt(),
-		Data:     data,
+// attemptPayload returns the recipient, value and data of the attempts of
+// etx. Once etx is cancelled, they send zero ether to its sender instead.
+func attemptPayload(etx EthTx) (to common.Address, value assets.Eth, data []byte) {
+	if etx.IsCancelled() {
+		return etx.FromAddress, assets.NewEthValue(0), []byte{}
 	}
+	return etx.ToAddress, etx.Value, etx.EncodedPayload
+}
+
+// attemptAccessList returns the access list of the attempts of etx, which is
+// dropped once etx is cancelled
+func attemptAccessList(etx EthTx) types.AccessList {
+	if etx.AccessList.Valid && !etx.IsCancelled() {
+		return etx.AccessList.AccessList
+	}
+	return nil
 }
 
 func (c *ChainKeyStore) signTx(address common.Address, tx *types.Transaction) (common.Hash, []byte, error) {
-	signedTx, err := c.keystore.SignTx(address, tx, &c.chainID)
+	signedTx, err := c.signer(address).SignTx(address, tx, &c.chainID)
 	if err != nil {
 		return common.Hash{}, nil, errors.Wrap(err, "signTx failed")
 	}
@@ -3,12 +3,16 @@ package txmgr_test
 import (
 	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
@@ -18,6 +22,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/testutils/evmtest"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	ksmocks "github.com/smartcontractkit/chainlink/core/services/keystore/mocks"
+	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
 func TestTxm_NewDynamicFeeTx(t *testing.T) {
@@ -109,3 +114,91 @@ func TestTxm_NewLegacyAttempt(t *testing.T) {
 		assert.Contains(t, err.Error(), fmt.Sprintf("specified gas price of 100 wei would exceed max configured gas price of 50 wei for key %s", addr.Hex()))
 	})
 }
+
+func TestTxm_NewAccessListAttempt(t *testing.T) {
+	addr := testutils.NewAddress()
+	gcfg := configtest.NewGeneralConfig(t, nil)
+	cfg := evmtest.NewChainScopedConfig(t, gcfg)
+	kst := ksmocks.NewEth(t)
+	cks := txmgr.NewChainKeyStore(*big.NewInt(1), cfg, kst)
+	accessList := types.AccessList{{Address: testutils.NewAddress(), StorageKeys: []common.Hash{utils.NewHash()}}}
+	var n int64
+
+	t.Run("creates attempt with access list", func(t *testing.T) {
+		tx := types.NewTx(&types.AccessListTx{})
+		kst.On("SignTx", addr, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Type() == types.AccessListTxType && tx.GasPrice().Cmp(big.NewInt(25)) == 0 && reflect.DeepEqual(tx.AccessList(), accessList)
+		}), big.NewInt(1)).Return(tx, nil).Once()
+
+		etx := txmgr.EthTx{Nonce: &n, FromAddress: addr, AccessList: txmgr.NullableEIP2930AccessListFrom(accessList)}
+		a, err := cks.NewAttempt(txmgr.AccessListTxType, etx, txmgr.AttemptFee{GasPrice: assets.NewWeiI(25)}, 100)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.AccessListTxType, a.TxType)
+		assert.Equal(t, 100, int(a.ChainSpecificGasLimit))
+		assert.Equal(t, "25 wei", a.GasPrice.String())
+		assert.Nil(t, a.GasTipCap)
+		assert.Nil(t, a.GasFeeCap)
+		assert.Equal(t, assets.NewWeiI(25).String(), a.Fee().GasPrice.String())
+	})
+
+	t.Run("drops access list once cancelled", func(t *testing.T) {
+		tx := types.NewTx(&types.AccessListTx{})
+		kst.On("SignTx", addr, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Type() == types.AccessListTxType && len(tx.AccessList()) == 0 && *tx.To() == addr
+		}), big.NewInt(1)).Return(tx, nil).Once()
+
+		now := time.Now()
+		etx := txmgr.EthTx{Nonce: &n, FromAddress: addr, AccessList: txmgr.NullableEIP2930AccessListFrom(accessList), CancelledAt: &now}
+		_, err := cks.NewAttempt(txmgr.AccessListTxType, etx, txmgr.AttemptFee{GasPrice: assets.NewWeiI(25)}, 100)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects unsupported transaction types", func(t *testing.T) {
+		_, err := cks.NewAttempt(0x3, txmgr.EthTx{Nonce: &n, FromAddress: addr}, txmgr.AttemptFee{GasPrice: assets.NewWeiI(25)}, 100)
+		require.EqualError(t, err, "unsupported transaction type 0x3")
+	})
+}
+
+func TestParseEthTxType(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		input  string
+		exp    int
+		expErr string
+	}{
+		{"0x0", txmgr.LegacyTxType, ""},
+		{"0x1", txmgr.AccessListTxType, ""},
+		{"2", txmgr.DynamicFeeTxType, ""},
+		{"0x3", 0, "unsupported transaction type 0x3"},
+		{"legacy", 0, `invalid transaction type "legacy"`},
+	} {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			txType, err := txmgr.ParseEthTxType(tt.input)
+			if tt.expErr != "" {
+				require.ErrorContains(t, err, tt.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.exp, txType)
+		})
+	}
+}
+
+func TestEthTxType(t *testing.T) {
+	t.Parallel()
+
+	legacyCfg := evmtest.NewChainScopedConfig(t, configtest.NewGeneralConfig(t, nil))
+	eip1559Cfg := evmtest.NewChainScopedConfig(t, configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].GasEstimator.EIP1559DynamicFees = ptr(true)
+	}))
+	accessList := txmgr.NullableEIP2930AccessListFrom(types.AccessList{{Address: testutils.NewAddress()}})
+
+	assert.Equal(t, txmgr.LegacyTxType, txmgr.EthTxType(legacyCfg, txmgr.EthTx{}))
+	assert.Equal(t, txmgr.LegacyTxType, txmgr.EthTxType(legacyCfg, txmgr.EthTx{AccessList: accessList}))
+	assert.Equal(t, txmgr.AccessListTxType, txmgr.EthTxType(legacyCfg, txmgr.EthTx{TxType: null.IntFrom(int64(txmgr.AccessListTxType)), AccessList: accessList}))
+	assert.Equal(t, txmgr.DynamicFeeTxType, txmgr.EthTxType(eip1559Cfg, txmgr.EthTx{}))
+	assert.Equal(t, txmgr.DynamicFeeTxType, txmgr.EthTxType(eip1559Cfg, txmgr.EthTx{AccessList: accessList}))
+	assert.Equal(t, txmgr.AccessListTxType, txmgr.EthTxType(eip1559Cfg, txmgr.EthTx{TxType: null.IntFrom(int64(txmgr.AccessListTxType))}))
+}
@@ -0,0 +1,214 @@
+package txmgr
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	evmtypes "github.com/smartcontractkit/chainlink/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/core/services/pg"
+)
+
+const (
+	// MaxEthTxBatchSize caps the number of transactions sent in a single batch
+	MaxEthTxBatchSize = 50
+	// MaxEthTxBatchGasLimit caps the gas limit of a batch
+	MaxEthTxBatchGasLimit = 10_000_000
+	// BatchCallGasOverhead is added to the gas limit of a batch for each of
+	// its calls, to pay for forwarding the call and logging its outcome
+	BatchCallGasOverhead = 10_000
+)
+
+const batchForwarderABIJSON = `[{"inputs":[{"internalType":"address[]","name":"targets","type":"address[]"},{"internalType":"bytes[]","name":"data","type":"bytes[]"},{"internalType":"uint256[]","name":"gasLimits","type":"uint256[]"}],"name":"forwardBatch","outputs":[],"stateMutability":"nonpayable","type":"function"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"uint256","name":"index","type":"uint256"},{"indexed":false,"internalType":"bool","name":"success","type":"bool"}],"name":"CallForwarded","type":"event"}]`
+
+var (
+	batchForwarderABI  = evmtypes.MustGetABI(batchForwarderABIJSON)
+	callForwardedTopic = batchForwarderABI.Events["CallForwarded"].ID
+)
+
+// groupEthTxBatches splits etxs, which must be ordered by ID, into batches of
+// transactions using the same forwarder. Each batch holds at most
+// MaxEthTxBatchSize transactions and has a gas limit of at most
+// MaxEthTxBatchGasLimit, unless it holds a single transaction.
+func groupEthTxBatches(etxs []EthTx) (batches [][]EthTx) {
+	open := make(map[common.Address]int)
+	gasLimits := make(map[common.Address]uint64)
+	for _, etx := range etxs {
+		forwarder := *etx.BatchForwarderAddress
+		gasLimit := uint64(etx.GasLimit) + BatchCallGasOverhead
+		i, exists := open[forwarder]
+		if exists && (len(batches[i]) >= MaxEthTxBatchSize || gasLimits[forwarder]+gasLimit > MaxEthTxBatchGasLimit) {
+			exists = false
+		}
+		if !exists {
+			i = len(batches)
+			batches = append(batches, nil)
+			open[forwarder] = i
+			gasLimits[forwarder] = 0
+		}
+		batches[i] = append(batches[i], etx)
+		gasLimits[forwarder] += gasLimit
+	}
+	return batches
+}
+
+// batchUnstartedEthTxs adds the unstarted transactions of fromAddress using
+// a batching forwarder to new batch transactions, which take their place in
+// the queue. A transaction that would be alone in its batch is sent directly
+// instead.
+func (eb *EthBroadcaster) batchUnstartedEthTxs(fromAddress common.Address) error {
+	// This runs on every loop of the broadcaster, so check for transactions
+	// to batch before locking them
+	var exists bool
+	err := eb.q.Get(&exists, `SELECT EXISTS (SELECT 1 FROM eth_txes WHERE evm_chain_id = $1 AND from_address = $2 AND state = 'unstarted' AND batch_forwarder_address IS NOT NULL AND batch_eth_tx_id IS NULL)`, eb.chainID.String(), fromAddress)
+	if err != nil {
+		return errors.Wrap(err, "failed to check for unbatched eth_txes")
+	}
+	if !exists {
+		return nil
+	}
+	return eb.q.Transaction(func(tx pg.Queryer) error {
+		var etxs []EthTx
+		err := tx.Select(&etxs, `SELECT * FROM eth_txes WHERE evm_chain_id = $1 AND from_address = $2 AND state = 'unstarted' AND batch_forwarder_address IS NOT NULL AND batch_eth_tx_id IS NULL ORDER BY id ASC FOR UPDATE`, eb.chainID.String(), fromAddress)
+		if err != nil {
+			return errors.Wrap(err, "failed to load unbatched eth_txes")
+		}
+		for _, batch := range groupEthTxBatches(etxs) {
+			if len(batch) < 2 {
+				if _, err = tx.Exec(`UPDATE eth_txes SET batch_forwarder_address = NULL WHERE id = $1`, batch[0].ID); err != nil {
+					return errors.Wrapf(err, "failed to unbatch eth_tx %d", batch[0].ID)
+				}
+				eb.logger.Debugw("Sending lone transaction without batching", "ethTxID", batch[0].ID, "fromAddress", fromAddress, "forwarder", batch[0].BatchForwarderAddress)
+				continue
+			}
+			batchEtx, err := insertEthTxBatch(tx, batch, eb.chainID)
+			if err != nil {
+				return err
+			}
+			eb.logger.Debugw("Batched transactions", "batchEthTxID", batchEtx.ID, "fromAddress", fromAddress, "forwarder", batchEtx.ToAddress, "n", len(batch))
+		}
+		return nil
+	})
+}
+
+func insertEthTxBatch(tx pg.Queryer, batch []EthTx, chainID big.Int) (batchEtx EthTx, err error) {
+	targets := make([]common.Address, len(batch))
+	data := make([][]byte, len(batch))
+	// Each call is limited to the gas limit of its transaction
+	gasLimits := make([]*big.Int, len(batch))
+	var gasLimit uint32
+	priority := batch[0].Priority
+	for i, etx := range batch {
+		targets[i] = etx.ToAddress
+		data[i] = etx.EncodedPayload
+		gasLimits[i] = new(big.Int).SetUint64(uint64(etx.GasLimit))
+		gasLimit += etx.GasLimit + BatchCallGasOverhead
+		if etx.Priority.HigherThan(priority) {
+			priority = etx.Priority
+		}
+	}
+	payload, err := batchForwarderABI.Pack("forwardBatch", targets, data, gasLimits)
+	if err != nil {
+		return batchEtx, errors.Wrap(err, "failed to encode batch")
+	}
+
+	// The batch takes the place of its first transaction in the queue, in the
+	// lane of its highest priority transaction
+	err = tx.Get(&batchEtx, `
+INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, state, created_at, evm_chain_id, priority)
+VALUES ($1, $2, $3, 0, $4, 'unstarted', $5, $6, $7)
+RETURNING *`, batch[0].FromAddress, *batch[0].BatchForwarderAddress, payload, gasLimit, batch[0].CreatedAt, chainID.String(), priority)
+	if err != nil {
+		return batchEtx, errors.Wrap(err, "failed to insert batch eth_tx")
+	}
+	for i, etx := range batch {
+		if _, err = tx.Exec(`UPDATE eth_txes SET batch_eth_tx_id = $1, batch_index = $2 WHERE id = $3`, batchEtx.ID, i, etx.ID); err != nil {
+			return batchEtx, errors.Wrapf(err, "failed to add eth_tx %d to batch", etx.ID)
+		}
+	}
+	return batchEtx, nil
+}
+
+// failEthTxBatch fatally errors the transactions of the batch sent by
+// batchEtxID, and returns the pipeline task runs waiting for them
+func failEthTxBatch(tx pg.Queryer, batchEtxID int64, errMsg string) (taskRunIDs []uuid.UUID, err error) {
+	var ids []uuid.NullUUID
+	err = tx.Select(&ids, `UPDATE eth_txes SET state = 'fatal_error', error = $2 WHERE batch_eth_tx_id = $1 AND state = 'unstarted' RETURNING pipeline_task_run_id`, batchEtxID, errMsg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fail transactions of batch eth_tx %d", batchEtxID)
+	}
+	for _, id := range ids {
+		if id.Valid {
+			taskRunIDs = append(taskRunIDs, id.UUID)
+		}
+	}
+	return taskRunIDs, nil
+}
+
+type failedBatchedEthTx struct {
+	PipelineTaskRunID uuid.NullUUID `db:"pipeline_task_run_id"`
+	Error             string        `db:"error"`
+}
+
+// syncEthTxBatches moves the transactions sent in the batches of chainID
+// along with their batch: they are confirmed once it is, are unstarted again
+// if it was re-orged out, and fatally error if it does. It returns the
+// transactions that fatally errored.
+func syncEthTxBatches(tx pg.Queryer, chainID big.Int) (failed []failedBatchedEthTx, err error) {
+	_, err = tx.Exec(`
+UPDATE eth_txes SET state = 'unstarted', broadcast_at = NULL, initial_broadcast_at = NULL
+FROM eth_txes batch
+WHERE eth_txes.batch_eth_tx_id = batch.id AND eth_txes.state = 'confirmed' AND batch.state <> 'confirmed' AND batch.evm_chain_id = $1`, chainID.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unconfirm batched eth_txes")
+	}
+	_, err = tx.Exec(`
+UPDATE eth_txes SET state = 'confirmed', broadcast_at = batch.broadcast_at, initial_broadcast_at = batch.initial_broadcast_at
+FROM eth_txes batch
+WHERE eth_txes.batch_eth_tx_id = batch.id AND eth_txes.state = 'unstarted' AND batch.state = 'confirmed' AND batch.evm_chain_id = $1`, chainID.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to confirm batched eth_txes")
+	}
+	err = tx.Select(&failed, `
+UPDATE eth_txes SET state = 'fatal_error', error = batch.error
+FROM eth_txes batch
+WHERE eth_txes.batch_eth_tx_id = batch.id AND eth_txes.state = 'unstarted' AND batch.state = 'fatal_error' AND batch.evm_chain_id = $1
+RETURNING eth_txes.pipeline_task_run_id, eth_txes.error`, chainID.String())
+	return failed, errors.Wrap(err, "failed to fail batched eth_txes")
+}
+
+// batchCallReceipt derives the receipt of the call at index in the batch
+// sent to forwarder from the receipt of the batch: its status is the outcome
+// of the call and it only holds the logs emitted by the call.
+func batchCallReceipt(receipt evmtypes.Receipt, forwarder common.Address, index int64) (evmtypes.Receipt, error) {
+	if receipt.Status == 0 {
+		// The whole batch reverted
+		return receipt, nil
+	}
+	var callLogs []*evmtypes.Log
+	for _, log := range receipt.Logs {
+		if log.Address != forwarder || len(log.Topics) != 2 || log.Topics[0] != callForwardedTopic {
+			callLogs = append(callLogs, log)
+			continue
+		}
+		if log.Topics[1].Big().Int64() != index {
+			// The logs that follow are emitted by the next call
+			callLogs = nil
+			continue
+		}
+		out, err := batchForwarderABI.Unpack("CallForwarded", log.Data)
+		if err != nil {
+			return receipt, errors.Wrap(err, "failed to decode CallForwarded log")
+		}
+		callReceipt := receipt
+		callReceipt.Logs = callLogs
+		callReceipt.Status = 0
+		if success, _ := out[0].(bool); success {
+			callReceipt.Status = 1
+		}
+		return callReceipt, nil
+	}
+	return receipt, errors.Errorf("batch transaction %s has no outcome for call %d", receipt.TxHash, index)
+}
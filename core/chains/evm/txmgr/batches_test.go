@@ -0,0 +1,341 @@
+package txmgr_test
+
+import (
+	"math/big"
+	"testing"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+	evmtypes "github.com/smartcontractkit/chainlink/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/evmtest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+func TestGroupEthTxBatches(t *testing.T) {
+	t.Parallel()
+
+	forwarderA := testutils.NewAddress()
+	forwarderB := testutils.NewAddress()
+	newTx := func(id int64, forwarder gethCommon.Address, gasLimit uint32) txmgr.EthTx {
+		return txmgr.EthTx{ID: id, BatchForwarderAddress: &forwarder, GasLimit: gasLimit}
+	}
+	ids := func(batches [][]txmgr.EthTx) (res [][]int64) {
+		for _, batch := range batches {
+			var batchIDs []int64
+			for _, etx := range batch {
+				batchIDs = append(batchIDs, etx.ID)
+			}
+			res = append(res, batchIDs)
+		}
+		return res
+	}
+
+	t.Run("groups by forwarder", func(t *testing.T) {
+		batches := txmgr.GroupEthTxBatches([]txmgr.EthTx{
+			newTx(1, forwarderA, 100),
+			newTx(2, forwarderB, 100),
+			newTx(3, forwarderA, 100),
+		})
+		assert.Equal(t, [][]int64{{1, 3}, {2}}, ids(batches))
+	})
+
+	t.Run("caps the number of transactions in a batch", func(t *testing.T) {
+		var etxs []txmgr.EthTx
+		for i := 0; i < txmgr.MaxEthTxBatchSize+1; i++ {
+			etxs = append(etxs, newTx(int64(i), forwarderA, 100))
+		}
+		batches := txmgr.GroupEthTxBatches(etxs)
+		require.Len(t, batches, 2)
+		assert.Len(t, batches[0], txmgr.MaxEthTxBatchSize)
+		assert.Len(t, batches[1], 1)
+	})
+
+	t.Run("caps the gas limit of a batch", func(t *testing.T) {
+		batches := txmgr.GroupEthTxBatches([]txmgr.EthTx{
+			newTx(1, forwarderA, 6_000_000),
+			newTx(2, forwarderA, 6_000_000),
+			newTx(3, forwarderA, 20_000_000),
+		})
+		assert.Equal(t, [][]int64{{1}, {2}, {3}}, ids(batches))
+	})
+}
+
+func TestBatchCallReceipt(t *testing.T) {
+	t.Parallel()
+
+	forwarder := testutils.NewAddress()
+	target := testutils.NewAddress()
+	outcome := func(index int64, success bool) *evmtypes.Log {
+		data := make([]byte, 32)
+		if success {
+			data[31] = 1
+		}
+		return &evmtypes.Log{
+			Address: forwarder,
+			Topics:  []gethCommon.Hash{txmgr.CallForwardedTopic(), gethCommon.BigToHash(big.NewInt(index))},
+			Data:    data,
+		}
+	}
+	targetLog0 := &evmtypes.Log{Address: target, Topics: []gethCommon.Hash{{0x1}}}
+	targetLog2 := &evmtypes.Log{Address: target, Topics: []gethCommon.Hash{{0x2}}}
+
+	receipt := evmtypes.Receipt{
+		TxHash: utils.NewHash(),
+		Status: 1,
+		Logs:   []*evmtypes.Log{targetLog0, outcome(0, true), outcome(1, false), targetLog2, outcome(2, true)},
+	}
+
+	t.Run("successful call", func(t *testing.T) {
+		callReceipt, err := txmgr.BatchCallReceipt(receipt, forwarder, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), callReceipt.Status)
+		assert.Equal(t, []*evmtypes.Log{targetLog0}, callReceipt.Logs)
+
+		callReceipt, err = txmgr.BatchCallReceipt(receipt, forwarder, 2)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), callReceipt.Status)
+		assert.Equal(t, []*evmtypes.Log{targetLog2}, callReceipt.Logs)
+	})
+
+	t.Run("failed call", func(t *testing.T) {
+		callReceipt, err := txmgr.BatchCallReceipt(receipt, forwarder, 1)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), callReceipt.Status)
+		assert.Empty(t, callReceipt.Logs)
+	})
+
+	t.Run("reverted batch", func(t *testing.T) {
+		reverted := evmtypes.Receipt{TxHash: utils.NewHash(), Status: 0}
+		callReceipt, err := txmgr.BatchCallReceipt(reverted, forwarder, 1)
+		require.NoError(t, err)
+		assert.Equal(t, reverted, callReceipt)
+	})
+
+	t.Run("missing outcome", func(t *testing.T) {
+		_, err := txmgr.BatchCallReceipt(receipt, forwarder, 3)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "has no outcome for call 3")
+	})
+
+	t.Run("ignores outcomes logged by other forwarders", func(t *testing.T) {
+		_, err := txmgr.BatchCallReceipt(receipt, testutils.NewAddress(), 0)
+		require.Error(t, err)
+	})
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_Batching(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	keyState, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	checkerFactory := &txmgr.CheckerFactory{Client: ethClient}
+
+	eb := cltest.NewEthBroadcaster(t, db, ethClient, ethKeyStore, evmcfg, []ethkey.State{keyState}, checkerFactory)
+
+	forwarder := testutils.NewAddress()
+	toAddress := testutils.NewAddress()
+
+	var etxs []txmgr.EthTx
+	for i := 0; i < 2; i++ {
+		etx := txmgr.EthTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: []byte{42, byte(i)},
+			Value:          assets.NewEthValue(0),
+			GasLimit:       242,
+			State:          txmgr.EthTxUnstarted,
+		}
+		require.NoError(t, borm.InsertEthTx(&etx))
+		pgtest.MustExec(t, db, `UPDATE eth_txes SET batch_forwarder_address = $1 WHERE id = $2`, forwarder, etx.ID)
+		etxs = append(etxs, etx)
+	}
+
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == 0 && tx.To() != nil && *tx.To() == forwarder
+	})).Return(nil).Once()
+
+	err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+	require.NoError(t, err)
+	assert.False(t, retryable)
+
+	var batchEtxID int64
+	for i, etx := range etxs {
+		etx, err = borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxUnstarted, etx.State)
+		require.True(t, etx.IsBatched())
+		assert.Equal(t, int64(i), etx.BatchIndex.Int64)
+		assert.Empty(t, etx.EthTxAttempts)
+		batchEtxID = etx.BatchEthTxID.Int64
+	}
+
+	batchEtx, err := borm.FindEthTxWithAttempts(batchEtxID)
+	require.NoError(t, err)
+	assert.Equal(t, txmgr.EthTxUnconfirmed, batchEtx.State)
+	assert.Equal(t, forwarder, batchEtx.ToAddress)
+	assert.Equal(t, uint32(2*(242+txmgr.BatchCallGasOverhead)), batchEtx.GasLimit)
+	require.Len(t, batchEtx.EthTxAttempts, 1)
+
+	args, err := txmgr.UnpackForwardBatch(batchEtx.EncodedPayload)
+	require.NoError(t, err)
+	require.Len(t, args, 3)
+	assert.Equal(t, []gethCommon.Address{toAddress, toAddress}, args[0])
+	assert.Equal(t, [][]byte{{42, 0}, {42, 1}}, args[1])
+	assert.Equal(t, []*big.Int{big.NewInt(242), big.NewInt(242)}, args[2])
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_BatchingLoneTx(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	keyState, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	checkerFactory := &txmgr.CheckerFactory{Client: ethClient}
+
+	eb := cltest.NewEthBroadcaster(t, db, ethClient, ethKeyStore, evmcfg, []ethkey.State{keyState}, checkerFactory)
+
+	forwarder := testutils.NewAddress()
+	toAddress := testutils.NewAddress()
+
+	etx := txmgr.EthTx{
+		FromAddress:    fromAddress,
+		ToAddress:      toAddress,
+		EncodedPayload: []byte{42},
+		Value:          assets.NewEthValue(0),
+		GasLimit:       242,
+		State:          txmgr.EthTxUnstarted,
+	}
+	require.NoError(t, borm.InsertEthTx(&etx))
+	pgtest.MustExec(t, db, `UPDATE eth_txes SET batch_forwarder_address = $1 WHERE id = $2`, forwarder, etx.ID)
+
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == 0 && tx.To() != nil && *tx.To() == toAddress
+	})).Return(nil).Once()
+
+	err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+	require.NoError(t, err)
+	assert.False(t, retryable)
+
+	etx, err = borm.FindEthTxWithAttempts(etx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, txmgr.EthTxUnconfirmed, etx.State)
+	assert.Nil(t, etx.BatchForwarderAddress)
+	assert.False(t, etx.IsBatched())
+	require.Len(t, etx.EthTxAttempts, 1)
+}
+
+func TestEthConfirmer_SyncBatchedEthTxes(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	keyState, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	checkerFactory := &txmgr.CheckerFactory{Client: ethClient}
+
+	eb := cltest.NewEthBroadcaster(t, db, ethClient, ethKeyStore, evmcfg, []ethkey.State{keyState}, checkerFactory)
+	ec := cltest.NewEthConfirmer(t, db, ethClient, evmcfg, ethKeyStore, []ethkey.State{keyState}, nil)
+
+	forwarder := testutils.NewAddress()
+	var etxs []txmgr.EthTx
+	for i := 0; i < 2; i++ {
+		etx := txmgr.EthTx{
+			FromAddress:    fromAddress,
+			ToAddress:      testutils.NewAddress(),
+			EncodedPayload: []byte{42, byte(i)},
+			Value:          assets.NewEthValue(0),
+			GasLimit:       242,
+			State:          txmgr.EthTxUnstarted,
+		}
+		require.NoError(t, borm.InsertEthTx(&etx))
+		pgtest.MustExec(t, db, `UPDATE eth_txes SET batch_forwarder_address = $1 WHERE id = $2`, forwarder, etx.ID)
+		etxs = append(etxs, etx)
+	}
+
+	ethClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+	err, _ := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+	require.NoError(t, err)
+
+	etx, err := borm.FindEthTxWithAttempts(etxs[0].ID)
+	require.NoError(t, err)
+	batchEtxID := etx.BatchEthTxID.Int64
+
+	assertStates := func(state txmgr.EthTxState) {
+		t.Helper()
+		for _, etx := range etxs {
+			etx, err := borm.FindEthTxWithAttempts(etx.ID)
+			require.NoError(t, err)
+			assert.Equal(t, state, etx.State)
+			assert.Nil(t, etx.Nonce)
+		}
+	}
+
+	t.Run("waits for the batch", func(t *testing.T) {
+		require.NoError(t, ec.SyncBatchedEthTxes(testutils.Context(t)))
+		assertStates(txmgr.EthTxUnstarted)
+	})
+
+	t.Run("confirms with the batch", func(t *testing.T) {
+		pgtest.MustExec(t, db, `UPDATE eth_txes SET state = 'confirmed' WHERE id = $1`, batchEtxID)
+		require.NoError(t, ec.SyncBatchedEthTxes(testutils.Context(t)))
+		assertStates(txmgr.EthTxConfirmed)
+	})
+
+	t.Run("unconfirms when the batch is re-orged out", func(t *testing.T) {
+		pgtest.MustExec(t, db, `UPDATE eth_txes SET state = 'unconfirmed' WHERE id = $1`, batchEtxID)
+		require.NoError(t, ec.SyncBatchedEthTxes(testutils.Context(t)))
+		assertStates(txmgr.EthTxUnstarted)
+	})
+
+	t.Run("fails with the batch", func(t *testing.T) {
+		pgtest.MustExec(t, db, `UPDATE eth_txes SET state = 'fatal_error', nonce = NULL, error = 'batch failed', broadcast_at = NULL, initial_broadcast_at = NULL WHERE id = $1`, batchEtxID)
+		pgtest.MustExec(t, db, `DELETE FROM eth_tx_attempts WHERE eth_tx_id = $1`, batchEtxID)
+		require.NoError(t, ec.SyncBatchedEthTxes(testutils.Context(t)))
+		assertStates(txmgr.EthTxFatalError)
+		etx, err := borm.FindEthTxWithAttempts(etxs[1].ID)
+		require.NoError(t, err)
+		require.True(t, etx.Error.Valid)
+		assert.Equal(t, "batch failed", etx.Error.String)
+	})
+}
+
+func TestEthTx_BatchedValueMustBeZero(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+
+	etx := txmgr.EthTx{
+		FromAddress:    fromAddress,
+		ToAddress:      testutils.NewAddress(),
+		EncodedPayload: []byte{42},
+		Value:          assets.NewEthValue(1),
+		GasLimit:       242,
+		State:          txmgr.EthTxUnstarted,
+	}
+	require.NoError(t, borm.InsertEthTx(&etx))
+	_, err := db.Exec(`UPDATE eth_txes SET batch_forwarder_address = $1 WHERE id = $2`, testutils.NewAddress(), etx.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "chk_eth_txes_batch_value")
+}
@@ -15,12 +15,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	uuid "github.com/satori/go.uuid"
 	"go.uber.org/multierr"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/sqlx"
 
-	"github.com/smartcontractkit/chainlink/core/assets"
 	evmclient "github.com/smartcontractkit/chainlink/core/chains/evm/client"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/label"
@@ -54,7 +54,15 @@ var (
 			float64(time.Minute),
 			float64(2 * time.Minute),
 		},
-	}, []string{"evmChainID"})
+	}, []string{"evmChainID", "priority"})
+	promUnstartedTxs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tx_manager_unstarted_txes",
+		Help: "The number of unstarted transactions in the queue of a key, by priority.",
+	}, []string{"evmChainID", "fromAddress", "priority"})
+	promQueueCapacityExceeded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tx_manager_queue_capacity_exceeded",
+		Help: "The number of transactions rejected because the queue of their key or priority was full.",
+	}, []string{"evmChainID", "priority"})
 )
 
 var errEthTxRemoved = errors.New("eth_tx removed")
@@ -294,6 +302,9 @@ func (eb *EthBroadcaster) processUnstartedEthTxs(ctx context.Context, fromAddres
 		if n > 0 {
 			eb.logger.Debugw("Finished processUnstartedEthTxs", "address", fromAddress, "time", time.Since(mark), "n", n, "id", "eth_broadcaster")
 		}
+		if err := eb.observeUnstartedTransactions(fromAddress); err != nil {
+			eb.logger.Errorw("Failed to observe unstarted transactions", "address", fromAddress, "err", err)
+		}
 	}()
 
 	err, retryable = eb.handleAnyInProgressEthTx(ctx, fromAddress)
@@ -317,6 +328,9 @@ func (eb *EthBroadcaster) processUnstartedEthTxs(ctx context.Context, fromAddres
 				continue
 			}
 		}
+		if err := eb.batchUnstartedEthTxs(fromAddress); err != nil {
+			return errors.Wrap(err, "processUnstartedEthTxs failed on batchUnstartedEthTxs"), true
+		}
 		etx, err := eb.nextUnstartedTransactionWithNonce(fromAddress)
 		if err != nil {
 			return errors.Wrap(err, "processUnstartedEthTxs failed on nextUnstartedTransactionWithNonce"), true
@@ -325,26 +339,9 @@ func (eb *EthBroadcaster) processUnstartedEthTxs(ctx context.Context, fromAddres
 			return nil, false
 		}
 		n++
-		var a EthTxAttempt
-		keySpecificMaxGasPriceWei := eb.config.KeySpecificMaxGasPriceWei(etx.FromAddress)
-		if eb.config.EvmEIP1559DynamicFees() {
-			fee, gasLimit, err := eb.estimator.GetDynamicFee(ctx, etx.GasLimit, keySpecificMaxGasPriceWei)
-			if err != nil {
-				return errors.Wrap(err, "failed to get dynamic gas fee"), true
-			}
-			a, err = eb.NewDynamicFeeAttempt(*etx, fee, gasLimit)
-			if err != nil {
-				return errors.Wrap(err, "processUnstartedEthTxs failed on NewDynamicFeeAttempt"), true
-			}
-		} else {
-			gasPrice, gasLimit, err := eb.estimator.GetLegacyGas(ctx, etx.EncodedPayload, etx.GasLimit, keySpecificMaxGasPriceWei)
-			if err != nil {
-				return errors.Wrap(err, "failed to estimate gas"), true
-			}
-			a, err = eb.NewLegacyAttempt(*etx, gasPrice, gasLimit)
-			if err != nil {
-				return errors.Wrap(err, "processUnstartedEthTxs failed on NewLegacyAttempt"), true
-			}
+		a, err := eb.newAttempt(ctx, *etx)
+		if err != nil {
+			return errors.Wrap(err, "processUnstartedEthTxs failed on newAttempt"), true
 		}
 
 		if err := eb.saveInProgressTransaction(etx, &a); errors.Is(err, errEthTxRemoved) {
@@ -354,6 +351,18 @@ func (eb *EthBroadcaster) processUnstartedEthTxs(ctx context.Context, fromAddres
 			return errors.Wrap(err, "processUnstartedEthTxs failed on saveInProgressTransaction"), true
 		}
 
+		// Only simulate the transaction before it is first broadcast, since
+		// an in_progress transaction that is retried may already be in the
+		// mempool
+		if reason, reverted := eb.simulate(ctx, *etx, a); reverted {
+			etx.Error = null.StringFrom(fmt.Sprintf("transaction reverted during simulation: %s", reason))
+			etx.RevertReason = null.StringFrom(reason)
+			if err := eb.saveFatallyErroredTransaction(etx.GetLogger(eb.logger), etx); err != nil {
+				return errors.Wrap(err, "processUnstartedEthTxs failed on saveFatallyErroredTransaction"), true
+			}
+			continue
+		}
+
 		if err, retryable := eb.handleInProgressEthTx(ctx, *etx, a, time.Now()); err != nil {
 			return errors.Wrap(err, "processUnstartedEthTxs failed on handleAnyInProgressEthTx"), retryable
 		}
@@ -434,7 +443,20 @@ func (eb *EthBroadcaster) handleInProgressEthTx(ctx context.Context, etx EthTx,
 	}
 	cancel()
 
-	sendError := sendTransaction(ctx, eb.ethClient, attempt, etx, lgr)
+	if err = checkAttemptGasSpendLimits(eb.q, eb.config, eb.chainID, etx, attempt); errors.Is(err, ErrGasSpendLimitExceeded) {
+		lgr.Criticalw("Gas spend limit exceeded, fatally erroring transaction", "err", err)
+		etx.Error = null.StringFrom(err.Error())
+		return eb.saveFatallyErroredTransaction(lgr, &etx), true
+	} else if err != nil {
+		return errors.Wrap(err, "checking gas spend limits"), true
+	}
+
+	sendError, relayErr := broadcastTransaction(ctx, eb.q, eb.ethClient, eb.config, eb.signer(etx.FromAddress), attempt, etx, 0, lgr)
+	if relayErr != nil {
+		// The in_progress attempt is submitted to the relay again on the
+		// next try
+		return errors.Wrapf(relayErr, "private relay did not accept transaction %s (eth_tx ID %d)", attempt.Hash.Hex(), etx.ID), true
+	}
 
 	if sendError.Fatal() {
 		lgr.Criticalw("Fatal error sending transaction", "err", sendError, "etx", etx)
@@ -529,7 +551,7 @@ func (eb *EthBroadcaster) handleInProgressEthTx(ctx context.Context, etx EthTx,
 		// Since we can re-enter this method by way of tryAgainBumpingGas,
 		// and we pass the same initialBroadcastAt timestamp there, when we re-enter
 		// this function we'll be using the same initialBroadcastAt.
-		observeTimeUntilBroadcast(eb.chainID, etx.CreatedAt, time.Now())
+		observeTimeUntilBroadcast(eb.chainID, etx.Priority, etx.CreatedAt, time.Now())
 		return eb.saveAttempt(&etx, attempt, EthTxAttemptBroadcast), true
 	}
 
@@ -646,9 +668,11 @@ func (eb *EthBroadcaster) saveInProgressTransaction(etx *EthTx, attempt *EthTxAt
 	})
 }
 
-// Finds earliest saved transaction that has yet to be broadcast from the given address
+// Finds earliest saved transaction that has yet to be broadcast from the given address,
+// in the highest priority lane that has one (priorities are declared from highest to lowest).
+// Transactions using a batching forwarder are only ever sent as part of a batch.
 func findNextUnstartedTransactionFromAddress(db *sqlx.DB, etx *EthTx, fromAddress gethCommon.Address, chainID big.Int) error {
-	err := db.Get(etx, `SELECT * FROM eth_txes WHERE from_address = $1 AND state = 'unstarted' AND evm_chain_id = $2 ORDER BY value ASC, created_at ASC, id ASC`, fromAddress, chainID.String())
+	err := db.Get(etx, `SELECT * FROM eth_txes WHERE from_address = $1 AND state = 'unstarted' AND evm_chain_id = $2 AND batch_forwarder_address IS NULL ORDER BY priority ASC, value ASC, created_at ASC, id ASC`, fromAddress, chainID.String())
 	return errors.Wrap(err, "failed to findNextUnstartedTransactionFromAddress")
 }
 
@@ -683,6 +707,21 @@ func (eb *EthBroadcaster) saveAttempt(etx *EthTx, attempt EthTxAttempt, NewAttem
 	})
 }
 
+// newAttempt estimates the fee of the first attempt of etx and builds it,
+// with the type chosen by EthTxType
+func (eb *EthBroadcaster) newAttempt(ctx context.Context, etx EthTx) (attempt EthTxAttempt, err error) {
+	txType := EthTxType(eb.config, etx)
+	builder, err := AttemptBuilderFor(txType)
+	if err != nil {
+		return attempt, err
+	}
+	fee, gasLimit, err := builder.EstimateFee(ctx, eb.estimator, etx, eb.config.KeySpecificMaxGasPriceWei(etx.FromAddress))
+	if err != nil {
+		return attempt, errors.Wrap(err, "failed to estimate gas")
+	}
+	return eb.NewAttempt(txType, etx, fee, gasLimit)
+}
+
 func (eb *EthBroadcaster) tryAgainBumpingGas(ctx context.Context, lgr logger.Logger, sendError *evmclient.SendError, etx EthTx, attempt EthTxAttempt, initialBroadcastAt time.Time) (err error, retryable bool) {
 	lgr.With(
 		"sendError", sendError,
@@ -695,83 +734,82 @@ func (eb *EthBroadcaster) tryAgainBumpingGas(ctx context.Context, lgr logger.Log
 		"Will bump and retry. ACTION REQUIRED: This is a configuration error. "+
 		"Consider increasing ETH_GAS_PRICE_DEFAULT (current value: %s)",
 		attempt.GasPrice, sendError.Error(), eb.config.EvmGasPriceDefault().String())
-	switch attempt.TxType {
-	case 0x0:
-		return eb.tryAgainBumpingLegacyGas(ctx, lgr, etx, attempt, initialBroadcastAt)
-	case 0x2:
-		return eb.tryAgainBumpingDynamicFeeGas(ctx, lgr, etx, attempt, initialBroadcastAt)
-	default:
+	builder, err := AttemptBuilderFor(attempt.TxType)
+	if err != nil {
 		err = errors.Errorf("invariant violation: Attempt %v had unrecognised transaction type %v"+
 			"This is a bug! Please report to https://github.com/smartcontractkit/chainlink/issues", attempt.ID, attempt.TxType)
 		logger.Sugared(eb.logger).AssumptionViolation(err.Error())
 		return err, false
 	}
-}
-
-func (eb *EthBroadcaster) tryAgainBumpingLegacyGas(ctx context.Context, lgr logger.Logger, etx EthTx, attempt EthTxAttempt, initialBroadcastAt time.Time) (err error, retryable bool) {
 	keySpecificMaxGasPriceWei := eb.config.KeySpecificMaxGasPriceWei(etx.FromAddress)
-	bumpedGasPrice, bumpedGasLimit, err := eb.estimator.BumpLegacyGas(ctx, attempt.GasPrice, etx.GasLimit, keySpecificMaxGasPriceWei, nil)
+	bumpedFee, bumpedGasLimit, err := builder.BumpFee(ctx, eb.estimator, etx, attempt, keySpecificMaxGasPriceWei, nil)
 	if err != nil {
-		return errors.Wrap(err, "tryAgainBumpingLegacyGas failed"), true
+		return errors.Wrap(err, "tryAgainBumpingGas failed"), true
 	}
-	if bumpedGasPrice.Cmp(attempt.GasPrice) == 0 || bumpedGasPrice.Cmp(eb.config.EvmMaxGasPriceWei()) >= 0 {
+	if bumpedFee.reachedBumpCeiling(attempt.Fee(), eb.config.EvmMaxGasPriceWei()) {
 		return errors.Errorf("hit gas price bump ceiling, will not bump further"), true // TODO: Is this terminal or retryable? Is it possible to send unsaved attempts here?
 	}
-	return eb.tryAgainWithNewLegacyGas(ctx, lgr, etx, attempt, initialBroadcastAt, bumpedGasPrice, bumpedGasLimit)
-}
-
-func (eb *EthBroadcaster) tryAgainBumpingDynamicFeeGas(ctx context.Context, lgr logger.Logger, etx EthTx, attempt EthTxAttempt, initialBroadcastAt time.Time) (err error, retryable bool) {
-	keySpecificMaxGasPriceWei := eb.config.KeySpecificMaxGasPriceWei(etx.FromAddress)
-	bumpedFee, bumpedGasLimit, err := eb.estimator.BumpDynamicFee(ctx, attempt.DynamicFee(), etx.GasLimit, keySpecificMaxGasPriceWei, nil)
-	if err != nil {
-		return errors.Wrap(err, "tryAgainBumpingDynamicFeeGas failed"), true
-	}
-	if bumpedFee.TipCap.Cmp(attempt.GasTipCap) == 0 || bumpedFee.FeeCap.Cmp(attempt.GasFeeCap) == 0 || bumpedFee.TipCap.Cmp(eb.config.EvmMaxGasPriceWei()) >= 0 || bumpedFee.TipCap.Cmp(eb.config.EvmMaxGasPriceWei()) >= 0 {
-		return errors.Errorf("hit gas price bump ceiling, will not bump further"), true // TODO: Is this terminal or retryable? Is it possible to send unsaved attempts here?
-	}
-	return eb.tryAgainWithNewDynamicFeeGas(ctx, lgr, etx, attempt, initialBroadcastAt, bumpedFee, bumpedGasLimit)
+	return eb.tryAgainWithNewFee(ctx, lgr, etx, attempt, initialBroadcastAt, bumpedFee, bumpedGasLimit)
 }
 
 func (eb *EthBroadcaster) tryAgainWithNewEstimation(ctx context.Context, lgr logger.Logger, sendError *evmclient.SendError, etx EthTx, attempt EthTxAttempt, initialBroadcastAt time.Time) (err error, retryable bool) {
-	if attempt.TxType == 0x2 {
+	if attempt.TxType == DynamicFeeTxType {
 		err = errors.Errorf("re-estimation is not supported for EIP-1559 transactions. Eth node returned error: %v. This is a bug", sendError.Error())
 		logger.Sugared(eb.logger).AssumptionViolation(err.Error())
 		return err, false
 	}
+	builder, err := AttemptBuilderFor(attempt.TxType)
+	if err != nil {
+		return errors.Wrap(err, "tryAgainWithNewEstimation failed"), false
+	}
 	keySpecificMaxGasPriceWei := eb.config.KeySpecificMaxGasPriceWei(etx.FromAddress)
-	gasPrice, gasLimit, err := eb.estimator.GetLegacyGas(ctx, etx.EncodedPayload, etx.GasLimit, keySpecificMaxGasPriceWei, gas.OptForceRefetch)
+	fee, gasLimit, err := builder.EstimateFee(ctx, eb.estimator, etx, keySpecificMaxGasPriceWei, gas.OptForceRefetch)
 	if err != nil {
 		return errors.Wrap(err, "tryAgainWithNewEstimation failed to estimate gas"), true
 	}
 	lgr.Warnw("L2 rejected transaction due to incorrect fee, re-estimated and will try again",
-		"etxID", etx.ID, "err", err, "newGasPrice", gasPrice, "newGasLimit", gasLimit)
-	return eb.tryAgainWithNewLegacyGas(ctx, lgr, etx, attempt, initialBroadcastAt, gasPrice, gasLimit)
+		"etxID", etx.ID, "err", err, "newGasPrice", fee.GasPrice, "newGasLimit", gasLimit)
+	return eb.tryAgainWithNewFee(ctx, lgr, etx, attempt, initialBroadcastAt, fee, gasLimit)
 }
 
-func (eb *EthBroadcaster) tryAgainWithNewLegacyGas(ctx context.Context, lgr logger.Logger, etx EthTx, attempt EthTxAttempt, initialBroadcastAt time.Time, newGasPrice *assets.Wei, newGasLimit uint32) (err error, retyrable bool) {
-	replacementAttempt, err := eb.NewLegacyAttempt(etx, newGasPrice, newGasLimit)
+func (eb *EthBroadcaster) tryAgainWithNewFee(ctx context.Context, lgr logger.Logger, etx EthTx, attempt EthTxAttempt, initialBroadcastAt time.Time, newFee AttemptFee, newGasLimit uint32) (err error, retyrable bool) {
+	replacementAttempt, err := eb.NewAttempt(attempt.TxType, etx, newFee, newGasLimit)
 	if err != nil {
-		return errors.Wrap(err, "tryAgainWithNewLegacyGas failed"), true
+		return errors.Wrap(err, "tryAgainWithNewFee failed"), true
 	}
 
 	if err = saveReplacementInProgressAttempt(eb.q, attempt, &replacementAttempt); err != nil {
-		return errors.Wrap(err, "tryAgainWithNewLegacyGas failed"), true
+		return errors.Wrap(err, "tryAgainWithNewFee failed"), true
 	}
-	lgr.Debugw("Bumped legacy gas on initial send", "oldGasPrice", attempt.GasPrice, "newGasPrice", newGasPrice)
+	lgr.Debugw("Bumped gas on initial send", "txType", attempt.TxType, "oldFee", attempt.Fee(), "newFee", newFee)
 	return eb.handleInProgressEthTx(ctx, etx, replacementAttempt, initialBroadcastAt)
 }
 
-func (eb *EthBroadcaster) tryAgainWithNewDynamicFeeGas(ctx context.Context, lgr logger.Logger, etx EthTx, attempt EthTxAttempt, initialBroadcastAt time.Time, newDynamicFee gas.DynamicFee, newGasLimit uint32) (err error, retyrable bool) {
-	replacementAttempt, err := eb.NewDynamicFeeAttempt(etx, newDynamicFee, newGasLimit)
-	if err != nil {
-		return errors.Wrap(err, "tryAgainWithNewDynamicFeeGas failed"), true
+// simulate calls the attempt with eth_call against the pending block, if
+// SimulateBeforeBroadcast is enabled, and returns the revert reason if it
+// reverts. The transaction is sent anyway if the simulation fails for any
+// other reason.
+func (eb *EthBroadcaster) simulate(ctx context.Context, etx EthTx, attempt EthTxAttempt) (string, bool) {
+	if !eb.config.EvmSimulateBeforeBroadcast() {
+		return "", false
 	}
-
-	if err = saveReplacementInProgressAttempt(eb.q, attempt, &replacementAttempt); err != nil {
-		return errors.Wrap(err, "tryAgainWithNewDynamicFeeGas failed"), true
+	// The simulate checker simulates the transaction anyway
+	if checkerSpec, err := etx.GetChecker(); err == nil && checkerSpec.CheckerType == TransmitCheckerTypeSimulate {
+		return "", false
 	}
-	lgr.Debugw("Bumped dynamic fee gas on initial send", "oldFee", attempt.DynamicFee(), "newFee", newDynamicFee)
-	return eb.handleInProgressEthTx(ctx, etx, replacementAttempt, initialBroadcastAt)
+	lgr := etx.GetLogger(eb.logger.With("gasPrice", attempt.GasPrice, "gasTipCap", attempt.GasTipCap, "gasFeeCap", attempt.GasFeeCap))
+	ctx, cancel := context.WithTimeout(ctx, TransmitCheckTimeout)
+	defer cancel()
+	_, err := simulateTx(ctx, eb.ethClient, etx, attempt, "pending")
+	if err == nil {
+		return "", false
+	}
+	if reason, ok := RevertReason(err); ok {
+		lgr.Warnw("Transaction reverted during simulation, fatally erroring transaction.", "revertReason", reason, "err", err)
+		return reason, true
+	}
+	lgr.Warnw("Transaction simulation failed, will attempt to send anyway", "err", err)
+	return "", false
 }
 
 func (eb *EthBroadcaster) saveFatallyErroredTransaction(lgr logger.Logger, etx *EthTx) error {
@@ -803,12 +841,34 @@ func (eb *EthBroadcaster) saveFatallyErroredTransaction(lgr logger.Logger, etx *
 	}
 	etx.Nonce = nil
 	etx.State = EthTxFatalError
-	return eb.q.Transaction(func(tx pg.Queryer) error {
+	// The transactions of a failed batch fail with it
+	var batchedTaskRunIDs []uuid.UUID
+	err := eb.q.Transaction(func(tx pg.Queryer) error {
 		if _, err := tx.Exec(`DELETE FROM eth_tx_attempts WHERE eth_tx_id = $1`, etx.ID); err != nil {
 			return errors.Wrapf(err, "saveFatallyErroredTransaction failed to delete eth_tx_attempt with eth_tx.ID %v", etx.ID)
 		}
-		return errors.Wrap(tx.Get(etx, `UPDATE eth_txes SET state=$1, error=$2, broadcast_at=NULL, initial_broadcast_at=NULL, nonce=NULL WHERE id=$3 RETURNING *`, etx.State, etx.Error, etx.ID), "saveFatallyErroredTransaction failed to save eth_tx")
+		if err := tx.Get(etx, `UPDATE eth_txes SET state=$1, error=$2, revert_reason=$3, broadcast_at=NULL, initial_broadcast_at=NULL, nonce=NULL WHERE id=$4 RETURNING *`, etx.State, etx.Error, etx.RevertReason, etx.ID); err != nil {
+			return errors.Wrap(err, "saveFatallyErroredTransaction failed to save eth_tx")
+		}
+		var err error
+		batchedTaskRunIDs, err = failEthTxBatch(tx, etx.ID, etx.Error.String)
+		return errors.Wrap(err, "saveFatallyErroredTransaction failed to save batched eth_txes")
 	})
+	if err != nil {
+		return err
+	}
+	if eb.resumeCallback == nil {
+		return nil
+	}
+	for _, id := range batchedTaskRunIDs {
+		err := eb.resumeCallback(id, nil, errors.Errorf("fatal error while sending batch transaction: %s", etx.Error.String))
+		if errors.Is(err, sql.ErrNoRows) {
+			lgr.Debugw("callback missing or already resumed", "etxID", etx.ID, "pipelineTaskRunID", id)
+		} else if err != nil {
+			return errors.Wrap(err, "failed to resume pipeline")
+		}
+	}
+	return nil
 }
 
 func (eb *EthBroadcaster) getNextNonce(address gethCommon.Address) (nonce int64, err error) {
@@ -819,7 +879,28 @@ func (eb *EthBroadcaster) incrementNextNonce(address gethCommon.Address, current
 	return eb.ChainKeyStore.keystore.IncrementNextNonce(address, &eb.chainID, currentNonce, qopts...)
 }
 
-func observeTimeUntilBroadcast(chainID big.Int, createdAt, broadcastAt time.Time) {
+func observeTimeUntilBroadcast(chainID big.Int, priority EthTxPriority, createdAt, broadcastAt time.Time) {
 	duration := float64(broadcastAt.Sub(createdAt))
-	promTimeUntilBroadcast.WithLabelValues(chainID.String()).Observe(duration)
+	promTimeUntilBroadcast.WithLabelValues(chainID.String(), string(priority)).Observe(duration)
+}
+
+// observeUnstartedTransactions updates the number of unstarted transactions
+// of fromAddress in each priority lane
+func (eb *EthBroadcaster) observeUnstartedTransactions(fromAddress gethCommon.Address) error {
+	var counts []struct {
+		Priority EthTxPriority
+		Count    int64
+	}
+	err := eb.q.Select(&counts, `SELECT priority, count(*) FROM eth_txes WHERE from_address = $1 AND state = 'unstarted' AND evm_chain_id = $2 AND batch_eth_tx_id IS NULL GROUP BY priority`, fromAddress, eb.chainID.String())
+	if err != nil {
+		return errors.Wrap(err, "failed to count unstarted transactions")
+	}
+	byPriority := make(map[EthTxPriority]int64)
+	for _, c := range counts {
+		byPriority[c.Priority] = c.Count
+	}
+	for _, p := range EthTxPriorities {
+		promUnstartedTxs.WithLabelValues(eb.chainID.String(), fromAddress.Hex(), string(p)).Set(float64(byPriority[p]))
+	}
+	return nil
 }
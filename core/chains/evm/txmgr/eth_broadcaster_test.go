@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +28,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
 	gasmocks "github.com/smartcontractkit/chainlink/core/chains/evm/gas/mocks"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+	evmtypes "github.com/smartcontractkit/chainlink/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest/heavyweight"
 	"github.com/smartcontractkit/chainlink/core/internal/testutils"
@@ -39,6 +42,7 @@ import (
 	ksmocks "github.com/smartcontractkit/chainlink/core/services/keystore/mocks"
 	"github.com/smartcontractkit/chainlink/core/services/pg"
 	"github.com/smartcontractkit/chainlink/core/services/pg/datatypes"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
@@ -446,6 +450,339 @@ func TestEthBroadcaster_ProcessUnstartedEthTxs_Success(t *testing.T) {
 	})
 }
 
+func TestEthBroadcaster_ProcessUnstartedEthTxs_AccessListTxType(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	keyState, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	checkerFactory := &txmgr.CheckerFactory{Client: ethClient}
+
+	eb := cltest.NewEthBroadcaster(t, db, ethClient, ethKeyStore, evmcfg, []ethkey.State{keyState}, checkerFactory)
+
+	accessList := gethTypes.AccessList{gethTypes.AccessTuple{Address: testutils.NewAddress(), StorageKeys: []gethCommon.Hash{utils.NewHash()}}}
+	etx := txmgr.EthTx{
+		FromAddress:    fromAddress,
+		ToAddress:      testutils.NewAddress(),
+		EncodedPayload: []byte{42, 42, 0},
+		Value:          assets.NewEthValue(242),
+		GasLimit:       242,
+		State:          txmgr.EthTxUnstarted,
+		AccessList:     txmgr.NullableEIP2930AccessListFrom(accessList),
+		TxType:         null.IntFrom(int64(txmgr.AccessListTxType)),
+	}
+	require.NoError(t, borm.InsertEthTx(&etx))
+
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Type() == gethTypes.AccessListTxType && tx.Nonce() == uint64(0) && reflect.DeepEqual(tx.AccessList(), accessList)
+	})).Return(nil).Once()
+
+	err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+	assert.NoError(t, err)
+	assert.False(t, retryable)
+
+	etx, err = borm.FindEthTxWithAttempts(etx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, txmgr.EthTxUnconfirmed, etx.State)
+	require.Len(t, etx.EthTxAttempts, 1)
+	attempt := etx.EthTxAttempts[0]
+	assert.Equal(t, txmgr.AccessListTxType, attempt.TxType)
+	assert.Equal(t, evmcfg.EvmGasPriceDefault().String(), attempt.GasPrice.String())
+	assert.Nil(t, attempt.GasTipCap)
+	assert.Nil(t, attempt.GasFeeCap)
+	assert.Equal(t, txmgr.EthTxAttemptBroadcast, attempt.State)
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_SimulateBeforeBroadcast(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].Transactions.SimulateBeforeBroadcast = ptr(true)
+	})
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	keyState, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	checkerFactory := &txmgr.CheckerFactory{Client: ethClient}
+
+	eb := cltest.NewEthBroadcaster(t, db, ethClient, ethKeyStore, evmcfg, []ethkey.State{keyState}, checkerFactory)
+
+	toAddress := testutils.NewAddress()
+
+	t.Run("sends tx when simulation succeeds", func(t *testing.T) {
+		etx := txmgr.EthTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: []byte{42, 0, 0},
+			Value:          assets.NewEthValue(442),
+			GasLimit:       242,
+			State:          txmgr.EthTxUnstarted,
+		}
+		require.NoError(t, borm.InsertEthTx(&etx))
+
+		ethClient.On("CallContext", mock.Anything, mock.AnythingOfType("*hexutil.Bytes"), "eth_call", mock.MatchedBy(func(callarg map[string]interface{}) bool {
+			return fmt.Sprintf("%s", callarg["value"]) == "0x1ba" // 442
+		}), "pending").Return(nil).Once()
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+			return tx.Nonce() == uint64(0) && tx.Value().Cmp(big.NewInt(442)) == 0
+		})).Return(nil).Once()
+
+		err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+		assert.NoError(t, err)
+		assert.False(t, retryable)
+
+		etx, err = borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxUnconfirmed, etx.State)
+		assert.False(t, etx.RevertReason.Valid)
+	})
+
+	t.Run("on revert, marks tx as fatally errored with the revert reason and does not send", func(t *testing.T) {
+		etx := txmgr.EthTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: []byte{42, 0, 0},
+			Value:          assets.NewEthValue(542),
+			GasLimit:       242,
+			State:          txmgr.EthTxUnstarted,
+		}
+		require.NoError(t, borm.InsertEthTx(&etx))
+
+		// Error(string) with the reason "not allowed"
+		data := hexutil.MustDecode("0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000b6e6f7420616c6c6f776564000000000000000000000000000000000000000000")
+		ethClient.On("CallContext", mock.Anything, mock.AnythingOfType("*hexutil.Bytes"), "eth_call", mock.MatchedBy(func(callarg map[string]interface{}) bool {
+			return fmt.Sprintf("%s", callarg["value"]) == "0x21e" // 542
+		}), "pending").Return(&evmclient.JsonError{Code: 3, Message: "execution reverted: not allowed", Data: hexutil.Encode(data)}).Once()
+
+		err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+		assert.NoError(t, err)
+		assert.False(t, retryable)
+
+		etx, err = borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxFatalError, etx.State)
+		assert.Equal(t, "transaction reverted during simulation: not allowed", etx.Error.String)
+		assert.Equal(t, "not allowed", etx.RevertReason.String)
+		assert.Len(t, etx.EthTxAttempts, 0)
+	})
+
+	t.Run("sends tx when simulation fails without reverting", func(t *testing.T) {
+		etx := txmgr.EthTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: []byte{42, 0, 0},
+			Value:          assets.NewEthValue(642),
+			GasLimit:       242,
+			State:          txmgr.EthTxUnstarted,
+		}
+		require.NoError(t, borm.InsertEthTx(&etx))
+
+		ethClient.On("CallContext", mock.Anything, mock.AnythingOfType("*hexutil.Bytes"), "eth_call", mock.MatchedBy(func(callarg map[string]interface{}) bool {
+			return fmt.Sprintf("%s", callarg["value"]) == "0x282" // 642
+		}), "pending").Return(errors.New("connection reset")).Once()
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+			return tx.Nonce() == uint64(1) && tx.Value().Cmp(big.NewInt(642)) == 0
+		})).Return(nil).Once()
+
+		err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+		assert.NoError(t, err)
+		assert.False(t, retryable)
+
+		etx, err = borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxUnconfirmed, etx.State)
+	})
+
+	t.Run("does not simulate in_progress tx again when retrying it", func(t *testing.T) {
+		// It may already have been sent, so no eth_call is expected
+		etx := cltest.MustInsertInProgressEthTxWithAttempt(t, borm, 2, fromAddress)
+
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+			return tx.Nonce() == uint64(2)
+		})).Return(nil).Once()
+
+		err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+		assert.NoError(t, err)
+		assert.False(t, retryable)
+
+		etx, err = borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxUnconfirmed, etx.State)
+	})
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_PrivateSubmission(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	stub := &relayStub{}
+	ts := newRelayStubServer(t, stub)
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].Transactions.PrivateRelay.URL = models.MustParseURL(ts.URL)
+	})
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	keyState, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+
+	eb := cltest.NewEthBroadcaster(t, db, ethClient, ethKeyStore, evmcfg, []ethkey.State{keyState}, &testCheckerFactory{})
+
+	toAddress := testutils.NewAddress()
+	ethClient.On("HeadByNumber", mock.Anything, (*big.Int)(nil)).Return(&evmtypes.Head{Number: 100}, nil)
+
+	t.Run("sends private transactions to the relay only", func(t *testing.T) {
+		etx := txmgr.EthTx{
+			FromAddress:       fromAddress,
+			ToAddress:         toAddress,
+			EncodedPayload:    []byte{42, 0, 0},
+			Value:             assets.NewEthValue(442),
+			GasLimit:          242,
+			State:             txmgr.EthTxUnstarted,
+			PrivateSubmission: true,
+		}
+		require.NoError(t, borm.InsertEthTx(&etx))
+
+		err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+		assert.NoError(t, err)
+		assert.False(t, retryable)
+
+		etx, err = borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxUnconfirmed, etx.State)
+		assert.True(t, etx.PrivateSubmission)
+		require.Len(t, etx.EthTxAttempts, 1)
+		assert.Equal(t, txmgr.EthTxAttemptBroadcast, etx.EthTxAttempts[0].State)
+
+		privateTxs := stub.PrivateTxs()
+		require.Len(t, privateTxs, 1)
+		assert.Equal(t, hexutil.Bytes(etx.EthTxAttempts[0].SignedRawTx), privateTxs[0].Tx)
+		require.NotNil(t, privateTxs[0].MaxBlockNumber)
+		assert.Equal(t, hexutil.Uint64(125), *privateTxs[0].MaxBlockNumber)
+		signatures := stub.Signatures()
+		require.Len(t, signatures, 1)
+		assert.True(t, strings.HasPrefix(signatures[0].header, fromAddress.Hex()+":"))
+	})
+
+	t.Run("submits the transaction to the relay again when it does not accept it", func(t *testing.T) {
+		setRelayStubErr(stub, errors.New("relay unavailable"))
+
+		etx := txmgr.EthTx{
+			FromAddress:       fromAddress,
+			ToAddress:         toAddress,
+			EncodedPayload:    []byte{42, 0, 0},
+			Value:             assets.NewEthValue(542),
+			GasLimit:          242,
+			State:             txmgr.EthTxUnstarted,
+			PrivateSubmission: true,
+		}
+		require.NoError(t, borm.InsertEthTx(&etx))
+
+		for i := 1; i < txmgr.PrivateRelayMaxFailures; i++ {
+			err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "private relay did not accept transaction")
+			assert.True(t, retryable)
+
+			etx, err = borm.FindEthTxWithAttempts(etx.ID)
+			require.NoError(t, err)
+			assert.Equal(t, txmgr.EthTxInProgress, etx.State)
+			assert.True(t, etx.PrivateSubmission)
+			assert.Equal(t, i, etx.PrivateRelayFailures)
+			require.Len(t, etx.EthTxAttempts, 1)
+			assert.Equal(t, txmgr.EthTxAttemptInProgress, etx.EthTxAttempts[0].State)
+		}
+		ethClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+
+		t.Run("broadcasts it publicly once the relay failed repeatedly", func(t *testing.T) {
+			ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+				return tx.Nonce() == uint64(*etx.Nonce)
+			})).Return(nil).Once()
+
+			err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+			assert.NoError(t, err)
+			assert.False(t, retryable)
+
+			etx, err = borm.FindEthTxWithAttempts(etx.ID)
+			require.NoError(t, err)
+			assert.Equal(t, txmgr.EthTxUnconfirmed, etx.State)
+			assert.False(t, etx.PrivateSubmission)
+			assert.Equal(t, txmgr.PrivateRelayMaxFailures, etx.PrivateRelayFailures)
+			require.Len(t, etx.EthTxAttempts, 1)
+			assert.Equal(t, txmgr.EthTxAttemptBroadcast, etx.EthTxAttempts[0].State)
+			assert.Len(t, stub.PrivateTxs(), 1)
+		})
+	})
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_GasSpendLimits(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		// Enough for a single transaction of 242 gas at the default price of 20 gwei
+		c.EVM[0].Transactions.SpendLimits.KeyDaily = assets.GWei(5000)
+	})
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	keyState, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+
+	eb := cltest.NewEthBroadcaster(t, db, ethClient, ethKeyStore, evmcfg, []ethkey.State{keyState}, &testCheckerFactory{})
+
+	toAddress := testutils.NewAddress()
+
+	t.Run("sends tx within the limits", func(t *testing.T) {
+		etx := txmgr.EthTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: []byte{42, 0, 0},
+			Value:          assets.NewEthValue(442),
+			GasLimit:       242,
+			State:          txmgr.EthTxUnstarted,
+		}
+		require.NoError(t, borm.InsertEthTx(&etx))
+
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+			return tx.Nonce() == uint64(0) && tx.Value().Cmp(big.NewInt(442)) == 0
+		})).Return(nil).Once()
+
+		err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+		assert.NoError(t, err)
+		assert.False(t, retryable)
+
+		etx, err = borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxUnconfirmed, etx.State)
+	})
+
+	t.Run("marks tx as fatally errored and does not send when it would exceed a limit", func(t *testing.T) {
+		etx := txmgr.EthTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: []byte{42, 0, 0},
+			Value:          assets.NewEthValue(542),
+			GasLimit:       242,
+			State:          txmgr.EthTxUnstarted,
+		}
+		require.NoError(t, borm.InsertEthTx(&etx))
+
+		err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+		assert.NoError(t, err)
+		assert.False(t, retryable)
+
+		etx, err = borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxFatalError, etx.State)
+		assert.Contains(t, etx.Error.String, "gas spend limit exceeded")
+		assert.Contains(t, etx.Error.String, "key "+fromAddress.String()+" would spend 9.68 micro of its daily limit of 5 micro")
+		assert.Len(t, etx.EthTxAttempts, 0)
+	})
+}
+
 func TestEthBroadcaster_TransmitChecking(t *testing.T) {
 	db := pgtest.NewSqlxDB(t)
 	cfg := configtest.NewTestGeneralConfig(t)
@@ -561,6 +898,62 @@ func TestEthBroadcaster_TransmitChecking(t *testing.T) {
 	})
 }
 
+func TestEthBroadcaster_ProcessUnstartedEthTxs_Priority(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	keyState, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	checkerFactory := &txmgr.CheckerFactory{Client: ethClient}
+
+	eb := cltest.NewEthBroadcaster(t, db, ethClient, ethKeyStore, evmcfg, []ethkey.State{keyState}, checkerFactory)
+
+	toAddress := gethCommon.HexToAddress("0x6C03DDA95a2AEd917EeCc6eddD4b9D16E6380411")
+	priorities := []txmgr.EthTxPriority{txmgr.EthTxPriorityLow, txmgr.EthTxPriorityNormal, txmgr.EthTxPriorityHigh}
+	etxs := make(map[txmgr.EthTxPriority]txmgr.EthTx)
+	for i, priority := range priorities {
+		etx := txmgr.EthTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: []byte{42, 42, byte(i)},
+			Value:          assets.NewEthValue(0),
+			GasLimit:       242,
+			CreatedAt:      time.Unix(int64(i), 0),
+			State:          txmgr.EthTxUnstarted,
+		}
+		require.NoError(t, borm.InsertEthTx(&etx))
+		pgtest.MustExec(t, db, `UPDATE eth_txes SET priority = $1 WHERE id = $2`, priority, etx.ID)
+		etxs[priority] = etx
+	}
+
+	// Higher priorities are sent first, even though they were queued last
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == 0 && tx.Data()[2] == 2
+	})).Return(nil).Once()
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == 1 && tx.Data()[2] == 1
+	})).Return(nil).Once()
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == 2 && tx.Data()[2] == 0
+	})).Return(nil).Once()
+
+	err, retryable := eb.ProcessUnstartedEthTxs(testutils.Context(t), keyState)
+	require.NoError(t, err)
+	assert.False(t, retryable)
+
+	for i, priority := range []txmgr.EthTxPriority{txmgr.EthTxPriorityHigh, txmgr.EthTxPriorityNormal, txmgr.EthTxPriorityLow} {
+		etx, err := borm.FindEthTxWithAttempts(etxs[priority].ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxUnconfirmed, etx.State)
+		assert.Equal(t, priority, etx.Priority)
+		require.NotNil(t, etx.Nonce)
+		assert.Equal(t, int64(i), *etx.Nonce)
+	}
+}
+
 func TestEthBroadcaster_ProcessUnstartedEthTxs_OptimisticLockingOnEthTx(t *testing.T) {
 	// non-transactional DB needed because we deliberately test for FK violation
 	cfg, db := heavyweight.FullTestDBV2(t, "eth_broadcaster_optimistic_locking", nil)
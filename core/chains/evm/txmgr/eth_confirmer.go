@@ -23,6 +23,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	uuid "github.com/satori/go.uuid"
 	"go.uber.org/multierr"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/sqlx"
 
@@ -138,6 +139,8 @@ type EthConfirmer struct {
 	wg        sync.WaitGroup
 
 	nConsecutiveBlocksChainTooShort int
+
+	chReplace chan replaceRequest
 }
 
 // NewEthConfirmer instantiates a new eth confirmer
@@ -167,6 +170,7 @@ func NewEthConfirmer(db *sqlx.DB, ethClient evmclient.Client, config Config, key
 		cancel,
 		sync.WaitGroup{},
 		0,
+		make(chan replaceRequest),
 	}
 }
 
@@ -214,6 +218,11 @@ func (ec *EthConfirmer) runLoop() {
 					continue
 				}
 			}
+		case req := <-ec.chReplace:
+			ctx, cancel := utils.WithCloseChan(req.ctx, ec.ctx.Done())
+			etx, err := ec.replaceEthTx(ctx, req.etxID, req.cancel, req.gasPrice)
+			cancel()
+			req.done <- replaceResult{etx, err}
 		case <-ec.ctx.Done():
 			return
 		}
@@ -248,6 +257,10 @@ func (ec *EthConfirmer) processHead(ctx context.Context, head *evmtypes.Head) er
 	ec.lggr.Debugw("Finished CheckForReceipts", "headNum", head.Number, "time", time.Since(mark), "id", "eth_confirmer")
 	mark = time.Now()
 
+	if err := ec.ResubmitPrivateTransactions(ctx, head.Number); err != nil {
+		return errors.Wrap(err, "ResubmitPrivateTransactions failed")
+	}
+
 	if err := ec.RebroadcastWhereNecessary(ctx, head.Number); err != nil {
 		return errors.Wrap(err, "RebroadcastWhereNecessary failed")
 	}
@@ -261,6 +274,10 @@ func (ec *EthConfirmer) processHead(ctx context.Context, head *evmtypes.Head) er
 
 	ec.lggr.Debugw("Finished EnsureConfirmedTransactionsInLongestChain", "headNum", head.Number, "time", time.Since(mark), "id", "eth_confirmer")
 
+	if err := ec.SyncBatchedEthTxes(ctx); err != nil {
+		return errors.Wrap(err, "SyncBatchedEthTxes failed")
+	}
+
 	if ec.resumeCallback != nil {
 		mark = time.Now()
 		if err := ec.ResumePendingTaskRuns(ctx, head); err != nil {
@@ -575,7 +592,7 @@ func (ec *EthConfirmer) batchFetchReceipts(ctx context.Context, attempts []EthTx
 		}
 
 		if receipt.Status == 0 {
-			// Do an eth call to obtain the revert reason.
+			// Replay the transaction at the block it was mined in to obtain the revert reason.
 			_, errCall := ec.ethClient.CallContract(ctx, ethereum.CallMsg{
 				From:       attempt.EthTx.FromAddress,
 				To:         &attempt.EthTx.ToAddress,
@@ -583,15 +600,17 @@ func (ec *EthConfirmer) batchFetchReceipts(ctx context.Context, attempts []EthTx
 				GasPrice:   attempt.GasPrice.ToInt(),
 				GasFeeCap:  attempt.GasFeeCap.ToInt(),
 				GasTipCap:  attempt.GasTipCap.ToInt(),
-				Value:      nil,
+				Value:      attempt.EthTx.Value.ToInt(),
 				Data:       attempt.EthTx.EncodedPayload,
-				AccessList: nil,
+				AccessList: attempt.EthTx.AccessList.AccessList,
 			}, receipt.BlockNumber)
-			rpcError, errExtract := evmclient.ExtractRPCError(errCall)
-			if errExtract == nil {
-				l.Warnw("transaction reverted on-chain", "hash", receipt.TxHash, "rpcError", rpcError.String())
+			if reason, ok := RevertReason(errCall); ok {
+				l.Warnw("transaction reverted on-chain", "hash", receipt.TxHash, "revertReason", reason)
+				if err := ec.saveRevertReason(attempt.EthTxID, reason); err != nil {
+					l.Errorw("Failed to save revert reason", "err", err)
+				}
 			} else {
-				l.Warnw("transaction reverted on-chain unable to extract revert reason", "hash", receipt.TxHash, "err", err)
+				l.Warnw("transaction reverted on-chain unable to extract revert reason", "hash", receipt.TxHash, "err", errCall)
 			}
 			// This might increment more than once e.g. in case of re-orgs going back and forth we might re-fetch the same receipt
 			promRevertedTxCount.WithLabelValues(ec.chainID.String()).Add(1)
@@ -615,6 +634,11 @@ func (ec *EthConfirmer) batchFetchReceipts(ctx context.Context, attempts []EthTx
 	return
 }
 
+func (ec *EthConfirmer) saveRevertReason(etxID int64, reason string) error {
+	_, err := ec.q.Exec(`UPDATE eth_txes SET revert_reason = $1 WHERE id = $2`, reason, etxID)
+	return errors.Wrap(err, "saveRevertReason failed")
+}
+
 func (ec *EthConfirmer) saveFetchedReceipts(receipts []evmtypes.Receipt) (err error) {
 	if len(receipts) == 0 {
 		return nil
@@ -877,7 +901,11 @@ func (ec *EthConfirmer) rebroadcastWhereNecessary(ctx context.Context, address g
 	for _, etx := range etxs {
 		lggr := etx.GetLogger(ec.lggr)
 
-		attempt, err := ec.attemptForRebroadcast(ctx, lggr, *etx)
+		if !ec.dueForRebroadcast(*etx, blockHeight, threshold) {
+			continue
+		}
+
+		attempt, err := ec.attemptForRebroadcast(ctx, lggr, *etx, blockHeight)
 		if err != nil {
 			return errors.Wrap(err, "attemptForRebroadcast failed")
 		}
@@ -959,6 +987,72 @@ func loadEthTxes(q pg.Queryer, attempts []EthTxAttempt) error {
 	return nil
 }
 
+// ResubmitPrivateTransactions resubmits the private transactions that were
+// not mined yet to the relay on every head, since bundles are only valid for a
+// single block. Transactions that were not mined within FallbackBlocks of
+// being first sent, that the relay repeatedly failed to accept, or that can no
+// longer be sent privately because the relay was removed, are broadcast
+// publicly from then on.
+func (ec *EthConfirmer) ResubmitPrivateTransactions(ctx context.Context, blockHeight int64) error {
+	var etxs []*EthTx
+	err := ec.q.SelectContext(ctx, &etxs, `SELECT * FROM eth_txes WHERE state = 'unconfirmed' AND private_submission AND evm_chain_id = $1 ORDER BY nonce ASC`, ec.chainID.String())
+	if err != nil {
+		return errors.Wrap(err, "failed to load private eth_txes")
+	}
+	if len(etxs) == 0 {
+		return nil
+	}
+	if err = loadEthTxesAttempts(ec.q.WithOpts(pg.WithParentCtx(ctx)), etxs); err != nil {
+		return err
+	}
+
+	fallbackBlocks := int64(ec.config.EvmPrivateRelayFallbackBlocks())
+	for _, etx := range etxs {
+		lggr := etx.GetLogger(ec.lggr)
+		// Attempts are sorted by descending fee
+		var latest *EthTxAttempt
+		var firstSentBefore int64
+		for i, a := range etx.EthTxAttempts {
+			if a.State != EthTxAttemptBroadcast {
+				continue
+			}
+			if latest == nil {
+				latest = &etx.EthTxAttempts[i]
+			}
+			if a.BroadcastBeforeBlockNum != nil && (firstSentBefore == 0 || *a.BroadcastBeforeBlockNum < firstSentBefore) {
+				firstSentBefore = *a.BroadcastBeforeBlockNum
+			}
+		}
+		if latest == nil {
+			continue
+		}
+
+		relay := privateRelay(ec.config, *etx)
+		if relay == nil || (firstSentBefore > 0 && blockHeight-firstSentBefore >= fallbackBlocks) {
+			lggr.Warnw("Private transaction was not mined, broadcasting it publicly", "ethTxID", etx.ID, "txHash", latest.Hash, "firstSentBeforeBlockNum", firstSentBefore)
+			if _, err = ec.q.ExecContext(ctx, `UPDATE eth_txes SET private_submission = false WHERE id = $1`, etx.ID); err != nil {
+				return errors.Wrap(err, "failed to update eth_tx")
+			}
+			etx.PrivateSubmission = false
+			if sendErr := sendTransaction(ctx, ec.ethClient, *latest, *etx, lggr); sendErr != nil {
+				// The resender broadcasts it again later
+				lggr.Warnw("Failed to broadcast private transaction publicly", "ethTxID", etx.ID, "txHash", latest.Hash, "err", sendErr)
+			}
+			continue
+		}
+
+		// Bundles only target the next block
+		sendErr, relayErr := broadcastTransaction(ctx, ec.q, ec.ethClient, ec.config, ec.signer(etx.FromAddress), *latest, *etx, blockHeight, lggr)
+		if relayErr != nil {
+			lggr.Warnw("Failed to resubmit transaction to private relay", "ethTxID", etx.ID, "txHash", latest.Hash, "err", relayErr)
+		} else if sendErr != nil {
+			// The resender broadcasts it again later
+			lggr.Warnw("Failed to broadcast private transaction publicly", "ethTxID", etx.ID, "txHash", latest.Hash, "err", sendErr)
+		}
+	}
+	return nil
+}
+
 // FindEthTxsRequiringRebroadcast returns attempts that hit insufficient eth,
 // and attempts that need bumping, in nonce ASC order
 func FindEthTxsRequiringRebroadcast(ctx context.Context, q pg.Q, lggr logger.Logger, address gethCommon.Address, blockNum, gasBumpThreshold, bumpDepth int64, maxInFlightTransactions uint32, chainID big.Int) (etxs []*EthTx, err error) {
@@ -1066,6 +1160,9 @@ func loadEthTxesAttempts(q pg.Queryer, etxs []*EthTx) error {
 // attempts which are unconfirmed for at least gasBumpThreshold blocks,
 // limited by limit pending transactions
 //
+// Transactions with an inclusion deadline are returned in every block once
+// all their attempts were broadcast, see InclusionDeadline
+//
 // It also returns eth_txes that are unconfirmed with no eth_tx_attempts
 func FindEthTxsRequiringGasBump(ctx context.Context, q pg.Q, lggr logger.Logger, address gethCommon.Address, blockNum, gasBumpThreshold, depth int64, chainID big.Int) (etxs []*EthTx, err error) {
 	if gasBumpThreshold == 0 {
@@ -1075,7 +1172,7 @@ func FindEthTxsRequiringGasBump(ctx context.Context, q pg.Q, lggr logger.Logger,
 	err = qq.Transaction(func(tx pg.Queryer) error {
 		stmt := `
 SELECT eth_txes.* FROM eth_txes
-LEFT JOIN eth_tx_attempts ON eth_txes.id = eth_tx_attempts.eth_tx_id AND (broadcast_before_block_num > $4 OR broadcast_before_block_num IS NULL OR eth_tx_attempts.state != 'broadcast')
+LEFT JOIN eth_tx_attempts ON eth_txes.id = eth_tx_attempts.eth_tx_id AND ((broadcast_before_block_num > $4 AND eth_txes.inclusion_deadline_blocks IS NULL AND eth_txes.inclusion_deadline_at IS NULL) OR broadcast_before_block_num IS NULL OR eth_tx_attempts.state != 'broadcast')
 WHERE eth_txes.state = 'unconfirmed' AND eth_tx_attempts.id IS NULL AND eth_txes.from_address = $1 AND eth_txes.evm_chain_id = $2
 	AND (($3 = 0) OR (eth_txes.id IN (SELECT id FROM eth_txes WHERE state = 'unconfirmed' AND from_address = $1 ORDER BY nonce ASC LIMIT $3)))
 ORDER BY nonce ASC
@@ -1089,7 +1186,7 @@ ORDER BY nonce ASC
 	return
 }
 
-func (ec *EthConfirmer) attemptForRebroadcast(ctx context.Context, lggr logger.Logger, etx EthTx) (attempt EthTxAttempt, err error) {
+func (ec *EthConfirmer) attemptForRebroadcast(ctx context.Context, lggr logger.Logger, etx EthTx, blockHeight int64) (attempt EthTxAttempt, err error) {
 	if len(etx.EthTxAttempts) > 0 {
 		etx.EthTxAttempts[0].EthTx = etx
 		previousAttempt := etx.EthTxAttempts[0]
@@ -1101,9 +1198,9 @@ func (ec *EthConfirmer) attemptForRebroadcast(ctx context.Context, lggr logger.L
 			previousAttempt.State = EthTxAttemptInProgress
 			return previousAttempt, nil
 		}
-		attempt, err = ec.bumpGas(ctx, etx, etx.EthTxAttempts)
+		attempt, err = ec.bumpGas(ctx, etx, etx.EthTxAttempts, blockHeight)
 
-		if gas.IsBumpErr(err) {
+		if gas.IsBumpErr(err) || errors.Is(err, ErrGasSpendLimitExceeded) {
 			lggr.Errorw("Failed to bump gas", append(logFields, "err", err)...)
 			// Do not create a new attempt if bumping gas would put us over the limit or cause some other problem
 			// Instead try to resubmit the previous attempt, and keep resubmitting until its accepted
@@ -1130,7 +1227,10 @@ func (ec *EthConfirmer) logFieldsPreviousAttempt(attempt EthTxAttempt) []interfa
 	}
 }
 
-func (ec *EthConfirmer) bumpGas(ctx context.Context, etx EthTx, previousAttempts []EthTxAttempt) (bumpedAttempt EthTxAttempt, err error) {
+// bumpGas returns an attempt replacing the highest priced of
+// previousAttempts. If etx has an inclusion deadline, the attempt pays at
+// least what is needed to meet it at blockHeight.
+func (ec *EthConfirmer) bumpGas(ctx context.Context, etx EthTx, previousAttempts []EthTxAttempt, blockHeight int64) (bumpedAttempt EthTxAttempt, err error) {
 	priorAttempts := make([]gas.PriorAttempt, len(previousAttempts))
 	// This feels a bit useless but until we get iterators there is no other
 	// way to cast an array of structs to an array of interfaces
@@ -1140,29 +1240,28 @@ func (ec *EthConfirmer) bumpGas(ctx context.Context, etx EthTx, previousAttempts
 	previousAttempt := previousAttempts[0]
 	logFields := ec.logFieldsPreviousAttempt(previousAttempt)
 	keySpecificMaxGasPriceWei := ec.config.KeySpecificMaxGasPriceWei(etx.FromAddress)
-	switch previousAttempt.TxType {
-	case 0x0: // Legacy
-		var bumpedGasPrice *assets.Wei
-		var bumpedGasLimit uint32
-		bumpedGasPrice, bumpedGasLimit, err = ec.estimator.BumpLegacyGas(ctx, previousAttempt.GasPrice, etx.GasLimit, keySpecificMaxGasPriceWei, priorAttempts)
-		if err == nil {
-			promNumGasBumps.WithLabelValues(ec.chainID.String()).Inc()
-			ec.lggr.Debugw("Rebroadcast bumping gas for Legacy tx", append(logFields, "bumpedGasPrice", bumpedGasPrice.String())...)
-			return ec.NewLegacyAttempt(etx, bumpedGasPrice, bumpedGasLimit)
-		}
-	case 0x2: // EIP1559
-		var bumpedFee gas.DynamicFee
-		var bumpedGasLimit uint32
-		original := previousAttempt.DynamicFee()
-		bumpedFee, bumpedGasLimit, err = ec.estimator.BumpDynamicFee(ctx, original, etx.GasLimit, keySpecificMaxGasPriceWei, priorAttempts)
-		if err == nil {
-			promNumGasBumps.WithLabelValues(ec.chainID.String()).Inc()
-			ec.lggr.Debugw("Rebroadcast bumping gas for DynamicFee tx", append(logFields, "bumpedTipCap", bumpedFee.TipCap.String(), "bumpedFeeCap", bumpedFee.FeeCap.String())...)
-			return ec.NewDynamicFeeAttempt(etx, bumpedFee, bumpedGasLimit)
-		}
-	default:
+	builder, err := AttemptBuilderFor(previousAttempt.TxType)
+	if err != nil {
 		err = errors.Errorf("invariant violation: Attempt %v had unrecognised transaction type %v"+
 			"This is a bug! Please report to https://github.com/smartcontractkit/chainlink/issues", previousAttempt.ID, previousAttempt.TxType)
+		return bumpedAttempt, errors.Wrap(err, "error bumping gas")
+	}
+	bumpedFee, bumpedGasLimit, err := builder.BumpFee(ctx, ec.estimator, etx, previousAttempt, keySpecificMaxGasPriceWei, priorAttempts)
+	if err == nil {
+		if target, ok := ec.inclusionDeadlineFee(etx, previousAttempt, blockHeight); ok && target.exceeds(bumpedFee) {
+			raisedFee := bumpedFee.raisedTo(target, keySpecificMaxGasPriceWei)
+			ec.lggr.Debugw("Rebroadcast raising gas bump to meet inclusion deadline", append(logFields, "bumpedFee", bumpedFee.String(), "raisedFee", raisedFee.String())...)
+			bumpedFee = raisedFee
+		}
+		if bumpedAttempt, err = ec.NewAttempt(previousAttempt.TxType, etx, bumpedFee, bumpedGasLimit); err != nil {
+			return bumpedAttempt, err
+		}
+		if err = ec.checkGasSpendLimits(etx, bumpedAttempt); err != nil {
+			return EthTxAttempt{}, errors.Wrap(err, "error bumping gas")
+		}
+		promNumGasBumps.WithLabelValues(ec.chainID.String()).Inc()
+		ec.lggr.Debugw("Rebroadcast bumping gas", append(logFields, "txType", previousAttempt.TxType, "bumpedFee", bumpedFee.String())...)
+		return bumpedAttempt, nil
 	}
 
 	if errors.Is(errors.Cause(err), gas.ErrBumpGasExceedsLimit) {
@@ -1172,6 +1271,16 @@ func (ec *EthConfirmer) bumpGas(ctx context.Context, etx EthTx, previousAttempts
 	return bumpedAttempt, errors.Wrap(err, "error bumping gas")
 }
 
+// checkGasSpendLimits returns an error wrapping ErrGasSpendLimitExceeded if
+// replacing the attempts of etx by attempt would exceed a spend limit
+func (ec *EthConfirmer) checkGasSpendLimits(etx EthTx, attempt EthTxAttempt) error {
+	err := checkAttemptGasSpendLimits(ec.q, ec.config, ec.chainID, etx, attempt)
+	if errors.Is(err, ErrGasSpendLimitExceeded) {
+		etx.GetLogger(ec.lggr).Criticalw("Gas spend limit exceeded, not bumping gas", "etxID", etx.ID, "err", err)
+	}
+	return err
+}
+
 // saveInProgressAttempt inserts or updates an attempt
 func (ec *EthConfirmer) saveInProgressAttempt(attempt *EthTxAttempt) error {
 	if attempt.State != EthTxAttemptInProgress {
@@ -1206,7 +1315,12 @@ func (ec *EthConfirmer) handleInProgressAttempt(ctx context.Context, lggr logger
 	}
 
 	now := time.Now()
-	sendError := sendTransaction(ctx, ec.ethClient, attempt, etx, lggr)
+	sendError, relayErr := broadcastTransaction(ctx, ec.q, ec.ethClient, ec.config, ec.signer(etx.FromAddress), attempt, etx, blockHeight, lggr)
+	if relayErr != nil {
+		// Leave the attempt in_progress, it is submitted to the relay again
+		// on the next head
+		return errors.Wrapf(relayErr, "private relay did not accept eth_tx %v with hash %s", etx.ID, attempt.Hash.Hex())
+	}
 
 	if sendError.IsTerminallyUnderpriced() {
 		// This should really not ever happen in normal operation since we
@@ -1227,7 +1341,7 @@ func (ec *EthConfirmer) handleInProgressAttempt(ctx context.Context, lggr logger
 			logger.Sugared(ec.lggr).AssumptionViolationw(err.Error(), "err", err, "attempt", attempt, "ethTxAttempts", etx.EthTxAttempts)
 			return err
 		}
-		replacementAttempt, err := ec.bumpGas(ctx, etx, etx.EthTxAttempts)
+		replacementAttempt, err := ec.bumpGas(ctx, etx, etx.EthTxAttempts, blockHeight)
 		if err != nil {
 			return errors.Wrap(err, "could not bump gas for terminally underpriced transaction")
 		}
@@ -1405,10 +1519,27 @@ func saveAttemptWithNewState(q pg.Queryer, timeout time.Duration, lggr logger.Lo
 //
 // If any of the confirmed transactions does not have a receipt in the chain, it has been
 // re-org'd out and will be rebroadcast.
+//
+// If the head carries its finalized block, receipts in or below it are irreversible and
+// are not checked, and the chain only needs to reach the block after it.
 func (ec *EthConfirmer) EnsureConfirmedTransactionsInLongestChain(ctx context.Context, head *evmtypes.Head) error {
-	if head.ChainLength() < ec.config.EvmFinalityDepth() {
+	minChainLength := ec.config.EvmFinalityDepth()
+	lowBlockNumber := head.EarliestInChain().Number
+	if f := head.Finalized; f != nil {
+		// The finalized block can be fetched from a node that is ahead of
+		// the one that delivered the head, so it is never taken past the head
+		finalized := f.Number
+		if finalized > head.Number {
+			finalized = head.Number
+		}
+		minChainLength = uint32(head.Number - finalized)
+		if lowBlockNumber <= finalized {
+			lowBlockNumber = finalized + 1
+		}
+	}
+	if head.ChainLength() < minChainLength {
 		logArgs := []interface{}{
-			"chainLength", head.ChainLength(), "evmFinalityDepth", ec.config.EvmFinalityDepth(),
+			"chainLength", head.ChainLength(), "evmFinalityDepth", ec.config.EvmFinalityDepth(), "minChainLength", minChainLength,
 		}
 		if ec.nConsecutiveBlocksChainTooShort > logAfterNConsecutiveBlocksChainTooShort {
 			warnMsg := "Chain length supplied for re-org detection was shorter than EvmFinalityDepth. Re-org protection is not working properly. This could indicate a problem with the remote RPC endpoint, a compatibility issue with a particular blockchain, a bug with this particular blockchain, heads table being truncated too early, remote node out of sync, or something else. If this happens a lot please raise a bug with the Chainlink team including a log output sample and details of the chain and RPC endpoint you are using."
@@ -1421,7 +1552,7 @@ func (ec *EthConfirmer) EnsureConfirmedTransactionsInLongestChain(ctx context.Co
 	} else {
 		ec.nConsecutiveBlocksChainTooShort = 0
 	}
-	etxs, err := findTransactionsConfirmedInBlockRange(ec.q, ec.lggr, head.Number, head.EarliestInChain().Number, ec.chainID)
+	etxs, err := findTransactionsConfirmedInBlockRange(ec.q, ec.lggr, head.Number, lowBlockNumber, ec.chainID)
 	if err != nil {
 		return errors.Wrap(err, "findTransactionsConfirmedInBlockRange failed")
 	}
@@ -1548,7 +1679,7 @@ func unconfirmEthTx(q pg.Queryer, etx EthTx) error {
 	if etx.State != EthTxConfirmed {
 		return errors.New("expected eth_tx state to be confirmed")
 	}
-	_, err := q.Exec(`UPDATE eth_txes SET state = 'unconfirmed' WHERE id = $1`, etx.ID)
+	_, err := q.Exec(`UPDATE eth_txes SET state = 'unconfirmed', revert_reason = NULL WHERE id = $1`, etx.ID)
 	return errors.Wrap(err, "unconfirmEthTx failed")
 }
 
@@ -1560,6 +1691,201 @@ func unbroadcastAttempt(q pg.Queryer, attempt EthTxAttempt) error {
 	return errors.Wrap(err, "unbroadcastAttempt failed")
 }
 
+// replaceRequest asks the runLoop to replace the pending attempt of an
+// eth_tx, so that it is never done concurrently with processing a head
+type replaceRequest struct {
+	ctx      context.Context
+	etxID    int64
+	cancel   bool
+	gasPrice *assets.Wei
+	done     chan replaceResult
+}
+
+type replaceResult struct {
+	etx EthTx
+	err error
+}
+
+// CancelEthTx cancels the given eth_tx. An unstarted eth_tx is simply marked
+// as fatally errored. The nonce of an unconfirmed one is instead replaced by
+// a transaction sending zero ether to its sender at a bumped gas price, which
+// goes through the usual gas bumping cycle until it is confirmed. Attempts
+// that were sent before the cancellation may still be confirmed first.
+func (ec *EthConfirmer) CancelEthTx(ctx context.Context, etxID int64) (EthTx, error) {
+	return ec.requestReplace(ctx, replaceRequest{etxID: etxID, cancel: true})
+}
+
+// BumpEthTx replaces the pending attempt of the given unconfirmed eth_tx with
+// one paying gasPrice, which is the fee cap of EIP-1559 transactions. It must
+// be at least the price of an automatic gas bump, which is also how the tip
+// cap of EIP-1559 transactions is bumped.
+func (ec *EthConfirmer) BumpEthTx(ctx context.Context, etxID int64, gasPrice *assets.Wei) (EthTx, error) {
+	if gasPrice == nil {
+		return EthTx{}, errors.New("gas price is required")
+	}
+	return ec.requestReplace(ctx, replaceRequest{etxID: etxID, gasPrice: gasPrice})
+}
+
+func (ec *EthConfirmer) requestReplace(ctx context.Context, req replaceRequest) (EthTx, error) {
+	req.ctx = ctx
+	req.done = make(chan replaceResult, 1)
+	select {
+	case ec.chReplace <- req:
+	case <-ctx.Done():
+		return EthTx{}, ctx.Err()
+	case <-ec.ctx.Done():
+		return EthTx{}, errors.New("EthConfirmer is stopped")
+	}
+	select {
+	case res := <-req.done:
+		return res.etx, res.err
+	case <-ctx.Done():
+		return EthTx{}, ctx.Err()
+	}
+}
+
+// NOTE: This SHOULD NOT be run concurrently with processHead
+func (ec *EthConfirmer) replaceEthTx(ctx context.Context, etxID int64, cancel bool, gasPrice *assets.Wei) (etx EthTx, err error) {
+	qq := ec.q.WithOpts(pg.WithParentCtx(ctx))
+	if etx, err = findEthTxWithAttempts(qq, etxID, ec.chainID); err != nil {
+		return etx, err
+	}
+	lggr := etx.GetLogger(ec.lggr)
+
+	switch etx.State {
+	case EthTxUnstarted:
+		if etx.IsBatched() {
+			return etx, errors.Errorf("eth_tx %d is sent as part of batch eth_tx %d, which must be replaced instead", etx.ID, etx.BatchEthTxID.Int64)
+		}
+		if !cancel {
+			return etx, errors.Errorf("eth_tx %d has not been broadcast yet", etx.ID)
+		}
+		return ec.cancelUnstartedEthTx(qq, lggr, etx)
+	case EthTxInProgress:
+		return etx, errors.Errorf("eth_tx %d is being broadcast, try again once it has been sent", etx.ID)
+	case EthTxUnconfirmed:
+	default:
+		return etx, errors.Errorf("eth_tx %d is %s and can no longer be replaced", etx.ID, etx.State)
+	}
+	if len(etx.EthTxAttempts) == 0 {
+		return etx, errors.Errorf("invariant violation: eth_tx %d was unconfirmed but didn't have any attempts", etx.ID)
+	}
+
+	var attempt EthTxAttempt
+	if cancel {
+		now := time.Now()
+		etx.CancelledAt = &now
+		// Cancellations have no inclusion deadline, so the block height is
+		// not needed
+		attempt, err = ec.bumpGas(ctx, etx, etx.EthTxAttempts, 0)
+	} else {
+		attempt, err = ec.manuallyBumpGas(etx, gasPrice)
+	}
+	if err != nil {
+		return etx, err
+	}
+
+	err = qq.Transaction(func(tx pg.Queryer) error {
+		res, err := tx.Exec(`UPDATE eth_txes SET cancelled_at = CASE WHEN $2 THEN COALESCE(cancelled_at, NOW()) ELSE cancelled_at END WHERE id = $1 AND state = 'unconfirmed'`, etx.ID, cancel)
+		if err != nil {
+			return errors.Wrap(err, "failed to update eth_tx")
+		}
+		if rowsAffected, err := res.RowsAffected(); err != nil {
+			return errors.Wrap(err, "failed to get RowsAffected")
+		} else if rowsAffected == 0 {
+			return errors.Errorf("eth_tx %d is no longer unconfirmed", etx.ID)
+		}
+		query, args, err := tx.BindNamed(insertIntoEthTxAttemptsQuery, &attempt)
+		if err != nil {
+			return errors.Wrap(err, "failed to BindNamed")
+		}
+		return errors.Wrap(tx.Get(&attempt, query, args...), "failed to insert into eth_tx_attempts")
+	})
+	if err != nil {
+		return etx, errors.Wrapf(err, "failed to replace eth_tx %d", etx.ID)
+	}
+
+	lggr.Infow("Manually replacing transaction", "cancel", cancel, "gasPrice", attempt.GasPrice, "gasTipCap", attempt.GasTipCap, "gasFeeCap", attempt.GasFeeCap)
+	// The block height is only used for logging
+	if err = ec.handleInProgressAttempt(ctx, lggr, etx, attempt, 0); err != nil {
+		return etx, errors.Wrap(err, "handleInProgressAttempt failed")
+	}
+	return findEthTxWithAttempts(qq, etx.ID, ec.chainID)
+}
+
+func (ec *EthConfirmer) cancelUnstartedEthTx(q pg.Q, lggr logger.Logger, etx EthTx) (EthTx, error) {
+	err := q.Transaction(func(tx pg.Queryer) error {
+		err := tx.Get(&etx, `UPDATE eth_txes SET state = 'fatal_error', error = 'cancelled', cancelled_at = NOW() WHERE id = $1 AND state = 'unstarted' AND batch_eth_tx_id IS NULL RETURNING *`, etx.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.Errorf("eth_tx %d is no longer unstarted, try again", etx.ID)
+		} else if err != nil {
+			return errors.Wrap(err, "failed to update eth_tx")
+		}
+		// Cancelling a batch cancels the transactions in it
+		taskRunIDs, err := failEthTxBatch(tx, etx.ID, "cancelled")
+		if err != nil {
+			return err
+		}
+		if etx.PipelineTaskRunID.Valid {
+			taskRunIDs = append(taskRunIDs, etx.PipelineTaskRunID.UUID)
+		}
+		if ec.resumeCallback == nil {
+			return nil
+		}
+		for _, id := range taskRunIDs {
+			err = ec.resumeCallback(id, nil, errors.New("transaction was cancelled"))
+			if errors.Is(err, sql.ErrNoRows) {
+				lggr.Debugw("callback missing or already resumed", "etxID", etx.ID, "pipelineTaskRunID", id)
+			} else if err != nil {
+				return errors.Wrap(err, "failed to resume pipeline")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return etx, errors.Wrapf(err, "failed to cancel eth_tx %d", etx.ID)
+	}
+	lggr.Info("Cancelled unstarted transaction")
+	return etx, nil
+}
+
+// manuallyBumpGas makes an attempt paying gasPrice, provided that it is high
+// enough to replace the previous attempts
+func (ec *EthConfirmer) manuallyBumpGas(etx EthTx, gasPrice *assets.Wei) (attempt EthTxAttempt, err error) {
+	previousAttempt := etx.EthTxAttempts[0]
+	lggr := logger.Sugared(ec.lggr)
+	builder, err := AttemptBuilderFor(previousAttempt.TxType)
+	if err != nil {
+		return attempt, errors.Errorf("invariant violation: Attempt %v had unrecognised transaction type %v"+
+			"This is a bug! Please report to https://github.com/smartcontractkit/chainlink/issues", previousAttempt.ID, previousAttempt.TxType)
+	}
+	keySpecificMaxGasPriceWei := ec.config.KeySpecificMaxGasPriceWei(etx.FromAddress)
+	fee, gasLimit, err := builder.ManualBumpFee(ec.config, lggr, etx, previousAttempt, gasPrice, keySpecificMaxGasPriceWei)
+	if err != nil {
+		return attempt, err
+	}
+	if attempt, err = ec.NewAttempt(previousAttempt.TxType, etx, fee, gasLimit); err != nil {
+		return attempt, err
+	}
+	if err = ec.checkGasSpendLimits(etx, attempt); err != nil {
+		return EthTxAttempt{}, err
+	}
+	promNumGasBumps.WithLabelValues(ec.chainID.String()).Inc()
+	return attempt, nil
+}
+
+// findEthTxWithAttempts returns the eth_tx with the given id on chainID, with
+// its attempts in descending price order
+func findEthTxWithAttempts(q pg.Q, etxID int64, chainID big.Int) (etx EthTx, err error) {
+	err = q.Transaction(func(tx pg.Queryer) error {
+		if err = tx.Get(&etx, `SELECT * FROM eth_txes WHERE id = $1 AND evm_chain_id = $2`, etxID, chainID.String()); err != nil {
+			return errors.Wrapf(err, "failed to load eth_tx %d", etxID)
+		}
+		return loadEthTxAttempts(tx, &etx)
+	}, pg.OptReadOnlyTx())
+	return etx, err
+}
+
 // ForceRebroadcast sends a transaction for every nonce in the given nonce range at the given gas price.
 // If an eth_tx exists for this nonce, we re-send the existing eth_tx with the supplied parameters.
 // If an eth_tx doesn't exist for this nonce, we send a zero transaction.
@@ -1607,7 +1933,7 @@ func (ec *EthConfirmer) sendEmptyTransaction(ctx context.Context, fromAddress ge
 	if gasLimit == 0 {
 		gasLimit = ec.config.EvmGasLimitDefault()
 	}
-	tx, err := sendEmptyTransaction(ctx, ec.ethClient, ec.keystore, uint64(nonce), gasLimit, big.NewInt(int64(gasPriceWei)), fromAddress, &ec.chainID)
+	tx, err := sendEmptyTransaction(ctx, ec.ethClient, ec.signer(fromAddress), uint64(nonce), gasLimit, big.NewInt(int64(gasPriceWei)), fromAddress, &ec.chainID)
 	if err != nil {
 		return gethCommon.Hash{}, errors.Wrap(err, "(EthConfirmer).sendEmptyTransaction failed")
 	}
@@ -1639,16 +1965,26 @@ func (ec *EthConfirmer) ResumePendingTaskRuns(ctx context.Context, head *evmtype
 		ID           uuid.UUID        `db:"id"`
 		Receipt      evmtypes.Receipt `db:"receipt"`
 		FailOnRevert bool             `db:"FailOnRevert"`
+		Cancelled    bool             `db:"Cancelled"`
+		RevertReason null.String      `db:"revert_reason"`
+		// Set for transactions sent in a batch
+		BatchIndex            null.Int        `db:"batch_index"`
+		BatchForwarderAddress *common.Address `db:"batch_forwarder_address"`
 	}
 	var receipts []x
 
 	// NOTE: we don't filter on eth_txes.state = 'confirmed', because a transaction with an attached receipt
 	// is guaranteed to be confirmed. This results in a slightly better query plan.
+	//
+	// Transactions sent in a batch are resumed with the receipt of their batch.
 	if err := ec.q.SelectContext(ctx, &receipts, `
-	SELECT pipeline_task_runs.id, eth_receipts.receipt, COALESCE((eth_txes.meta->>'FailOnRevert')::boolean, false) "FailOnRevert" FROM pipeline_task_runs
+	SELECT pipeline_task_runs.id, eth_receipts.receipt, COALESCE((eth_txes.meta->>'FailOnRevert')::boolean, false) "FailOnRevert",
+		COALESCE(eth_tx_attempts.created_at >= sent.cancelled_at, false) "Cancelled",
+		eth_txes.batch_index, eth_txes.batch_forwarder_address, eth_txes.revert_reason FROM pipeline_task_runs
 	INNER JOIN pipeline_runs ON pipeline_runs.id = pipeline_task_runs.pipeline_run_id
 	INNER JOIN eth_txes ON eth_txes.pipeline_task_run_id = pipeline_task_runs.id
-	INNER JOIN eth_tx_attempts ON eth_txes.id = eth_tx_attempts.eth_tx_id
+	INNER JOIN eth_txes sent ON sent.id = COALESCE(eth_txes.batch_eth_tx_id, eth_txes.id)
+	INNER JOIN eth_tx_attempts ON sent.id = eth_tx_attempts.eth_tx_id
 	INNER JOIN eth_receipts ON eth_tx_attempts.hash = eth_receipts.tx_hash
 	WHERE pipeline_runs.state = 'suspended' AND eth_receipts.block_number <= ($1 - eth_txes.min_confirmations) AND eth_txes.evm_chain_id = $2
 	`, head.Number, ec.chainID.String()); err != nil {
@@ -1663,8 +1999,22 @@ func (ec *EthConfirmer) ResumePendingTaskRuns(ctx context.Context, head *evmtype
 	for _, data := range receipts {
 		var taskErr error
 		var output interface{}
-		if data.FailOnRevert && data.Receipt.Status == 0 {
-			taskErr = errors.Errorf("transaction %s reverted on-chain", data.Receipt.TxHash)
+		var err error
+		if data.BatchIndex.Valid {
+			data.Receipt, err = batchCallReceipt(data.Receipt, *data.BatchForwarderAddress, data.BatchIndex.Int64)
+		}
+		if err != nil {
+			taskErr = errors.Wrap(err, "failed to get the outcome of the batched transaction")
+		} else if data.Cancelled {
+			// The receipt is for one of the zero ether transfers that replaced
+			// the transaction
+			taskErr = errors.Errorf("transaction was cancelled, %s replaced it on-chain", data.Receipt.TxHash)
+		} else if data.FailOnRevert && data.Receipt.Status == 0 {
+			if data.RevertReason.Valid {
+				taskErr = errors.Errorf("transaction %s reverted on-chain: %s", data.Receipt.TxHash, data.RevertReason.String)
+			} else {
+				taskErr = errors.Errorf("transaction %s reverted on-chain", data.Receipt.TxHash)
+			}
 		} else {
 			output = data.Receipt
 		}
@@ -1678,6 +2028,35 @@ func (ec *EthConfirmer) ResumePendingTaskRuns(ctx context.Context, head *evmtype
 	return nil
 }
 
+// SyncBatchedEthTxes moves the transactions sent in batches to the state of
+// their batch, once it is confirmed or has fatally errored, and fails the
+// pipeline task runs waiting for those that errored.
+func (ec *EthConfirmer) SyncBatchedEthTxes(ctx context.Context) error {
+	var failed []failedBatchedEthTx
+	err := ec.q.WithOpts(pg.WithParentCtx(ctx)).Transaction(func(tx pg.Queryer) (err error) {
+		failed, err = syncEthTxBatches(tx, ec.chainID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if ec.resumeCallback == nil {
+		return nil
+	}
+	for _, etx := range failed {
+		if !etx.PipelineTaskRunID.Valid {
+			continue
+		}
+		err := ec.resumeCallback(etx.PipelineTaskRunID.UUID, nil, errors.Errorf("batch transaction failed: %s", etx.Error))
+		if errors.Is(err, sql.ErrNoRows) {
+			ec.lggr.Debugw("callback missing or already resumed", "pipelineTaskRunID", etx.PipelineTaskRunID.UUID)
+		} else if err != nil {
+			return errors.Wrap(err, "failed to resume pipeline")
+		}
+	}
+	return nil
+}
+
 // observeUntilTxConfirmed observes the promBlocksUntilTxConfirmed metric for each confirmed
 // transaction.
 func observeUntilTxConfirmed(chainID big.Int, attempts []EthTxAttempt, receipts []evmtypes.Receipt) {
@@ -33,6 +33,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
 	ksmocks "github.com/smartcontractkit/chainlink/core/services/keystore/mocks"
 	"github.com/smartcontractkit/chainlink/core/services/pg"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
@@ -565,7 +566,7 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 		ethClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(nil, &evmclient.JsonError{
 			Code:    1,
 			Message: "reverted",
-			Data:    utils.ConcatBytes(sig[:4], data),
+			Data:    hexutil.Encode(utils.ConcatBytes(sig[:4], data)),
 		}).Once()
 
 		// Do the thing
@@ -576,6 +577,7 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 		require.NoError(t, err)
 
 		attempt5_1 = etx5.EthTxAttempts[0]
+		assert.Equal(t, fmt.Sprintf("custom error %s (%s)", hexutil.Encode(sig[:4]), hexutil.Encode(data)), etx5.RevertReason.String)
 
 		// And the attempts
 		require.Equal(t, txmgr.EthTxAttemptBroadcast, attempt5_1.State)
@@ -2544,6 +2546,88 @@ func TestEthConfirmer_RebroadcastWhereNecessary_WhenOutOfEth(t *testing.T) {
 	})
 }
 
+func TestEthConfirmer_ResubmitPrivateTransactions(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	stub := &relayStub{}
+	ts := newRelayStubServer(t, stub)
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].Transactions.PrivateRelay.URL = models.MustParseURL(ts.URL)
+		c.EVM[0].Transactions.PrivateRelay.Method = ptr(txmgr.PrivateRelayMethodSendBundle)
+		c.EVM[0].Transactions.PrivateRelay.FallbackBlocks = ptr[uint32](10)
+	})
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	state, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	ec := cltest.NewEthConfirmer(t, db, ethClient, evmcfg, ethKeyStore, []ethkey.State{state}, nil)
+	ctx := testutils.Context(t)
+
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 0, fromAddress)
+	attempt := etx.EthTxAttempts[0]
+	pgtest.MustExec(t, db, `UPDATE eth_txes SET private_submission = true WHERE id = $1`, etx.ID)
+	pgtest.MustExec(t, db, `UPDATE eth_tx_attempts SET broadcast_before_block_num = 100 WHERE id = $1`, attempt.ID)
+
+	t.Run("resubmits bundles for the next block", func(t *testing.T) {
+		require.NoError(t, ec.ResubmitPrivateTransactions(ctx, 105))
+
+		bundles := stub.Bundles()
+		require.Len(t, bundles, 1)
+		assert.Equal(t, []hexutil.Bytes{attempt.SignedRawTx}, bundles[0].Txs)
+		assert.Equal(t, hexutil.Uint64(106), bundles[0].BlockNumber)
+
+		etx, err := borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.True(t, etx.PrivateSubmission)
+	})
+
+	t.Run("broadcasts publicly once the fallback blocks have passed", func(t *testing.T) {
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == uint64(*etx.Nonce)
+		})).Return(nil).Once()
+
+		require.NoError(t, ec.ResubmitPrivateTransactions(ctx, 110))
+
+		assert.Len(t, stub.Bundles(), 1)
+		etx, err := borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.False(t, etx.PrivateSubmission)
+
+		// Public transactions are left to the resender and gas bumping
+		require.NoError(t, ec.ResubmitPrivateTransactions(ctx, 111))
+		assert.Len(t, stub.Bundles(), 1)
+	})
+
+	t.Run("broadcasts publicly once the relay failed repeatedly", func(t *testing.T) {
+		setRelayStubErr(stub, errors.New("relay unavailable"))
+		etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 1, fromAddress)
+		pgtest.MustExec(t, db, `UPDATE eth_txes SET private_submission = true WHERE id = $1`, etx.ID)
+		pgtest.MustExec(t, db, `UPDATE eth_tx_attempts SET broadcast_before_block_num = 110 WHERE id = $1`, etx.EthTxAttempts[0].ID)
+
+		for i := 1; i < txmgr.PrivateRelayMaxFailures; i++ {
+			require.NoError(t, ec.ResubmitPrivateTransactions(ctx, 111))
+
+			etx, err := borm.FindEthTxWithAttempts(etx.ID)
+			require.NoError(t, err)
+			assert.True(t, etx.PrivateSubmission)
+			assert.Equal(t, i, etx.PrivateRelayFailures)
+		}
+
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == uint64(*etx.Nonce)
+		})).Return(nil).Once()
+
+		require.NoError(t, ec.ResubmitPrivateTransactions(ctx, 111))
+
+		etx, err := borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.False(t, etx.PrivateSubmission)
+		assert.Len(t, stub.Bundles(), 1)
+	})
+}
+
 func TestEthConfirmer_EnsureConfirmedTransactionsInLongestChain(t *testing.T) {
 	t.Parallel()
 
@@ -2716,6 +2800,38 @@ func TestEthConfirmer_EnsureConfirmedTransactionsInLongestChain(t *testing.T) {
 		assert.Equal(t, txmgr.EthTxAttemptBroadcast, attempt.State)
 		assert.Len(t, attempt.EthReceipts, 1)
 	})
+
+	t.Run("does nothing to confirmed transactions with receipts in or below the finalized block even if not included in the chain", func(t *testing.T) {
+		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, borm, 8, 1, fromAddress)
+		attempt := etx.EthTxAttempts[0]
+		// Include one within head height but a different block hash
+		cltest.MustInsertEthReceipt(t, borm, head.Parent.Number, utils.NewHash(), attempt.Hash)
+
+		headWithFinalized := head
+		headWithFinalized.Finalized = &evmtypes.Head{Number: head.Parent.Number, Hash: utils.NewHash()}
+
+		require.NoError(t, ec.EnsureConfirmedTransactionsInLongestChain(testutils.Context(t), &headWithFinalized))
+
+		etx, err := borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxConfirmed, etx.State)
+	})
+
+	t.Run("treats a finalized block ahead of the head as the head", func(t *testing.T) {
+		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, borm, 9, 1, fromAddress)
+		attempt := etx.EthTxAttempts[0]
+		// Include one at head height but a different block hash
+		cltest.MustInsertEthReceipt(t, borm, head.Number, utils.NewHash(), attempt.Hash)
+
+		headWithFinalized := head
+		headWithFinalized.Finalized = &evmtypes.Head{Number: head.Number + 2, Hash: utils.NewHash()}
+
+		require.NoError(t, ec.EnsureConfirmedTransactionsInLongestChain(testutils.Context(t), &headWithFinalized))
+
+		etx, err := borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxConfirmed, etx.State)
+	})
 }
 
 func TestEthConfirmer_ForceRebroadcast(t *testing.T) {
@@ -2818,6 +2934,112 @@ func TestEthConfirmer_ForceRebroadcast(t *testing.T) {
 	})
 }
 
+func TestEthConfirmer_CancelEthTx(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	borm := cltest.NewTxmORM(t, db, cfg)
+
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	state, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+
+	config := newTestChainScopedConfig(t)
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	ec := cltest.NewEthConfirmer(t, db, ethClient, config, ethKeyStore, []ethkey.State{state}, nil)
+	require.NoError(t, ec.Start(testutils.Context(t)))
+	t.Cleanup(func() { assert.NoError(t, ec.Close()) })
+
+	t.Run("marks an unstarted eth_tx as fatally errored", func(t *testing.T) {
+		etx := cltest.NewEthTx(t, fromAddress)
+		require.NoError(t, borm.InsertEthTx(&etx))
+
+		etx, err := ec.CancelEthTx(testutils.Context(t), etx.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, txmgr.EthTxFatalError, etx.State)
+		assert.Equal(t, "cancelled", etx.Error.String)
+		assert.True(t, etx.IsCancelled())
+	})
+
+	t.Run("replaces an unconfirmed eth_tx with a zero ether transfer to itself", func(t *testing.T) {
+		etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 0, fromAddress)
+
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == uint64(*etx.Nonce) &&
+				*tx.To() == fromAddress &&
+				tx.Value().Sign() == 0 &&
+				len(tx.Data()) == 0 &&
+				tx.GasPrice().Cmp(etx.EthTxAttempts[0].GasPrice.ToInt()) > 0
+		})).Return(nil).Once()
+
+		etx, err := ec.CancelEthTx(testutils.Context(t), etx.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, txmgr.EthTxUnconfirmed, etx.State)
+		assert.True(t, etx.IsCancelled())
+		require.Len(t, etx.EthTxAttempts, 2)
+		assert.Equal(t, txmgr.EthTxAttemptBroadcast, etx.EthTxAttempts[0].State)
+	})
+
+	t.Run("errors if the eth_tx is already confirmed", func(t *testing.T) {
+		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, borm, 1, 1, fromAddress)
+
+		_, err := ec.CancelEthTx(testutils.Context(t), etx.ID)
+		require.EqualError(t, err, fmt.Sprintf("eth_tx %d is confirmed and can no longer be replaced", etx.ID))
+	})
+}
+
+func TestEthConfirmer_BumpEthTx(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	borm := cltest.NewTxmORM(t, db, cfg)
+
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	state, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+
+	config := newTestChainScopedConfig(t)
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	ec := cltest.NewEthConfirmer(t, db, ethClient, config, ethKeyStore, []ethkey.State{state}, nil)
+	require.NoError(t, ec.Start(testutils.Context(t)))
+	t.Cleanup(func() { assert.NoError(t, ec.Close()) })
+
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 0, fromAddress)
+
+	t.Run("errors if the gas price is too low to replace the previous attempt", func(t *testing.T) {
+		_, err := ec.BumpEthTx(testutils.Context(t), etx.ID, assets.GWei(1))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "gas price of 1 gwei is too low to replace the previous attempt")
+	})
+
+	t.Run("sends a new attempt at the given gas price", func(t *testing.T) {
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == uint64(*etx.Nonce) &&
+				*tx.To() == etx.ToAddress &&
+				reflect.DeepEqual(tx.Data(), etx.EncodedPayload) &&
+				tx.GasPrice().Cmp(assets.GWei(10).ToInt()) == 0
+		})).Return(nil).Once()
+
+		bumped, err := ec.BumpEthTx(testutils.Context(t), etx.ID, assets.GWei(10))
+		require.NoError(t, err)
+
+		assert.False(t, bumped.IsCancelled())
+		require.Len(t, bumped.EthTxAttempts, 2)
+		assert.Equal(t, assets.GWei(10), bumped.EthTxAttempts[0].GasPrice)
+		assert.Equal(t, txmgr.EthTxAttemptBroadcast, bumped.EthTxAttempts[0].State)
+	})
+
+	t.Run("errors if the eth_tx has not been broadcast yet", func(t *testing.T) {
+		unstarted := cltest.NewEthTx(t, fromAddress)
+		require.NoError(t, borm.InsertEthTx(&unstarted))
+
+		_, err := ec.BumpEthTx(testutils.Context(t), unstarted.ID, assets.GWei(10))
+		require.EqualError(t, err, fmt.Sprintf("eth_tx %d has not been broadcast yet", unstarted.ID))
+	})
+}
+
 func TestEthConfirmer_ResumePendingRuns(t *testing.T) {
 	t.Parallel()
 
@@ -2970,6 +3192,44 @@ func TestEthConfirmer_ResumePendingRuns(t *testing.T) {
 			t.Fatal("no value received")
 		}
 	})
+
+	pgtest.MustExec(t, db, `DELETE FROM pipeline_runs`)
+
+	t.Run("includes the revert reason of eth_txes that reverted", func(t *testing.T) {
+		ch := make(chan interface{})
+		var err error
+		ec := cltest.NewEthConfirmer(t, db, ethClient, evmcfg, ethKeyStore, []ethkey.State{state}, func(id uuid.UUID, value interface{}, thisErr error) error {
+			err = thisErr
+			ch <- value
+			return nil
+		})
+
+		run := cltest.MustInsertPipelineRun(t, db)
+		tr := cltest.MustInsertUnfinishedPipelineTaskRun(t, db, run.ID)
+		pgtest.MustExec(t, db, `UPDATE pipeline_runs SET state = 'suspended' WHERE id = $1`, run.ID)
+
+		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, borm, 5, 1, fromAddress)
+		pgtest.MustExec(t, db, `UPDATE eth_txes SET meta='{"FailOnRevert": true}', revert_reason = 'insufficient balance' WHERE id = $1`, etx.ID)
+		attempt := etx.EthTxAttempts[0]
+
+		cltest.MustInsertRevertedEthReceipt(t, borm, head.Number-minConfirmations, head.Hash, attempt.Hash)
+
+		pgtest.MustExec(t, db, `UPDATE eth_txes SET pipeline_task_run_id = $1, min_confirmations = $2 WHERE id = $3`, &tr.ID, minConfirmations, etx.ID)
+
+		go func() {
+			err2 := ec.ResumePendingTaskRuns(testutils.Context(t), &head)
+			require.NoError(t, err2)
+		}()
+
+		select {
+		case data := <-ch:
+			assert.EqualError(t, err, fmt.Sprintf("transaction %s reverted on-chain: insufficient balance", attempt.Hash.Hex()))
+			assert.Nil(t, data)
+
+		case <-testutils.AfterWaitTimeout(t):
+			t.Fatal("no value received")
+		}
+	})
 }
 
 func ptr[T any](t T) *T { return &t }
@@ -0,0 +1,116 @@
+package txmgr
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services/pg"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// EthTxEventType is the kind of transition described by an EthTxEvent
+type EthTxEventType string
+
+const (
+	// EthTxEventCreated is emitted when a transaction is inserted into the queue
+	EthTxEventCreated = EthTxEventType("created")
+	// EthTxEventInProgress is emitted when the broadcaster picks up a transaction and assigns it a nonce
+	EthTxEventInProgress = EthTxEventType("in_progress")
+	// EthTxEventBroadcast is emitted when the first attempt of a transaction was sent
+	EthTxEventBroadcast = EthTxEventType("broadcast")
+	// EthTxEventUnconfirmed is emitted when a transaction that was thought to be mined turns out to not be
+	EthTxEventUnconfirmed = EthTxEventType("unconfirmed")
+	// EthTxEventReorged is emitted when the receipt of a confirmed transaction was removed by a re-org
+	EthTxEventReorged = EthTxEventType("reorged")
+	// EthTxEventConfirmed is emitted when a receipt was found for one of the attempts of a transaction
+	EthTxEventConfirmed = EthTxEventType("confirmed")
+	// EthTxEventConfirmedMissingReceipt is emitted when the nonce of a transaction was used up but none of its attempts has a receipt
+	EthTxEventConfirmedMissingReceipt = EthTxEventType("confirmed_missing_receipt")
+	// EthTxEventFatal is emitted when a transaction failed permanently
+	EthTxEventFatal = EthTxEventType("fatal")
+
+	// EthTxEventAttemptCreated is emitted when a new attempt is saved before being sent
+	EthTxEventAttemptCreated = EthTxEventType("attempt_created")
+	// EthTxEventAttemptBroadcast is emitted when the first broadcast attempt of a transaction was sent
+	EthTxEventAttemptBroadcast = EthTxEventType("attempt_broadcast")
+	// EthTxEventAttemptInProgress is emitted when a broadcast attempt must be sent again, e.g. after a re-org
+	EthTxEventAttemptInProgress = EthTxEventType("attempt_in_progress")
+	// EthTxEventBumped is emitted when a replacement attempt with a higher fee was sent
+	EthTxEventBumped = EthTxEventType("bumped")
+	// EthTxEventInsufficientEth is emitted when an attempt could not be sent because the sending key ran out of funds
+	EthTxEventInsufficientEth = EthTxEventType("insufficient_eth")
+)
+
+// EthTxEvent describes a state transition of an EthTx or one of its
+// attempts. Events are published on pg.ChannelEthTxEvents by database
+// triggers, once the transition was committed, while events are enabled with
+// SetEthTxEventsEnabled. Attempt fields are only set for events of attempts.
+type EthTxEvent struct {
+	Type              EthTxEventType `json:"type"`
+	EVMChainID        utils.Big      `json:"evmChainID"`
+	EthTxID           int64          `json:"ethTxID"`
+	FromAddress       common.Address `json:"fromAddress"`
+	ToAddress         common.Address `json:"toAddress"`
+	Nonce             *int64         `json:"nonce"`
+	State             EthTxState     `json:"state"`
+	PreviousState     EthTxState     `json:"previousState,omitempty"`
+	Priority          EthTxPriority  `json:"priority,omitempty"`
+	Error             string         `json:"error,omitempty"`
+	PipelineTaskRunID *uuid.UUID     `json:"pipelineTaskRunID,omitempty"`
+
+	AttemptID            *int64            `json:"attemptID,omitempty"`
+	Hash                 *common.Hash      `json:"hash,omitempty"`
+	AttemptState         EthTxAttemptState `json:"attemptState,omitempty"`
+	PreviousAttemptState EthTxAttemptState `json:"previousAttemptState,omitempty"`
+	GasPrice             *assets.Wei       `json:"gasPrice,omitempty"`
+	GasTipCap            *assets.Wei       `json:"gasTipCap,omitempty"`
+	GasFeeCap            *assets.Wei       `json:"gasFeeCap,omitempty"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SetEthTxEventsEnabled enables or disables the database triggers publishing
+// EthTxEvents
+func SetEthTxEventsEnabled(q pg.Queryer, enabled bool) error {
+	stmt := `DELETE FROM eth_tx_events_enabled`
+	if enabled {
+		stmt = `INSERT INTO eth_tx_events_enabled (enabled_at) SELECT NOW() WHERE NOT EXISTS (SELECT 1 FROM eth_tx_events_enabled)`
+	}
+	_, err := q.Exec(stmt)
+	return errors.Wrap(err, "failed to set eth tx events")
+}
+
+// ParseEthTxEvent decodes the payload of a notification on pg.ChannelEthTxEvents
+func ParseEthTxEvent(payload string) (event EthTxEvent, err error) {
+	err = json.Unmarshal([]byte(payload), &event)
+	return event, errors.Wrap(err, "failed to parse eth tx event")
+}
+
+// EthTxEventFilter selects the events of a chain, an address or a
+// transaction. Unset fields match any event.
+type EthTxEventFilter struct {
+	EVMChainID *big.Int
+	// Address matches both the sender and the recipient of a transaction
+	Address *common.Address
+	EthTxID *int64
+}
+
+// Matches returns true if the event passes all the criteria of the filter
+func (f EthTxEventFilter) Matches(event EthTxEvent) bool {
+	if f.EVMChainID != nil && f.EVMChainID.Cmp(event.EVMChainID.ToInt()) != 0 {
+		return false
+	}
+	if f.Address != nil && *f.Address != event.FromAddress && *f.Address != event.ToAddress {
+		return false
+	}
+	if f.EthTxID != nil && *f.EthTxID != event.EthTxID {
+		return false
+	}
+	return true
+}
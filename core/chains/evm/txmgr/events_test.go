@@ -0,0 +1,124 @@
+package txmgr_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+func TestEthTxEventPayload(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	_, fromAddress := cltest.MustInsertRandomKey(t, ethKeyStore, 0)
+
+	etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, borm, 3, 1, fromAddress)
+	attempt := etx.EthTxAttempts[0]
+
+	t.Run("transaction event", func(t *testing.T) {
+		var payload string
+		require.NoError(t, db.Get(&payload, `SELECT eth_tx_event_payload('reorged', eth_txes, 'confirmed', NULL, NULL) FROM eth_txes WHERE id = $1`, etx.ID))
+
+		event, err := txmgr.ParseEthTxEvent(payload)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxEventReorged, event.Type)
+		assert.Equal(t, etx.EVMChainID.String(), event.EVMChainID.String())
+		assert.Equal(t, etx.ID, event.EthTxID)
+		assert.Equal(t, fromAddress, event.FromAddress)
+		assert.Equal(t, etx.ToAddress, event.ToAddress)
+		require.NotNil(t, event.Nonce)
+		assert.Equal(t, int64(3), *event.Nonce)
+		assert.Equal(t, txmgr.EthTxConfirmed, event.State)
+		assert.Equal(t, txmgr.EthTxConfirmed, event.PreviousState)
+		assert.Equal(t, txmgr.EthTxPriorityNormal, event.Priority)
+		assert.Nil(t, event.AttemptID)
+		assert.Nil(t, event.Hash)
+		assert.False(t, event.Timestamp.IsZero())
+	})
+
+	t.Run("attempt event", func(t *testing.T) {
+		var payload string
+		require.NoError(t, db.Get(&payload, `SELECT eth_tx_event_payload('bumped', eth_txes, NULL, eth_tx_attempts, 'in_progress')
+FROM eth_txes JOIN eth_tx_attempts ON eth_tx_attempts.eth_tx_id = eth_txes.id WHERE eth_tx_attempts.id = $1`, attempt.ID))
+
+		event, err := txmgr.ParseEthTxEvent(payload)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxEventBumped, event.Type)
+		assert.Equal(t, etx.ID, event.EthTxID)
+		assert.Empty(t, event.PreviousState)
+		require.NotNil(t, event.AttemptID)
+		assert.Equal(t, attempt.ID, *event.AttemptID)
+		require.NotNil(t, event.Hash)
+		assert.Equal(t, attempt.Hash, *event.Hash)
+		assert.Equal(t, txmgr.EthTxAttemptBroadcast, event.AttemptState)
+		assert.Equal(t, txmgr.EthTxAttemptInProgress, event.PreviousAttemptState)
+		require.NotNil(t, event.GasPrice)
+		assert.Equal(t, assets.NewWeiI(1).String(), event.GasPrice.String())
+		assert.Nil(t, event.GasTipCap)
+	})
+}
+
+func TestSetEthTxEventsEnabled(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+
+	count := func() (n int) {
+		require.NoError(t, db.Get(&n, `SELECT count(*) FROM eth_tx_events_enabled`))
+		return
+	}
+	require.Equal(t, 0, count())
+
+	require.NoError(t, txmgr.SetEthTxEventsEnabled(db, true))
+	assert.Equal(t, 1, count())
+	require.NoError(t, txmgr.SetEthTxEventsEnabled(db, true))
+	assert.Equal(t, 1, count())
+
+	require.NoError(t, txmgr.SetEthTxEventsEnabled(db, false))
+	assert.Equal(t, 0, count())
+}
+
+func TestEthTxEventFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	from, to := testutils.NewAddress(), testutils.NewAddress()
+	event := txmgr.EthTxEvent{
+		Type:        txmgr.EthTxEventConfirmed,
+		EVMChainID:  *utils.NewBigI(42),
+		EthTxID:     7,
+		FromAddress: from,
+		ToAddress:   to,
+	}
+	other := testutils.NewAddress()
+	id, otherID := int64(7), int64(8)
+
+	for _, tt := range []struct {
+		name   string
+		filter txmgr.EthTxEventFilter
+		exp    bool
+	}{
+		{"empty", txmgr.EthTxEventFilter{}, true},
+		{"chain", txmgr.EthTxEventFilter{EVMChainID: big.NewInt(42)}, true},
+		{"other chain", txmgr.EthTxEventFilter{EVMChainID: big.NewInt(1)}, false},
+		{"sender", txmgr.EthTxEventFilter{Address: &from}, true},
+		{"recipient", txmgr.EthTxEventFilter{Address: &to}, true},
+		{"other address", txmgr.EthTxEventFilter{Address: &other}, false},
+		{"transaction", txmgr.EthTxEventFilter{EthTxID: &id}, true},
+		{"other transaction", txmgr.EthTxEventFilter{EthTxID: &otherID}, false},
+		{"all", txmgr.EthTxEventFilter{EVMChainID: big.NewInt(42), Address: &from, EthTxID: &id}, true},
+		{"all but one", txmgr.EthTxEventFilter{EVMChainID: big.NewInt(42), Address: &other, EthTxID: &id}, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exp, tt.filter.Matches(event))
+		})
+	}
+}
@@ -1,6 +1,14 @@
 package txmgr
 
-import evmclient "github.com/smartcontractkit/chainlink/core/chains/evm/client"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	evmclient "github.com/smartcontractkit/chainlink/core/chains/evm/client"
+	evmtypes "github.com/smartcontractkit/chainlink/core/chains/evm/types"
+)
 
 func SetEthClientOnEthConfirmer(ethClient evmclient.Client, ethConfirmer *EthConfirmer) {
 	ethConfirmer.ethClient = ethClient
@@ -13,3 +21,31 @@ func SetResumeCallbackOnEthBroadcaster(resumeCallback ResumeCallback, ethBroadca
 func (er *EthResender) ResendUnconfirmed() error {
 	return er.resendUnconfirmed()
 }
+
+func GroupEthTxBatches(etxs []EthTx) [][]EthTx {
+	return groupEthTxBatches(etxs)
+}
+
+func BatchCallReceipt(receipt evmtypes.Receipt, forwarder common.Address, index int64) (evmtypes.Receipt, error) {
+	return batchCallReceipt(receipt, forwarder, index)
+}
+
+func UnpackForwardBatch(payload []byte) ([]interface{}, error) {
+	return batchForwarderABI.Methods["forwardBatch"].Inputs.Unpack(payload[4:])
+}
+
+func CallForwardedTopic() common.Hash {
+	return callForwardedTopic
+}
+
+func InclusionUrgency(etx EthTx, blockHeight int64, now time.Time, blockTime time.Duration) float64 {
+	return inclusionUrgency(etx, blockHeight, now, blockTime)
+}
+
+func InclusionDeadlinePercentile(base uint16, urgency float64) uint16 {
+	return inclusionDeadlinePercentile(base, urgency)
+}
+
+func (f AttemptFee) RaisedTo(target AttemptFee, max *assets.Wei) AttemptFee {
+	return f.raisedTo(target, max)
+}
@@ -0,0 +1,158 @@
+package txmgr
+
+import (
+	"math"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/core/null"
+)
+
+// InclusionDeadline is the deadline for a transaction to be included in a
+// block by.
+//
+// A transaction with a deadline is considered for bumping in every block,
+// not only every GasBumpThreshold blocks. It is priced at a percentile of the
+// prices paid by the transactions in recent blocks, which rises from the
+// percentile the gas estimator prices new transactions at when it is first
+// sent to 100 when only the next block can still meet the deadline, and is
+// capped at the max gas price of its key. It is bumped early whenever that
+// price is higher than the price it pays, by at least the usual bump. The
+// BlockHistory and FeeHistory estimators, including as part of a Composite
+// estimator, keep the prices of recent blocks. Other gas estimators bump it as
+// usual.
+type InclusionDeadline struct {
+	// Blocks is the number of blocks after the transaction is first broadcast
+	// that it should be included within
+	Blocks uint32
+	// Duration is the time after the transaction is created that it should be
+	// included within
+	Duration time.Duration
+}
+
+// IsSet returns true if d is a deadline in blocks or time
+func (d InclusionDeadline) IsSet() bool {
+	return d.Blocks > 0 || d.Duration > 0
+}
+
+// columns returns the eth_txes columns of d, for a transaction created at now
+func (d InclusionDeadline) columns(now time.Time) (blocks null.Uint32, at *time.Time) {
+	if d.Blocks > 0 {
+		blocks = null.Uint32From(d.Blocks)
+	}
+	if d.Duration > 0 {
+		t := now.Add(d.Duration)
+		at = &t
+	}
+	return
+}
+
+// HasInclusionDeadline returns true if the transaction has a deadline to be
+// included by, see InclusionDeadline
+func (e EthTx) HasInclusionDeadline() bool {
+	return e.InclusionDeadlineBlocks.Valid || e.InclusionDeadlineAt != nil
+}
+
+// inclusionUrgency returns how close etx is to its inclusion deadline at
+// blockHeight and now, from 0 when it has just been sent to 1 when only the
+// next block can still meet it. blockTime is the average time between blocks,
+// or zero if it is not known.
+func inclusionUrgency(etx EthTx, blockHeight int64, now time.Time, blockTime time.Duration) (urgency float64) {
+	if etx.InclusionDeadlineBlocks.Valid && etx.InclusionDeadlineBlocks.Uint32 > 0 {
+		firstSentBefore := blockHeight
+		for _, a := range etx.EthTxAttempts {
+			if a.BroadcastBeforeBlockNum != nil && *a.BroadcastBeforeBlockNum < firstSentBefore {
+				firstSentBefore = *a.BroadcastBeforeBlockNum
+			}
+		}
+		urgency = float64(blockHeight-firstSentBefore+1) / float64(etx.InclusionDeadlineBlocks.Uint32)
+	}
+	if at := etx.InclusionDeadlineAt; at != nil {
+		window := at.Sub(etx.CreatedAt)
+		if window <= 0 {
+			return 1
+		}
+		remaining := at.Sub(now) - blockTime
+		urgency = math.Max(urgency, 1-float64(remaining)/float64(window))
+	}
+	return math.Min(math.Max(urgency, 0), 1)
+}
+
+// inclusionDeadlinePercentile returns the percentile of recent prices paid by
+// a transaction with urgency, rising from base to 100
+func inclusionDeadlinePercentile(base uint16, urgency float64) uint16 {
+	if base >= 100 {
+		return 100
+	}
+	return base + uint16(math.Ceil(float64(100-base)*urgency))
+}
+
+// inclusionDeadlineFee returns the fee that etx should pay at blockHeight to
+// meet its inclusion deadline, capped at the max gas price of its key. Only
+// the tip cap of a dynamic fee is set. It returns false if etx has no
+// deadline, or if the gas estimator cannot price it.
+func (ec *EthConfirmer) inclusionDeadlineFee(etx EthTx, previousAttempt EthTxAttempt, blockHeight int64) (fee AttemptFee, ok bool) {
+	if !etx.HasInclusionDeadline() || etx.CancelledAt != nil {
+		return fee, false
+	}
+	builder, err := AttemptBuilderFor(previousAttempt.TxType)
+	if err != nil {
+		return fee, false
+	}
+	pe := gas.GetPercentileEstimator(ec.estimator)
+	if pe == nil {
+		return fee, false
+	}
+	urgency := inclusionUrgency(etx, blockHeight, time.Now(), pe.AverageBlockTime())
+	percentile := inclusionDeadlinePercentile(pe.BasePercentile(), urgency)
+	gasPrice, tipCap, err := pe.PercentilePrices(percentile)
+	if err != nil {
+		etx.GetLogger(ec.lggr).Debugw("Cannot price transaction for its inclusion deadline, bumping it as usual", "etxID", etx.ID, "percentile", percentile, "err", err)
+		return fee, false
+	}
+	return builder.PercentileFee(gasPrice, tipCap, ec.config.KeySpecificMaxGasPriceWei(etx.FromAddress))
+}
+
+// dueForRebroadcast returns false for transactions with an inclusion deadline
+// that are only considered for bumping because of it, and do not need a
+// higher price to meet it yet
+func (ec *EthConfirmer) dueForRebroadcast(etx EthTx, blockHeight, gasBumpThreshold int64) bool {
+	if !etx.HasInclusionDeadline() || len(etx.EthTxAttempts) == 0 {
+		return true
+	}
+	previousAttempt := etx.EthTxAttempts[0]
+	if previousAttempt.State == EthTxAttemptInsufficientEth {
+		return true
+	}
+	for _, a := range etx.EthTxAttempts {
+		if a.BroadcastBeforeBlockNum != nil && *a.BroadcastBeforeBlockNum > blockHeight-gasBumpThreshold {
+			target, ok := ec.inclusionDeadlineFee(etx, previousAttempt, blockHeight)
+			return ok && target.exceeds(previousAttempt.Fee())
+		}
+	}
+	return true
+}
+
+// exceeds returns true if f pays more than previous. Only the tip caps of
+// dynamic fees are compared.
+func (f AttemptFee) exceeds(previous AttemptFee) bool {
+	if f.GasPrice != nil {
+		return f.GasPrice.Cmp(previous.GasPrice) > 0
+	}
+	return f.DynamicFee.TipCap.Cmp(previous.DynamicFee.TipCap) > 0
+}
+
+// raisedTo returns f raised to pay at least target, capped at max. The fee
+// cap of a dynamic fee is raised by as much as its tip cap.
+func (f AttemptFee) raisedTo(target AttemptFee, max *assets.Wei) AttemptFee {
+	if !target.exceeds(f) {
+		return f
+	}
+	if f.GasPrice != nil {
+		return AttemptFee{GasPrice: target.GasPrice}
+	}
+	tipCap := target.DynamicFee.TipCap
+	feeCap := assets.WeiMin(f.DynamicFee.FeeCap.Add(tipCap.Sub(f.DynamicFee.TipCap)), max)
+	return AttemptFee{DynamicFee: gas.DynamicFee{TipCap: tipCap, FeeCap: feeCap}}
+}
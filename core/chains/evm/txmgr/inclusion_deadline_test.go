@@ -0,0 +1,171 @@
+package txmgr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/evmtest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	clnull "github.com/smartcontractkit/chainlink/core/null"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+)
+
+func TestInclusionUrgency(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1600000000, 0)
+	sentBefore := func(n int64) txmgr.EthTxAttempt { return txmgr.EthTxAttempt{BroadcastBeforeBlockNum: &n} }
+	deadlineAt := func(d time.Duration) *time.Time {
+		at := now.Add(d)
+		return &at
+	}
+
+	for _, tt := range []struct {
+		name        string
+		etx         txmgr.EthTx
+		blockHeight int64
+		blockTime   time.Duration
+		expected    float64
+	}{
+		{"no deadline", txmgr.EthTx{}, 10, 0, 0},
+		{"not broadcast yet", txmgr.EthTx{InclusionDeadlineBlocks: clnull.Uint32From(4)}, 10, 0, 0.25},
+		{"blocks since first broadcast", txmgr.EthTx{InclusionDeadlineBlocks: clnull.Uint32From(4), EthTxAttempts: []txmgr.EthTxAttempt{sentBefore(10), sentBefore(9)}}, 10, 0, 0.5},
+		{"only the next block meets the deadline", txmgr.EthTx{InclusionDeadlineBlocks: clnull.Uint32From(4), EthTxAttempts: []txmgr.EthTxAttempt{sentBefore(7)}}, 10, 0, 1},
+		{"missed the deadline", txmgr.EthTx{InclusionDeadlineBlocks: clnull.Uint32From(4), EthTxAttempts: []txmgr.EthTxAttempt{sentBefore(2)}}, 10, 0, 1},
+		{"time since creation", txmgr.EthTx{CreatedAt: now.Add(-time.Minute), InclusionDeadlineAt: deadlineAt(3 * time.Minute)}, 10, 0, 0.25},
+		{"time since creation and the next block", txmgr.EthTx{CreatedAt: now.Add(-time.Minute), InclusionDeadlineAt: deadlineAt(3 * time.Minute)}, 10, time.Minute, 0.5},
+		{"time past the deadline", txmgr.EthTx{CreatedAt: now.Add(-time.Minute), InclusionDeadlineAt: deadlineAt(-time.Second)}, 10, 0, 1},
+		{"most urgent of blocks and time", txmgr.EthTx{InclusionDeadlineBlocks: clnull.Uint32From(4), CreatedAt: now.Add(-time.Minute), InclusionDeadlineAt: deadlineAt(3 * time.Minute)}, 10, 0, 0.25},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, txmgr.InclusionUrgency(tt.etx, tt.blockHeight, now, tt.blockTime), 1e-9)
+		})
+	}
+
+	assert.Equal(t, uint16(60), txmgr.InclusionDeadlinePercentile(60, 0))
+	assert.Equal(t, uint16(70), txmgr.InclusionDeadlinePercentile(60, 0.25))
+	assert.Equal(t, uint16(100), txmgr.InclusionDeadlinePercentile(60, 1))
+	assert.Equal(t, uint16(100), txmgr.InclusionDeadlinePercentile(100, 0.5))
+}
+
+func TestAttemptFee_RaisedTo(t *testing.T) {
+	t.Parallel()
+
+	max := assets.GWei(100)
+
+	t.Run("legacy", func(t *testing.T) {
+		fee := txmgr.AttemptFee{GasPrice: assets.GWei(20)}
+		assert.Equal(t, fee, fee.RaisedTo(txmgr.AttemptFee{GasPrice: assets.GWei(10)}, max))
+		assert.Equal(t, txmgr.AttemptFee{GasPrice: assets.GWei(30)}, fee.RaisedTo(txmgr.AttemptFee{GasPrice: assets.GWei(30)}, max))
+	})
+
+	t.Run("dynamic fee raises the fee cap by as much as the tip cap", func(t *testing.T) {
+		fee := txmgr.AttemptFee{DynamicFee: gas.DynamicFee{TipCap: assets.GWei(2), FeeCap: assets.GWei(50)}}
+		assert.Equal(t, fee, fee.RaisedTo(txmgr.AttemptFee{DynamicFee: gas.DynamicFee{TipCap: assets.GWei(1)}}, max))
+
+		raised := fee.RaisedTo(txmgr.AttemptFee{DynamicFee: gas.DynamicFee{TipCap: assets.GWei(10)}}, max)
+		assert.Equal(t, assets.GWei(10), raised.DynamicFee.TipCap)
+		assert.Equal(t, assets.GWei(58), raised.DynamicFee.FeeCap)
+
+		raised = fee.RaisedTo(txmgr.AttemptFee{DynamicFee: gas.DynamicFee{TipCap: assets.GWei(80)}}, max)
+		assert.Equal(t, assets.GWei(80), raised.DynamicFee.TipCap)
+		assert.Equal(t, max, raised.DynamicFee.FeeCap)
+	})
+}
+
+// percentileEstimator prices transactions at percentile gwei, from a base
+// percentile of 60
+type percentileEstimator struct {
+	gas.Estimator
+}
+
+func (percentileEstimator) PercentilePrices(percentile uint16) (gasPrice, tipCap *assets.Wei, err error) {
+	return assets.GWei(int64(percentile)), nil, nil
+}
+
+func (percentileEstimator) AverageBlockTime() time.Duration { return 0 }
+
+func (percentileEstimator) BasePercentile() uint16 { return 60 }
+
+func TestEthConfirmer_RebroadcastWhereNecessary_InclusionDeadline(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].GasEstimator.PriceDefault = assets.GWei(20)
+		c.EVM[0].GasEstimator.PriceMax = assets.GWei(500)
+		c.EVM[0].GasEstimator.BumpMin = assets.GWei(5)
+		c.EVM[0].GasEstimator.BumpPercent = ptr[uint16](20)
+		c.EVM[0].GasEstimator.BumpThreshold = ptr[uint32](10)
+	})
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	_, fromAddress := cltest.MustInsertRandomKeyReturningState(t, ethKeyStore, 0)
+	keys, err := ethKeyStore.EnabledKeysForChain(testutils.FixtureChainID)
+	require.NoError(t, err)
+	keyStates, err := ethKeyStore.GetStatesForKeys(keys)
+	require.NoError(t, err)
+
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	lggr := logger.TestLogger(t)
+	estimator := percentileEstimator{gas.NewFixedPriceEstimator(evmcfg, lggr)}
+	ec := txmgr.NewEthConfirmer(db, ethClient, evmcfg, ethKeyStore, keyStates, estimator, nil, lggr)
+
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 0, fromAddress)
+	pgtest.MustExec(t, db, `UPDATE eth_tx_attempts SET broadcast_before_block_num = 29 WHERE eth_tx_id = $1`, etx.ID)
+
+	expectSent := func(gasPrice *assets.Wei) {
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.GasPrice().Cmp(gasPrice.ToInt()) == 0
+		})).Return(nil).Once()
+	}
+	rebroadcast := func(blockHeight int64) txmgr.EthTx {
+		require.NoError(t, ec.RebroadcastWhereNecessary(testutils.Context(t), blockHeight))
+		etx, err = borm.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		return etx
+	}
+
+	t.Run("does not bump transactions without a deadline before the threshold", func(t *testing.T) {
+		require.Len(t, rebroadcast(30).EthTxAttempts, 1)
+	})
+
+	pgtest.MustExec(t, db, `UPDATE eth_txes SET inclusion_deadline_blocks = 4 WHERE id = $1`, etx.ID)
+
+	t.Run("bumps to the percentile of the deadline before the threshold", func(t *testing.T) {
+		// Half way to the deadline, between the 60th and 100th percentiles
+		expectSent(assets.GWei(80))
+		etx = rebroadcast(30)
+		require.Len(t, etx.EthTxAttempts, 2)
+		assert.Equal(t, "80 gwei", etx.EthTxAttempts[0].GasPrice.String())
+		assert.Equal(t, txmgr.EthTxAttemptBroadcast, etx.EthTxAttempts[0].State)
+	})
+
+	pgtest.MustExec(t, db, `UPDATE eth_tx_attempts SET broadcast_before_block_num = 30 WHERE eth_tx_id = $1 AND broadcast_before_block_num IS NULL`, etx.ID)
+
+	t.Run("does not bump if the price of the deadline is already paid", func(t *testing.T) {
+		require.Len(t, rebroadcast(30).EthTxAttempts, 2)
+	})
+
+	t.Run("bumps by at least the usual bump", func(t *testing.T) {
+		// The 90th percentile is below 80 gwei bumped by 20%
+		expectSent(assets.GWei(96))
+		etx = rebroadcast(31)
+		require.Len(t, etx.EthTxAttempts, 3)
+		assert.Equal(t, "96 gwei", etx.EthTxAttempts[0].GasPrice.String())
+	})
+}
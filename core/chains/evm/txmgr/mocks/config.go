@@ -11,6 +11,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
+
+	url "net/url"
 )
 
 // Config is an autogenerated mock type for the Config type
@@ -130,6 +132,64 @@ func (_m *Config) ChainType() config.ChainType {
 	return r0
 }
 
+// CompositeEstimatorBandPercent provides a mock function with given fields:
+func (_m *Config) CompositeEstimatorBandPercent() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
+// CompositeEstimatorHistorySize provides a mock function with given fields:
+func (_m *Config) CompositeEstimatorHistorySize() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
+// CompositeEstimatorModes provides a mock function with given fields:
+func (_m *Config) CompositeEstimatorModes() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// CompositeEstimatorPolicy provides a mock function with given fields:
+func (_m *Config) CompositeEstimatorPolicy() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // DatabaseDefaultQueryTimeout provides a mock function with given fields:
 func (_m *Config) DatabaseDefaultQueryTimeout() time.Duration {
 	ret := _m.Called()
@@ -378,6 +438,38 @@ func (_m *Config) EvmGasTipCapMinimum() *assets.Wei {
 	return r0
 }
 
+// EvmJobSpendLimitDaily provides a mock function with given fields:
+func (_m *Config) EvmJobSpendLimitDaily() *assets.Wei {
+	ret := _m.Called()
+
+	var r0 *assets.Wei
+	if rf, ok := ret.Get(0).(func() *assets.Wei); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	return r0
+}
+
+// EvmJobSpendLimitHourly provides a mock function with given fields:
+func (_m *Config) EvmJobSpendLimitHourly() *assets.Wei {
+	ret := _m.Called()
+
+	var r0 *assets.Wei
+	if rf, ok := ret.Get(0).(func() *assets.Wei); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	return r0
+}
+
 // EvmMaxGasPriceWei provides a mock function with given fields:
 func (_m *Config) EvmMaxGasPriceWei() *assets.Wei {
 	ret := _m.Called()
@@ -422,6 +514,20 @@ func (_m *Config) EvmMaxQueuedTransactions() uint64 {
 	return r0
 }
 
+// EvmMaxQueuedTransactionsPriority provides a mock function with given fields: priority
+func (_m *Config) EvmMaxQueuedTransactionsPriority(priority string) uint64 {
+	ret := _m.Called(priority)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(string) uint64); ok {
+		r0 = rf(priority)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
 // EvmMinGasPriceWei provides a mock function with given fields:
 func (_m *Config) EvmMinGasPriceWei() *assets.Wei {
 	ret := _m.Called()
@@ -452,6 +558,106 @@ func (_m *Config) EvmNonceAutoSync() bool {
 	return r0
 }
 
+// EvmNonceGapsEnabled provides a mock function with given fields:
+func (_m *Config) EvmNonceGapsEnabled() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EvmNonceGapsFill provides a mock function with given fields:
+func (_m *Config) EvmNonceGapsFill() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EvmNonceGapsResync provides a mock function with given fields:
+func (_m *Config) EvmNonceGapsResync() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EvmPrivateRelayAllTransactions provides a mock function with given fields:
+func (_m *Config) EvmPrivateRelayAllTransactions() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EvmPrivateRelayFallbackBlocks provides a mock function with given fields:
+func (_m *Config) EvmPrivateRelayFallbackBlocks() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// EvmPrivateRelayMethod provides a mock function with given fields:
+func (_m *Config) EvmPrivateRelayMethod() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// EvmPrivateRelayURL provides a mock function with given fields:
+func (_m *Config) EvmPrivateRelayURL() *url.URL {
+	ret := _m.Called()
+
+	var r0 *url.URL
+	if rf, ok := ret.Get(0).(func() *url.URL); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*url.URL)
+		}
+	}
+
+	return r0
+}
+
 // EvmRPCDefaultBatchSize provides a mock function with given fields:
 func (_m *Config) EvmRPCDefaultBatchSize() uint32 {
 	ret := _m.Called()
@@ -466,6 +672,20 @@ func (_m *Config) EvmRPCDefaultBatchSize() uint32 {
 	return r0
 }
 
+// EvmSimulateBeforeBroadcast provides a mock function with given fields:
+func (_m *Config) EvmSimulateBeforeBroadcast() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // EvmUseForwarders provides a mock function with given fields:
 func (_m *Config) EvmUseForwarders() bool {
 	ret := _m.Called()
@@ -480,6 +700,34 @@ func (_m *Config) EvmUseForwarders() bool {
 	return r0
 }
 
+// FeeHistoryEstimatorBlockCount provides a mock function with given fields:
+func (_m *Config) FeeHistoryEstimatorBlockCount() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
+// FeeHistoryEstimatorRewardPercentile provides a mock function with given fields:
+func (_m *Config) FeeHistoryEstimatorRewardPercentile() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
 // GasEstimatorMode provides a mock function with given fields:
 func (_m *Config) GasEstimatorMode() string {
 	ret := _m.Called()
@@ -510,6 +758,54 @@ func (_m *Config) KeySpecificMaxGasPriceWei(addr common.Address) *assets.Wei {
 	return r0
 }
 
+// KeySpecificSignerURL provides a mock function with given fields: addr
+func (_m *Config) KeySpecificSignerURL(addr common.Address) *url.URL {
+	ret := _m.Called(addr)
+
+	var r0 *url.URL
+	if rf, ok := ret.Get(0).(func(common.Address) *url.URL); ok {
+		r0 = rf(addr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*url.URL)
+		}
+	}
+
+	return r0
+}
+
+// KeySpecificSpendLimitDaily provides a mock function with given fields: addr
+func (_m *Config) KeySpecificSpendLimitDaily(addr common.Address) *assets.Wei {
+	ret := _m.Called(addr)
+
+	var r0 *assets.Wei
+	if rf, ok := ret.Get(0).(func(common.Address) *assets.Wei); ok {
+		r0 = rf(addr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	return r0
+}
+
+// KeySpecificSpendLimitHourly provides a mock function with given fields: addr
+func (_m *Config) KeySpecificSpendLimitHourly(addr common.Address) *assets.Wei {
+	ret := _m.Called(addr)
+
+	var r0 *assets.Wei
+	if rf, ok := ret.Get(0).(func(common.Address) *assets.Wei); ok {
+		r0 = rf(addr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	return r0
+}
+
 // LogSQL provides a mock function with given fields:
 func (_m *Config) LogSQL() bool {
 	ret := _m.Called()
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	big "math/big"
+
 	common "github.com/ethereum/go-ethereum/common"
 	mock "github.com/stretchr/testify/mock"
 
@@ -194,6 +196,50 @@ func (_m *ORM) FindEthTxWithAttempts(etxID int64) (txmgr.EthTx, error) {
 	return r0, r1
 }
 
+// FindJobGasSpends provides a mock function with given fields: jobID
+func (_m *ORM) FindJobGasSpends(jobID int32) ([]txmgr.GasSpend, error) {
+	ret := _m.Called(jobID)
+
+	var r0 []txmgr.GasSpend
+	if rf, ok := ret.Get(0).(func(int32) []txmgr.GasSpend); ok {
+		r0 = rf(jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]txmgr.GasSpend)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = rf(jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindKeyGasSpend provides a mock function with given fields: address, chainID
+func (_m *ORM) FindKeyGasSpend(address common.Address, chainID big.Int) (txmgr.GasSpend, error) {
+	ret := _m.Called(address, chainID)
+
+	var r0 txmgr.GasSpend
+	if rf, ok := ret.Get(0).(func(common.Address, big.Int) txmgr.GasSpend); ok {
+		r0 = rf(address, chainID)
+	} else {
+		r0 = ret.Get(0).(txmgr.GasSpend)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, big.Int) error); ok {
+		r1 = rf(address, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InsertEthReceipt provides a mock function with given fields: receipt
 func (_m *ORM) InsertEthReceipt(receipt *txmgr.EthReceipt) error {
 	ret := _m.Called(receipt)
@@ -27,6 +27,48 @@ type TxManager struct {
 	mock.Mock
 }
 
+// BumpEthTx provides a mock function with given fields: ctx, etxID, gasPrice
+func (_m *TxManager) BumpEthTx(ctx context.Context, etxID int64, gasPrice *assets.Wei) (txmgr.EthTx, error) {
+	ret := _m.Called(ctx, etxID, gasPrice)
+
+	var r0 txmgr.EthTx
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *assets.Wei) txmgr.EthTx); ok {
+		r0 = rf(ctx, etxID, gasPrice)
+	} else {
+		r0 = ret.Get(0).(txmgr.EthTx)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *assets.Wei) error); ok {
+		r1 = rf(ctx, etxID, gasPrice)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelEthTx provides a mock function with given fields: ctx, etxID
+func (_m *TxManager) CancelEthTx(ctx context.Context, etxID int64) (txmgr.EthTx, error) {
+	ret := _m.Called(ctx, etxID)
+
+	var r0 txmgr.EthTx
+	if rf, ok := ret.Get(0).(func(context.Context, int64) txmgr.EthTx); ok {
+		r0 = rf(ctx, etxID)
+	} else {
+		r0 = ret.Get(0).(txmgr.EthTx)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, etxID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Close provides a mock function with given fields:
 func (_m *TxManager) Close() error {
 	ret := _m.Called()
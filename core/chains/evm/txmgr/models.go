@@ -49,6 +49,10 @@ type EthTxMeta struct {
 	// Used for keepers
 	UpkeepID *string `json:"UpkeepID,omitempty"`
 
+	// Priority is the lane of the transaction in the queue of its sending
+	// key. Defaults to EthTxPriorityNormal.
+	Priority EthTxPriority `json:"Priority,omitempty"`
+
 	// Used only for forwarded txs, tracks the original destination address.
 	// When this is set, it indicates tx is forwarded through To address.
 	FwdrDestAddress *common.Address `json:"ForwarderDestAddress,omitempty"`
@@ -101,6 +105,48 @@ const (
 	TransmitCheckerTypeVRFV2 = TransmitCheckerType("vrf_v2")
 )
 
+// EthTxPriority is the lane of a transaction in the queue of its sending key.
+// The unstarted transactions of higher priority lanes are always sent first.
+type EthTxPriority string
+
+const (
+	EthTxPriorityHigh   = EthTxPriority("high")
+	EthTxPriorityNormal = EthTxPriority("normal")
+	EthTxPriorityLow    = EthTxPriority("low")
+)
+
+// EthTxPriorities lists the priorities from highest to lowest
+var EthTxPriorities = []EthTxPriority{EthTxPriorityHigh, EthTxPriorityNormal, EthTxPriorityLow}
+
+// ParseEthTxPriority parses s as a priority, the empty string being
+// EthTxPriorityNormal
+func ParseEthTxPriority(s string) (EthTxPriority, error) {
+	if s == "" {
+		return EthTxPriorityNormal, nil
+	}
+	for _, p := range EthTxPriorities {
+		if string(p) == s {
+			return p, nil
+		}
+	}
+	return "", errors.Errorf("invalid transaction priority %q, must be one of high, normal or low", s)
+}
+
+// HigherThan returns true if transactions of priority p are sent before those
+// of priority o
+func (p EthTxPriority) HigherThan(o EthTxPriority) bool {
+	return p.rank() < o.rank()
+}
+
+func (p EthTxPriority) rank() int {
+	for i, q := range EthTxPriorities {
+		if p == q {
+			return i
+		}
+	}
+	return len(EthTxPriorities)
+}
+
 type NullableEIP2930AccessList struct {
 	AccessList types.AccessList
 	Valid      bool
@@ -167,6 +213,9 @@ type EthTx struct {
 	// necessarily the same as the on-chain encoded value (i.e. Optimism)
 	GasLimit uint32
 	Error    null.String
+	// RevertReason is the decoded reason of the revert of the transaction,
+	// during simulation or on-chain
+	RevertReason null.String
 	// BroadcastAt is updated every time an attempt for this eth_tx is re-sent
 	// In almost all cases it will be within a second or so of the actual send time.
 	BroadcastAt *time.Time
@@ -174,7 +223,9 @@ type EthTx struct {
 	InitialBroadcastAt *time.Time
 	CreatedAt          time.Time
 	State              EthTxState
-	EthTxAttempts      []EthTxAttempt `json:"-"`
+	// Priority is the lane of the transaction in the queue of its sending key
+	Priority      EthTxPriority
+	EthTxAttempts []EthTxAttempt `json:"-"`
 	// Marshalled EthTxMeta
 	// Used for additional context around transactions which you want to log
 	// at send time.
@@ -185,13 +236,48 @@ type EthTx struct {
 	PipelineTaskRunID uuid.NullUUID
 	MinConfirmations  cnull.Uint32
 
-	// AccessList is optional and only has an effect on DynamicFee transactions
-	// on chains that support it (e.g. Ethereum Mainnet after London hard fork)
+	// AccessList is optional and only has an effect on access list and
+	// DynamicFee transactions on chains that support them (e.g. Ethereum
+	// Mainnet after Berlin and London hard forks)
 	AccessList NullableEIP2930AccessList
+	// TxType is the EIP-2718 type of the attempts of the transaction. If it is
+	// not set, the type is chosen from the chain configuration, see EthTxType.
+	TxType null.Int
 
 	// TransmitChecker defines the check that should be performed before a transaction is submitted on
 	// chain.
 	TransmitChecker *datatypes.JSON
+
+	// PrivateSubmission is set when the attempts of the transaction are sent
+	// to the private relay of the chain instead of the public mempool, see
+	// PrivateRelay
+	PrivateSubmission bool
+	// PrivateRelayFailures counts the consecutive failures of the private
+	// relay to accept the transaction
+	PrivateRelayFailures int
+
+	// InclusionDeadlineBlocks and InclusionDeadlineAt are the deadline for the
+	// transaction to be included by, see InclusionDeadline
+	InclusionDeadlineBlocks cnull.Uint32
+	InclusionDeadlineAt     *time.Time
+
+	// CancelledAt is set when the transaction is cancelled. From then on, its
+	// nonce is replaced by attempts sending zero ether to FromAddress.
+	CancelledAt *time.Time
+
+	// BatchForwarderAddress is set if the transaction should be sent through
+	// the batching forwarder at this address, see BatchingStrategy
+	BatchForwarderAddress *common.Address
+	// BatchEthTxID is the ID of the eth_tx sending the batch this transaction
+	// is part of, and BatchIndex the position of its call in that batch. The
+	// transaction itself remains unstarted.
+	BatchEthTxID null.Int
+	BatchIndex   null.Int
+}
+
+// IsBatched returns true if the transaction was added to a batch
+func (e EthTx) IsBatched() bool {
+	return e.BatchEthTxID.Valid
 }
 
 func (e EthTx) GetError() error {
@@ -201,6 +287,11 @@ func (e EthTx) GetError() error {
 	return nil
 }
 
+// IsCancelled returns true if the transaction was cancelled
+func (e EthTx) IsCancelled() bool {
+	return e.CancelledAt != nil
+}
+
 // GetID allows EthTx to be used as jsonapi.MarshalIdentifier
 func (e EthTx) GetID() string {
 	return fmt.Sprintf("%d", e.ID)
@@ -318,6 +409,20 @@ func (a EthTxAttempt) DynamicFee() gas.DynamicFee {
 	}
 }
 
+// Fee returns the fee paid by the attempt
+func (a EthTxAttempt) Fee() AttemptFee {
+	if a.TxType == DynamicFeeTxType {
+		return AttemptFee{DynamicFee: a.DynamicFee()}
+	}
+	return AttemptFee{GasPrice: a.GasPrice}
+}
+
+func (a *EthTxAttempt) setFee(fee AttemptFee) {
+	a.GasPrice = fee.GasPrice
+	a.GasTipCap = fee.DynamicFee.TipCap
+	a.GasFeeCap = fee.DynamicFee.FeeCap
+}
+
 func (a EthTxAttempt) GetBroadcastBeforeBlockNum() *int64 {
 	return a.BroadcastBeforeBlockNum
 }
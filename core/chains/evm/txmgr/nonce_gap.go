@@ -0,0 +1,282 @@
+package txmgr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+	"go.uber.org/multierr"
+
+	evmclient "github.com/smartcontractkit/chainlink/core/chains/evm/client"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
+	evmtypes "github.com/smartcontractkit/chainlink/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pg"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// maxNonceGapFills is the most empty transactions sent to fill the gaps of a
+// key on each head
+const maxNonceGapFills = 10
+
+// NonceGap is how the nonce of a sending key on chain diverged from the nonce
+// that the node tracks for it
+type NonceGap struct {
+	Address common.Address
+	// MinedNonce is the nonce of the key at the latest block
+	MinedNonce uint64
+	// ChainNonce is the nonce of the key including the pending transactions
+	ChainNonce uint64
+	// LocalNonce is the next nonce the node assigns to a transaction of the key
+	LocalNonce uint64
+	// Missing holds the nonces between ChainNonce and LocalNonce that no
+	// transaction of the node uses. They will never be mined, and block all
+	// the transactions with higher nonces.
+	Missing []uint64
+}
+
+// ChainAhead reports whether the key sent transactions that the node does not
+// know about, either from an external wallet or before its database was
+// restored from a backup. Transactions with the nonces of the node would fail.
+func (g NonceGap) ChainAhead() bool {
+	return g.ChainNonce > g.LocalNonce
+}
+
+// Empty reports whether the nonces of the key are in sync
+func (g NonceGap) Empty() bool {
+	return !g.ChainAhead() && len(g.Missing) == 0
+}
+
+func (g NonceGap) String() string {
+	if g.ChainAhead() {
+		return fmt.Sprintf("on-chain nonce %d is ahead of local nonce %d", g.ChainNonce, g.LocalNonce)
+	}
+	return fmt.Sprintf("nonces %v are missing between on-chain nonce %d and local nonce %d", g.Missing, g.ChainNonce, g.LocalNonce)
+}
+
+// NonceGapDetector compares the nonces of the sending keys on chain with the
+// database on every head. Keys with a gap are reported as degraded until it
+// is closed, which it can do itself:
+//
+//   - Missing nonces are filled with empty transactions, so that the
+//     transactions with higher nonces can be mined.
+//   - When the chain is ahead, the next nonce of the key is fast-forwarded to
+//     the on-chain nonce while the EthBroadcaster and EthConfirmer are
+//     stopped, so that unstarted transactions are assigned the nonces that
+//     follow it.
+type NonceGapDetector struct {
+	q         pg.Q
+	ethClient evmclient.Client
+	chainID   big.Int
+	config    Config
+	keyStore  KeyStore
+	ks        ChainKeyStore
+	estimator gas.Estimator
+	syncer    *NonceSyncer
+	resync    func(ctx context.Context, address common.Address) error
+	lggr      logger.Logger
+
+	mb     *utils.Mailbox[*evmtypes.Head]
+	gapsMu sync.RWMutex
+	gaps   map[common.Address]NonceGap
+	chStop chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewNonceGapDetector returns a NonceGapDetector for the keys of the chain of
+// ethClient. resync is called to fast-forward the nonce of a key when the
+// chain is ahead, if Resync is enabled.
+func NewNonceGapDetector(db *sqlx.DB, ethClient evmclient.Client, config Config, keyStore KeyStore, estimator gas.Estimator, lggr logger.Logger, resync func(ctx context.Context, address common.Address) error) *NonceGapDetector {
+	lggr = lggr.Named("NonceGapDetector")
+	return &NonceGapDetector{
+		q:         pg.NewQ(db, lggr, config),
+		ethClient: ethClient,
+		chainID:   *ethClient.ChainID(),
+		config:    config,
+		keyStore:  keyStore,
+		ks:        NewChainKeyStore(*ethClient.ChainID(), config, keyStore),
+		estimator: estimator,
+		syncer:    NewNonceSyncer(db, lggr, config, ethClient, keyStore),
+		resync:    resync,
+		lggr:      lggr,
+		mb:        utils.NewSingleMailbox[*evmtypes.Head](),
+		gaps:      make(map[common.Address]NonceGap),
+		chStop:    make(chan struct{}),
+	}
+}
+
+// Start the detector. Should only be called once.
+func (d *NonceGapDetector) Start() {
+	d.lggr.Debugw("NonceGapDetector: started", "fill", d.config.EvmNonceGapsFill(), "resync", d.config.EvmNonceGapsResync())
+	d.wg.Add(1)
+	go d.runLoop()
+}
+
+// Stop the detector. Should only be called once.
+func (d *NonceGapDetector) Stop() {
+	d.lggr.Debug("NonceGapDetector: stopping")
+	close(d.chStop)
+	d.wg.Wait()
+}
+
+// OnNewHead schedules a check of all the keys
+func (d *NonceGapDetector) OnNewHead(head *evmtypes.Head) {
+	d.mb.Deliver(head)
+}
+
+func (d *NonceGapDetector) runLoop() {
+	defer d.wg.Done()
+	ctx, cancel := utils.ContextFromChan(d.chStop)
+	defer cancel()
+	for {
+		select {
+		case <-d.chStop:
+			return
+		case <-d.mb.Notify():
+			if _, exists := d.mb.Retrieve(); !exists {
+				continue
+			}
+			if err := d.CheckAll(ctx); err != nil {
+				d.lggr.Errorw("NonceGapDetector: failed to check nonces", "err", err)
+			}
+		}
+	}
+}
+
+// CheckAll checks the nonces of all the enabled keys of the chain, and closes
+// the gaps it finds if remediation is enabled
+func (d *NonceGapDetector) CheckAll(ctx context.Context) (merr error) {
+	keyStates, err := d.keyStore.GetStatesForChain(&d.chainID)
+	if err != nil {
+		return errors.Wrap(err, "failed to load key states")
+	}
+	checked := make(map[common.Address]struct{})
+	for _, state := range keyStates {
+		if state.Disabled {
+			continue
+		}
+		address := state.Address.Address()
+		checked[address] = struct{}{}
+		gap, err := d.Check(ctx, address)
+		if err != nil {
+			merr = multierr.Combine(merr, errors.Wrapf(err, "failed to check nonce of key %s", address.Hex()))
+			continue
+		}
+		d.setGap(address, gap)
+		if gap.Empty() {
+			continue
+		}
+		d.lggr.Criticalw(fmt.Sprintf("Nonce gap detected for key %s: %s", address.Hex(), gap), "address", address.Hex(),
+			"minedNonce", gap.MinedNonce, "chainNonce", gap.ChainNonce, "localNonce", gap.LocalNonce, "missingNonces", gap.Missing)
+		merr = multierr.Combine(merr, d.remediate(ctx, gap))
+	}
+	d.gapsMu.Lock()
+	defer d.gapsMu.Unlock()
+	for address := range d.gaps {
+		if _, ok := checked[address]; !ok {
+			delete(d.gaps, address)
+		}
+	}
+	return
+}
+
+// Check compares the nonce of address on chain with the database
+func (d *NonceGapDetector) Check(ctx context.Context, address common.Address) (gap NonceGap, err error) {
+	gap.Address = address
+	if gap.MinedNonce, err = d.ethClient.NonceAt(ctx, address, nil); err != nil {
+		return gap, errors.Wrap(err, "failed to fetch mined nonce")
+	}
+	if gap.ChainNonce, err = d.ethClient.PendingNonceAt(ctx, address); err != nil {
+		return gap, errors.Wrap(err, "failed to fetch pending nonce")
+	}
+	if gap.ChainNonce < gap.MinedNonce {
+		// Some nodes do not track pending transactions
+		gap.ChainNonce = gap.MinedNonce
+	}
+
+	q := d.q.WithOpts(pg.WithParentCtx(ctx))
+	nextNonce, err := d.keyStore.GetNextNonce(address, &d.chainID, pg.WithParentCtx(ctx))
+	if err != nil {
+		return gap, err
+	}
+	gap.LocalNonce = uint64(nextNonce)
+	inProgress, err := d.syncer.hasInProgressTransaction(q, address)
+	if err != nil {
+		return gap, err
+	} else if inProgress {
+		// The in_progress transaction holds next_nonce
+		gap.LocalNonce++
+	}
+	if gap.ChainNonce >= gap.LocalNonce {
+		return gap, nil
+	}
+
+	err = q.Select(&gap.Missing, `
+SELECT n FROM generate_series($1::bigint, $2::bigint - 1) AS n
+WHERE NOT EXISTS (SELECT 1 FROM eth_txes WHERE evm_chain_id = $3 AND from_address = $4 AND nonce = n)
+ORDER BY n LIMIT $5`, gap.ChainNonce, gap.LocalNonce, d.chainID.String(), address, maxNonceGapFills)
+	return gap, errors.Wrap(err, "failed to load missing nonces")
+}
+
+func (d *NonceGapDetector) remediate(ctx context.Context, gap NonceGap) error {
+	if gap.ChainAhead() {
+		if !d.config.EvmNonceGapsResync() {
+			return nil
+		}
+		d.lggr.Warnw(fmt.Sprintf("Resyncing nonce of key %s with on-chain nonce %d", gap.Address.Hex(), gap.ChainNonce), "address", gap.Address.Hex(), "localNonce", gap.LocalNonce, "chainNonce", gap.ChainNonce)
+		return errors.Wrapf(d.resync(ctx, gap.Address), "failed to resync nonce of key %s", gap.Address.Hex())
+	}
+	if !d.config.EvmNonceGapsFill() {
+		return nil
+	}
+	return d.fill(ctx, gap)
+}
+
+// fill sends an empty transaction for each missing nonce of gap
+func (d *NonceGapDetector) fill(ctx context.Context, gap NonceGap) error {
+	gasLimit := d.config.EvmGasLimitDefault()
+	gasPrice, _, err := d.estimator.GetLegacyGas(ctx, []byte{}, gasLimit, d.config.KeySpecificMaxGasPriceWei(gap.Address))
+	if err != nil {
+		return errors.Wrap(err, "failed to estimate gas price of empty transactions")
+	}
+	for _, nonce := range gap.Missing {
+		tx, err := sendEmptyTransaction(ctx, d.ethClient, d.ks.signer(gap.Address), nonce, gasLimit, gasPrice.ToInt(), gap.Address, &d.chainID)
+		if err != nil {
+			d.lggr.Warnw("NonceGapDetector: failed to fill nonce gap with empty transaction", "address", gap.Address.Hex(), "nonce", nonce, "err", err)
+			continue
+		}
+		d.lggr.Infow("NonceGapDetector: filled nonce gap with empty transaction", "address", gap.Address.Hex(), "nonce", nonce, "txHash", tx.Hash(), "gasPrice", gasPrice)
+	}
+	return nil
+}
+
+func (d *NonceGapDetector) setGap(address common.Address, gap NonceGap) {
+	d.gapsMu.Lock()
+	defer d.gapsMu.Unlock()
+	if gap.Empty() {
+		delete(d.gaps, address)
+		return
+	}
+	d.gaps[address] = gap
+}
+
+// Healthy returns an error for each key with a nonce gap
+func (d *NonceGapDetector) Healthy() (merr error) {
+	d.gapsMu.RLock()
+	defer d.gapsMu.RUnlock()
+	addresses := make([]common.Address, 0, len(d.gaps))
+	for address := range d.gaps {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool { return bytes.Compare(addresses[i][:], addresses[j][:]) < 0 })
+	for _, address := range addresses {
+		merr = multierr.Append(merr, errors.Errorf("key %s is degraded: %s", address.Hex(), d.gaps[address]))
+	}
+	return
+}
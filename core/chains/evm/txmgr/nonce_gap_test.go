@@ -0,0 +1,127 @@
+package txmgr_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	gasmocks "github.com/smartcontractkit/chainlink/core/chains/evm/gas/mocks"
+	evmmocks "github.com/smartcontractkit/chainlink/core/chains/evm/mocks"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/evmtest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+)
+
+func TestNonceGapDetector(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, fill, resync bool) (*txmgr.NonceGapDetector, *evmmocks.Client, *gasmocks.Estimator, common.Address, *[]common.Address) {
+		db := pgtest.NewSqlxDB(t)
+		cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+			c.EVM[0].Transactions.NonceGaps.Enabled = ptr(true)
+			c.EVM[0].Transactions.NonceGaps.Fill = ptr(fill)
+			c.EVM[0].Transactions.NonceGaps.Resync = ptr(resync)
+		})
+		borm := cltest.NewTxmORM(t, db, cfg)
+		ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+		_, fromAddress := cltest.MustInsertRandomKey(t, ethKeyStore, 4)
+		ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+		estimator := gasmocks.NewEstimator(t)
+		evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+
+		var resyncs []common.Address
+		d := txmgr.NewNonceGapDetector(db, ethClient, evmcfg, ethKeyStore, estimator, logger.TestLogger(t), func(ctx context.Context, address common.Address) error {
+			resyncs = append(resyncs, address)
+			return nil
+		})
+
+		// Nonce 2 was never sent
+		cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, borm, 0, 42, fromAddress)
+		cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 1, fromAddress)
+		cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 3, fromAddress)
+		return d, ethClient, estimator, fromAddress, &resyncs
+	}
+
+	t.Run("reports the nonces missing between the chain and the database", func(t *testing.T) {
+		d, ethClient, _, fromAddress, _ := setup(t, false, false)
+		ethClient.On("NonceAt", mock.Anything, fromAddress, (*big.Int)(nil)).Return(uint64(1), nil)
+		ethClient.On("PendingNonceAt", mock.Anything, fromAddress).Return(uint64(2), nil)
+
+		gap, err := d.Check(testutils.Context(t), fromAddress)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), gap.MinedNonce)
+		assert.Equal(t, uint64(2), gap.ChainNonce)
+		assert.Equal(t, uint64(4), gap.LocalNonce)
+		assert.Equal(t, []uint64{2}, gap.Missing)
+		assert.False(t, gap.ChainAhead())
+		assert.False(t, gap.Empty())
+	})
+
+	t.Run("reports nothing when the nonces are in sync", func(t *testing.T) {
+		d, ethClient, _, fromAddress, _ := setup(t, false, false)
+		ethClient.On("NonceAt", mock.Anything, fromAddress, (*big.Int)(nil)).Return(uint64(1), nil)
+		ethClient.On("PendingNonceAt", mock.Anything, fromAddress).Return(uint64(4), nil)
+
+		gap, err := d.Check(testutils.Context(t), fromAddress)
+		require.NoError(t, err)
+		assert.True(t, gap.Empty())
+
+		require.NoError(t, d.CheckAll(testutils.Context(t)))
+		assert.NoError(t, d.Healthy())
+	})
+
+	t.Run("reports the key as degraded and fills the gap with empty transactions", func(t *testing.T) {
+		d, ethClient, estimator, fromAddress, _ := setup(t, true, false)
+		ethClient.On("NonceAt", mock.Anything, fromAddress, (*big.Int)(nil)).Return(uint64(1), nil)
+		ethClient.On("PendingNonceAt", mock.Anything, fromAddress).Return(uint64(2), nil).Once()
+		estimator.On("GetLegacyGas", mock.Anything, []byte{}, uint32(500000), mock.Anything).Return(assets.GWei(30), uint32(500000), nil).Once()
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+			return tx.Nonce() == 2 && *tx.To() == fromAddress && tx.Value().Sign() == 0 && tx.GasPrice().Cmp(assets.GWei(30).ToInt()) == 0
+		})).Return(nil).Once()
+
+		require.NoError(t, d.CheckAll(testutils.Context(t)))
+		err := d.Healthy()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "key "+fromAddress.Hex()+" is degraded: nonces [2] are missing between on-chain nonce 2 and local nonce 4")
+
+		// Once the empty transaction is in the mempool, the key is healthy again
+		ethClient.On("PendingNonceAt", mock.Anything, fromAddress).Return(uint64(4), nil).Once()
+		require.NoError(t, d.CheckAll(testutils.Context(t)))
+		assert.NoError(t, d.Healthy())
+	})
+
+	t.Run("resyncs the nonce of the key when the chain is ahead", func(t *testing.T) {
+		d, ethClient, _, fromAddress, resyncs := setup(t, true, true)
+		ethClient.On("NonceAt", mock.Anything, fromAddress, (*big.Int)(nil)).Return(uint64(9), nil)
+		ethClient.On("PendingNonceAt", mock.Anything, fromAddress).Return(uint64(10), nil)
+
+		require.NoError(t, d.CheckAll(testutils.Context(t)))
+		assert.Equal(t, []common.Address{fromAddress}, *resyncs)
+
+		err := d.Healthy()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "key "+fromAddress.Hex()+" is degraded: on-chain nonce 10 is ahead of local nonce 4")
+	})
+
+	t.Run("does not remediate unless enabled", func(t *testing.T) {
+		d, ethClient, _, fromAddress, resyncs := setup(t, false, false)
+		ethClient.On("NonceAt", mock.Anything, fromAddress, (*big.Int)(nil)).Return(uint64(9), nil)
+		ethClient.On("PendingNonceAt", mock.Anything, fromAddress).Return(uint64(10), nil)
+
+		require.NoError(t, d.CheckAll(testutils.Context(t)))
+		assert.Empty(t, *resyncs)
+		assert.Error(t, d.Healthy())
+	})
+}
@@ -1,6 +1,7 @@
 package txmgr
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -25,6 +26,8 @@ type ORM interface {
 	InsertEthTx(etx *EthTx) error
 	InsertEthReceipt(receipt *EthReceipt) error
 	FindEthTxWithAttempts(etxID int64) (etx EthTx, err error)
+	FindJobGasSpends(jobID int32) ([]GasSpend, error)
+	FindKeyGasSpend(address common.Address, chainID big.Int) (GasSpend, error)
 }
 
 type orm struct {
@@ -172,6 +175,20 @@ func (o *orm) FindEthTxAttemptsByEthTxIDs(ids []int64) ([]EthTxAttempt, error) {
 	return attempts, nil
 }
 
+// FindKeyGasSpend returns what address spent on gas on the chain over the
+// last hour and day
+func (o *orm) FindKeyGasSpend(address common.Address, chainID big.Int) (spend GasSpend, err error) {
+	spend, err = KeyGasSpend(o.q, address, chainID)
+	return spend, errors.Wrap(err, "FindKeyGasSpend failed")
+}
+
+// FindJobGasSpends returns what the job spent on gas over the last hour and
+// day, on each chain it sent transactions on
+func (o *orm) FindJobGasSpends(jobID int32) (spends []GasSpend, err error) {
+	spends, err = JobGasSpends(o.q, jobID)
+	return spends, errors.Wrap(err, "FindJobGasSpends failed")
+}
+
 func (o *orm) FindEthTxByHash(hash common.Hash) (*EthTx, error) {
 	var etx EthTx
 
@@ -192,8 +209,8 @@ func (o *orm) InsertEthTx(etx *EthTx) error {
 	if etx.CreatedAt == (time.Time{}) {
 		etx.CreatedAt = time.Now()
 	}
-	const insertEthTxSQL = `INSERT INTO eth_txes (nonce, from_address, to_address, encoded_payload, value, gas_limit, error, broadcast_at, initial_broadcast_at, created_at, state, meta, subject, pipeline_task_run_id, min_confirmations, evm_chain_id, access_list, transmit_checker) VALUES (
-:nonce, :from_address, :to_address, :encoded_payload, :value, :gas_limit, :error, :broadcast_at, :initial_broadcast_at, :created_at, :state, :meta, :subject, :pipeline_task_run_id, :min_confirmations, :evm_chain_id, :access_list, :transmit_checker
+	const insertEthTxSQL = `INSERT INTO eth_txes (nonce, from_address, to_address, encoded_payload, value, gas_limit, error, broadcast_at, initial_broadcast_at, created_at, state, meta, subject, pipeline_task_run_id, min_confirmations, evm_chain_id, access_list, transmit_checker, tx_type, private_submission, inclusion_deadline_blocks, inclusion_deadline_at) VALUES (
+:nonce, :from_address, :to_address, :encoded_payload, :value, :gas_limit, :error, :broadcast_at, :initial_broadcast_at, :created_at, :state, :meta, :subject, :pipeline_task_run_id, :min_confirmations, :evm_chain_id, :access_list, :transmit_checker, :tx_type, :private_submission, :inclusion_deadline_blocks, :inclusion_deadline_at
 ) RETURNING *`
 	err := o.q.GetNamed(insertEthTxSQL, etx, etx)
 	return errors.Wrap(err, "InsertEthTx failed")
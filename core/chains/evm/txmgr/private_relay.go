@@ -0,0 +1,204 @@
+package txmgr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	evmclient "github.com/smartcontractkit/chainlink/core/chains/evm/client"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pg"
+)
+
+// PrivateRelayTimeout is how long a private relay has to accept a transaction
+const PrivateRelayTimeout = 10 * time.Second
+
+// PrivateRelayMaxFailures is how many times in a row a private relay may fail
+// to accept a transaction before it is broadcast publicly instead
+const PrivateRelayMaxFailures = 3
+
+const (
+	// PrivateRelayMethodSendPrivateTransaction submits a transaction that the
+	// relay keeps sending to block builders until it is mined, or its max
+	// block number is reached
+	PrivateRelayMethodSendPrivateTransaction = "eth_sendPrivateTransaction"
+	// PrivateRelayMethodSendBundle submits a bundle holding a single
+	// transaction, which is only valid for the next block
+	PrivateRelayMethodSendBundle = "eth_sendBundle"
+)
+
+// PrivateRelaySignatureHeader is the header holding the signature of the
+// body of the requests sent to a private relay, which is how Flashbots-style
+// relays authenticate their senders
+const PrivateRelaySignatureHeader = "X-Flashbots-Signature"
+
+// PrivateRelay sends signed transactions to a Flashbots-style relay, which
+// passes them on to block builders without gossiping them in the public
+// mempool, so that they cannot be front-run.
+type PrivateRelay struct {
+	url            url.URL
+	method         string
+	fallbackBlocks uint32
+}
+
+// NewPrivateRelay returns a PrivateRelay for the JSON-RPC server at u.
+// Transactions sent with eth_sendPrivateTransaction expire fallbackBlocks
+// after being sent, or use the default of the relay if it is 0.
+func NewPrivateRelay(u url.URL, method string, fallbackBlocks uint32) *PrivateRelay {
+	return &PrivateRelay{url: u, method: method, fallbackBlocks: fallbackBlocks}
+}
+
+// signingTransport sets the signature header of the requests to a private
+// relay: the address of the sender and its signature of the hash of the body,
+// as a hex string
+type signingTransport struct {
+	fromAddress common.Address
+	signer      Signer
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read request body")
+		}
+	}
+	sig, err := t.signer.SignMessage(t.fromAddress, []byte(crypto.Keccak256Hash(body).Hex()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign request")
+	}
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.Header.Set(PrivateRelaySignatureHeader, fmt.Sprintf("%s:%s", t.fromAddress.Hex(), hexutil.Encode(sig)))
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+type sendPrivateTransactionArgs struct {
+	Tx             hexutil.Bytes   `json:"tx"`
+	MaxBlockNumber *hexutil.Uint64 `json:"maxBlockNumber,omitempty"`
+}
+
+type sendBundleArgs struct {
+	Txs         []hexutil.Bytes `json:"txs"`
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+}
+
+// SendTransaction sends tx to the relay, headNumber being the latest head. The
+// request is signed with signer by fromAddress, the sender of tx.
+func (r *PrivateRelay) SendTransaction(ctx context.Context, tx *gethTypes.Transaction, headNumber int64, fromAddress common.Address, signer Signer) error {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to encode transaction")
+	}
+
+	var args interface{}
+	if r.method == PrivateRelayMethodSendBundle {
+		args = sendBundleArgs{Txs: []hexutil.Bytes{raw}, BlockNumber: hexutil.Uint64(headNumber + 1)}
+	} else {
+		a := sendPrivateTransactionArgs{Tx: raw}
+		if r.fallbackBlocks > 0 {
+			max := hexutil.Uint64(headNumber + int64(r.fallbackBlocks))
+			a.MaxBlockNumber = &max
+		}
+		args = a
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, PrivateRelayTimeout)
+	defer cancel()
+
+	httpClient := &http.Client{Timeout: PrivateRelayTimeout, Transport: &signingTransport{fromAddress: fromAddress, signer: signer}}
+	client, err := rpc.DialHTTPWithClient(r.url.String(), httpClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to dial private relay")
+	}
+	defer client.Close()
+
+	var result json.RawMessage
+	err = client.CallContext(ctx, &result, r.method, args)
+	return errors.Wrapf(err, "private relay failed to accept transaction %s", tx.Hash())
+}
+
+// privateRelay returns the relay that the attempts of etx are sent to, or nil
+// if they are broadcast publicly
+func privateRelay(cfg Config, etx EthTx) *PrivateRelay {
+	if !etx.PrivateSubmission {
+		return nil
+	}
+	u := cfg.EvmPrivateRelayURL()
+	if u == nil {
+		return nil
+	}
+	return NewPrivateRelay(*u, cfg.EvmPrivateRelayMethod(), cfg.EvmPrivateRelayFallbackBlocks())
+}
+
+// broadcastTransaction sends the attempt to the private relay of the chain if
+// its transaction is private, or to the public mempool otherwise. headNumber
+// is the latest head, or 0 if it must be fetched.
+//
+// An attempt that the relay does not accept is not sent, and relayErr is
+// returned so that the caller submits it again later, without revealing it in
+// the public mempool. Once the relay failed PrivateRelayMaxFailures times in a
+// row, the transaction is broadcast publicly from then on.
+func broadcastTransaction(ctx context.Context, q pg.Queryer, ethClient evmclient.Client, cfg Config, signer Signer, a EthTxAttempt, e EthTx, headNumber int64, lggr logger.Logger) (sendErr *evmclient.SendError, relayErr error) {
+	relay := privateRelay(cfg, e)
+	if relay == nil {
+		return sendTransaction(ctx, ethClient, a, e, lggr), nil
+	}
+	relayErr = sendPrivateTransaction(ctx, ethClient, relay, signer, a, e.FromAddress, headNumber)
+	if relayErr == nil {
+		lggr.Debugw("Sent transaction to private relay", "ethTxAttemptID", a.ID, "txHash", a.Hash, "meta", e.Meta, "gasLimit", e.GasLimit)
+		if e.PrivateRelayFailures > 0 {
+			_, err := q.ExecContext(ctx, `UPDATE eth_txes SET private_relay_failures = 0 WHERE id = $1`, e.ID)
+			return nil, errors.Wrap(err, "failed to reset private relay failures")
+		}
+		return nil, nil
+	}
+
+	var failures int
+	err := q.GetContext(ctx, &failures, `UPDATE eth_txes SET private_relay_failures = private_relay_failures + 1, private_submission = private_relay_failures + 1 < $2
+WHERE id = $1 RETURNING private_relay_failures`, e.ID, PrivateRelayMaxFailures)
+	if err != nil {
+		return nil, multierr.Combine(relayErr, errors.Wrap(err, "failed to count private relay failure"))
+	}
+	if failures < PrivateRelayMaxFailures {
+		lggr.Warnw("Private relay did not accept transaction, will submit it again", "ethTxAttemptID", a.ID, "txHash", a.Hash, "failures", failures, "err", relayErr)
+		return nil, relayErr
+	}
+	lggr.Warnw("Private relay repeatedly failed to accept transaction, broadcasting it publicly", "ethTxAttemptID", a.ID, "txHash", a.Hash, "failures", failures, "err", relayErr)
+	e.PrivateSubmission = false
+	return sendTransaction(ctx, ethClient, a, e, lggr), nil
+}
+
+func sendPrivateTransaction(ctx context.Context, ethClient evmclient.Client, relay *PrivateRelay, signer Signer, a EthTxAttempt, fromAddress common.Address, headNumber int64) error {
+	signedTx, err := a.GetSignedTx()
+	if err != nil {
+		return err
+	}
+	if headNumber == 0 {
+		head, err := ethClient.HeadByNumber(ctx, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch latest head")
+		}
+		if head == nil {
+			return errors.New("failed to fetch latest head: got nil head")
+		}
+		headNumber = head.Number
+	}
+	return relay.SendTransaction(ctx, signedTx, headNumber, fromAddress, signer)
+}
@@ -0,0 +1,222 @@
+package txmgr_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+type sendPrivateTransactionArgs struct {
+	Tx             hexutil.Bytes   `json:"tx"`
+	MaxBlockNumber *hexutil.Uint64 `json:"maxBlockNumber"`
+}
+
+type sendBundleArgs struct {
+	Txs         []hexutil.Bytes `json:"txs"`
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+}
+
+// relayStub is a private relay serving eth_sendPrivateTransaction and
+// eth_sendBundle, which records the transactions it receives and the
+// signature headers of the requests
+type relayStub struct {
+	mu         sync.Mutex
+	err        error
+	privateTxs []sendPrivateTransactionArgs
+	bundles    []sendBundleArgs
+	signatures []relaySignature
+}
+
+type relaySignature struct {
+	body   []byte
+	header string
+}
+
+func (r *relayStub) SendPrivateTransaction(args sendPrivateTransactionArgs) (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.privateTxs = append(r.privateTxs, args)
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(args.Tx); err != nil {
+		return nil, err
+	}
+	return tx.Hash(), nil
+}
+
+func (r *relayStub) SendBundle(args sendBundleArgs) (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.bundles = append(r.bundles, args)
+	return map[string]interface{}{"bundleHash": utils.NewHash()}, nil
+}
+
+func (r *relayStub) PrivateTxs() []sendPrivateTransactionArgs {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.privateTxs
+}
+
+func (r *relayStub) Bundles() []sendBundleArgs {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.bundles
+}
+
+func (r *relayStub) Signatures() []relaySignature {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.signatures
+}
+
+func setRelayStubErr(r *relayStub, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+}
+
+func newRelayStubServer(t *testing.T, stub *relayStub) *httptest.Server {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", stub))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		stub.mu.Lock()
+		stub.signatures = append(stub.signatures, relaySignature{body: body, header: req.Header.Get(txmgr.PrivateRelaySignatureHeader)})
+		stub.mu.Unlock()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		server.ServeHTTP(w, req)
+	}))
+	t.Cleanup(ts.Close)
+	t.Cleanup(server.Stop)
+	return ts
+}
+
+// keySigner signs messages with key, like the keystore
+type keySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *keySigner) SignMessage(address common.Address, message []byte) ([]byte, error) {
+	sig, err := crypto.Sign(accounts.TextHash(message), s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+func (s *keySigner) SignTx(fromAddress common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+func TestPrivateRelay_SendTransaction(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	signer := &keySigner{key: key}
+	to := testutils.NewAddress()
+	tx := types.NewTx(&types.LegacyTx{Nonce: 3, GasPrice: big.NewInt(100), Gas: 21000, To: &to, Value: big.NewInt(42)})
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	t.Run("sends private transactions expiring after the fallback blocks", func(t *testing.T) {
+		stub := &relayStub{}
+		ts := newRelayStubServer(t, stub)
+		relay := txmgr.NewPrivateRelay(*models.MustParseURL(ts.URL).URL(), txmgr.PrivateRelayMethodSendPrivateTransaction, 25)
+
+		require.NoError(t, relay.SendTransaction(testutils.Context(t), tx, 100, from, signer))
+
+		privateTxs := stub.PrivateTxs()
+		require.Len(t, privateTxs, 1)
+		assert.Equal(t, hexutil.Bytes(raw), privateTxs[0].Tx)
+		require.NotNil(t, privateTxs[0].MaxBlockNumber)
+		assert.Equal(t, hexutil.Uint64(125), *privateTxs[0].MaxBlockNumber)
+		assert.Empty(t, stub.Bundles())
+	})
+
+	t.Run("leaves the expiry of private transactions to the relay without fallback", func(t *testing.T) {
+		stub := &relayStub{}
+		ts := newRelayStubServer(t, stub)
+		relay := txmgr.NewPrivateRelay(*models.MustParseURL(ts.URL).URL(), txmgr.PrivateRelayMethodSendPrivateTransaction, 0)
+
+		require.NoError(t, relay.SendTransaction(testutils.Context(t), tx, 100, from, signer))
+
+		privateTxs := stub.PrivateTxs()
+		require.Len(t, privateTxs, 1)
+		assert.Nil(t, privateTxs[0].MaxBlockNumber)
+	})
+
+	t.Run("sends bundles for the next block", func(t *testing.T) {
+		stub := &relayStub{}
+		ts := newRelayStubServer(t, stub)
+		relay := txmgr.NewPrivateRelay(*models.MustParseURL(ts.URL).URL(), txmgr.PrivateRelayMethodSendBundle, 25)
+
+		require.NoError(t, relay.SendTransaction(testutils.Context(t), tx, 100, from, signer))
+
+		bundles := stub.Bundles()
+		require.Len(t, bundles, 1)
+		assert.Equal(t, []hexutil.Bytes{raw}, bundles[0].Txs)
+		assert.Equal(t, hexutil.Uint64(101), bundles[0].BlockNumber)
+		assert.Empty(t, stub.PrivateTxs())
+	})
+
+	t.Run("signs requests with the sender", func(t *testing.T) {
+		stub := &relayStub{}
+		ts := newRelayStubServer(t, stub)
+		relay := txmgr.NewPrivateRelay(*models.MustParseURL(ts.URL).URL(), txmgr.PrivateRelayMethodSendPrivateTransaction, 25)
+
+		require.NoError(t, relay.SendTransaction(testutils.Context(t), tx, 100, from, signer))
+
+		signatures := stub.Signatures()
+		require.Len(t, signatures, 1)
+		address, sigHex, found := strings.Cut(signatures[0].header, ":")
+		require.True(t, found)
+		assert.Equal(t, from.Hex(), address)
+		sig, err := hexutil.Decode(sigHex)
+		require.NoError(t, err)
+		require.Len(t, sig, crypto.SignatureLength)
+		sig[crypto.RecoveryIDOffset] -= 27
+		pub, err := crypto.SigToPub(accounts.TextHash([]byte(crypto.Keccak256Hash(signatures[0].body).Hex())), sig)
+		require.NoError(t, err)
+		assert.Equal(t, from, crypto.PubkeyToAddress(*pub))
+	})
+
+	t.Run("returns relay errors", func(t *testing.T) {
+		ts := newRelayStubServer(t, &relayStub{err: errors.New("bundle rejected")})
+		relay := txmgr.NewPrivateRelay(*models.MustParseURL(ts.URL).URL(), txmgr.PrivateRelayMethodSendBundle, 25)
+
+		err := relay.SendTransaction(testutils.Context(t), tx, 100, from, signer)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "private relay failed to accept transaction "+tx.Hash().Hex())
+		assert.Contains(t, err.Error(), "bundle rejected")
+	})
+}
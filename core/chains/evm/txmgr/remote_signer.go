@@ -0,0 +1,186 @@
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// RemoteSignerTimeout is how long a remote signer has to sign a transaction
+const RemoteSignerTimeout = 10 * time.Second
+
+// Signer signs the transactions and messages of a sending key. The keystore
+// signs with the keys it holds, while a RemoteSigner delegates to an external
+// service.
+type Signer interface {
+	// SignMessage signs message as an EIP-191 personal message, and returns
+	// the signature in the [R || S || V] format, V being 27 or 28
+	SignMessage(address common.Address, message []byte) ([]byte, error)
+	SignTx(fromAddress common.Address, tx *gethTypes.Transaction, chainID *big.Int) (*gethTypes.Transaction, error)
+}
+
+var _ Signer = (KeyStore)(nil)
+var _ Signer = (*RemoteSigner)(nil)
+
+// RemoteSigner signs transactions with the eth_signTransaction JSON-RPC
+// method of a remote signer, such as Web3Signer or a geth node backed by
+// Clef, so that the private key of the sending key does not have to be held
+// by the node.
+//
+// The signed transaction returned by the signer is only accepted if it is the
+// requested transaction, signed by the requested sender.
+type RemoteSigner struct {
+	url    url.URL
+	client *http.Client
+}
+
+// NewRemoteSigner returns a RemoteSigner for the JSON-RPC server at u
+func NewRemoteSigner(u url.URL) *RemoteSigner {
+	return &RemoteSigner{url: u, client: &http.Client{Timeout: RemoteSignerTimeout}}
+}
+
+// signTransactionArgs are the arguments of eth_signTransaction, as defined by
+// go-ethereum's TransactionArgs. The type of the transaction is inferred by
+// the signer from the fee fields and access list.
+type signTransactionArgs struct {
+	From                 common.Address        `json:"from"`
+	To                   *common.Address       `json:"to"`
+	Gas                  hexutil.Uint64        `json:"gas"`
+	GasPrice             *hexutil.Big          `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big          `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big          `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big          `json:"value"`
+	Nonce                hexutil.Uint64        `json:"nonce"`
+	Data                 hexutil.Bytes         `json:"data"`
+	AccessList           *gethTypes.AccessList `json:"accessList,omitempty"`
+	ChainID              *hexutil.Big          `json:"chainId"`
+}
+
+func newSignTransactionArgs(fromAddress common.Address, tx *gethTypes.Transaction, chainID *big.Int) signTransactionArgs {
+	args := signTransactionArgs{
+		From:    fromAddress,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == gethTypes.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+	if al := tx.AccessList(); len(al) > 0 {
+		args.AccessList = &al
+	}
+	return args
+}
+
+// SignTx asks the remote signer to sign tx on behalf of fromAddress
+func (s *RemoteSigner) SignTx(fromAddress common.Address, tx *gethTypes.Transaction, chainID *big.Int) (*gethTypes.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), RemoteSignerTimeout)
+	defer cancel()
+
+	client, err := rpc.DialHTTPWithClient(s.url.String(), s.client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to dial remote signer")
+	}
+	defer client.Close()
+
+	var result json.RawMessage
+	if err = client.CallContext(ctx, &result, "eth_signTransaction", newSignTransactionArgs(fromAddress, tx, chainID)); err != nil {
+		return nil, errors.Wrapf(err, "remote signer failed to sign transaction for %s", fromAddress)
+	}
+	raw, err := parseSignTransactionResult(result)
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx := new(gethTypes.Transaction)
+	if err = signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, errors.Wrap(err, "remote signer returned an invalid transaction")
+	}
+	signer := gethTypes.LatestSignerForChainID(chainID)
+	if signer.Hash(signedTx) != signer.Hash(tx) {
+		return nil, errors.Errorf("remote signer returned a different transaction than requested: got %s, expected %s", signer.Hash(signedTx), signer.Hash(tx))
+	}
+	sender, err := gethTypes.Sender(signer, signedTx)
+	if err != nil {
+		return nil, errors.Wrap(err, "remote signer returned a transaction with an invalid signature")
+	}
+	if sender != fromAddress {
+		return nil, errors.Errorf("remote signer signed the transaction with %s instead of %s", sender, fromAddress)
+	}
+	return signedTx, nil
+}
+
+// SignMessage asks the remote signer to sign message on behalf of address
+// with eth_sign
+func (s *RemoteSigner) SignMessage(address common.Address, message []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), RemoteSignerTimeout)
+	defer cancel()
+
+	client, err := rpc.DialHTTPWithClient(s.url.String(), s.client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to dial remote signer")
+	}
+	defer client.Close()
+
+	var sig hexutil.Bytes
+	if err = client.CallContext(ctx, &sig, "eth_sign", address, hexutil.Bytes(message)); err != nil {
+		return nil, errors.Wrapf(err, "remote signer failed to sign message for %s", address)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, errors.Errorf("remote signer returned a signature of %d bytes", len(sig))
+	}
+	recoverable := common.CopyBytes(sig)
+	if recoverable[crypto.RecoveryIDOffset] >= 27 {
+		recoverable[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(accounts.TextHash(message), recoverable)
+	if err != nil {
+		return nil, errors.Wrap(err, "remote signer returned an invalid signature")
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != address {
+		return nil, errors.Errorf("remote signer signed the message with %s instead of %s", signer, address)
+	}
+	recoverable[crypto.RecoveryIDOffset] += 27
+	return recoverable, nil
+}
+
+// parseSignTransactionResult extracts the raw signed transaction from the
+// result of eth_signTransaction. Web3Signer returns it as a hex string, while
+// go-ethereum returns an object holding it in "raw".
+func parseSignTransactionResult(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if strings.HasPrefix(strings.TrimSpace(string(result)), `"`) {
+		if err := json.Unmarshal(result, &raw); err != nil {
+			return nil, errors.Wrap(err, "failed to decode remote signer result")
+		}
+		return raw, nil
+	}
+	var obj struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &obj); err != nil {
+		return nil, errors.Wrap(err, "failed to decode remote signer result")
+	}
+	if len(obj.Raw) == 0 {
+		return nil, errors.New("remote signer returned no signed transaction")
+	}
+	return obj.Raw, nil
+}
@@ -0,0 +1,259 @@
+package txmgr_test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	evmcfg "github.com/smartcontractkit/chainlink/core/chains/evm/config/v2"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/evmtest"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+	ksmocks "github.com/smartcontractkit/chainlink/core/services/keystore/mocks"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+type signTransactionArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// signerStub is a remote signer serving eth_signTransaction and eth_sign with key. Like
+// geth, it returns an object with the raw transaction, unless hexResult is
+// set, in which case it returns the raw transaction like Web3Signer.
+type signerStub struct {
+	key       *ecdsa.PrivateKey
+	hexResult bool
+	err       error
+	tamper    func(*types.LegacyTx)
+}
+
+func (s *signerStub) SignTransaction(args signTransactionArgs) (interface{}, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	var tx *types.Transaction
+	if args.MaxFeePerGas != nil {
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   args.ChainID.ToInt(),
+			Nonce:     uint64(args.Nonce),
+			GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+			GasFeeCap: args.MaxFeePerGas.ToInt(),
+			Gas:       uint64(args.Gas),
+			To:        args.To,
+			Value:     args.Value.ToInt(),
+			Data:      args.Data,
+		})
+	} else {
+		legacy := &types.LegacyTx{
+			Nonce:    uint64(args.Nonce),
+			GasPrice: args.GasPrice.ToInt(),
+			Gas:      uint64(args.Gas),
+			To:       args.To,
+			Value:    args.Value.ToInt(),
+			Data:     args.Data,
+		}
+		if s.tamper != nil {
+			s.tamper(legacy)
+		}
+		tx = types.NewTx(legacy)
+	}
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(args.ChainID.ToInt()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if s.hexResult {
+		return hexutil.Bytes(raw), nil
+	}
+	return map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signed}, nil
+}
+
+func (s *signerStub) Sign(address common.Address, message hexutil.Bytes) (hexutil.Bytes, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	sig, err := crypto.Sign(accounts.TextHash(message), s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+func newSignerStubServer(t *testing.T, stub *signerStub) *httptest.Server {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", stub))
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	t.Cleanup(server.Stop)
+	return ts
+}
+
+func TestRemoteSigner_SignTx(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := testutils.NewAddress()
+	chainID := big.NewInt(1337)
+
+	legacyTx := types.NewTx(&types.LegacyTx{Nonce: 7, GasPrice: big.NewInt(100), Gas: 21000, To: &to, Value: big.NewInt(42), Data: []byte{1, 2, 3}})
+	dynamicFeeTx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 8, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(200), Gas: 21000, To: &to, Value: big.NewInt(42)})
+
+	t.Run("signs legacy transactions", func(t *testing.T) {
+		ts := newSignerStubServer(t, &signerStub{key: key})
+		signer := txmgr.NewRemoteSigner(*models.MustParseURL(ts.URL).URL())
+
+		signed, err := signer.SignTx(from, legacyTx, chainID)
+		require.NoError(t, err)
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		require.NoError(t, err)
+		assert.Equal(t, from, sender)
+		assert.Equal(t, legacyTx.Nonce(), signed.Nonce())
+		assert.Equal(t, legacyTx.Data(), signed.Data())
+	})
+
+	t.Run("signs dynamic fee transactions", func(t *testing.T) {
+		ts := newSignerStubServer(t, &signerStub{key: key, hexResult: true})
+		signer := txmgr.NewRemoteSigner(*models.MustParseURL(ts.URL).URL())
+
+		signed, err := signer.SignTx(from, dynamicFeeTx, chainID)
+		require.NoError(t, err)
+		assert.Equal(t, uint8(types.DynamicFeeTxType), signed.Type())
+		assert.Equal(t, dynamicFeeTx.GasFeeCap(), signed.GasFeeCap())
+		assert.Equal(t, dynamicFeeTx.GasTipCap(), signed.GasTipCap())
+	})
+
+	t.Run("returns signer errors", func(t *testing.T) {
+		ts := newSignerStubServer(t, &signerStub{key: key, err: errors.New("account is locked")})
+		signer := txmgr.NewRemoteSigner(*models.MustParseURL(ts.URL).URL())
+
+		_, err := signer.SignTx(from, legacyTx, chainID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "remote signer failed to sign transaction for "+from.Hex())
+		assert.Contains(t, err.Error(), "account is locked")
+	})
+
+	t.Run("rejects a different transaction", func(t *testing.T) {
+		ts := newSignerStubServer(t, &signerStub{key: key, tamper: func(tx *types.LegacyTx) { tx.Nonce++ }})
+		signer := txmgr.NewRemoteSigner(*models.MustParseURL(ts.URL).URL())
+
+		_, err := signer.SignTx(from, legacyTx, chainID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "remote signer returned a different transaction than requested")
+	})
+
+	t.Run("rejects a transaction signed with another key", func(t *testing.T) {
+		otherKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		ts := newSignerStubServer(t, &signerStub{key: otherKey})
+		signer := txmgr.NewRemoteSigner(*models.MustParseURL(ts.URL).URL())
+
+		_, err = signer.SignTx(from, legacyTx, chainID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "remote signer signed the transaction with "+crypto.PubkeyToAddress(otherKey.PublicKey).Hex())
+	})
+}
+
+func TestRemoteSigner_SignMessage(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	message := []byte("hello")
+
+	t.Run("signs messages", func(t *testing.T) {
+		ts := newSignerStubServer(t, &signerStub{key: key})
+		signer := txmgr.NewRemoteSigner(*models.MustParseURL(ts.URL).URL())
+
+		sig, err := signer.SignMessage(from, message)
+		require.NoError(t, err)
+		require.Len(t, sig, crypto.SignatureLength)
+		sig[crypto.RecoveryIDOffset] -= 27
+		pub, err := crypto.SigToPub(accounts.TextHash(message), sig)
+		require.NoError(t, err)
+		assert.Equal(t, from, crypto.PubkeyToAddress(*pub))
+	})
+
+	t.Run("returns signer errors", func(t *testing.T) {
+		ts := newSignerStubServer(t, &signerStub{key: key, err: errors.New("account is locked")})
+		signer := txmgr.NewRemoteSigner(*models.MustParseURL(ts.URL).URL())
+
+		_, err := signer.SignMessage(from, message)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "remote signer failed to sign message for "+from.Hex())
+		assert.Contains(t, err.Error(), "account is locked")
+	})
+
+	t.Run("rejects a message signed with another key", func(t *testing.T) {
+		otherKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		ts := newSignerStubServer(t, &signerStub{key: otherKey})
+		signer := txmgr.NewRemoteSigner(*models.MustParseURL(ts.URL).URL())
+
+		_, err = signer.SignMessage(from, message)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "remote signer signed the message with "+crypto.PubkeyToAddress(otherKey.PublicKey).Hex())
+	})
+}
+
+func TestChainKeyStore_RemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	ts := newSignerStubServer(t, &signerStub{key: key})
+
+	gcfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		addr := ethkey.EIP55AddressFromAddress(from)
+		c.EVM[0].KeySpecific = evmcfg.KeySpecificConfig{{
+			Key:    &addr,
+			Signer: evmcfg.KeySpecificSigner{URL: models.MustParseURL(ts.URL)},
+		}}
+	})
+	cfg := evmtest.NewChainScopedConfig(t, gcfg)
+	// The keystore must not be asked to sign
+	kst := ksmocks.NewEth(t)
+	cks := txmgr.NewChainKeyStore(*big.NewInt(1), cfg, kst)
+
+	var n int64 = 3
+	a, err := cks.NewLegacyAttempt(txmgr.EthTx{Nonce: &n, FromAddress: from, ToAddress: testutils.NewAddress()}, assets.GWei(20), 21000)
+	require.NoError(t, err)
+
+	signed := new(types.Transaction)
+	require.NoError(t, signed.UnmarshalBinary(a.SignedRawTx))
+	assert.Equal(t, a.Hash, signed.Hash())
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), signed)
+	require.NoError(t, err)
+	assert.Equal(t, from, sender)
+}
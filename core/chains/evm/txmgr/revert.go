@@ -0,0 +1,93 @@
+package txmgr
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	evmclient "github.com/smartcontractkit/chainlink/core/chains/evm/client"
+)
+
+// revertErrorCode is the JSON-RPC error code of a reverted call in geth
+const revertErrorCode = 3
+
+var (
+	// panicSelector is the selector of Panic(uint256), raised by failed
+	// assertions and runtime errors since Solidity 0.8.0
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+
+	// panicCodes describes the codes of Panic(uint256), see
+	// https://docs.soliditylang.org/en/latest/control-structures.html#panic-via-assert-and-error-via-require
+	panicCodes = map[uint64]string{
+		0x01: "assertion failed",
+		0x11: "arithmetic overflow or underflow",
+		0x12: "division or modulo by zero",
+		0x21: "invalid enum value",
+		0x22: "invalid storage byte array encoding",
+		0x31: "pop on empty array",
+		0x32: "array index out of bounds",
+		0x41: "out of memory",
+		0x51: "call to invalid internal function",
+	}
+)
+
+// RevertReason returns the reason of a revert from the error returned by an
+// eth_call, or false if err is not a revert. A JSON-RPC error is a revert if
+// it has the code 3, revert data or an "execution reverted" message, other
+// errors (e.g. rate limits or out of gas) say nothing about the call. The
+// revert data, when the node returns it, is decoded as an Error(string), a
+// Panic(uint256) or a custom error. Otherwise the reason is the error message
+// of the node.
+func RevertReason(err error) (string, bool) {
+	jErr, extractErr := evmclient.ExtractRPCError(err)
+	if extractErr != nil {
+		return "", false
+	}
+	data, hasData := revertData(jErr.Data)
+	if !hasData && jErr.Code != revertErrorCode && !strings.Contains(jErr.Message, "execution reverted") {
+		return "", false
+	}
+	if len(data) >= 4 {
+		return DecodeRevertData(data), true
+	}
+	if reason := strings.TrimPrefix(jErr.Message, "execution reverted: "); reason != "" {
+		return reason, true
+	}
+	return "execution reverted", true
+}
+
+// revertData returns the bytes of the data field of a JSON-RPC error, which
+// some nodes prefix with "Reverted "
+func revertData(data interface{}) ([]byte, bool) {
+	s, ok := data.(string)
+	if !ok {
+		return nil, false
+	}
+	b, err := hexutil.Decode(strings.TrimPrefix(s, "Reverted "))
+	return b, err == nil
+}
+
+// DecodeRevertData decodes the data returned by a reverted call
+func DecodeRevertData(data []byte) string {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	if len(data) == 36 && bytes.Equal(data[:4], panicSelector) {
+		code := new(big.Int).SetBytes(data[4:])
+		if desc, ok := panicCodes[code.Uint64()]; ok && code.IsUint64() {
+			return fmt.Sprintf("panic: %s (0x%x)", desc, code)
+		}
+		return fmt.Sprintf("panic: 0x%x", code)
+	}
+	if len(data) < 4 {
+		return fmt.Sprintf("invalid revert data %s", hexutil.Encode(data))
+	}
+	if len(data) == 4 {
+		return fmt.Sprintf("custom error %s", hexutil.Encode(data))
+	}
+	return fmt.Sprintf("custom error %s (%s)", hexutil.Encode(data[:4]), hexutil.Encode(data[4:]))
+}
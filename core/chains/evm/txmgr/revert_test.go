@@ -0,0 +1,54 @@
+package txmgr_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	evmclient "github.com/smartcontractkit/chainlink/core/chains/evm/client"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+)
+
+func TestRevertReason(t *testing.T) {
+	t.Parallel()
+
+	errorString := "0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000b6e6f7420616c6c6f776564000000000000000000000000000000000000000000"
+
+	for _, tt := range []struct {
+		name     string
+		err      error
+		reason   string
+		reverted bool
+	}{
+		{"not a json-rpc error", errors.New("connection reset"), "", false},
+		{"error string", &evmclient.JsonError{Code: 3, Message: "execution reverted: not allowed", Data: errorString}, "not allowed", true},
+		{"prefixed error string", &evmclient.JsonError{Code: -32015, Message: "VM execution error.", Data: "Reverted " + errorString}, "not allowed", true},
+		{"panic", &evmclient.JsonError{Code: 3, Message: "execution reverted", Data: "0x4e487b710000000000000000000000000000000000000000000000000000000000000011"}, "panic: arithmetic overflow or underflow (0x11)", true},
+		{"unknown panic code", &evmclient.JsonError{Code: 3, Message: "execution reverted", Data: "0x4e487b710000000000000000000000000000000000000000000000000000000000000099"}, "panic: 0x99", true},
+		{"custom error", &evmclient.JsonError{Code: 3, Message: "execution reverted", Data: "0x82b42900"}, "custom error 0x82b42900", true},
+		{"custom error with arguments", &evmclient.JsonError{Code: 3, Message: "execution reverted", Data: "0x82b429002a"}, "custom error 0x82b42900 (0x2a)", true},
+		{"message only", &evmclient.JsonError{Code: -32000, Message: "execution reverted: not allowed"}, "not allowed", true},
+		{"no reason", &evmclient.JsonError{Code: -32000, Message: "execution reverted: "}, "execution reverted", true},
+		{"empty revert data", &evmclient.JsonError{Code: 3, Message: "execution reverted", Data: "0x"}, "execution reverted", true},
+		{"revert code only", &evmclient.JsonError{Code: 3, Message: "reverted"}, "reverted", true},
+		{"rate limited", &evmclient.JsonError{Code: -32005, Message: "daily request count exceeded, request rate limited"}, "", false},
+		{"out of gas", &evmclient.JsonError{Code: -32000, Message: "out of gas"}, "", false},
+		{"not revert data", &evmclient.JsonError{Code: -32000, Message: "header not found", Data: "not found"}, "", false},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			reason, reverted := txmgr.RevertReason(tt.err)
+			assert.Equal(t, tt.reverted, reverted)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestDecodeRevertData(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "panic: assertion failed (0x1)", txmgr.DecodeRevertData(hexutil.MustDecode("0x4e487b710000000000000000000000000000000000000000000000000000000000000001")))
+	assert.Equal(t, "invalid revert data 0x2a", txmgr.DecodeRevertData([]byte{0x2a}))
+}
@@ -0,0 +1,200 @@
+package txmgr
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/services/pg"
+)
+
+// ErrGasSpendLimitExceeded is returned when a transaction would make its key
+// or its job spend more on gas than their hourly or daily limit
+var ErrGasSpendLimitExceeded = errors.New("gas spend limit exceeded")
+
+// gasSpendQuery sums the spend of the transactions of a chain, other than $3,
+// which also match a condition on $4 and were mined, or are still in flight
+// and were last broadcast, since $2.
+//
+// The spend of a mined transaction is what it paid, i.e. the gas used times
+// the effective gas price from its receipt. Receipts of nodes that do not
+// return the effective gas price fall back to the gas price or fee cap of the
+// mined attempt. Receipts store these as hex quantities, which are converted
+// through bit(64). The spend of a transaction in flight is the maximum fee it
+// can pay, i.e. the gas limit times the gas price or fee cap of its highest
+// priced attempt.
+const gasSpendQuery = `
+SELECT COALESCE(SUM(COALESCE(mined.fee, in_flight.fee)), 0)
+FROM eth_txes
+LEFT JOIN LATERAL (
+	SELECT ('x' || lpad(substr(eth_receipts.receipt->>'gasUsed', 3), 16, '0'))::bit(64)::bigint *
+		COALESCE(('x' || lpad(substr(eth_receipts.receipt->>'effectiveGasPrice', 3), 16, '0'))::bit(64)::bigint, eth_tx_attempts.gas_price, eth_tx_attempts.gas_fee_cap) AS fee,
+		eth_receipts.created_at
+	FROM eth_tx_attempts
+	INNER JOIN eth_receipts ON eth_receipts.tx_hash = eth_tx_attempts.hash
+	WHERE eth_tx_attempts.eth_tx_id = eth_txes.id
+	ORDER BY eth_receipts.block_number DESC
+	LIMIT 1
+) mined ON TRUE
+LEFT JOIN LATERAL (
+	SELECT MAX(eth_tx_attempts.chain_specific_gas_limit * COALESCE(eth_tx_attempts.gas_fee_cap, eth_tx_attempts.gas_price)) AS fee
+	FROM eth_tx_attempts
+	WHERE eth_tx_attempts.eth_tx_id = eth_txes.id
+) in_flight ON mined.fee IS NULL
+WHERE eth_txes.evm_chain_id = $1 AND COALESCE(mined.created_at, eth_txes.broadcast_at) >= $2 AND eth_txes.id <> $3 AND %s`
+
+const (
+	gasSpendByKey = "eth_txes.from_address = $4"
+	gasSpendByJob = "eth_txes.meta->>'JobID' = $4"
+)
+
+// GasSpend is what a key or a job spent on gas on a chain, over the last hour
+// and the last day
+type GasSpend struct {
+	EVMChainID big.Int
+	Hourly     *assets.Wei
+	Daily      *assets.Wei
+}
+
+func gasSpend(q pg.Queryer, chainID big.Int, since time.Time, excludeID int64, cond string, arg interface{}) (*assets.Wei, error) {
+	spend := new(assets.Wei)
+	err := q.Get(spend, fmt.Sprintf(gasSpendQuery, cond), chainID.String(), since, excludeID, arg)
+	return spend, errors.Wrap(err, "failed to sum gas spend")
+}
+
+func loadGasSpend(q pg.Queryer, chainID big.Int, cond string, arg interface{}) (s GasSpend, err error) {
+	now := time.Now()
+	s.EVMChainID = chainID
+	if s.Hourly, err = gasSpend(q, chainID, now.Add(-time.Hour), 0, cond, arg); err != nil {
+		return
+	}
+	s.Daily, err = gasSpend(q, chainID, now.Add(-24*time.Hour), 0, cond, arg)
+	return
+}
+
+// KeyGasSpend returns what fromAddress spent on gas on the chain
+func KeyGasSpend(q pg.Queryer, fromAddress common.Address, chainID big.Int) (GasSpend, error) {
+	return loadGasSpend(q, chainID, gasSpendByKey, fromAddress)
+}
+
+// JobGasSpends returns what the job spent on gas on each chain it broadcast
+// transactions on during the last day
+func JobGasSpends(q pg.Queryer, jobID int32) (spends []GasSpend, err error) {
+	var chainIDs []string
+	err = q.Select(&chainIDs, `SELECT DISTINCT evm_chain_id FROM eth_txes WHERE meta->>'JobID' = $1 AND broadcast_at >= $2 ORDER BY evm_chain_id`,
+		strconv.Itoa(int(jobID)), time.Now().Add(-24*time.Hour))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load chains of job")
+	}
+	for _, id := range chainIDs {
+		chainID, ok := new(big.Int).SetString(id, 10)
+		if !ok {
+			return nil, errors.Errorf("invalid chain ID %q", id)
+		}
+		s, err := loadGasSpend(q, *chainID, gasSpendByJob, strconv.Itoa(int(jobID)))
+		if err != nil {
+			return nil, err
+		}
+		spends = append(spends, s)
+	}
+	return
+}
+
+type gasSpendLimit struct {
+	subject string
+	cond    string
+	arg     interface{}
+	period  string
+	window  time.Duration
+	limit   *assets.Wei
+}
+
+func gasSpendLimits(cfg Config, fromAddress common.Address, jobID *int32) []gasSpendLimit {
+	key := fmt.Sprintf("key %s", fromAddress)
+	limits := []gasSpendLimit{
+		{key, gasSpendByKey, fromAddress, "hourly", time.Hour, cfg.KeySpecificSpendLimitHourly(fromAddress)},
+		{key, gasSpendByKey, fromAddress, "daily", 24 * time.Hour, cfg.KeySpecificSpendLimitDaily(fromAddress)},
+	}
+	if jobID != nil {
+		job := fmt.Sprintf("job %d", *jobID)
+		id := strconv.Itoa(int(*jobID))
+		limits = append(limits,
+			gasSpendLimit{job, gasSpendByJob, id, "hourly", time.Hour, cfg.EvmJobSpendLimitHourly()},
+			gasSpendLimit{job, gasSpendByJob, id, "daily", 24 * time.Hour, cfg.EvmJobSpendLimitDaily()},
+		)
+	}
+	return limits
+}
+
+// jobGasSpendLimited returns whether the gas spend of jobs is limited
+func jobGasSpendLimited(cfg Config) bool {
+	for _, limit := range []*assets.Wei{cfg.EvmJobSpendLimitHourly(), cfg.EvmJobSpendLimitDaily()} {
+		if limit != nil && !limit.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckGasSpendLimits returns an error wrapping ErrGasSpendLimitExceeded if
+// fromAddress, or the job jobID when set, already spent its hourly or daily
+// limit. Limits that are unset or zero are disabled.
+func CheckGasSpendLimits(q pg.Queryer, cfg Config, chainID big.Int, fromAddress common.Address, jobID *int32) error {
+	now := time.Now()
+	for _, l := range gasSpendLimits(cfg, fromAddress, jobID) {
+		if l.limit == nil || l.limit.IsZero() {
+			continue
+		}
+		spend, err := gasSpend(q, chainID, now.Add(-l.window), 0, l.cond, l.arg)
+		if err != nil {
+			return err
+		}
+		if spend.Cmp(l.limit) >= 0 {
+			return errors.Wrapf(ErrGasSpendLimitExceeded, "%s spent %s of its %s limit of %s", l.subject, spend, l.period, l.limit)
+		}
+	}
+	return nil
+}
+
+// checkAttemptGasSpendLimits returns an error wrapping
+// ErrGasSpendLimitExceeded if sending attempt would make the key or the job of
+// etx spend more than their hourly or daily limit. The maximum fee of attempt
+// replaces the spend of the previous attempts of etx.
+func checkAttemptGasSpendLimits(q pg.Queryer, cfg Config, chainID big.Int, etx EthTx, attempt EthTxAttempt) error {
+	var jobID *int32
+	if meta, err := etx.GetMeta(); err != nil {
+		return err
+	} else if meta != nil {
+		jobID = meta.JobID
+	}
+	fee := attemptMaxFee(attempt)
+	now := time.Now()
+	for _, l := range gasSpendLimits(cfg, etx.FromAddress, jobID) {
+		if l.limit == nil || l.limit.IsZero() {
+			continue
+		}
+		spend, err := gasSpend(q, chainID, now.Add(-l.window), etx.ID, l.cond, l.arg)
+		if err != nil {
+			return err
+		}
+		spend = spend.Add(fee)
+		if spend.Cmp(l.limit) > 0 {
+			return errors.Wrapf(ErrGasSpendLimitExceeded, "%s would spend %s of its %s limit of %s", l.subject, spend, l.period, l.limit)
+		}
+	}
+	return nil
+}
+
+// attemptMaxFee returns the maximum fee that attempt can pay
+func attemptMaxFee(attempt EthTxAttempt) *assets.Wei {
+	price := attempt.Fee().MaxPrice()
+	if price == nil {
+		return assets.NewWeiI(0)
+	}
+	return price.Mul(big.NewInt(int64(attempt.ChainSpecificGasLimit)))
+}
@@ -0,0 +1,126 @@
+package txmgr_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	configtest "github.com/smartcontractkit/chainlink/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/evmtest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/pg"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+func TestGasSpend(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, nil)
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	_, fromAddress := cltest.MustInsertRandomKey(t, ethKeyStore, 0)
+	_, otherAddress := cltest.MustInsertRandomKey(t, ethKeyStore, 0)
+
+	// Each attempt can pay 42 gas at 1 wei
+	etx0 := cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 0, fromAddress)
+	etx1 := cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 1, fromAddress)
+	etx2 := cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 2, fromAddress)
+	cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 0, otherAddress)
+	cltest.MustInsertUnconfirmedEthTx(t, borm, 3, fromAddress)
+
+	// Only the highest priced attempt counts
+	attempt := newBroadcastLegacyEthTxAttempt(t, etx0.ID, 2)
+	require.NoError(t, borm.InsertEthTxAttempt(&attempt))
+
+	pgtest.MustExec(t, db, `UPDATE eth_txes SET broadcast_at = NOW() - interval '2 hours' WHERE id = $1`, etx1.ID)
+	pgtest.MustExec(t, db, `UPDATE eth_txes SET broadcast_at = NOW() - interval '2 days' WHERE id = $1`, etx2.ID)
+	pgtest.MustExec(t, db, `UPDATE eth_txes SET meta = '{"JobID": 7}' WHERE id IN ($1, $2)`, etx0.ID, etx1.ID)
+
+	// Mined transactions count what they paid when they were mined, however
+	// long ago they were broadcast
+	mined := func(nonce int64, gasUsed uint64, effectiveGasPrice *big.Int) {
+		etx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, borm, nonce, 1, fromAddress)
+		pgtest.MustExec(t, db, `UPDATE eth_txes SET broadcast_at = NOW() - interval '2 days' WHERE id = $1`, etx.ID)
+		r := cltest.NewEthReceipt(t, 1, utils.NewHash(), etx.EthTxAttempts[0].Hash, 0x1)
+		r.Receipt.GasUsed = gasUsed
+		r.Receipt.EffectiveGasPrice = effectiveGasPrice
+		require.NoError(t, borm.InsertEthReceipt(&r))
+	}
+	mined(4, 10, big.NewInt(3))
+	// Without an effective gas price, the gas price of the mined attempt is used
+	mined(5, 5, nil)
+
+	t.Run("sums the spend of a key", func(t *testing.T) {
+		spend, err := borm.FindKeyGasSpend(fromAddress, cltest.FixtureChainID)
+		require.NoError(t, err)
+		assert.Equal(t, cltest.FixtureChainID.String(), spend.EVMChainID.String())
+		assert.Equal(t, assets.NewWeiI(84+30+5).String(), spend.Hourly.String())
+		assert.Equal(t, assets.NewWeiI(126+30+5).String(), spend.Daily.String())
+	})
+
+	t.Run("sums the spend of a job on each chain", func(t *testing.T) {
+		spends, err := borm.FindJobGasSpends(7)
+		require.NoError(t, err)
+		require.Len(t, spends, 1)
+		assert.Equal(t, cltest.FixtureChainID.String(), spends[0].EVMChainID.String())
+		assert.Equal(t, assets.NewWeiI(84), spends[0].Hourly)
+		assert.Equal(t, assets.NewWeiI(126), spends[0].Daily)
+
+		spends, err = borm.FindJobGasSpends(8)
+		require.NoError(t, err)
+		assert.Empty(t, spends)
+	})
+}
+
+func TestCheckGasSpendLimits(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].Transactions.SpendLimits.KeyHourly = assets.NewWeiI(100)
+		c.EVM[0].Transactions.SpendLimits.JobDaily = assets.NewWeiI(42)
+	})
+	borm := cltest.NewTxmORM(t, db, cfg)
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+	_, fromAddress := cltest.MustInsertRandomKey(t, ethKeyStore, 0)
+	_, otherAddress := cltest.MustInsertRandomKey(t, ethKeyStore, 0)
+	q := pg.NewQ(db, logger.TestLogger(t), cfg)
+
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 0, fromAddress)
+	pgtest.MustExec(t, db, `UPDATE eth_txes SET meta = '{"JobID": 7}' WHERE id = $1`, etx.ID)
+	jobID, otherJobID := int32(7), int32(8)
+
+	t.Run("allows transactions under the limits", func(t *testing.T) {
+		require.NoError(t, txmgr.CheckGasSpendLimits(q, evmcfg, cltest.FixtureChainID, fromAddress, nil))
+		require.NoError(t, txmgr.CheckGasSpendLimits(q, evmcfg, cltest.FixtureChainID, fromAddress, &otherJobID))
+		require.NoError(t, txmgr.CheckGasSpendLimits(q, evmcfg, cltest.FixtureChainID, otherAddress, nil))
+	})
+
+	t.Run("rejects transactions of a job that spent its limit", func(t *testing.T) {
+		err := txmgr.CheckGasSpendLimits(q, evmcfg, cltest.FixtureChainID, otherAddress, &jobID)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, txmgr.ErrGasSpendLimitExceeded)
+		assert.Contains(t, err.Error(), "job 7 spent 42 wei of its daily limit of 42 wei")
+	})
+
+	t.Run("rejects transactions of a key that spent its limit", func(t *testing.T) {
+		cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 1, fromAddress)
+		cltest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, borm, 2, fromAddress)
+
+		err := txmgr.CheckGasSpendLimits(q, evmcfg, cltest.FixtureChainID, fromAddress, nil)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, txmgr.ErrGasSpendLimitExceeded)
+		assert.Contains(t, err.Error(), "key "+fromAddress.String()+" spent 126 wei of its hourly limit of 100 wei")
+
+		require.NoError(t, txmgr.CheckGasSpendLimits(q, evmcfg, cltest.FixtureChainID, otherAddress, nil))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 
@@ -69,12 +70,12 @@ func (s DropOldestStrategy) PruneQueue(q pg.Queryer) (n int64, err error) {
 	defer cancel()
 	res, err := q.ExecContext(ctx, `
 DELETE FROM eth_txes
-WHERE state = 'unstarted' AND subject = $1 AND
+WHERE state = 'unstarted' AND subject = $1 AND batch_eth_tx_id IS NULL AND
 id < (
 	SELECT min(id) FROM (
 		SELECT id
 		FROM eth_txes
-		WHERE state = 'unstarted' AND subject = $2
+		WHERE state = 'unstarted' AND subject = $2 AND batch_eth_tx_id IS NULL
 		ORDER BY id DESC
 		LIMIT $3
 	) numbers
@@ -84,3 +85,24 @@ id < (
 	}
 	return res.RowsAffected()
 }
+
+var _ TxStrategy = BatchingStrategy{}
+
+// BatchingStrategy sends transactions through the batching forwarder at
+// ForwarderAddress, see contracts/src/v0.8/dev/BatchForwarder.sol. The
+// EthBroadcaster coalesces the unstarted transactions of a key that use the
+// same forwarder into a single call to its forwardBatch, and the outcome of
+// each call is read from the CallForwarded logs of the receipt.
+//
+// Transactions are otherwise queued like with the wrapped strategy. Those
+// with a transmit checker are never batched.
+type BatchingStrategy struct {
+	TxStrategy
+	ForwarderAddress common.Address
+}
+
+// NewBatchingStrategy creates a new TxStrategy that batches transactions
+// through the forwarder at forwarderAddress, and queues them with strategy.
+func NewBatchingStrategy(strategy TxStrategy, forwarderAddress common.Address) BatchingStrategy {
+	return BatchingStrategy{strategy, forwarderAddress}
+}
@@ -100,6 +100,25 @@ func (s *SimulateChecker) Check(
 	tx EthTx,
 	a EthTxAttempt,
 ) error {
+	// always run simulation on "latest" block
+	b, err := simulateTx(ctx, s.Client, tx, a, evmclient.ToBlockNumArg(nil))
+	if err != nil {
+		if jErr := evmclient.ExtractRPCErrorOrNil(err); jErr != nil {
+			l.Criticalw("Transaction reverted during simulation",
+				"ethTxAttemptID", a.ID, "txHash", a.Hash, "err", err, "rpcErr", jErr.String(), "returnValue", b.String())
+			return errors.Errorf("transaction reverted during simulation: %s", jErr.String())
+		}
+		l.Warnw("Transaction simulation failed, will attempt to send anyway",
+			"ethTxAttemptID", a.ID, "txHash", a.Hash, "err", err, "returnValue", b.String())
+	} else {
+		l.Debugw("Transaction simulation succeeded",
+			"ethTxAttemptID", a.ID, "txHash", a.Hash, "returnValue", b.String())
+	}
+	return nil
+}
+
+// simulateTx calls the attempt with eth_call against the given block
+func simulateTx(ctx context.Context, client evmclient.Client, tx EthTx, a EthTxAttempt, blockNumArg string) (hexutil.Bytes, error) {
 	// See: https://github.com/ethereum/go-ethereum/blob/acdf9238fb03d79c9b1c20c2fa476a7e6f4ac2ac/ethclient/gethclient/gethclient.go#L193
 	callArg := map[string]interface{}{
 		"from": tx.FromAddress,
@@ -114,22 +133,12 @@ func (s *SimulateChecker) Check(
 		"value":                (*hexutil.Big)(tx.Value.ToInt()),
 		"data":                 hexutil.Bytes(tx.EncodedPayload),
 	}
-	var b hexutil.Bytes
-	// always run simulation on "latest" block
-	err := s.Client.CallContext(ctx, &b, "eth_call", callArg, evmclient.ToBlockNumArg(nil))
-	if err != nil {
-		if jErr := evmclient.ExtractRPCErrorOrNil(err); jErr != nil {
-			l.Criticalw("Transaction reverted during simulation",
-				"ethTxAttemptID", a.ID, "txHash", a.Hash, "err", err, "rpcErr", jErr.String(), "returnValue", b.String())
-			return errors.Errorf("transaction reverted during simulation: %s", jErr.String())
-		}
-		l.Warnw("Transaction simulation failed, will attempt to send anyway",
-			"ethTxAttemptID", a.ID, "txHash", a.Hash, "err", err, "returnValue", b.String())
-	} else {
-		l.Debugw("Transaction simulation succeeded",
-			"ethTxAttemptID", a.ID, "txHash", a.Hash, "returnValue", b.String())
+	if tx.AccessList.Valid {
+		callArg["accessList"] = tx.AccessList.AccessList
 	}
-	return nil
+	var b hexutil.Bytes
+	err := client.CallContext(ctx, &b, "eth_call", callArg, blockNumArg)
+	return b, err
 }
 
 // VRFV1Checker is an implementation of TransmitChecker that checks whether a VRF V1 fulfillment
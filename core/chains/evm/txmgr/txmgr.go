@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"fmt"
 	"math/big"
+	"net/url"
 	"sync"
 	"time"
 
@@ -44,12 +45,26 @@ type Config interface {
 	EvmGasBumpThreshold() uint64
 	EvmGasBumpTxDepth() uint16
 	EvmGasLimitDefault() uint32
+	EvmJobSpendLimitDaily() *assets.Wei
+	EvmJobSpendLimitHourly() *assets.Wei
 	EvmMaxInFlightTransactions() uint32
 	EvmMaxQueuedTransactions() uint64
+	EvmMaxQueuedTransactionsPriority(priority string) uint64
 	EvmNonceAutoSync() bool
+	EvmNonceGapsEnabled() bool
+	EvmNonceGapsFill() bool
+	EvmNonceGapsResync() bool
+	EvmPrivateRelayAllTransactions() bool
+	EvmPrivateRelayFallbackBlocks() uint32
+	EvmPrivateRelayMethod() string
+	EvmPrivateRelayURL() *url.URL
+	EvmSimulateBeforeBroadcast() bool
 	EvmUseForwarders() bool
 	EvmRPCDefaultBatchSize() uint32
 	KeySpecificMaxGasPriceWei(addr common.Address) *assets.Wei
+	KeySpecificSignerURL(addr common.Address) *url.URL
+	KeySpecificSpendLimitDaily(addr common.Address) *assets.Wei
+	KeySpecificSpendLimitHourly(addr common.Address) *assets.Wei
 	TriggerFallbackDBPollInterval() time.Duration
 }
 
@@ -60,6 +75,7 @@ type KeyStore interface {
 	GetNextNonce(address common.Address, chainID *big.Int, qopts ...pg.QOpt) (int64, error)
 	GetStatesForChain(chainID *big.Int) ([]ethkey.State, error)
 	IncrementNextNonce(address common.Address, chainID *big.Int, currentNonce int64, qopts ...pg.QOpt) error
+	SignMessage(address common.Address, message []byte) ([]byte, error)
 	SignTx(fromAddress common.Address, tx *gethTypes.Transaction, chainID *big.Int) (*gethTypes.Transaction, error)
 	SubscribeToKeyChanges() (ch chan struct{}, unsub func())
 }
@@ -83,6 +99,8 @@ type TxManager interface {
 	RegisterResumeCallback(fn ResumeCallback)
 	SendEther(chainID *big.Int, from, to common.Address, value assets.Eth, gasLimit uint32) (etx EthTx, err error)
 	Reset(f func(), addr common.Address, abandon bool) error
+	CancelEthTx(ctx context.Context, etxID int64) (etx EthTx, err error)
+	BumpEthTx(ctx context.Context, etxID int64, gasPrice *assets.Wei) (etx EthTx, err error)
 }
 
 type reset struct {
@@ -111,6 +129,7 @@ type Txm struct {
 	chHeads        chan *evmtypes.Head
 	trigger        chan common.Address
 	reset          chan reset
+	confirmer      chan chan *EthConfirmer
 	resumeCallback ResumeCallback
 
 	chStop   chan struct{}
@@ -120,6 +139,7 @@ type Txm struct {
 	reaper      *Reaper
 	ethResender *EthResender
 	fwdMgr      *forwarders.FwdMgr
+	nonceGaps   *NonceGapDetector
 }
 
 func (b *Txm) RegisterResumeCallback(fn ResumeCallback) {
@@ -153,6 +173,7 @@ func NewTxm(db *sqlx.DB, ethClient evmclient.Client, cfg Config, keyStore KeySto
 		chStop:           make(chan struct{}),
 		chSubbed:         make(chan struct{}),
 		reset:            make(chan reset),
+		confirmer:        make(chan chan *EthConfirmer),
 	}
 	if cfg.EthTxResendAfterThreshold() > 0 {
 		b.ethResender = NewEthResender(lggr, db, ethClient, keyStore, defaultResenderPollInterval, cfg)
@@ -169,6 +190,11 @@ func NewTxm(db *sqlx.DB, ethClient evmclient.Client, cfg Config, keyStore KeySto
 	} else {
 		b.logger.Info("EvmForwarderManager: Disabled")
 	}
+	if cfg.EvmNonceGapsEnabled() {
+		b.nonceGaps = NewNonceGapDetector(db, ethClient, cfg, keyStore, b.gasEstimator, lggr, b.resyncNonce)
+	} else {
+		b.logger.Info("NonceGapDetector: Disabled")
+	}
 
 	return &b
 }
@@ -220,6 +246,10 @@ func (b *Txm) Start(ctx context.Context) (merr error) {
 			b.ethResender.Start()
 		}
 
+		if b.nonceGaps != nil {
+			b.nonceGaps.Start()
+		}
+
 		if b.fwdMgr != nil {
 			if err = ms.Start(ctx, b.fwdMgr); err != nil {
 				return errors.Wrap(err, "Txm: EVMForwarderManager failed to start")
@@ -251,6 +281,37 @@ func (b *Txm) Reset(callback func(), addr common.Address, abandon bool) (err err
 	return err
 }
 
+// resyncNonce stops EthBroadcaster/EthConfirmer, fast-forwards the next nonce
+// of the key to its on-chain nonce, then starts them again. Unstarted
+// transactions are assigned the nonces that follow it.
+func (b *Txm) resyncNonce(ctx context.Context, address common.Address) (err error) {
+	syncer := NewNonceSyncer(b.db, b.logger, b.config, b.ethClient, b.keyStore)
+	done := make(chan error)
+	f := func() {
+		err = syncer.fastForwardNonceIfNecessary(ctx, address)
+	}
+	select {
+	case b.reset <- reset{f, done}:
+	case <-b.chStop:
+		return errors.New("Txm was stopped")
+	}
+	if resetErr := <-done; resetErr != nil {
+		return resetErr
+	}
+	return err
+}
+
+// Healthy reports the keys with a nonce gap as degraded
+func (b *Txm) Healthy() error {
+	if err := b.StartStopOnce.Healthy(); err != nil {
+		return err
+	}
+	if b.nonceGaps != nil {
+		return b.nonceGaps.Healthy()
+	}
+	return nil
+}
+
 // abandon, scoped to the key of this txm:
 // - marks all pending and inflight transactions fatally errored (note: at this point all transactions are either confirmed or fatally errored)
 // this must not be run while EthBroadcaster or EthConfirmer are running
@@ -269,6 +330,9 @@ func (b *Txm) Close() (merr error) {
 		if b.ethResender != nil {
 			b.ethResender.Stop()
 		}
+		if b.nonceGaps != nil {
+			b.nonceGaps.Stop()
+		}
 		if b.fwdMgr != nil {
 			if err := b.fwdMgr.Close(); err != nil {
 				return errors.Wrap(err, "Txm: failed to stop EVMForwarderManager")
@@ -387,6 +451,8 @@ func (b *Txm) runLoop(eb *EthBroadcaster, ec *EthConfirmer, keyStates []ethkey.S
 				continue
 			}
 			execReset(&reset)
+		case ch := <-b.confirmer:
+			ch <- ec
 		case <-b.chStop:
 			// close and exit
 			//
@@ -424,12 +490,53 @@ func (b *Txm) runLoop(eb *EthBroadcaster, ec *EthConfirmer, keyStates []ethkey.S
 	}
 }
 
+// CancelEthTx cancels the given transaction, see EthConfirmer.CancelEthTx
+func (b *Txm) CancelEthTx(ctx context.Context, etxID int64) (etx EthTx, err error) {
+	ec, err := b.getEthConfirmer(ctx)
+	if err != nil {
+		return etx, err
+	}
+	return ec.CancelEthTx(ctx, etxID)
+}
+
+// BumpEthTx replaces the pending attempt of the given transaction with one
+// paying gasPrice, see EthConfirmer.BumpEthTx
+func (b *Txm) BumpEthTx(ctx context.Context, etxID int64, gasPrice *assets.Wei) (etx EthTx, err error) {
+	ec, err := b.getEthConfirmer(ctx)
+	if err != nil {
+		return etx, err
+	}
+	return ec.BumpEthTx(ctx, etxID, gasPrice)
+}
+
+// getEthConfirmer returns the EthConfirmer currently owned by the runLoop
+func (b *Txm) getEthConfirmer(ctx context.Context) (ec *EthConfirmer, err error) {
+	ok := b.IfStarted(func() {
+		ch := make(chan *EthConfirmer, 1)
+		select {
+		case b.confirmer <- ch:
+			ec = <-ch
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-b.chStop:
+			err = errors.New("Txm was stopped")
+		}
+	})
+	if !ok {
+		return nil, errors.New("not started")
+	}
+	return ec, err
+}
+
 // OnNewLongestChain conforms to HeadTrackable
 func (b *Txm) OnNewLongestChain(ctx context.Context, head *evmtypes.Head) {
 	ok := b.IfStarted(func() {
 		if b.reaper != nil {
 			b.reaper.SetLatestBlockNum(head.Number)
 		}
+		if b.nonceGaps != nil {
+			b.nonceGaps.OnNewHead(head)
+		}
 		b.gasEstimator.OnNewLongestChain(ctx, head)
 		select {
 		case b.chHeads <- head:
@@ -467,6 +574,22 @@ type NewTx struct {
 
 	// Checker defines the check that should be run before a transaction is submitted on chain.
 	Checker TransmitCheckerSpec
+
+	// TxType is the EIP-2718 type of the transaction. If it is not set, the
+	// type is chosen from the chain configuration, see EthTxType.
+	TxType null.Int64
+	// AccessList is only included in access list and dynamic fee transactions
+	AccessList NullableEIP2930AccessList
+
+	// PrivateSubmission sends the transaction to the private relay of the
+	// chain instead of the public mempool. If it is nil, the chain
+	// configuration decides.
+	PrivateSubmission *bool
+
+	// InclusionDeadline is the deadline for the transaction to be included in
+	// a block by. If it is set, the transaction is bumped towards the price
+	// needed to meet it, see InclusionDeadline.
+	InclusionDeadline InclusionDeadline
 }
 
 // CreateEthTransaction inserts a new transaction
@@ -495,11 +618,72 @@ func (b *Txm) CreateEthTransaction(newTx NewTx, qs ...pg.QOpt) (etx EthTx, err e
 		}
 	}
 
+	if err = b.validateTxType(newTx); err != nil {
+		return etx, errors.Wrap(err, "Txm#CreateEthTransaction")
+	}
+
+	privateSubmission := b.config.EvmPrivateRelayAllTransactions()
+	if newTx.PrivateSubmission != nil {
+		privateSubmission = *newTx.PrivateSubmission
+	}
+	if privateSubmission && b.config.EvmPrivateRelayURL() == nil {
+		return etx, errors.New("Txm#CreateEthTransaction: private submission requires a private relay to be configured for this chain")
+	}
+
+	if newTx.InclusionDeadline.Duration < 0 {
+		return etx, errors.New("Txm#CreateEthTransaction: inclusion deadline must not be negative")
+	}
+	if newTx.InclusionDeadline.IsSet() && gas.GetPercentileEstimator(b.gasEstimator) == nil {
+		b.logger.Warnw("Gas estimator does not keep the prices of recent blocks, transaction with an inclusion deadline will be bumped as usual", "fromAddress", newTx.FromAddress, "toAddress", newTx.ToAddress, "inclusionDeadline", newTx.InclusionDeadline)
+	}
+	deadlineBlocks, deadlineAt := newTx.InclusionDeadline.columns(time.Now())
+
+	priority := EthTxPriorityNormal
+	if newTx.Meta != nil {
+		if priority, err = ParseEthTxPriority(string(newTx.Meta.Priority)); err != nil {
+			return etx, errors.Wrap(err, "Txm#CreateEthTransaction")
+		}
+	}
+
 	err = CheckEthTxQueueCapacity(q, newTx.FromAddress, b.config.EvmMaxQueuedTransactions(), b.chainID)
+	if err == nil {
+		err = CheckEthTxPriorityQueueCapacity(q, newTx.FromAddress, priority, b.config.EvmMaxQueuedTransactionsPriority(string(priority)), b.chainID)
+	}
 	if err != nil {
+		promQueueCapacityExceeded.WithLabelValues(b.chainID.String(), string(priority)).Inc()
+		return etx, errors.Wrap(err, "Txm#CreateEthTransaction")
+	}
+
+	var jobID *int32
+	if newTx.Meta != nil {
+		jobID = newTx.Meta.JobID
+	}
+	if err = CheckGasSpendLimits(q, b.config, b.chainID, newTx.FromAddress, jobID); err != nil {
+		if errors.Is(err, ErrGasSpendLimitExceeded) {
+			b.logger.Criticalw("Gas spend limit exceeded, rejecting transaction", "fromAddress", newTx.FromAddress, "toAddress", newTx.ToAddress, "jobID", jobID, "err", err)
+		}
 		return etx, errors.Wrap(err, "Txm#CreateEthTransaction")
 	}
 
+	var batchForwarderAddress *common.Address
+	if s, ok := newTx.Strategy.(BatchingStrategy); ok {
+		if newTx.Checker.CheckerType != "" {
+			b.logger.Debugw("Not batching transaction with a transmit checker", "fromAddress", newTx.FromAddress, "toAddress", newTx.ToAddress, "checker", newTx.Checker.CheckerType)
+		} else if newTx.TxType.Valid || newTx.AccessList.Valid {
+			b.logger.Debugw("Not batching typed transaction", "fromAddress", newTx.FromAddress, "toAddress", newTx.ToAddress, "txType", newTx.TxType, "accessList", newTx.AccessList)
+		} else if privateSubmission {
+			b.logger.Debugw("Not batching private transaction", "fromAddress", newTx.FromAddress, "toAddress", newTx.ToAddress)
+		} else if newTx.InclusionDeadline.IsSet() {
+			b.logger.Debugw("Not batching transaction with an inclusion deadline", "fromAddress", newTx.FromAddress, "toAddress", newTx.ToAddress, "inclusionDeadline", newTx.InclusionDeadline)
+		} else if jobID != nil && jobGasSpendLimited(b.config) {
+			// The batch eth_tx belongs to no job, so the spend of the job
+			// would not count towards its limits
+			b.logger.Debugw("Not batching transaction of a job with a gas spend limit", "fromAddress", newTx.FromAddress, "toAddress", newTx.ToAddress, "jobID", *jobID)
+		} else {
+			batchForwarderAddress = &s.ForwarderAddress
+		}
+	}
+
 	value := 0
 	err = q.Transaction(func(tx pg.Queryer) error {
 		if newTx.PipelineTaskRunID != nil {
@@ -514,12 +698,12 @@ func (b *Txm) CreateEthTransaction(newTx NewTx, qs ...pg.QOpt) (etx EthTx, err e
 			}
 		}
 		err := tx.Get(&etx, `
-INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, state, created_at, meta, subject, evm_chain_id, min_confirmations, pipeline_task_run_id, transmit_checker)
+INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, state, created_at, meta, subject, evm_chain_id, min_confirmations, pipeline_task_run_id, transmit_checker, batch_forwarder_address, priority, tx_type, access_list, private_submission, inclusion_deadline_blocks, inclusion_deadline_at)
 VALUES (
-$1,$2,$3,$4,$5,'unstarted',NOW(),$6,$7,$8,$9,$10,$11
+$1,$2,$3,$4,$5,'unstarted',NOW(),$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18
 )
 RETURNING "eth_txes".*
-`, newTx.FromAddress, newTx.ToAddress, newTx.EncodedPayload, value, newTx.GasLimit, newTx.Meta, newTx.Strategy.Subject(), b.chainID.String(), newTx.MinConfirmations, newTx.PipelineTaskRunID, newTx.Checker)
+`, newTx.FromAddress, newTx.ToAddress, newTx.EncodedPayload, value, newTx.GasLimit, newTx.Meta, newTx.Strategy.Subject(), b.chainID.String(), newTx.MinConfirmations, newTx.PipelineTaskRunID, newTx.Checker, batchForwarderAddress, priority, newTx.TxType, newTx.AccessList, privateSubmission, deadlineBlocks, deadlineAt)
 		if err != nil {
 			return errors.Wrap(err, "Txm#CreateEthTransaction failed to insert eth_tx")
 		}
@@ -536,6 +720,25 @@ RETURNING "eth_txes".*
 	return
 }
 
+// validateTxType checks that the transaction type requested by newTx can be
+// sent on this chain
+func (b *Txm) validateTxType(newTx NewTx) error {
+	if !newTx.TxType.Valid {
+		return nil
+	}
+	txType := int(newTx.TxType.Int64)
+	if _, err := AttemptBuilderFor(txType); err != nil {
+		return err
+	}
+	if txType == DynamicFeeTxType && !b.config.EvmEIP1559DynamicFees() {
+		return errors.New("dynamic fee transactions require EIP-1559 to be enabled on this chain")
+	}
+	if txType == LegacyTxType && newTx.AccessList.Valid {
+		return errors.New("legacy transactions cannot have an access list")
+	}
+	return nil
+}
+
 // Calls forwarderMgr to get a proper forwarder for a given EOA.
 func (b *Txm) GetForwarderForEOA(eoa common.Address) (forwarder common.Address, err error) {
 	if !b.config.EvmUseForwarders() {
@@ -586,8 +789,17 @@ func NewChainKeyStore(chainID big.Int, config Config, keystore KeyStore) ChainKe
 	return ChainKeyStore{chainID, config, keystore}
 }
 
+// signer returns the Signer for address, which is its remote signer if one
+// is configured and the keystore otherwise
+func (c *ChainKeyStore) signer(address common.Address) Signer {
+	if u := c.config.KeySpecificSignerURL(address); u != nil {
+		return NewRemoteSigner(*u)
+	}
+	return c.keystore
+}
+
 func (c *ChainKeyStore) SignTx(address common.Address, tx *gethTypes.Transaction) (common.Hash, []byte, error) {
-	signedTx, err := c.keystore.SignTx(address, tx, &c.chainID)
+	signedTx, err := c.signer(address).SignTx(address, tx, &c.chainID)
 	if err != nil {
 		return common.Hash{}, nil, errors.Wrap(err, "SignTx failed")
 	}
@@ -623,7 +835,7 @@ func sendTransaction(ctx context.Context, ethClient evmclient.Client, a EthTxAtt
 func sendEmptyTransaction(
 	ctx context.Context,
 	ethClient evmclient.Client,
-	keyStore KeyStore,
+	signer Signer,
 	nonce uint64,
 	gasLimit uint32,
 	gasPriceWei *big.Int,
@@ -632,7 +844,7 @@ func sendEmptyTransaction(
 ) (_ *gethTypes.Transaction, err error) {
 	defer utils.WrapIfError(&err, "sendEmptyTransaction failed")
 
-	signedTx, err := makeEmptyTransaction(keyStore, nonce, gasLimit, gasPriceWei, fromAddress, chainID)
+	signedTx, err := makeEmptyTransaction(signer, nonce, gasLimit, gasPriceWei, fromAddress, chainID)
 	if err != nil {
 		return nil, err
 	}
@@ -641,11 +853,11 @@ func sendEmptyTransaction(
 }
 
 // makes a transaction that sends 0 eth to self
-func makeEmptyTransaction(keyStore KeyStore, nonce uint64, gasLimit uint32, gasPriceWei *big.Int, fromAddress common.Address, chainID *big.Int) (*gethTypes.Transaction, error) {
+func makeEmptyTransaction(signer Signer, nonce uint64, gasLimit uint32, gasPriceWei *big.Int, fromAddress common.Address, chainID *big.Int) (*gethTypes.Transaction, error) {
 	value := big.NewInt(0)
 	payload := []byte{}
 	tx := gethTypes.NewTransaction(nonce, fromAddress, value, uint64(gasLimit), gasPriceWei, payload)
-	return keyStore.SignTx(fromAddress, tx, chainID)
+	return signer.SignTx(fromAddress, tx, chainID)
 }
 
 const insertIntoEthTxAttemptsQuery = `
@@ -683,8 +895,10 @@ func CountUnstartedTransactions(q pg.Q, fromAddress common.Address, chainID big.
 	return countTransactionsWithState(q, fromAddress, EthTxUnstarted, chainID)
 }
 
+// countTransactionsWithState doesn't count the unstarted transactions that
+// were added to a batch
 func countTransactionsWithState(q pg.Q, fromAddress common.Address, state EthTxState, chainID big.Int) (count uint32, err error) {
-	err = q.Get(&count, `SELECT count(*) FROM eth_txes WHERE from_address = $1 AND state = $2 AND evm_chain_id = $3`,
+	err = q.Get(&count, `SELECT count(*) FROM eth_txes WHERE from_address = $1 AND state = $2 AND evm_chain_id = $3 AND batch_eth_tx_id IS NULL`,
 		fromAddress, state, chainID.String())
 	return count, errors.Wrap(err, "failed to countTransactionsWithState")
 }
@@ -696,7 +910,7 @@ func CheckEthTxQueueCapacity(q pg.Queryer, fromAddress common.Address, maxQueued
 		return nil
 	}
 	var count uint64
-	err = q.Get(&count, `SELECT count(*) FROM eth_txes WHERE from_address = $1 AND state = 'unstarted' AND evm_chain_id = $2`, fromAddress, chainID.String())
+	err = q.Get(&count, `SELECT count(*) FROM eth_txes WHERE from_address = $1 AND state = 'unstarted' AND evm_chain_id = $2 AND batch_eth_tx_id IS NULL`, fromAddress, chainID.String())
 	if err != nil {
 		err = errors.Wrap(err, "txmgr.CheckEthTxQueueCapacity query failed")
 		return
@@ -708,6 +922,25 @@ func CheckEthTxQueueCapacity(q pg.Queryer, fromAddress common.Address, maxQueued
 	return
 }
 
+// CheckEthTxPriorityQueueCapacity returns an error if inserting a transaction
+// of this priority would exceed the maximum queue size of its lane.
+func CheckEthTxPriorityQueueCapacity(q pg.Queryer, fromAddress common.Address, priority EthTxPriority, maxQueuedTransactions uint64, chainID big.Int) (err error) {
+	if maxQueuedTransactions == 0 {
+		return nil
+	}
+	var count uint64
+	err = q.Get(&count, `SELECT count(*) FROM eth_txes WHERE from_address = $1 AND state = 'unstarted' AND evm_chain_id = $2 AND priority = $3 AND batch_eth_tx_id IS NULL`, fromAddress, chainID.String(), priority)
+	if err != nil {
+		err = errors.Wrap(err, "txmgr.CheckEthTxPriorityQueueCapacity query failed")
+		return
+	}
+
+	if count >= maxQueuedTransactions {
+		err = errors.Errorf("cannot create transaction; too many unstarted %s priority transactions in the queue (%v/%v)", priority, count, maxQueuedTransactions)
+	}
+	return
+}
+
 var _ TxManager = &NullTxManager{}
 
 type NullTxManager struct {
@@ -738,6 +971,16 @@ func (n *NullTxManager) Reset(f func(), addr common.Address, abandon bool) error
 func (n *NullTxManager) SendEther(chainID *big.Int, from, to common.Address, value assets.Eth, gasLimit uint32) (etx EthTx, err error) {
 	return etx, errors.New(n.ErrMsg)
 }
+
+// CancelEthTx does nothing, null functionality
+func (n *NullTxManager) CancelEthTx(ctx context.Context, etxID int64) (etx EthTx, err error) {
+	return etx, errors.New(n.ErrMsg)
+}
+
+// BumpEthTx does nothing, null functionality
+func (n *NullTxManager) BumpEthTx(ctx context.Context, etxID int64, gasPrice *assets.Wei) (etx EthTx, err error) {
+	return etx, errors.New(n.ErrMsg)
+}
 func (n *NullTxManager) Healthy() error                           { return nil }
 func (n *NullTxManager) Ready() error                             { return nil }
 func (n *NullTxManager) GetGasEstimator() gas.Estimator           { return nil }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"net/url"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/forwarders"
@@ -29,6 +31,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/testutils/evmtest"
 	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	clnull "github.com/smartcontractkit/chainlink/core/null"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
 	ksmocks "github.com/smartcontractkit/chainlink/core/services/keystore/mocks"
@@ -53,7 +56,7 @@ func TestTxm_SendEther_DoesNotSendToZero(t *testing.T) {
 	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
 	lggr := logger.TestLogger(t)
 	checkerFactory := &testCheckerFactory{}
-	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr, pgtest.NewQConfig(true)), ethClient, lggr, 100*time.Millisecond, 2, 3, 2, 1000)
+	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr, pgtest.NewQConfig(true)), ethClient, lggr, 100*time.Millisecond, 2, false, 3, 2, 1000)
 	txm := txmgr.NewTxm(db, ethClient, config, nil, nil, lggr, checkerFactory, lp)
 
 	_, err := txm.SendEther(big.NewInt(0), from, to, *value, 21000)
@@ -153,6 +156,45 @@ func TestTxm_CheckEthTxQueueCapacity(t *testing.T) {
 	})
 }
 
+func TestTxm_CheckEthTxPriorityQueueCapacity(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, nil)
+	borm := cltest.NewTxmORM(t, db, cfg)
+	ethKeyStore := cltest.NewKeyStore(t, db, cfg).Eth()
+
+	_, fromAddress := cltest.MustAddRandomKeyToKeystore(t, ethKeyStore)
+
+	for i := 0; i < 2; i++ {
+		etx := cltest.MustInsertUnstartedEthTx(t, borm, fromAddress)
+		pgtest.MustExec(t, db, `UPDATE eth_txes SET priority = 'low' WHERE id = $1`, etx.ID)
+	}
+	cltest.MustInsertUnstartedEthTx(t, borm, fromAddress)
+
+	t.Run("with fewer unstarted eth_txes of the priority than limit returns nil", func(t *testing.T) {
+		err := txmgr.CheckEthTxPriorityQueueCapacity(db, fromAddress, txmgr.EthTxPriorityLow, 3, cltest.FixtureChainID)
+		require.NoError(t, err)
+		err = txmgr.CheckEthTxPriorityQueueCapacity(db, fromAddress, txmgr.EthTxPriorityHigh, 1, cltest.FixtureChainID)
+		require.NoError(t, err)
+	})
+
+	t.Run("with equal or more unstarted eth_txes of the priority than limit returns error", func(t *testing.T) {
+		err := txmgr.CheckEthTxPriorityQueueCapacity(db, fromAddress, txmgr.EthTxPriorityLow, 2, cltest.FixtureChainID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot create transaction; too many unstarted low priority transactions in the queue (2/2)")
+
+		err = txmgr.CheckEthTxPriorityQueueCapacity(db, fromAddress, txmgr.EthTxPriorityNormal, 1, cltest.FixtureChainID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "too many unstarted normal priority transactions in the queue (1/1)")
+	})
+
+	t.Run("disables check with 0 limit", func(t *testing.T) {
+		err := txmgr.CheckEthTxPriorityQueueCapacity(db, fromAddress, txmgr.EthTxPriorityLow, 0, cltest.FixtureChainID)
+		require.NoError(t, err)
+	})
+}
+
 func TestTxm_CountUnconfirmedTransactions(t *testing.T) {
 	t.Parallel()
 
@@ -218,7 +260,7 @@ func TestTxm_CreateEthTransaction(t *testing.T) {
 
 	lggr := logger.TestLogger(t)
 	checkerFactory := &testCheckerFactory{}
-	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr, pgtest.NewQConfig(true)), ethClient, lggr, 100*time.Millisecond, 2, 3, 2, 1000)
+	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr, pgtest.NewQConfig(true)), ethClient, lggr, 100*time.Millisecond, 2, false, 3, 2, 1000)
 	txm := txmgr.NewTxm(db, ethClient, config, kst.Eth(), nil, lggr, checkerFactory, lp)
 
 	t.Run("with queue under capacity inserts eth_tx", func(t *testing.T) {
@@ -440,6 +482,163 @@ func TestTxm_CreateEthTransaction(t *testing.T) {
 
 		config.AssertExpectations(t)
 	})
+
+	t.Run("sets the priority from meta", func(t *testing.T) {
+		pgtest.MustExec(t, db, `DELETE FROM eth_txes`)
+		config.On("EvmMaxQueuedTransactions").Return(uint64(1)).Once()
+
+		etx, err := txm.CreateEthTransaction(txmgr.NewTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: payload,
+			GasLimit:       gasLimit,
+			Meta:           &txmgr.EthTxMeta{Priority: txmgr.EthTxPriorityLow},
+			Strategy:       txmgr.NewSendEveryStrategy(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxPriorityLow, etx.Priority)
+
+		config.AssertExpectations(t)
+	})
+
+	t.Run("returns error if the priority is invalid", func(t *testing.T) {
+		_, err := txm.CreateEthTransaction(txmgr.NewTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: payload,
+			GasLimit:       gasLimit,
+			Meta:           &txmgr.EthTxMeta{Priority: "urgent"},
+			Strategy:       txmgr.NewSendEveryStrategy(),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid transaction priority "urgent"`)
+	})
+
+	t.Run("sets the tx type and access list", func(t *testing.T) {
+		pgtest.MustExec(t, db, `DELETE FROM eth_txes`)
+		config.On("EvmMaxQueuedTransactions").Return(uint64(1)).Once()
+		accessList := gethtypes.AccessList{{Address: testutils.NewAddress(), StorageKeys: []gethcommon.Hash{utils.NewHash()}}}
+
+		etx, err := txm.CreateEthTransaction(txmgr.NewTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: payload,
+			GasLimit:       gasLimit,
+			Strategy:       txmgr.NewSendEveryStrategy(),
+			TxType:         clnull.Int64From(int64(txmgr.AccessListTxType)),
+			AccessList:     txmgr.NullableEIP2930AccessListFrom(accessList),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, null.IntFrom(int64(txmgr.AccessListTxType)), etx.TxType)
+		require.True(t, etx.AccessList.Valid)
+		assert.Equal(t, accessList, etx.AccessList.AccessList)
+
+		config.AssertExpectations(t)
+	})
+
+	t.Run("sets the inclusion deadline", func(t *testing.T) {
+		pgtest.MustExec(t, db, `DELETE FROM eth_txes`)
+		config.On("EvmMaxQueuedTransactions").Return(uint64(1)).Once()
+
+		before := time.Now()
+		etx, err := txm.CreateEthTransaction(txmgr.NewTx{
+			FromAddress:       fromAddress,
+			ToAddress:         toAddress,
+			EncodedPayload:    payload,
+			GasLimit:          gasLimit,
+			Strategy:          txmgr.NewSendEveryStrategy(),
+			InclusionDeadline: txmgr.InclusionDeadline{Blocks: 5, Duration: time.Minute},
+		})
+		require.NoError(t, err)
+		assert.True(t, etx.HasInclusionDeadline())
+		assert.Equal(t, clnull.Uint32From(5), etx.InclusionDeadlineBlocks)
+		require.NotNil(t, etx.InclusionDeadlineAt)
+		assert.WithinDuration(t, before.Add(time.Minute), *etx.InclusionDeadlineAt, 5*time.Second)
+
+		config.AssertExpectations(t)
+	})
+
+	t.Run("returns error if the inclusion deadline is negative", func(t *testing.T) {
+		_, err := txm.CreateEthTransaction(txmgr.NewTx{
+			FromAddress:       fromAddress,
+			ToAddress:         toAddress,
+			EncodedPayload:    payload,
+			GasLimit:          gasLimit,
+			Strategy:          txmgr.NewSendEveryStrategy(),
+			InclusionDeadline: txmgr.InclusionDeadline{Duration: -time.Minute},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "inclusion deadline must not be negative")
+	})
+
+	t.Run("returns error if the tx type is not supported on the chain", func(t *testing.T) {
+		config.On("EvmEIP1559DynamicFees").Return(false).Once()
+
+		_, err := txm.CreateEthTransaction(txmgr.NewTx{
+			FromAddress:    fromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: payload,
+			GasLimit:       gasLimit,
+			Strategy:       txmgr.NewSendEveryStrategy(),
+			TxType:         clnull.Int64From(int64(txmgr.DynamicFeeTxType)),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dynamic fee transactions require EIP-1559 to be enabled on this chain")
+	})
+
+	t.Run("returns error if private submission is requested without a private relay", func(t *testing.T) {
+		privateSubmission := true
+		_, err := txm.CreateEthTransaction(txmgr.NewTx{
+			FromAddress:       fromAddress,
+			ToAddress:         toAddress,
+			EncodedPayload:    payload,
+			GasLimit:          gasLimit,
+			Strategy:          txmgr.NewSendEveryStrategy(),
+			PrivateSubmission: &privateSubmission,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "private submission requires a private relay to be configured for this chain")
+	})
+}
+
+func TestTxm_CreateEthTransaction_Batching(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].Transactions.SpendLimits.JobDaily = assets.NewWeiI(42)
+	})
+	evmcfg := evmtest.NewChainScopedConfig(t, cfg)
+	kst := cltest.NewKeyStore(t, db, cfg)
+	_, fromAddress := cltest.MustInsertRandomKey(t, kst.Eth(), 0)
+	forwarder := testutils.NewAddress()
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	txm := txmgr.NewTxm(db, ethClient, evmcfg, kst.Eth(), nil, logger.TestLogger(t), &testCheckerFactory{}, nil)
+
+	newTx := func(meta *txmgr.EthTxMeta) txmgr.NewTx {
+		return txmgr.NewTx{
+			FromAddress:    fromAddress,
+			ToAddress:      testutils.NewAddress(),
+			EncodedPayload: []byte{1, 2, 3},
+			GasLimit:       1000,
+			Meta:           meta,
+			Strategy:       txmgr.NewBatchingStrategy(txmgr.NewSendEveryStrategy(), forwarder),
+		}
+	}
+
+	t.Run("batches transactions without a job", func(t *testing.T) {
+		etx, err := txm.CreateEthTransaction(newTx(nil))
+		require.NoError(t, err)
+		require.NotNil(t, etx.BatchForwarderAddress)
+		assert.Equal(t, forwarder, *etx.BatchForwarderAddress)
+	})
+
+	t.Run("does not batch transactions of a job with a gas spend limit", func(t *testing.T) {
+		jobID := int32(7)
+		etx, err := txm.CreateEthTransaction(newTx(&txmgr.EthTxMeta{JobID: &jobID}))
+		require.NoError(t, err)
+		assert.Nil(t, etx.BatchForwarderAddress)
+	})
 }
 
 func newMockTxStrategy(t *testing.T) *txmmocks.TxStrategy {
@@ -453,7 +652,9 @@ func newMockConfig(t *testing.T) *txmmocks.Config {
 	cfg.On("EvmGasBumpTxDepth").Return(uint16(42)).Maybe().Once()
 	cfg.On("EvmMaxInFlightTransactions").Return(uint32(42)).Maybe()
 	cfg.On("EvmMaxQueuedTransactions").Return(uint64(42)).Maybe().Once()
+	cfg.On("EvmMaxQueuedTransactionsPriority", mock.Anything).Return(uint64(0)).Maybe()
 	cfg.On("EvmNonceAutoSync").Return(true).Maybe()
+	cfg.On("EvmNonceGapsEnabled").Return(false).Maybe()
 	cfg.On("EvmGasLimitDefault").Return(uint32(42)).Maybe().Once()
 	cfg.On("BlockHistoryEstimatorBatchSize").Return(uint32(42)).Maybe().Once()
 	cfg.On("BlockHistoryEstimatorBlockDelay").Return(uint16(42)).Maybe().Once()
@@ -471,7 +672,15 @@ func newMockConfig(t *testing.T) *txmmocks.Config {
 	cfg.On("EvmGasTipCapMinimum").Return(assets.NewWeiI(42)).Maybe().Once()
 	cfg.On("EvmMaxGasPriceWei").Return(assets.NewWeiI(42)).Maybe().Once()
 	cfg.On("EvmMinGasPriceWei").Return(assets.NewWeiI(42)).Maybe().Once()
+	cfg.On("EvmJobSpendLimitDaily").Return(assets.NewWeiI(0)).Maybe()
+	cfg.On("EvmJobSpendLimitHourly").Return(assets.NewWeiI(0)).Maybe()
+	cfg.On("EvmPrivateRelayAllTransactions").Return(false).Maybe()
+	cfg.On("EvmPrivateRelayURL").Return((*url.URL)(nil)).Maybe()
+	cfg.On("EvmSimulateBeforeBroadcast").Return(false).Maybe()
 	cfg.On("EvmUseForwarders").Return(true).Maybe()
+	cfg.On("KeySpecificSignerURL", mock.Anything).Return(nil).Maybe()
+	cfg.On("KeySpecificSpendLimitDaily", mock.Anything).Return(assets.NewWeiI(0)).Maybe()
+	cfg.On("KeySpecificSpendLimitHourly", mock.Anything).Return(assets.NewWeiI(0)).Maybe()
 	cfg.On("LogSQL").Maybe().Return(false)
 	cfg.On("DatabaseDefaultQueryTimeout").Return(pg.DefaultQueryTimeout).Maybe()
 
@@ -499,7 +708,7 @@ func TestTxm_CreateEthTransaction_OutOfEth(t *testing.T) {
 
 	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
 	lggr := logger.TestLogger(t)
-	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr, pgtest.NewQConfig(true)), ethClient, lggr, 100*time.Millisecond, 2, 3, 2, 1000)
+	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr, pgtest.NewQConfig(true)), ethClient, lggr, 100*time.Millisecond, 2, false, 3, 2, 1000)
 	kst := cltest.NewKeyStore(t, db, cfg)
 	txm := txmgr.NewTxm(db, ethClient, config, kst.Eth(), nil, lggr, &testCheckerFactory{}, lp)
 
@@ -596,7 +805,7 @@ func TestTxm_Lifecycle(t *testing.T) {
 	lggr := logger.TestLogger(t)
 	checkerFactory := &testCheckerFactory{}
 
-	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr, pgtest.NewQConfig(true)), ethClient, lggr, 100*time.Millisecond, 2, 3, 2, 1000)
+	lp := logpoller.NewLogPoller(logpoller.NewORM(testutils.FixtureChainID, db, lggr, pgtest.NewQConfig(true)), ethClient, lggr, 100*time.Millisecond, 2, false, 3, 2, 1000)
 	txm := txmgr.NewTxm(db, ethClient, config, kst, eventBroadcaster, lggr, checkerFactory, lp)
 
 	head := cltest.Head(42)
@@ -644,6 +853,7 @@ func TestTxm_SignTx(t *testing.T) {
 	t.Run("returns correct hash for non-okex chains", func(t *testing.T) {
 		chainID := big.NewInt(1)
 		cfg := txmmocks.NewConfig(t)
+		cfg.On("KeySpecificSignerURL", addr).Return(nil)
 		kst := ksmocks.NewEth(t)
 		kst.On("SignTx", to, tx, chainID).Return(tx, nil).Once()
 		cks := txmgr.NewChainKeyStore(*chainID, cfg, kst)
@@ -656,6 +866,7 @@ func TestTxm_SignTx(t *testing.T) {
 	t.Run("returns correct hash for okex chains", func(t *testing.T) {
 		chainID := big.NewInt(1)
 		cfg := txmmocks.NewConfig(t)
+		cfg.On("KeySpecificSignerURL", addr).Return(nil)
 		kst := ksmocks.NewEth(t)
 		kst.On("SignTx", to, tx, chainID).Return(tx, nil).Once()
 		cks := txmgr.NewChainKeyStore(*chainID, cfg, kst)
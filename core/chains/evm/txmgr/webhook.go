@@ -0,0 +1,169 @@
+package txmgr
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pg"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+const (
+	// EventWebhookTimeout is how long the webhook endpoint has to accept an event
+	EventWebhookTimeout = 10 * time.Second
+	// EventWebhookSignatureHeader holds the signature of a webhook request
+	EventWebhookSignatureHeader = "X-Chainlink-Signature"
+	// EventWebhookTimestampHeader holds the time a webhook request was signed,
+	// in seconds since the Unix epoch
+	EventWebhookTimestampHeader = "X-Chainlink-Timestamp"
+	// EventWebhookTolerance is how far the timestamp of a webhook request may
+	// be from the clock of the endpoint. Endpoints should reject requests
+	// outside of it, so that a captured request can't be replayed later.
+	EventWebhookTolerance = 5 * time.Minute
+)
+
+// EventWebhook POSTs every EthTxEvent, encoded as JSON, to an external
+// endpoint. Delivery is best effort: an event that the endpoint fails to
+// accept is logged and dropped.
+//
+// Requests are signed with the shared secret of the webhook, see
+// SignEthTxEvent, so that the endpoint can authenticate the node.
+type EventWebhook struct {
+	utils.StartStopOnce
+	eventBroadcaster pg.EventBroadcaster
+	url              url.URL
+	secret           []byte
+	client           *http.Client
+	lggr             logger.SugaredLogger
+
+	sub    pg.Subscription
+	chStop chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewEventWebhook returns an EventWebhook delivering to u
+func NewEventWebhook(eventBroadcaster pg.EventBroadcaster, u url.URL, secret string, lggr logger.Logger) *EventWebhook {
+	return &EventWebhook{
+		eventBroadcaster: eventBroadcaster,
+		url:              u,
+		secret:           []byte(secret),
+		client:           &http.Client{Timeout: EventWebhookTimeout},
+		lggr:             logger.Sugared(lggr.Named("EventWebhook")),
+		chStop:           make(chan struct{}),
+	}
+}
+
+// SignEthTxEvent returns the value of the EventWebhookSignatureHeader for
+// body, signed at timestamp: the hex encoded HMAC-SHA256 of the timestamp, a
+// dot and body, keyed with secret, prefixed with the name of the hash
+// function.
+func SignEthTxEvent(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyEthTxEvent checks the signature and timestamp headers of a webhook
+// request with body, as received at now. The timestamp must be within
+// EventWebhookTolerance of now.
+func VerifyEthTxEvent(secret []byte, timestamp, signature string, body []byte, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid timestamp")
+	}
+	if d := now.Sub(time.Unix(ts, 0)); d > EventWebhookTolerance || d < -EventWebhookTolerance {
+		return errors.Errorf("timestamp %d is more than %s away from %d", ts, EventWebhookTolerance, now.Unix())
+	}
+	if !hmac.Equal([]byte(signature), []byte(SignEthTxEvent(secret, ts, body))) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// Start subscribes to the events of all transactions
+func (w *EventWebhook) Start(context.Context) error {
+	return w.StartOnce("EventWebhook", func() (err error) {
+		w.sub, err = w.eventBroadcaster.Subscribe(pg.ChannelEthTxEvents, "")
+		if err != nil {
+			return errors.Wrap(err, "EventWebhook could not start")
+		}
+		w.wg.Add(1)
+		go w.run()
+		return nil
+	})
+}
+
+// Close stops delivering events
+func (w *EventWebhook) Close() error {
+	return w.StopOnce("EventWebhook", func() error {
+		if w.sub != nil {
+			w.sub.Close()
+		}
+		close(w.chStop)
+		w.wg.Wait()
+		return nil
+	})
+}
+
+func (w *EventWebhook) run() {
+	defer w.wg.Done()
+	ctx, cancel := utils.ContextFromChan(w.chStop)
+	defer cancel()
+	for {
+		select {
+		case ev, ok := <-w.sub.Events():
+			if !ok {
+				w.lggr.Debug("Subscription channel closed, exiting delivery loop")
+				return
+			}
+			event, err := ParseEthTxEvent(ev.Payload)
+			if err != nil {
+				w.lggr.Errorw("Failed to parse event", "payload", ev.Payload, "err", err)
+				continue
+			}
+			if err = w.deliver(ctx, event); err != nil {
+				w.lggr.Warnw("Failed to deliver event", "type", event.Type, "ethTxID", event.EthTxID, "err", err)
+			}
+		case <-w.chStop:
+			return
+		}
+	}
+}
+
+func (w *EventWebhook) deliver(ctx context.Context, event EthTxEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode event")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	timestamp := time.Now().Unix()
+	req.Header.Set(EventWebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(EventWebhookSignatureHeader, SignEthTxEvent(w.secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "request failed")
+	}
+	defer w.lggr.ErrorIfFn(resp.Body.Close, "Error closing response body")
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("endpoint responded with status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package txmgr_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pg"
+)
+
+func TestSignEthTxEvent(t *testing.T) {
+	t.Parallel()
+
+	sig := txmgr.SignEthTxEvent([]byte("key"), 1665921600, []byte("The quick brown fox jumps over the lazy dog"))
+	assert.Equal(t, "sha256=c52fe44939c904ac929194e0a393d7532cc351c34a169f49ae07e9feff6094dc", sig)
+}
+
+func TestVerifyEthTxEvent(t *testing.T) {
+	t.Parallel()
+
+	secret, body := []byte("key"), []byte(`{"type": "confirmed"}`)
+	now := time.Unix(1665921600, 0)
+	sig := txmgr.SignEthTxEvent(secret, now.Unix(), body)
+
+	for _, tt := range []struct {
+		name      string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		err       string
+	}{
+		{"valid", "1665921600", sig, body, now, ""},
+		{"within tolerance", "1665921600", sig, body, now.Add(txmgr.EventWebhookTolerance), ""},
+		{"too old", "1665921600", sig, body, now.Add(txmgr.EventWebhookTolerance + time.Second), "is more than 5m0s away"},
+		{"in the future", "1665921600", sig, body, now.Add(-txmgr.EventWebhookTolerance - time.Second), "is more than 5m0s away"},
+		{"invalid timestamp", "foo", sig, body, now, "invalid timestamp"},
+		{"other timestamp", "1665921601", sig, body, now, "invalid signature"},
+		{"other body", "1665921600", sig, []byte("{}"), now, "invalid signature"},
+		{"other secret", "1665921600", txmgr.SignEthTxEvent([]byte("other"), now.Unix(), body), body, now, "invalid signature"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := txmgr.VerifyEthTxEvent(secret, tt.timestamp, tt.signature, tt.body, tt.now)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
+
+func TestEventWebhook(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		body      []byte
+		timestamp string
+		signature string
+	}
+	chRequests := make(chan request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		chRequests <- request{body, r.Header.Get(txmgr.EventWebhookTimestampHeader), r.Header.Get(txmgr.EventWebhookSignatureHeader)}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	eventBroadcaster := pg.NewNullEventBroadcaster()
+	secret := "webhook-secret"
+	webhook := txmgr.NewEventWebhook(eventBroadcaster, *u, secret, logger.TestLogger(t))
+	require.NoError(t, webhook.Start(testutils.Context(t)))
+	t.Cleanup(func() { assert.NoError(t, webhook.Close()) })
+
+	from := testutils.NewAddress()
+	eventBroadcaster.Sub.Ch <- pg.Event{
+		Channel: pg.ChannelEthTxEvents,
+		Payload: `{"type": "confirmed", "evmChainID": "42", "ethTxID": 7, "fromAddress": "` + from.Hex() + `", "state": "confirmed", "previousState": "unconfirmed", "timestamp": "2022-10-16T12:00:00.123456+00:00"}`,
+	}
+
+	select {
+	case req := <-chRequests:
+		assert.NoError(t, txmgr.VerifyEthTxEvent([]byte(secret), req.timestamp, req.signature, req.body, time.Now()))
+		event, err := txmgr.ParseEthTxEvent(string(req.body))
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.EthTxEventConfirmed, event.Type)
+		assert.Equal(t, int64(42), event.EVMChainID.ToInt().Int64())
+		assert.Equal(t, int64(7), event.EthTxID)
+		assert.Equal(t, from, event.FromAddress)
+		assert.Equal(t, txmgr.EthTxUnconfirmed, event.PreviousState)
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("timed out waiting for the webhook request")
+	}
+}
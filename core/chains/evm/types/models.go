@@ -38,6 +38,9 @@ type Head struct {
 	StateRoot        common.Hash
 	Difficulty       *utils.Big
 	TotalDifficulty  *utils.Big
+	// Finalized is the latest finalized block as of this head, if the chain
+	// reports it with the finalized block tag
+	Finalized *Head
 }
 
 // NewHead returns a Head instance.
@@ -59,6 +62,16 @@ func (h *Head) EarliestInChain() *Head {
 	return h
 }
 
+// LatestFinalizedBlockNumber returns the number of the latest finalized block
+// as of this head: its Finalized block if known, or the block finalityDepth
+// blocks behind it otherwise
+func (h *Head) LatestFinalizedBlockNumber(finalityDepth uint32) int64 {
+	if h.Finalized != nil {
+		return h.Finalized.Number
+	}
+	return h.Number - int64(finalityDepth)
+}
+
 // IsInChain returns true if the given hash matches the hash of a head in the chain
 func (h *Head) IsInChain(blockHash common.Hash) bool {
 	for {
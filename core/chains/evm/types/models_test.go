@@ -225,6 +225,14 @@ func TestHead_EarliestInChain(t *testing.T) {
 	assert.Equal(t, int64(1), head.EarliestInChain().Number)
 }
 
+func TestHead_LatestFinalizedBlockNumber(t *testing.T) {
+	head := evmtypes.Head{Number: 100}
+	assert.Equal(t, int64(50), head.LatestFinalizedBlockNumber(50))
+
+	head.Finalized = &evmtypes.Head{Number: 36}
+	assert.Equal(t, int64(36), head.LatestFinalizedBlockNumber(50))
+}
+
 func TestHead_IsInChain(t *testing.T) {
 	hash1 := utils.NewHash()
 	hash2 := utils.NewHash()
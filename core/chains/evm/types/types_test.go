@@ -150,6 +150,7 @@ func TestReceipt_MarshalUnmarshalJson(t *testing.T) {
 	t.Parallel()
 
 	receipt := types.FromGethReceipt(testGethReceipt)
+	receipt.EffectiveGasPrice = big.NewInt(20000000000)
 	json, err := receipt.MarshalJSON()
 	assert.NoError(t, err)
 	assert.NotEmpty(t, json)
@@ -443,6 +443,21 @@ func NewApp(client *Client) *cli.App {
 					Usage:  "Trigger a job run",
 					Action: client.TriggerPipelineRun,
 				},
+				{
+					Name:   "simulate",
+					Usage:  "Execute the pipeline of a job spec once without creating the job or sending transactions",
+					Action: client.SimulateJob,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "spec",
+							Usage: "TOML job spec, or the path of a file containing it",
+						},
+						cli.StringFlag{
+							Name:  "vars",
+							Usage: "JSON object of pipeline variables, e.g. '{\"jobRun\": {\"requestBody\": \"{}\"}}'",
+						},
+					},
+				},
 			},
 		},
 		{
@@ -1075,6 +1090,22 @@ func NewApp(client *Client) *cli.App {
 							Usage:  "get information on a specific Ethereum Transaction",
 							Action: client.ShowTransaction,
 						},
+						{
+							Name:   "cancel",
+							Usage:  "Cancel a specific Ethereum Transaction, replacing it with a transfer of zero ETH to its sender if it was sent",
+							Action: client.CancelTransaction,
+						},
+						{
+							Name:   "bump",
+							Usage:  "Replace the pending attempt of a specific Ethereum Transaction with one paying a higher gas price",
+							Action: client.BumpTransaction,
+							Flags: []cli.Flag{
+								cli.StringFlag{
+									Name:  "gas-price",
+									Usage: "the new gas price (fee cap for EIP-1559 transactions), e.g. 50gwei",
+								},
+							},
+						},
 					},
 				},
 				{
@@ -1130,6 +1161,18 @@ func NewApp(client *Client) *cli.App {
 					cli.StringFlag{Name: "id", Usage: "chain ID, options: [mainnet, testnet, devnet, localnet]"}),
 				chainCommand("StarkNet", StarkNetChainClient(client), cli.StringFlag{Name: "id", Usage: "chain ID"}),
 				chainCommand("Terra", TerraChainClient(client), cli.StringFlag{Name: "id", Usage: "chain ID"}),
+				{
+					Name:  "gas",
+					Usage: "Commands for the gas prices of EVM chains",
+					Subcommands: cli.Commands{
+						{
+							Name:   "show",
+							Usage:  "Show the current gas prices of an EVM chain, and what a pending transaction would be bumped to",
+							Action: client.ShowEVMGasPrices,
+							Flags:  []cli.Flag{cli.Int64Flag{Name: "id", Usage: "chain ID"}},
+						},
+					},
+				},
 			},
 		},
 		{
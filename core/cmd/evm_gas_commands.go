@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+var evmGasPricesHeaders = []string{"Chain ID", "EIP-1559", "Gas Price", "Bumped Gas Price", "Fee Cap", "Tip Cap", "Bumped Fee Cap", "Bumped Tip Cap", "Block History", "Errors"}
+
+// EVMGasPricesPresenter implements TableRenderer for an EVMGasPricesResource.
+type EVMGasPricesPresenter struct {
+	presenters.EVMGasPricesResource
+}
+
+// ToRow presents the EVMGasPricesResource as a slice of strings.
+func (p *EVMGasPricesPresenter) ToRow() []string {
+	return []string{
+		p.GetID(),
+		strconv.FormatBool(p.EIP1559DynamicFees),
+		weiOrNone(p.GasPrice),
+		weiOrNone(p.BumpedGasPrice),
+		weiOrNone(p.FeeCap),
+		weiOrNone(p.TipCap),
+		weiOrNone(p.BumpedFeeCap),
+		weiOrNone(p.BumpedTipCap),
+		p.blockHistory(),
+		strings.Join(p.Errors, "\n"),
+	}
+}
+
+func (p *EVMGasPricesPresenter) blockHistory() string {
+	w := p.BlockHistory
+	if w == nil {
+		return ""
+	}
+	blocks := "none"
+	if n := len(w.BlockNumbers); n > 0 {
+		blocks = fmt.Sprintf("%d to %d", w.BlockNumbers[0], w.BlockNumbers[n-1])
+	}
+	return fmt.Sprintf("%d%% percentile of %d/%d blocks (%s), gas price %s, tip cap %s",
+		w.Percentile, len(w.BlockNumbers), w.Size, blocks, weiOrNone(w.GasPrice), weiOrNone(w.TipCap))
+}
+
+// RenderTable implements TableRenderer
+func (p EVMGasPricesPresenter) RenderTable(rt RendererTable) error {
+	renderList(evmGasPricesHeaders, [][]string{p.ToRow()}, rt.Writer)
+	return nil
+}
+
+func weiOrNone(w *assets.Wei) string {
+	if w == nil {
+		return "none"
+	}
+	return w.String()
+}
+
+// ShowEVMGasPrices shows what the gas estimator of an EVM chain currently
+// believes gas costs, and what a pending attempt would be bumped to.
+func (cli *Client) ShowEVMGasPrices(c *cli.Context) (err error) {
+	if !c.IsSet("id") {
+		return cli.errorOut(errors.New("must pass --id"))
+	}
+	resp, err := cli.HTTP.Get(fmt.Sprintf("/v2/chains/evm/%d/gas", c.Int64("id")))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.renderAPIResponse(resp, &EVMGasPricesPresenter{})
+}
@@ -0,0 +1,43 @@
+package cmd_test
+
+import (
+	"flag"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	client2 "github.com/smartcontractkit/chainlink/core/chains/evm/client"
+	"github.com/smartcontractkit/chainlink/core/cmd"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+)
+
+func TestClient_ShowEVMGasPrices(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplicationV2(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].Enabled = ptr(true)
+		c.EVM[0].NonceAutoSync = ptr(false)
+		c.EVM[0].BalanceMonitor.Enabled = ptr(false)
+		c.EVM[0].GasEstimator.Mode = ptr("FixedPrice")
+	})
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("cli", 0)
+	set.Int64("id", client2.NullClientChainID, "")
+	require.NoError(t, set.Set("id", strconv.Itoa(client2.NullClientChainID)))
+	c := cli.NewContext(nil, set, nil)
+
+	require.NoError(t, client.ShowEVMGasPrices(c))
+	require.Len(t, r.Renders, 1)
+	p := r.Renders[0].(*cmd.EVMGasPricesPresenter)
+	assert.Equal(t, strconv.Itoa(client2.NullClientChainID), p.ID)
+	require.NotNil(t, p.GasPrice)
+	assert.Equal(t, "20 gwei", p.GasPrice.String())
+	require.NotNil(t, p.BumpedGasPrice)
+	assert.Equal(t, "25 gwei", p.BumpedGasPrice.String())
+	assert.Empty(t, p.Errors)
+	assertTableRenders(t, r)
+}
@@ -82,6 +82,63 @@ func (cli *Client) ShowTransaction(c *cli.Context) (err error) {
 	return err
 }
 
+// CancelTransaction cancels the transaction with the given hash, replacing it
+// with a transfer of zero ETH to its sender if it was already sent
+func (cli *Client) CancelTransaction(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("must pass the hash of the transaction"))
+	}
+	hash := c.Args().First()
+	resp, err := cli.HTTP.Post("/v2/transactions/evm/"+hash+"/cancel", nil)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	err = cli.renderAPIResponse(resp, &EthTxPresenter{})
+	return err
+}
+
+// BumpTransaction replaces the pending attempt of the transaction with the
+// given hash with one paying the given gas price
+func (cli *Client) BumpTransaction(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("must pass the hash of the transaction"))
+	}
+	if !c.IsSet("gas-price") {
+		return cli.errorOut(errors.New("must pass --gas-price"))
+	}
+	hash := c.Args().First()
+
+	var gasPrice assets.Wei
+	if err = gasPrice.UnmarshalText([]byte(c.String("gas-price"))); err != nil {
+		return cli.errorOut(multierr.Combine(
+			errors.New("while parsing gas price"), err))
+	}
+
+	requestData, err := json.Marshal(models.BumpEthTxRequest{GasPrice: &gasPrice})
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/transactions/evm/"+hash+"/bump", bytes.NewBuffer(requestData))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	err = cli.renderAPIResponse(resp, &EthTxPresenter{})
+	return err
+}
+
 // IndexTxAttempts returns the list of transactions in descending order,
 // taking an optional page parameter
 func (cli *Client) IndexTxAttempts(c *cli.Context) error {
@@ -15,6 +15,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/cmd"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
 func TestClient_IndexTransactions(t *testing.T) {
@@ -72,6 +73,43 @@ func TestClient_ShowTransaction(t *testing.T) {
 	assert.Equal(t, &tx.FromAddress, renderedTx.From)
 }
 
+func TestClient_CancelTransaction(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplicationV2(t, nil)
+	client, _ := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("test cancel tx", 0)
+	c := cli.NewContext(nil, set, nil)
+	require.EqualError(t, client.CancelTransaction(c), "must pass the hash of the transaction")
+
+	set = flag.NewFlagSet("test cancel tx", 0)
+	set.Parse([]string{utils.NewHash().Hex()})
+	c = cli.NewContext(nil, set, nil)
+	require.Error(t, client.CancelTransaction(c))
+}
+
+func TestClient_BumpTransaction(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplicationV2(t, nil)
+	client, _ := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("test bump tx", 0)
+	set.String("gas-price", "", "")
+	set.Parse([]string{utils.NewHash().Hex()})
+	c := cli.NewContext(nil, set, nil)
+	require.EqualError(t, client.BumpTransaction(c), "must pass --gas-price")
+
+	require.NoError(t, set.Set("gas-price", "lots"))
+	c = cli.NewContext(nil, set, nil)
+	require.ErrorContains(t, client.BumpTransaction(c), "while parsing gas price")
+
+	require.NoError(t, set.Set("gas-price", "50 gwei"))
+	c = cli.NewContext(nil, set, nil)
+	require.Error(t, client.BumpTransaction(c))
+}
+
 func TestClient_IndexTxAttempts(t *testing.T) {
 	t.Parallel()
 
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -153,6 +155,65 @@ func (ps JobPresenters) RenderTable(rt RendererTable) error {
 	return nil
 }
 
+// PipelineSimulationPresenter wraps the JSONAPI Pipeline Simulation Resource
+// and adds rendering functionality
+type PipelineSimulationPresenter struct {
+	JAID
+	presenters.PipelineSimulationResource
+}
+
+// RenderTable implements TableRenderer
+func (p *PipelineSimulationPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Task", "Type", "Inputs", "Output", "Error", "Attempts", "Duration"})
+	for _, tr := range p.TaskRuns {
+		var inputs []string
+		for _, input := range tr.Inputs {
+			if input.Error != nil {
+				inputs = append(inputs, "error: "+*input.Error)
+			} else {
+				inputs = append(inputs, stringOrEmpty(input.Value))
+			}
+		}
+		var duration string
+		if tr.FinishedAt.Valid {
+			duration = tr.FinishedAt.Time.Sub(tr.CreatedAt).String()
+		} else if tr.Pending {
+			duration = "pending"
+		}
+		table.Append([]string{
+			tr.DotID,
+			string(tr.Type),
+			strings.Join(inputs, "\n"),
+			stringOrEmpty(tr.Output),
+			stringOrEmpty(tr.Error),
+			strconv.FormatUint(uint64(tr.Attempts), 10),
+			duration,
+		})
+	}
+	render("Pipeline Simulation", table)
+
+	table = rt.newTable([]string{"Outputs", "Errors"})
+	var outputs, errs []string
+	for _, o := range p.Outputs {
+		outputs = append(outputs, stringOrEmpty(o))
+	}
+	for _, e := range p.FatalErrors {
+		if e != nil {
+			errs = append(errs, *e)
+		}
+	}
+	table.Append([]string{strings.Join(outputs, "\n"), strings.Join(errs, "\n")})
+	render("Result", table)
+	return nil
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 // ListJobs lists all jobs
 func (cli *Client) ListJobs(c *cli.Context) (err error) {
 	return cli.getPage("/v2/jobs", c.Int("page"), &JobPresenters{})
@@ -220,6 +281,46 @@ func (cli *Client) CreateJob(c *cli.Context) (err error) {
 	return err
 }
 
+// SimulateJob executes the pipeline of a job spec once, without creating the
+// job or sending any transactions, and renders the result of every task
+func (cli *Client) SimulateJob(c *cli.Context) (err error) {
+	if !c.IsSet("spec") {
+		return cli.errorOut(errors.New("must pass in TOML or filepath with --spec"))
+	}
+
+	tomlString, err := getTOMLString(c.String("spec"))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	var vars map[string]interface{}
+	if c.IsSet("vars") {
+		if err = json.Unmarshal([]byte(c.String("vars")), &vars); err != nil {
+			return cli.errorOut(errors.Wrap(err, "invalid --vars, must be a JSON object"))
+		}
+	}
+
+	request, err := json.Marshal(web.SimulateJobRequest{
+		TOML: tomlString,
+		Vars: vars,
+	})
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/jobs/simulate", bytes.NewReader(request))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.renderAPIResponse(resp, &PipelineSimulationPresenter{})
+}
+
 // DeleteJob deletes a job
 func (cli *Client) DeleteJob(c *cli.Context) error {
 	if !c.Args().Present() {
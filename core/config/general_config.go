@@ -53,6 +53,7 @@ type FeatureFlags interface {
 	FeatureOffchainReporting2() bool
 	FeatureUICSAKeys() bool
 	FeatureLogPoller() bool
+	FeatureTxEvents() bool
 
 	AutoPprofEnabled() bool
 	EVMEnabled() bool
@@ -129,6 +130,8 @@ type BasicConfig interface {
 	HTTPServerWriteTimeout() time.Duration
 	InsecureFastScrypt() bool
 	JSONConsole() bool
+	JobPipelineHTTPCircuitBreakerCooldown() time.Duration
+	JobPipelineHTTPCircuitBreakerFailureThreshold() uint32
 	JobPipelineMaxRunDuration() time.Duration
 	JobPipelineMaxSuccessfulRuns() uint64
 	JobPipelineReaperInterval() time.Duration
@@ -194,6 +197,8 @@ type BasicConfig interface {
 	TelemetryIngressSendTimeout() time.Duration
 	TelemetryIngressUseBatchSend() bool
 	TriggerFallbackDBPollInterval() time.Duration
+	TxWebhookSecret() string
+	TxWebhookURL() *url.URL
 	UnAuthenticatedRateLimit() int64
 	UnAuthenticatedRateLimitPeriod() models.Duration
 	VRFPassword() string
@@ -762,6 +767,12 @@ func (c *generalConfig) FeatureLogPoller() bool {
 	return c.viper.GetBool(envvar.Name("FeatureLogPoller"))
 }
 
+// FeatureTxEvents is not supported by the legacy config; the lifecycle events
+// of transactions can only be enabled with TOML.
+func (c *generalConfig) FeatureTxEvents() bool {
+	return false
+}
+
 // FeatureOffchainReporting enables the OCR job type.
 func (c *generalConfig) FeatureOffchainReporting() bool {
 	return getEnvWithFallback(c, envvar.NewBool("FeatureOffchainReporting"))
@@ -895,6 +906,27 @@ func (c *generalConfig) TriggerFallbackDBPollInterval() time.Duration {
 	return getEnvWithFallback(c, envvar.NewDuration("TriggerFallbackDBPollInterval"))
 }
 
+// TxWebhookSecret is not supported by the legacy config; the transaction
+// event webhook can only be configured with V2 TOML secrets.
+func (c *generalConfig) TxWebhookSecret() string {
+	return ""
+}
+
+func (c *generalConfig) TxWebhookURL() *url.URL {
+	return nil
+}
+
+// JobPipelineHTTPCircuitBreakerFailureThreshold is not supported by the
+// legacy config; the circuit breakers of http and bridge tasks can only be
+// enabled with TOML.
+func (c *generalConfig) JobPipelineHTTPCircuitBreakerFailureThreshold() uint32 {
+	return 0
+}
+
+func (c *generalConfig) JobPipelineHTTPCircuitBreakerCooldown() time.Duration {
+	return 30 * time.Second
+}
+
 // JobPipelineMaxRunDuration is the maximum time that a job run may take
 func (c *generalConfig) JobPipelineMaxRunDuration() time.Duration {
 	return getEnvWithFallback(c, envvar.JobPipelineMaxRunDuration)
@@ -928,6 +928,20 @@ func (_m *GeneralConfig) FeatureOffchainReporting2() bool {
 	return r0
 }
 
+// FeatureTxEvents provides a mock function with given fields:
+func (_m *GeneralConfig) FeatureTxEvents() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // FeatureUICSAKeys provides a mock function with given fields:
 func (_m *GeneralConfig) FeatureUICSAKeys() bool {
 	ret := _m.Called()
@@ -2309,6 +2323,34 @@ func (_m *GeneralConfig) JSONConsole() bool {
 	return r0
 }
 
+// JobPipelineHTTPCircuitBreakerCooldown provides a mock function with given fields:
+func (_m *GeneralConfig) JobPipelineHTTPCircuitBreakerCooldown() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// JobPipelineHTTPCircuitBreakerFailureThreshold provides a mock function with given fields:
+func (_m *GeneralConfig) JobPipelineHTTPCircuitBreakerFailureThreshold() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
 // JobPipelineMaxRunDuration provides a mock function with given fields:
 func (_m *GeneralConfig) JobPipelineMaxRunDuration() time.Duration {
 	ret := _m.Called()
@@ -3936,6 +3978,36 @@ func (_m *GeneralConfig) TriggerFallbackDBPollInterval() time.Duration {
 	return r0
 }
 
+// TxWebhookSecret provides a mock function with given fields:
+func (_m *GeneralConfig) TxWebhookSecret() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TxWebhookURL provides a mock function with given fields:
+func (_m *GeneralConfig) TxWebhookURL() *url.URL {
+	ret := _m.Called()
+
+	var r0 *url.URL
+	if rf, ok := ret.Get(0).(func() *url.URL); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*url.URL)
+		}
+	}
+
+	return r0
+}
+
 // UnAuthenticatedRateLimit provides a mock function with given fields:
 func (_m *GeneralConfig) UnAuthenticatedRateLimit() int64 {
 	ret := _m.Called()
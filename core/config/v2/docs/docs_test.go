@@ -20,6 +20,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink/cfgtest"
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 )
 
 func TestDoc(t *testing.T) {
@@ -51,7 +52,9 @@ func TestDoc(t *testing.T) {
 		// clean up KeySpecific as a special case
 		require.Equal(t, 1, len(docDefaults.KeySpecific))
 		ks := evmcfg.KeySpecific{Key: new(ethkey.EIP55Address),
-			GasEstimator: evmcfg.KeySpecificGasEstimator{PriceMax: new(assets.Wei)}}
+			GasEstimator: evmcfg.KeySpecificGasEstimator{PriceMax: new(assets.Wei)},
+			Signer:       evmcfg.KeySpecificSigner{URL: new(models.URL)},
+			SpendLimits:  evmcfg.KeySpecificSpendLimits{Hourly: new(assets.Wei), Daily: new(assets.Wei)}}
 		require.Equal(t, ks, docDefaults.KeySpecific[0])
 		docDefaults.KeySpecific = nil
 
@@ -63,6 +66,12 @@ func TestDoc(t *testing.T) {
 		require.Zero(t, *docDefaults.GasEstimator.LimitJobType.FM)
 		docDefaults.GasEstimator.LimitJobType = evmcfg.GasLimitJobType{}
 
+		// per-priority queue caps are nilable
+		require.Zero(t, *docDefaults.Transactions.MaxQueuedPriority.High)
+		require.Zero(t, *docDefaults.Transactions.MaxQueuedPriority.Normal)
+		require.Zero(t, *docDefaults.Transactions.MaxQueuedPriority.Low)
+		docDefaults.Transactions.MaxQueuedPriority = evmcfg.TransactionsMaxQueuedPriority{}
+
 		// EIP1559FeeCapBufferBlocks doesn't have a constant default - it is derived from another field
 		require.Zero(t, *docDefaults.GasEstimator.BlockHistory.EIP1559FeeCapBufferBlocks)
 		docDefaults.GasEstimator.BlockHistory.EIP1559FeeCapBufferBlocks = nil
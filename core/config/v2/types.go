@@ -114,6 +114,7 @@ type Secrets struct {
 	Password  Passwords        `toml:",omitempty"`
 	Pyroscope PyroscopeSecrets `toml:",omitempty"`
 	Mercury   MercurySecrets   `toml:",omitempty"`
+	TxWebhook TxWebhookSecrets `toml:",omitempty"`
 }
 
 func dbURLPasswordComplexity(err error) string {
@@ -188,9 +189,25 @@ func (m *MercurySecrets) ValidateConfig() (err error) {
 	return nil
 }
 
+type TxWebhookSecrets struct {
+	URL    *models.SecretURL
+	Secret *models.Secret
+}
+
+func (t *TxWebhookSecrets) ValidateConfig() (err error) {
+	if t.URL != nil && (t.Secret == nil || *t.Secret == "") {
+		err = multierr.Append(err, ErrEmpty{Name: "Secret", Msg: "must be provided and non-empty when URL is set"})
+	}
+	if t.Secret != nil && t.URL == nil {
+		err = multierr.Append(err, ErrMissing{Name: "URL", Msg: "must be set when Secret is set"})
+	}
+	return err
+}
+
 type Feature struct {
 	FeedsManager *bool
 	LogPoller    *bool
+	TxEvents     *bool
 	UICSAKeys    *bool
 }
 
@@ -201,6 +218,9 @@ func (f *Feature) setFrom(f2 *Feature) {
 	if v := f2.LogPoller; v != nil {
 		f.LogPoller = v
 	}
+	if v := f2.TxEvents; v != nil {
+		f.TxEvents = v
+	}
 	if v := f2.UICSAKeys; v != nil {
 		f.UICSAKeys = v
 	}
@@ -587,6 +607,9 @@ func (j *JobPipeline) setFrom(f *JobPipeline) {
 type JobPipelineHTTPRequest struct {
 	DefaultTimeout *models.Duration
 	MaxSize        *utils.FileSize
+
+	CircuitBreakerFailureThreshold *uint32
+	CircuitBreakerCooldown         *models.Duration
 }
 
 func (j *JobPipelineHTTPRequest) setFrom(f *JobPipelineHTTPRequest) {
@@ -596,6 +619,12 @@ func (j *JobPipelineHTTPRequest) setFrom(f *JobPipelineHTTPRequest) {
 	if v := f.MaxSize; v != nil {
 		j.MaxSize = v
 	}
+	if v := f.CircuitBreakerFailureThreshold; v != nil {
+		j.CircuitBreakerFailureThreshold = v
+	}
+	if v := f.CircuitBreakerCooldown; v != nil {
+		j.CircuitBreakerCooldown = v
+	}
 }
 
 type FluxMonitor struct {
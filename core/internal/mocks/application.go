@@ -484,6 +484,36 @@ func (_m *Application) SetLogLevel(lvl zapcore.Level) error {
 	return r0
 }
 
+// SimulateJobV2 provides a mock function with given fields: ctx, jb, vars
+func (_m *Application) SimulateJobV2(ctx context.Context, jb job.Job, vars map[string]interface{}) (pipeline.Run, pipeline.TaskRunResults, error) {
+	ret := _m.Called(ctx, jb, vars)
+
+	var r0 pipeline.Run
+	if rf, ok := ret.Get(0).(func(context.Context, job.Job, map[string]interface{}) pipeline.Run); ok {
+		r0 = rf(ctx, jb, vars)
+	} else {
+		r0 = ret.Get(0).(pipeline.Run)
+	}
+
+	var r1 pipeline.TaskRunResults
+	if rf, ok := ret.Get(1).(func(context.Context, job.Job, map[string]interface{}) pipeline.TaskRunResults); ok {
+		r1 = rf(ctx, jb, vars)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(pipeline.TaskRunResults)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, job.Job, map[string]interface{}) error); ok {
+		r2 = rf(ctx, jb, vars)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Start provides a mock function with given fields: ctx
 func (_m *Application) Start(ctx context.Context) error {
 	ret := _m.Called(ctx)
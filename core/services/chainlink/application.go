@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -95,6 +96,8 @@ type Application interface {
 	DeleteJob(ctx context.Context, jobID int32) error
 	RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable) (int64, error)
 	ResumeJobV2(ctx context.Context, taskID uuid.UUID, result pipeline.Result) error
+	// SimulateJobV2 executes the pipeline of a job once, without creating the job or persisting the run.
+	SimulateJobV2(ctx context.Context, jb job.Job, vars map[string]interface{}) (pipeline.Run, pipeline.TaskRunResults, error)
 	// Testing only
 	RunJobV2(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error)
 
@@ -279,6 +282,13 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 	}
 
 	srvcs = append(srvcs, eventBroadcaster, mailMon)
+	if err := txmgr.SetEthTxEventsEnabled(db, cfg.FeatureTxEvents() || cfg.TxWebhookURL() != nil); err != nil {
+		return nil, errors.Wrap(err, "NewApplication: failed to set transaction events")
+	}
+	if u := cfg.TxWebhookURL(); u != nil {
+		globalLogger.Infow("EventWebhook: delivering transaction events", "url", u.Redacted())
+		srvcs = append(srvcs, txmgr.NewEventWebhook(eventBroadcaster, *u, cfg.TxWebhookSecret(), globalLogger))
+	}
 	srvcs = append(srvcs, chains.services()...)
 	promReporter := promreporter.NewPromReporter(db.DB, globalLogger)
 	srvcs = append(srvcs, promReporter)
@@ -769,6 +779,33 @@ func (app *ChainlinkApplication) ResumeJobV2(
 	return app.pipelineRunner.ResumeRun(taskID, result.Value, result.Error)
 }
 
+// SimulateJobV2 executes the pipeline of jb once, with the given vars, without
+// creating the job or persisting the run. See pipeline.Runner.SimulateRun for
+// how tasks with side effects are handled.
+func (app *ChainlinkApplication) SimulateJobV2(
+	ctx context.Context,
+	jb job.Job,
+	vars map[string]interface{},
+) (pipeline.Run, pipeline.TaskRunResults, error) {
+	if jb.Pipeline.Source == "" {
+		return pipeline.Run{}, nil, errors.Errorf("%s job has no pipeline to simulate", jb.Type)
+	}
+
+	spec := pipeline.Spec{
+		DotDagSource:      jb.Pipeline.Source,
+		CreatedAt:         time.Now(),
+		MaxTaskDuration:   jb.MaxTaskDuration,
+		ForwardingAllowed: jb.ForwardingAllowed,
+		JobName:           jb.Name.ValueOrZero(),
+		JobType:           string(jb.Type),
+	}
+	if jb.GasLimit.Valid {
+		spec.GasLimit = &jb.GasLimit.Uint32
+	}
+
+	return app.pipelineRunner.SimulateRun(ctx, spec, pipeline.NewVarsFrom(vars), app.logger.Named("PipelineSimulation"))
+}
+
 func (app *ChainlinkApplication) GetFeedsService() feeds.Service {
 	return app.FeedsService
 }
@@ -300,6 +300,10 @@ func (g *generalConfig) FeatureLogPoller() bool {
 	return *g.c.Feature.LogPoller
 }
 
+func (g *generalConfig) FeatureTxEvents() bool {
+	return *g.c.Feature.TxEvents
+}
+
 func (g *generalConfig) FeatureUICSAKeys() bool {
 	return *g.c.Feature.UICSAKeys
 }
@@ -572,6 +576,14 @@ func (g *generalConfig) DefaultHTTPTimeout() models.Duration {
 	return *g.c.JobPipeline.HTTPRequest.DefaultTimeout
 }
 
+func (g *generalConfig) JobPipelineHTTPCircuitBreakerFailureThreshold() uint32 {
+	return *g.c.JobPipeline.HTTPRequest.CircuitBreakerFailureThreshold
+}
+
+func (g *generalConfig) JobPipelineHTTPCircuitBreakerCooldown() time.Duration {
+	return g.c.JobPipeline.HTTPRequest.CircuitBreakerCooldown.Duration()
+}
+
 func (g *generalConfig) ShutdownGracePeriod() time.Duration {
 	return g.c.ShutdownGracePeriod.Duration()
 }
@@ -39,6 +39,17 @@ func (g *generalConfig) PyroscopeAuthToken() string {
 	return string(*g.secrets.Pyroscope.AuthToken)
 }
 
+func (g *generalConfig) TxWebhookURL() *url.URL {
+	return g.secrets.TxWebhook.URL.URL()
+}
+
+func (g *generalConfig) TxWebhookSecret() string {
+	if g.secrets.TxWebhook.Secret == nil {
+		return ""
+	}
+	return string(*g.secrets.TxWebhook.Secret)
+}
+
 func (g *generalConfig) MercuryCredentials(url string) (username, password string, err error) {
 	if g.secrets.Mercury.Credentials == nil {
 		return "", "", errors.New("no Mercury credentials were specified in the config")
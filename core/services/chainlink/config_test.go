@@ -237,6 +237,7 @@ func TestConfig_Marshal(t *testing.T) {
 	full.Feature = config.Feature{
 		FeedsManager: ptr(true),
 		LogPoller:    ptr(true),
+		TxEvents:     ptr(true),
 		UICSAKeys:    ptr(true),
 	}
 	full.Database = config.Database{
@@ -321,8 +322,10 @@ func TestConfig_Marshal(t *testing.T) {
 		ReaperThreshold:           models.MustNewDuration(7 * 24 * time.Hour),
 		ResultWriteQueueDepth:     ptr[uint32](10),
 		HTTPRequest: config.JobPipelineHTTPRequest{
-			MaxSize:        ptr[utils.FileSize](100 * utils.MB),
-			DefaultTimeout: models.MustNewDuration(time.Minute),
+			MaxSize:                        ptr[utils.FileSize](100 * utils.MB),
+			DefaultTimeout:                 models.MustNewDuration(time.Minute),
+			CircuitBreakerFailureThreshold: ptr[uint32](5),
+			CircuitBreakerCooldown:         models.MustNewDuration(time.Minute),
 		},
 	}
 	full.FluxMonitor = config.FluxMonitor{
@@ -431,6 +434,7 @@ func TestConfig_Marshal(t *testing.T) {
 				BlockBackfillSkip:    ptr(true),
 				ChainType:            ptr("Optimism"),
 				FinalityDepth:        ptr[uint32](42),
+				FinalityTagEnabled:   ptr(true),
 				FlagsContractAddress: mustAddress("0xae4E781a6218A8031764928E88d457937A954fC3"),
 
 				GasEstimator: evmcfg.GasEstimator{
@@ -467,6 +471,16 @@ func TestConfig_Marshal(t *testing.T) {
 						EIP1559FeeCapBufferBlocks: ptr[uint16](13),
 						TransactionPercentile:     ptr[uint16](15),
 					},
+					FeeHistory: evmcfg.FeeHistoryEstimator{
+						BlockCount:       ptr[uint16](16),
+						RewardPercentile: ptr[uint16](45),
+					},
+					Composite: evmcfg.CompositeEstimator{
+						Modes:       &[]string{"FeeHistory", "FixedPrice"},
+						Policy:      ptr("FirstHealthy"),
+						BandPercent: ptr[uint16](150),
+						HistorySize: ptr[uint16](20),
+					},
 				},
 
 				KeySpecific: []evmcfg.KeySpecific{
@@ -475,6 +489,13 @@ func TestConfig_Marshal(t *testing.T) {
 						GasEstimator: evmcfg.KeySpecificGasEstimator{
 							PriceMax: assets.NewWei(utils.HexToBig("FFFFFFFFFFFFFFFFFFFFFFFF")),
 						},
+						Signer: evmcfg.KeySpecificSigner{
+							URL: mustURL("https://signer.example.com"),
+						},
+						SpendLimits: evmcfg.KeySpecificSpendLimits{
+							Hourly: assets.GWei(200),
+							Daily:  assets.GWei(2000),
+						},
 					},
 				},
 
@@ -491,12 +512,35 @@ func TestConfig_Marshal(t *testing.T) {
 				RPCBlockQueryDelay:       ptr[uint16](10),
 
 				Transactions: evmcfg.Transactions{
-					MaxInFlight:          ptr[uint32](19),
-					MaxQueued:            ptr[uint32](99),
-					ReaperInterval:       &minute,
-					ReaperThreshold:      &minute,
-					ResendAfterThreshold: &hour,
-					ForwardersEnabled:    ptr(true),
+					MaxInFlight:             ptr[uint32](19),
+					MaxQueued:               ptr[uint32](99),
+					ReaperInterval:          &minute,
+					ReaperThreshold:         &minute,
+					ResendAfterThreshold:    &hour,
+					ForwardersEnabled:       ptr(true),
+					SimulateBeforeBroadcast: ptr(true),
+					MaxQueuedPriority: evmcfg.TransactionsMaxQueuedPriority{
+						High:   ptr[uint32](10),
+						Normal: ptr[uint32](50),
+						Low:    ptr[uint32](20),
+					},
+					PrivateRelay: evmcfg.TransactionsPrivateRelay{
+						URL:             mustURL("https://relay.example.com"),
+						Method:          ptr("eth_sendBundle"),
+						FallbackBlocks:  ptr[uint32](10),
+						AllTransactions: ptr(true),
+					},
+					SpendLimits: evmcfg.TransactionsSpendLimits{
+						KeyHourly: assets.GWei(500),
+						KeyDaily:  assets.GWei(5000),
+						JobHourly: assets.GWei(100),
+						JobDaily:  assets.GWei(1000),
+					},
+					NonceGaps: evmcfg.TransactionsNonceGaps{
+						Enabled: ptr(true),
+						Fill:    ptr(true),
+						Resync:  ptr(true),
+					},
 				},
 
 				HeadTracker: evmcfg.HeadTracker{
@@ -625,6 +669,7 @@ Headers = ['Authorization: token', 'X-SomeOther-Header: value with spaces | and
 		{"Feature", Config{Core: config.Core{Feature: full.Feature}}, `[Feature]
 FeedsManager = true
 LogPoller = true
+TxEvents = true
 UICSAKeys = true
 `},
 		{"Database", Config{Core: config.Core{Database: full.Database}}, `[Database]
@@ -716,6 +761,8 @@ ResultWriteQueueDepth = 10
 [JobPipeline.HTTPRequest]
 DefaultTimeout = '1m0s'
 MaxSize = '100.00mb'
+CircuitBreakerFailureThreshold = 5
+CircuitBreakerCooldown = '1m0s'
 `},
 		{"OCR", Config{Core: config.Core{OCR: full.OCR}}, `[OCR]
 Enabled = true
@@ -811,6 +858,7 @@ BlockBackfillDepth = 100
 BlockBackfillSkip = true
 ChainType = 'Optimism'
 FinalityDepth = 42
+FinalityTagEnabled = true
 FlagsContractAddress = '0xae4E781a6218A8031764928E88d457937A954fC3'
 LinkContractAddress = '0x538aAaB4ea120b2bC2fe5D296852D948F07D849e'
 LogBackfillBatchSize = 17
@@ -831,6 +879,29 @@ MaxQueued = 99
 ReaperInterval = '1m0s'
 ReaperThreshold = '1m0s'
 ResendAfterThreshold = '1h0m0s'
+SimulateBeforeBroadcast = true
+
+[EVM.Transactions.MaxQueuedPriority]
+High = 10
+Normal = 50
+Low = 20
+
+[EVM.Transactions.PrivateRelay]
+URL = 'https://relay.example.com'
+Method = 'eth_sendBundle'
+FallbackBlocks = 10
+AllTransactions = true
+
+[EVM.Transactions.SpendLimits]
+KeyHourly = '500 gwei'
+KeyDaily = '5 micro'
+JobHourly = '100 gwei'
+JobDaily = '1 micro'
+
+[EVM.Transactions.NonceGaps]
+Enabled = true
+Fill = true
+Resync = true
 
 [EVM.BalanceMonitor]
 Enabled = true
@@ -868,6 +939,16 @@ CheckInclusionPercentile = 19
 EIP1559FeeCapBufferBlocks = 13
 TransactionPercentile = 15
 
+[EVM.GasEstimator.FeeHistory]
+BlockCount = 16
+RewardPercentile = 45
+
+[EVM.GasEstimator.Composite]
+Modes = ['FeeHistory', 'FixedPrice']
+Policy = 'FirstHealthy'
+BandPercent = 150
+HistorySize = 20
+
 [EVM.HeadTracker]
 HistoryDepth = 15
 MaxBufferSize = 17
@@ -879,6 +960,13 @@ Key = '0x2a3e23c6f242F5345320814aC8a1b4E58707D292'
 [EVM.KeySpecific.GasEstimator]
 PriceMax = '79.228162514264337593543950335 gether'
 
+[EVM.KeySpecific.Signer]
+URL = 'https://signer.example.com'
+
+[EVM.KeySpecific.SpendLimits]
+Hourly = '200 gwei'
+Daily = '2 micro'
+
 [EVM.NodePool]
 PollFailureThreshold = 5
 PollInterval = '1m0s'
@@ -1040,15 +1128,21 @@ func TestConfig_Validate(t *testing.T) {
 					- WSURL: missing: required for primary nodes
 					- HTTPURL: missing: required for all nodes
 				- 1.HTTPURL: missing: required for all nodes
-		- 1: 6 errors:
+		- 1: 7 errors:
 			- ChainType: invalid value (Foo): must not be set with this chain id
 			- Nodes: missing: must have at least one node
 			- ChainType: invalid value (Foo): must be one of arbitrum, metis, optimism, xdai, optimismBedrock or omitted
 			- HeadTracker.HistoryDepth: invalid value (30): must be equal to or reater than FinalityDepth
+			- Transactions.PrivateRelay: 3 errors:
+				- Method: invalid value (eth_sendRawTransaction): must be eth_sendPrivateTransaction or eth_sendBundle
+				- FallbackBlocks: invalid value (0): must be greater than 0
+				- URL: invalid value (ftp): must be http or https
 			- GasEstimator: 2 errors:
 				- FeeCapDefault: invalid value (101 wei): must be equal to PriceMax (99 wei) since you are using FixedPrice estimation with gas bumping disabled in EIP1559 mode - PriceMax will be used as the FeeCap for transactions instead of FeeCapDefault
 				- PriceMax: invalid value (1 gwei): must be greater than or equal to PriceDefault
-			- KeySpecific.Key: invalid value (0xde709f2102306220921060314715629080e2fb77): duplicate - must be unique
+			- KeySpecific: 2 errors:
+				- Key: invalid value (0xde709f2102306220921060314715629080e2fb77): duplicate - must be unique
+				- 1.Signer.URL: invalid value (ftp): must be http or https
 		- 2: 5 errors:
 			- ChainType: invalid value (Arbitrum): only "optimism" can be used with this chain id
 			- Nodes: missing: must have at least one node
@@ -1283,6 +1377,14 @@ URL = "http://example.COM/foo"`,
 	- Database.URL: empty: must be provided and non-empty
 	- Password.Keystore: empty: must be provided and non-empty
 	- Mercury.Credentials: may not contain duplicate URLs`},
+		{name: "tx-webhook-without-secret",
+			toml: `
+[TxWebhook]
+URL = "https://example.com/tx-events"`,
+			exp: `invalid secrets: 3 errors:
+	- Database.URL: empty: must be provided and non-empty
+	- Password.Keystore: empty: must be provided and non-empty
+	- TxWebhook.Secret: empty: must be provided and non-empty when URL is set`},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			var s Secrets
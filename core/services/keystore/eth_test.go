@@ -8,8 +8,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
@@ -302,6 +304,32 @@ func Test_EthKeyStore_SignTx(t *testing.T) {
 	require.NotEqual(t, tx, signed)
 }
 
+func Test_EthKeyStore_SignMessage(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	config := configtest.NewTestGeneralConfig(t)
+	keyStore := cltest.NewKeyStore(t, db, config)
+	ethKeyStore := keyStore.Eth()
+
+	k, _ := cltest.MustAddRandomKeyToKeystore(t, ethKeyStore)
+	message := []byte("hello")
+
+	randomAddress := testutils.NewAddress()
+	_, err := ethKeyStore.SignMessage(randomAddress, message)
+	require.EqualError(t, err, fmt.Sprintf("unable to find eth key with id %s", randomAddress.Hex()))
+
+	sig, err := ethKeyStore.SignMessage(k.Address, message)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+	assert.Contains(t, []byte{27, 28}, sig[64])
+
+	sig[64] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash(message), sig)
+	require.NoError(t, err)
+	assert.Equal(t, k.Address, crypto.PubkeyToAddress(*pub))
+}
+
 func Test_EthKeyStore_E2E(t *testing.T) {
 	t.Parallel()
 
@@ -429,6 +429,29 @@ func (_m *Eth) Reset(address common.Address, chainID *big.Int, nonce int64, qopt
 	return r0
 }
 
+// SignMessage provides a mock function with given fields: address, message
+func (_m *Eth) SignMessage(address common.Address, message []byte) ([]byte, error) {
+	ret := _m.Called(address, message)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(common.Address, []byte) []byte); ok {
+		r0 = rf(address, message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, []byte) error); ok {
+		r1 = rf(address, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignTx provides a mock function with given fields: fromAddress, tx, chainID
 func (_m *Eth) SignTx(fromAddress common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
 	ret := _m.Called(fromAddress, tx, chainID)
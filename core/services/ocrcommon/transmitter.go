@@ -76,6 +76,7 @@ func (t *transmitter) CreateEthTransaction(ctx context.Context, toAddress common
 		ToAddress:        toAddress,
 		EncodedPayload:   payload,
 		GasLimit:         t.gasLimit,
+		Meta:             &txmgr.EthTxMeta{Priority: txmgr.EthTxPriorityHigh},
 		ForwarderAddress: t.forwarderAddress(),
 		Strategy:         t.strategy,
 		Checker:          t.checker,
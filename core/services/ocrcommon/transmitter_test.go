@@ -51,7 +51,7 @@ func Test_DefaultTransmitter_CreateEthTransaction(t *testing.T) {
 		ToAddress:      toAddress,
 		EncodedPayload: payload,
 		GasLimit:       gasLimit,
-		Meta:           nil,
+		Meta:           &txmgr.EthTxMeta{Priority: txmgr.EthTxPriorityHigh},
 		Strategy:       strategy,
 	}, mock.Anything).Return(txmgr.EthTx{}, nil).Once()
 	require.NoError(t, transmitter.CreateEthTransaction(testutils.Context(t), toAddress, payload))
@@ -92,7 +92,7 @@ func Test_DefaultTransmitter_Forwarding_Enabled_CreateEthTransaction(t *testing.
 		ToAddress:      toAddress,
 		EncodedPayload: payload,
 		GasLimit:       gasLimit,
-		Meta:           nil,
+		Meta:           &txmgr.EthTxMeta{Priority: txmgr.EthTxPriorityHigh},
 		Strategy:       strategy,
 	}, mock.Anything).Return(txmgr.EthTx{}, nil).Once()
 	txm.On("CreateEthTransaction", txmgr.NewTx{
@@ -100,7 +100,7 @@ func Test_DefaultTransmitter_Forwarding_Enabled_CreateEthTransaction(t *testing.
 		ToAddress:      toAddress,
 		EncodedPayload: payload,
 		GasLimit:       gasLimit,
-		Meta:           nil,
+		Meta:           &txmgr.EthTxMeta{Priority: txmgr.EthTxPriorityHigh},
 		Strategy:       strategy,
 	}, mock.Anything).Return(txmgr.EthTx{}, nil).Once()
 	require.NoError(t, transmitter.CreateEthTransaction(testutils.Context(t), toAddress, payload))
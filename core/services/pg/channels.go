@@ -5,3 +5,6 @@ const (
 	ChannelInsertOnEthTx    = "insert_on_eth_txes"
 	ChannelInsertOnTerraMsg = "insert_on_terra_msg"
 )
+
+// Postgres channel to listen for lifecycle events of eth_txes and eth_tx_attempts
+const ChannelEthTxEvents = "eth_tx_events"
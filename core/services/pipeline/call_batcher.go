@@ -0,0 +1,316 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/core/chains/evm"
+	evmclient "github.com/smartcontractkit/chainlink/core/chains/evm/client"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+const (
+	// CallBatchRPC sends batched calls as a single JSON-RPC batch request of
+	// eth_calls
+	CallBatchRPC = "rpc"
+	// CallBatchMulticall sends batched calls as a single eth_call to the
+	// Multicall3 contract's aggregate3
+	CallBatchMulticall = "multicall"
+)
+
+const (
+	// DefaultCallBatchWindow is how long the first call of a batch waits for
+	// other calls to join it
+	DefaultCallBatchWindow = 10 * time.Millisecond
+	// DefaultCallBatchSize is the most calls sent in a single batch
+	DefaultCallBatchSize = 100
+)
+
+// DefaultMulticall3Address is the address of the Multicall3 contract, which
+// is the same on most EVM chains, see https://github.com/mds1/multicall
+var DefaultMulticall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+const multicall3ABIJSON = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+var multicall3ABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(multicall3ABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+var promETHCallBatchSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "pipeline_task_eth_call_batch_size",
+	Help:    "Number of ethcall task calls sent in each batch",
+	Buckets: []float64{1, 2, 5, 10, 20, 50, 100},
+},
+	[]string{"evm_chain_id", "mode"},
+)
+
+// callBatcher coalesces the calls of concurrent ethcall tasks that set
+// `batch`, from the same run or from different runs, into a single request to
+// the chain. It is shared by all the tasks of a runner.
+//
+// The first call for a chain and batch mode starts a batch, which is sent
+// once window has passed or it holds maxSize calls. Every call in a batch is
+// made at the latest block, and each task gets back its own result or error.
+type callBatcher struct {
+	window  time.Duration
+	maxSize int
+	chStop  <-chan struct{}
+
+	mu      sync.Mutex
+	pending map[callBatchKey]*callBatch
+}
+
+type callBatchKey struct {
+	chainID   string
+	mode      string
+	multicall common.Address
+}
+
+type callBatch struct {
+	calls []*batchedCall
+}
+
+type batchedCall struct {
+	ctx    context.Context
+	msg    ethereum.CallMsg
+	done   chan struct{}
+	result []byte
+	err    error
+}
+
+func newCallBatcher(window time.Duration, maxSize int, chStop <-chan struct{}) *callBatcher {
+	return &callBatcher{
+		window:  window,
+		maxSize: maxSize,
+		chStop:  chStop,
+		pending: make(map[callBatchKey]*callBatch),
+	}
+}
+
+// call adds msg to the pending batch for chain and mode, and waits for its
+// result. multicall is only used in CallBatchMulticall mode.
+func (b *callBatcher) call(ctx context.Context, chain evm.Chain, mode string, multicall common.Address, msg ethereum.CallMsg) ([]byte, error) {
+	c := &batchedCall{ctx: ctx, msg: msg, done: make(chan struct{})}
+	key := callBatchKey{chainID: chain.ID().String(), mode: mode, multicall: multicall}
+
+	b.mu.Lock()
+	batch, exists := b.pending[key]
+	if !exists {
+		batch = &callBatch{}
+		b.pending[key] = batch
+		time.AfterFunc(b.window, func() {
+			if b.take(key, batch) {
+				b.send(chain, key, batch.calls)
+			}
+		})
+	}
+	batch.calls = append(batch.calls, c)
+	full := len(batch.calls) >= b.maxSize
+	if full {
+		delete(b.pending, key)
+	}
+	b.mu.Unlock()
+
+	if full {
+		go b.send(chain, key, batch.calls)
+	}
+
+	select {
+	case <-c.done:
+		return c.result, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// take removes batch from the pending batches, unless it was already sent
+// because it filled up.
+func (b *callBatcher) take(key callBatchKey, batch *callBatch) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pending[key] != batch {
+		return false
+	}
+	delete(b.pending, key)
+	return true
+}
+
+func (b *callBatcher) send(chain evm.Chain, key callBatchKey, calls []*batchedCall) {
+	defer func() {
+		for _, c := range calls {
+			close(c.done)
+		}
+	}()
+
+	// The batch is abandoned once every task that joined it has given up
+	ctx, cancel := utils.ContextFromChan(b.chStop)
+	defer cancel()
+	if deadline, ok := latestDeadline(calls); ok {
+		var cancelDeadline context.CancelFunc
+		ctx, cancelDeadline = context.WithDeadline(ctx, deadline)
+		defer cancelDeadline()
+	}
+
+	promETHCallBatchSize.WithLabelValues(key.chainID, key.mode).Observe(float64(len(calls)))
+
+	if len(calls) == 1 {
+		calls[0].result, calls[0].err = chain.Client().CallContract(ctx, calls[0].msg, nil)
+		return
+	}
+	switch key.mode {
+	case CallBatchMulticall:
+		sendMulticall(ctx, chain, key.multicall, calls)
+	default:
+		sendRPCBatch(ctx, chain, calls)
+	}
+}
+
+func latestDeadline(calls []*batchedCall) (latest time.Time, ok bool) {
+	for _, c := range calls {
+		deadline, hasDeadline := c.ctx.Deadline()
+		if !hasDeadline {
+			return time.Time{}, false
+		}
+		if deadline.After(latest) {
+			latest = deadline
+		}
+	}
+	return latest, true
+}
+
+func sendRPCBatch(ctx context.Context, chain evm.Chain, calls []*batchedCall) {
+	reqs := make([]rpc.BatchElem, len(calls))
+	for i, c := range calls {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []interface{}{toCallArg(c.msg), evmclient.ToBlockNumArg(nil)},
+			Result: new(hexutil.Bytes),
+		}
+	}
+	if err := chain.Client().BatchCallContext(ctx, reqs); err != nil {
+		for _, c := range calls {
+			c.err = errors.Wrap(err, "batched eth_call failed")
+		}
+		return
+	}
+	for i, c := range calls {
+		if reqs[i].Error != nil {
+			c.err = reqs[i].Error
+			continue
+		}
+		c.result = *reqs[i].Result.(*hexutil.Bytes)
+	}
+}
+
+func sendMulticall(ctx context.Context, chain evm.Chain, multicall common.Address, calls []*batchedCall) {
+	setErr := func(err error) {
+		for _, c := range calls {
+			c.err = err
+		}
+	}
+
+	aggregated := make([]multicall3Call, len(calls))
+	var gas uint64
+	unlimited := false
+	for i, c := range calls {
+		aggregated[i] = multicall3Call{Target: *c.msg.To, AllowFailure: true, CallData: c.msg.Data}
+		gas += c.msg.Gas
+		unlimited = unlimited || c.msg.Gas == 0
+	}
+	if unlimited {
+		// One of the calls has no gas limit, so neither does the batch
+		gas = 0
+	}
+	data, err := multicall3ABI.Pack("aggregate3", aggregated)
+	if err != nil {
+		setErr(errors.Wrap(err, "failed to encode multicall"))
+		return
+	}
+
+	resp, err := chain.Client().CallContract(ctx, ethereum.CallMsg{To: &multicall, Data: data, Gas: gas}, nil)
+	if err != nil {
+		setErr(errors.Wrap(err, "multicall failed"))
+		return
+	}
+	out, err := multicall3ABI.Unpack("aggregate3", resp)
+	if err != nil {
+		setErr(errors.Wrap(err, "failed to decode multicall result"))
+		return
+	}
+	results := *abi.ConvertType(out[0], new([]multicall3Result)).(*[]multicall3Result)
+	if len(results) != len(calls) {
+		setErr(errors.Errorf("multicall returned %v results for %v calls", len(results), len(calls)))
+		return
+	}
+	for i, c := range calls {
+		if !results[i].Success {
+			// Mimic the error the RPC would have returned for the call alone,
+			// so that the revert reason can be extracted the same way
+			c.err = &evmclient.JsonError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(results[i].ReturnData)}
+			continue
+		}
+		c.result = results[i].ReturnData
+	}
+}
+
+// canMulticall reports whether msg can be made through Multicall3, which
+// calls the target itself and with the gas price of the aggregate call. Calls
+// that set neither still see Multicall3 as msg.sender instead of the zero
+// address; tasks opt in to that with batch="multicall".
+func canMulticall(msg ethereum.CallMsg) bool {
+	return msg.From == (common.Address{}) && msg.GasPrice == nil && msg.GasTipCap == nil && msg.GasFeeCap == nil
+}
+
+// toCallArg converts msg into the eth_call argument, like go-ethereum's
+// ethclient does.
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	return arg
+}
@@ -0,0 +1,204 @@
+package pipeline
+
+import (
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ErrCircuitOpen is returned instead of making a request to a URL whose
+// circuit breaker has tripped.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// NOTE: The breakers are keyed by URL, so the metrics only count breakers per
+// state rather than labelling them by URL; the URLs may be unbounded and may
+// contain credentials.
+var (
+	promCircuitBreakers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pipeline_http_circuit_breakers",
+		Help: "Number of per-URL circuit breakers in each state",
+	},
+		[]string{"state"},
+	)
+	promCircuitBreakerTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_http_circuit_breaker_transitions_total",
+		Help: "Number of times a per-URL circuit breaker entered each state",
+	},
+		[]string{"state"},
+	)
+	promCircuitBreakerRejections = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pipeline_http_circuit_breaker_rejections_total",
+		Help: "Number of requests failed fast by an open circuit breaker",
+	})
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitHalfOpen
+	circuitOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitClosed:
+		return "closed"
+	case circuitHalfOpen:
+		return "half_open"
+	case circuitOpen:
+		return "open"
+	}
+	return "unknown"
+}
+
+// circuitOutcome is what a request let through by a circuit breaker reports
+// back once it is done.
+type circuitOutcome int
+
+const (
+	circuitSuccess circuitOutcome = iota
+	circuitFailure
+	// circuitIgnored is reported when the request was abandoned for reasons
+	// that say nothing about the health of the remote, e.g. the run was
+	// cancelled.
+	circuitIgnored
+)
+
+type circuitBreaker struct {
+	state    circuitState
+	failures uint32
+	openedAt time.Time
+	probing  bool
+}
+
+// circuitBreakers holds one circuit breaker per URL, shared by all the http
+// and bridge tasks of a runner.
+//
+// A breaker starts closed. After failureThreshold consecutive failures it
+// opens and every request fails fast with ErrCircuitOpen. Once cooldown has
+// passed it becomes half-open and lets a single probe request through: if the
+// probe succeeds the breaker closes again, otherwise it re-opens for another
+// cooldown. A failureThreshold of 0 disables the breakers.
+type circuitBreakers struct {
+	failureThreshold uint32
+	cooldown         time.Duration
+	now              func() time.Time
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+func newCircuitBreakers(failureThreshold uint32, cooldown time.Duration) *circuitBreakers {
+	return &circuitBreakers{
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		now:              time.Now,
+		breakers:         make(map[string]*circuitBreaker),
+	}
+}
+
+// circuitKey identifies the breaker for u. The query string and credentials
+// are dropped so that requests for different parameters to the same endpoint
+// share a breaker.
+func circuitKey(u *url.URL) string {
+	return u.Scheme + "://" + u.Host + u.Path
+}
+
+// allow returns ErrCircuitOpen if the breaker for u is failing fast.
+// Otherwise the request may proceed and the caller must report its outcome by
+// calling done exactly once. cb may be nil, in which case every request is
+// allowed.
+func (cb *circuitBreakers) allow(u *url.URL) (done func(circuitOutcome), err error) {
+	if cb == nil || cb.failureThreshold == 0 {
+		return func(circuitOutcome) {}, nil
+	}
+	key := circuitKey(u)
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	b, exists := cb.breakers[key]
+	if !exists {
+		b = &circuitBreaker{state: circuitClosed}
+		cb.breakers[key] = b
+		promCircuitBreakers.WithLabelValues(circuitClosed.String()).Inc()
+	}
+
+	probe := false
+	switch b.state {
+	case circuitOpen:
+		if cb.now().Sub(b.openedAt) < cb.cooldown {
+			promCircuitBreakerRejections.Inc()
+			return nil, errors.Wrapf(ErrCircuitOpen, "too many failed requests to %s, retrying after %s", key, b.openedAt.Add(cb.cooldown).Format(time.RFC3339))
+		}
+		cb.transition(b, circuitHalfOpen)
+		fallthrough
+	case circuitHalfOpen:
+		if b.probing {
+			promCircuitBreakerRejections.Inc()
+			return nil, errors.Wrapf(ErrCircuitOpen, "waiting for a probe request to %s to complete", key)
+		}
+		b.probing = true
+		probe = true
+	}
+
+	var once sync.Once
+	return func(outcome circuitOutcome) {
+		once.Do(func() { cb.report(key, b, probe, outcome) })
+	}, nil
+}
+
+func (cb *circuitBreakers) report(key string, b *circuitBreaker, probe bool, outcome circuitOutcome) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+	if cb.breakers[key] != b {
+		// The breaker was dropped by a concurrent success
+		return
+	}
+
+	switch outcome {
+	case circuitSuccess:
+		b.failures = 0
+		if b.state == circuitHalfOpen && probe {
+			cb.transition(b, circuitClosed)
+		}
+		if b.state == circuitClosed {
+			// Healthy URLs need no state, so don't keep them around
+			delete(cb.breakers, key)
+			promCircuitBreakers.WithLabelValues(circuitClosed.String()).Dec()
+		}
+	case circuitFailure:
+		b.failures++
+		switch b.state {
+		case circuitClosed:
+			if b.failures >= cb.failureThreshold {
+				cb.transition(b, circuitOpen)
+			}
+		case circuitHalfOpen:
+			if probe {
+				cb.transition(b, circuitOpen)
+			}
+		case circuitOpen:
+		}
+	case circuitIgnored:
+	}
+}
+
+func (cb *circuitBreakers) transition(b *circuitBreaker, to circuitState) {
+	promCircuitBreakers.WithLabelValues(b.state.String()).Dec()
+	promCircuitBreakers.WithLabelValues(to.String()).Inc()
+	promCircuitBreakerTransitions.WithLabelValues(to.String()).Inc()
+	if to == circuitOpen {
+		b.openedAt = cb.now()
+	}
+	b.state = to
+}
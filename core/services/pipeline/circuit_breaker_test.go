@@ -0,0 +1,121 @@
+package pipeline
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	cb := newCircuitBreakers(3, time.Minute)
+	cb.now = func() time.Time { return now }
+
+	u, err := url.Parse("https://example.com/price?from=ETH&to=USD")
+	require.NoError(t, err)
+	other, err := url.Parse("https://example.com/price?from=BTC&to=USD")
+	require.NoError(t, err)
+
+	fail := func(u *url.URL) {
+		done, err := cb.allow(u)
+		require.NoError(t, err)
+		done(circuitFailure)
+	}
+
+	t.Run("success resets the failure count", func(t *testing.T) {
+		fail(u)
+		fail(u)
+		done, err := cb.allow(u)
+		require.NoError(t, err)
+		done(circuitSuccess)
+		assert.Empty(t, cb.breakers)
+	})
+
+	t.Run("ignored outcomes do not count", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			done, err := cb.allow(u)
+			require.NoError(t, err)
+			done(circuitIgnored)
+		}
+		done, err := cb.allow(u)
+		require.NoError(t, err)
+		done(circuitSuccess)
+	})
+
+	t.Run("trips after consecutive failures and fails fast", func(t *testing.T) {
+		fail(u)
+		fail(u)
+		fail(u)
+
+		_, err := cb.allow(u)
+		require.True(t, errors.Is(err, ErrCircuitOpen))
+		// the query string is not part of the key
+		_, err = cb.allow(other)
+		require.True(t, errors.Is(err, ErrCircuitOpen))
+
+		now = now.Add(59 * time.Second)
+		_, err = cb.allow(u)
+		require.True(t, errors.Is(err, ErrCircuitOpen))
+	})
+
+	t.Run("failed probe re-opens", func(t *testing.T) {
+		now = now.Add(time.Second)
+		done, err := cb.allow(u)
+		require.NoError(t, err)
+
+		// only a single probe is let through
+		_, err = cb.allow(u)
+		require.True(t, errors.Is(err, ErrCircuitOpen))
+
+		done(circuitFailure)
+		_, err = cb.allow(u)
+		require.True(t, errors.Is(err, ErrCircuitOpen))
+	})
+
+	t.Run("ignored probe lets another probe through", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		done, err := cb.allow(u)
+		require.NoError(t, err)
+		done(circuitIgnored)
+
+		done, err = cb.allow(u)
+		require.NoError(t, err)
+		done(circuitIgnored)
+	})
+
+	t.Run("successful probe closes", func(t *testing.T) {
+		done, err := cb.allow(u)
+		require.NoError(t, err)
+		done(circuitSuccess)
+		// reporting twice is harmless
+		done(circuitFailure)
+
+		done, err = cb.allow(u)
+		require.NoError(t, err)
+		done(circuitSuccess)
+		assert.Empty(t, cb.breakers)
+	})
+
+	t.Run("nil allows everything", func(t *testing.T) {
+		var cb *circuitBreakers
+		done, err := cb.allow(u)
+		require.NoError(t, err)
+		done(circuitFailure)
+	})
+
+	t.Run("zero failure threshold disables the breakers", func(t *testing.T) {
+		cb := newCircuitBreakers(0, time.Minute)
+		for i := 0; i < 10; i++ {
+			done, err := cb.allow(u)
+			require.NoError(t, err)
+			done(circuitFailure)
+		}
+		assert.Empty(t, cb.breakers)
+	})
+}
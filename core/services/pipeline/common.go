@@ -21,6 +21,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/chains/evm"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/config"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	cnull "github.com/smartcontractkit/chainlink/core/null"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -66,6 +67,8 @@ type (
 		DefaultHTTPLimit() int64
 		DefaultHTTPTimeout() models.Duration
 		TriggerFallbackDBPollInterval() time.Duration
+		JobPipelineHTTPCircuitBreakerCooldown() time.Duration
+		JobPipelineHTTPCircuitBreakerFailureThreshold() uint32
 		JobPipelineMaxRunDuration() time.Duration
 		JobPipelineReaperInterval() time.Duration
 		JobPipelineReaperThreshold() time.Duration
@@ -207,6 +210,38 @@ func (result *TaskRunResult) IsTerminal() bool {
 // TaskRunResults represents a collection of results for all task runs for one pipeline run
 type TaskRunResults []TaskRunResult
 
+// InputsFor returns the results that were passed to task as its inputs,
+// ordered by the output index of the input tasks like the scheduler does.
+// Inputs that have no result (yet) are returned as an empty Result.
+func (trrs TaskRunResults) InputsFor(task Task) []Result {
+	type input struct {
+		index  int32
+		result Result
+	}
+	var inputs []input
+	for _, dep := range task.Inputs() {
+		if !dep.PropagateResult {
+			continue
+		}
+		in := input{index: dep.InputTask.OutputIndex()}
+		for _, trr := range trrs {
+			if trr.Task.ID() == dep.InputTask.ID() {
+				in.result = trr.Result
+				break
+			}
+		}
+		inputs = append(inputs, in)
+	}
+	sort.SliceStable(inputs, func(i, j int) bool {
+		return inputs[i].index < inputs[j].index
+	})
+	results := make([]Result, len(inputs))
+	for i, in := range inputs {
+		results[i] = in.result
+	}
+	return results
+}
+
 // FinalResult pulls the FinalResult for the pipeline_run from the task runs
 // It needs to respect the output index of each task
 func (trrs TaskRunResults) FinalResult(l logger.Logger) FinalResult {
@@ -350,12 +385,15 @@ const (
 	TaskTypeETHABIEncode2    TaskType = "ethabiencode2"
 	TaskTypeETHCall          TaskType = "ethcall"
 	TaskTypeETHGetBlock      TaskType = "ethgetblock"
+	TaskTypeETHGetLogs       TaskType = "ethgetlogs"
 	TaskTypeETHTx            TaskType = "ethtx"
 	TaskTypeEstimateGasLimit TaskType = "estimategaslimit"
+	TaskTypeForEach          TaskType = "foreach"
 	TaskTypeHTTP             TaskType = "http"
 	TaskTypeHexDecode        TaskType = "hexdecode"
 	TaskTypeHexEncode        TaskType = "hexencode"
 	TaskTypeJSONParse        TaskType = "jsonparse"
+	TaskTypeJSONTransform    TaskType = "jsontransform"
 	TaskTypeLength           TaskType = "length"
 	TaskTypeLessThan         TaskType = "lessthan"
 	TaskTypeLookup           TaskType = "lookup"
@@ -365,10 +403,16 @@ const (
 	TaskTypeMerge            TaskType = "merge"
 	TaskTypeMode             TaskType = "mode"
 	TaskTypeMultiply         TaskType = "multiply"
+	TaskTypeOutlierFilter    TaskType = "outlierfilter"
+	TaskTypePreviousRuns     TaskType = "previousruns"
+	TaskTypeScript           TaskType = "script"
 	TaskTypeSum              TaskType = "sum"
+	TaskTypeTrimmedMean      TaskType = "trimmedmean"
 	TaskTypeUppercase        TaskType = "uppercase"
 	TaskTypeVRF              TaskType = "vrf"
 	TaskTypeVRFV2            TaskType = "vrfv2"
+	TaskTypeWeightedMedian   TaskType = "weightedmedian"
+	TaskTypeWinsorize        TaskType = "winsorize"
 
 	// Testing only.
 	TaskTypePanic TaskType = "panic"
@@ -407,6 +451,18 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		task = &MeanTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeMedian:
 		task = &MedianTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeForEach:
+		task = &ForEachTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypePreviousRuns:
+		task = &PreviousRunsTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeWeightedMedian:
+		task = &WeightedMedianTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeTrimmedMean:
+		task = &TrimmedMeanTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeWinsorize:
+		task = &WinsorizeTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeOutlierFilter:
+		task = &OutlierFilterTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeMode:
 		task = &ModeTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeSum:
@@ -415,6 +471,8 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		task = &AnyTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeJSONParse:
 		task = &JSONParseTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeJSONTransform:
+		task = &JSONTransformTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeMemo:
 		task = &MemoTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeMultiply:
@@ -431,6 +489,8 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		task = &ETHCallTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeETHGetBlock:
 		task = &ETHGetBlockTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeETHGetLogs:
+		task = &ETHGetLogsTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeETHTx:
 		task = &ETHTxTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeETHABIEncode:
@@ -459,6 +519,8 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		task = &UppercaseTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeConditional:
 		task = &ConditionalTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeScript:
+		task = &ScriptTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeHexDecode:
 		task = &HexDecodeTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeHexEncode:
@@ -497,6 +559,14 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 	if err != nil {
 		return nil, err
 	}
+	if bridge, ok := task.(*BridgeTask); ok {
+		// Bridge tasks have a cacheTTL of their own, for falling back to their
+		// last response, which is decoded into the BaseTask as well.
+		bridge.BaseTask.CacheTTL = 0
+	}
+	if err = validateCacheAttributes(task); err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
@@ -557,6 +627,19 @@ func SelectGasLimit(cfg config.ChainScopedConfig, jobType string, specGasLimit *
 	return cfg.EvmGasLimitDefault()
 }
 
+// SelectTxPriority returns the priority of the transactions of jobType, or
+// the empty string for the default priority. Time-critical OCR transmissions
+// are sent before anything else, and keeper performs after everything else.
+func SelectTxPriority(jobType string) txmgr.EthTxPriority {
+	switch jobType {
+	case OffchainReportingJobType, OffchainReporting2JobType:
+		return txmgr.EthTxPriorityHigh
+	case KeeperJobType:
+		return txmgr.EthTxPriorityLow
+	}
+	return ""
+}
+
 // replaceBytesWithHex replaces all []byte with hex-encoded strings
 func replaceBytesWithHex(val interface{}) interface{} {
 	switch value := val.(type) {
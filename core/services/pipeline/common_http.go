@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
+	"github.com/jpillora/backoff"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -105,3 +108,167 @@ func httpRequestCtx(ctx context.Context, t Task, cfg Config) (requestCtx context
 	}
 	return
 }
+
+// httpRetryPolicy decides whether a failed http or bridge request is worth
+// another attempt within the same task run, and how long to wait first.
+//
+// These tasks spend their `retries` themselves rather than leaving them to
+// the scheduler, so that every attempt goes through the circuit breaker and
+// only the failures matching `retryOn` are retried.
+type httpRetryPolicy struct {
+	retries uint32
+	backoff backoff.Backoff
+	retryOn httpRetryOnParam
+}
+
+func newHTTPRetryPolicy(t Task, initialBackoff DurationParam, retryOn httpRetryOnParam) httpRetryPolicy {
+	return httpRetryPolicy{
+		retries: t.TaskRetries(),
+		backoff: backoff.Backoff{
+			Factor: 2,
+			Min:    time.Duration(initialBackoff),
+			Max:    t.TaskMaxBackoff(),
+		},
+		retryOn: retryOn,
+	}
+}
+
+const (
+	httpRetryOnTimeout    = "timeout"
+	httpRetryOnConnection = "connection"
+	httpRetryOn4xx        = "4xx"
+	httpRetryOn5xx        = "5xx"
+)
+
+// defaultHTTPRetryOn matches the failures isRetryableHTTPError considers
+// retryable
+var defaultHTTPRetryOn = []string{httpRetryOn5xx, httpRetryOnTimeout, httpRetryOnConnection}
+
+// httpRetryOnParam lists the failures that are retried: "4xx" and "5xx" for
+// a class of status codes, a specific status code such as "429", "timeout"
+// for an attempt that ran out of time and "connection" for any other failure
+// that did not produce a response.
+type httpRetryOnParam []string
+
+func (p *httpRetryOnParam) UnmarshalPipelineParam(val interface{}) error {
+	var conditions StringSliceParam
+	if err := conditions.UnmarshalPipelineParam(val); err != nil {
+		return err
+	}
+	for _, c := range conditions {
+		switch c {
+		case httpRetryOnTimeout, httpRetryOnConnection, httpRetryOn4xx, httpRetryOn5xx:
+		default:
+			code, err := strconv.Atoi(c)
+			if err != nil || code < 100 || code > 599 {
+				return errors.Wrapf(ErrBadInput, "unknown retry condition %q", c)
+			}
+		}
+	}
+	*p = httpRetryOnParam(conditions)
+	return nil
+}
+
+func (p httpRetryOnParam) matches(statusCode int, timedOut bool) bool {
+	for _, c := range p {
+		switch c {
+		case httpRetryOnTimeout:
+			if timedOut {
+				return true
+			}
+		case httpRetryOnConnection:
+			if statusCode == 0 && !timedOut {
+				return true
+			}
+		case httpRetryOn4xx:
+			if statusCode >= 400 && statusCode < 500 {
+				return true
+			}
+		case httpRetryOn5xx:
+			if statusCode >= 500 && statusCode < 600 {
+				return true
+			}
+		default:
+			if code, err := strconv.Atoi(c); err == nil && code == statusCode {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type httpAttempt func(ctx context.Context) (responseBytes []byte, statusCode int, headers http.Header, elapsed time.Duration, err error)
+
+// makeHTTPRequestWithRetries makes up to 1+policy.retries attempts to reach
+// reqURL, stopping at the first success, at a failure that policy does not
+// retry or when ctx is done. Each attempt must first be let through by the
+// circuit breaker for reqURL and gets its own default HTTP timeout.
+func makeHTTPRequestWithRetries(
+	ctx context.Context,
+	lggr logger.Logger,
+	t Task,
+	cfg Config,
+	reqURL URLParam,
+	policy httpRetryPolicy,
+	breakers *circuitBreakers,
+	attempt httpAttempt,
+) (responseBytes []byte, statusCode int, headers http.Header, elapsed time.Duration, err error) {
+	u := url.URL(reqURL)
+	for i := uint32(0); ; i++ {
+		var timedOut bool
+		responseBytes, statusCode, headers, elapsed, timedOut, err = makeHTTPAttempt(ctx, t, cfg, &u, breakers, attempt)
+		if err == nil || errors.Is(err, ErrCircuitOpen) || ctx.Err() != nil {
+			return
+		}
+		if i >= policy.retries || !policy.retryOn.matches(statusCode, timedOut) {
+			return
+		}
+
+		delay := policy.backoff.ForAttempt(float64(i))
+		lggr.Debugw("HTTP request failed, retrying",
+			"err", err,
+			"statusCode", statusCode,
+			"url", u.String(),
+			"attempt", i+1,
+			"delay", delay,
+		)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+func makeHTTPAttempt(
+	ctx context.Context,
+	t Task,
+	cfg Config,
+	u *url.URL,
+	breakers *circuitBreakers,
+	attempt httpAttempt,
+) (responseBytes []byte, statusCode int, headers http.Header, elapsed time.Duration, timedOut bool, err error) {
+	done, err := breakers.allow(u)
+	if err != nil {
+		return nil, 0, nil, 0, false, err
+	}
+
+	requestCtx, cancel := httpRequestCtx(ctx, t, cfg)
+	defer cancel()
+
+	responseBytes, statusCode, headers, elapsed, err = attempt(requestCtx)
+	timedOut = err != nil && errors.Is(requestCtx.Err(), context.DeadlineExceeded)
+
+	switch {
+	case err == nil:
+		done(circuitSuccess)
+	case errors.Is(ctx.Err(), context.Canceled), errors.Is(errors.Cause(err), clhttp.ErrDisallowedIP):
+		done(circuitIgnored)
+	case isRetryableHTTPError(statusCode, err):
+		done(circuitFailure)
+	default:
+		// The remote answered, it just didn't like the request
+		done(circuitSuccess)
+	}
+	return responseBytes, statusCode, headers, elapsed, timedOut, err
+}
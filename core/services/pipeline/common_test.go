@@ -13,6 +13,7 @@ import (
 	"gopkg.in/guregu/null.v4"
 
 	v2 "github.com/smartcontractkit/chainlink/core/chains/evm/config/v2"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	configtest2 "github.com/smartcontractkit/chainlink/core/internal/testutils/configtest/v2"
 	"github.com/smartcontractkit/chainlink/core/internal/testutils/evmtest"
@@ -140,17 +141,25 @@ func TestUnmarshalTaskFromMap(t *testing.T) {
 		{pipeline.TaskTypeBridge, &pipeline.BridgeTask{}},
 		{pipeline.TaskTypeMean, &pipeline.MeanTask{}},
 		{pipeline.TaskTypeMedian, &pipeline.MedianTask{}},
+		{pipeline.TaskTypeWeightedMedian, &pipeline.WeightedMedianTask{}},
+		{pipeline.TaskTypeTrimmedMean, &pipeline.TrimmedMeanTask{}},
+		{pipeline.TaskTypeWinsorize, &pipeline.WinsorizeTask{}},
+		{pipeline.TaskTypeOutlierFilter, &pipeline.OutlierFilterTask{}},
+		{pipeline.TaskTypePreviousRuns, &pipeline.PreviousRunsTask{}},
+		{pipeline.TaskTypeForEach, &pipeline.ForEachTask{}},
 		{pipeline.TaskTypeMode, &pipeline.ModeTask{}},
 		{pipeline.TaskTypeSum, &pipeline.SumTask{}},
 		{pipeline.TaskTypeMultiply, &pipeline.MultiplyTask{}},
 		{pipeline.TaskTypeDivide, &pipeline.DivideTask{}},
 		{pipeline.TaskTypeJSONParse, &pipeline.JSONParseTask{}},
+		{pipeline.TaskTypeJSONTransform, &pipeline.JSONTransformTask{}},
 		{pipeline.TaskTypeCBORParse, &pipeline.CBORParseTask{}},
 		{pipeline.TaskTypeAny, &pipeline.AnyTask{}},
 		{pipeline.TaskTypeVRF, &pipeline.VRFTask{}},
 		{pipeline.TaskTypeVRFV2, &pipeline.VRFTaskV2{}},
 		{pipeline.TaskTypeEstimateGasLimit, &pipeline.EstimateGasLimitTask{}},
 		{pipeline.TaskTypeETHCall, &pipeline.ETHCallTask{}},
+		{pipeline.TaskTypeETHGetLogs, &pipeline.ETHGetLogsTask{}},
 		{pipeline.TaskTypeETHTx, &pipeline.ETHTxTask{}},
 		{pipeline.TaskTypeETHABIEncode, &pipeline.ETHABIEncodeTask{}},
 		{pipeline.TaskTypeETHABIEncode2, &pipeline.ETHABIEncodeTask2{}},
@@ -162,6 +171,7 @@ func TestUnmarshalTaskFromMap(t *testing.T) {
 		{pipeline.TaskTypeConditional, &pipeline.ConditionalTask{}},
 		{pipeline.TaskTypeHexDecode, &pipeline.HexDecodeTask{}},
 		{pipeline.TaskTypeBase64Decode, &pipeline.Base64DecodeTask{}},
+		{pipeline.TaskTypeScript, &pipeline.ScriptTask{}},
 	}
 
 	for _, test := range tests {
@@ -370,3 +380,12 @@ func TestSelectGasLimit(t *testing.T) {
 		assert.Equal(t, uint32(999), gasLimit)
 	})
 }
+
+func TestSelectTxPriority(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, txmgr.EthTxPriorityHigh, pipeline.SelectTxPriority(pipeline.OffchainReportingJobType))
+	assert.Equal(t, txmgr.EthTxPriorityHigh, pipeline.SelectTxPriority(pipeline.OffchainReporting2JobType))
+	assert.Equal(t, txmgr.EthTxPriorityLow, pipeline.SelectTxPriority(pipeline.KeeperJobType))
+	assert.Equal(t, txmgr.EthTxPriority(""), pipeline.SelectTxPriority(pipeline.DirectRequestJobType))
+}
@@ -0,0 +1,135 @@
+package pipeline
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// The lexer below is shared by the jq (jsontransform) and script task
+// languages.
+
+type exprTokenKind int
+
+const (
+	exprTokEOF exprTokenKind = iota
+	exprTokIdent
+	exprTokField
+	exprTokVar
+	exprTokNumber
+	exprTokString
+	exprTokOp
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	pos  int
+}
+
+// lexExpr splits src into tokens. ops lists the operators recognised by the
+// calling language, longest first. Errors are wrapped with errSyntax.
+func lexExpr(src string, ops []string, errSyntax error) ([]exprToken, error) {
+	var toks []exprToken
+	i := 0
+	for i < len(src) {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '"':
+			start := i
+			i++
+			var sb strings.Builder
+			for {
+				if i >= len(src) {
+					return nil, errors.Wrapf(errSyntax, "unterminated string at %d", start)
+				}
+				if src[i] == '"' {
+					i++
+					break
+				}
+				if src[i] == '\\' {
+					if i+1 >= len(src) {
+						return nil, errors.Wrapf(errSyntax, "unterminated string at %d", start)
+					}
+					switch src[i+1] {
+					case 'n':
+						sb.WriteByte('\n')
+					case 't':
+						sb.WriteByte('\t')
+					case 'r':
+						sb.WriteByte('\r')
+					case '"', '\\', '/':
+						sb.WriteByte(src[i+1])
+					default:
+						return nil, errors.Wrapf(errSyntax, "invalid escape sequence at %d", i)
+					}
+					i += 2
+					continue
+				}
+				sb.WriteByte(src[i])
+				i++
+			}
+			toks = append(toks, exprToken{kind: exprTokString, text: sb.String(), pos: start})
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+				i++
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				i++
+				if i < len(src) && (src[i] == '+' || src[i] == '-') {
+					i++
+				}
+				for i < len(src) && src[i] >= '0' && src[i] <= '9' {
+					i++
+				}
+			}
+			toks = append(toks, exprToken{kind: exprTokNumber, text: src[start:i], pos: start})
+		case c == '$' || c == '_' || unicode.IsLetter(c) || c == '.' && i+1 < len(src) && (src[i+1] == '_' || unicode.IsLetter(rune(src[i+1]))):
+			start := i
+			kind := exprTokIdent
+			switch c {
+			case '$':
+				kind = exprTokVar
+				i++
+			case '.':
+				kind = exprTokField
+				i++
+			}
+			for i < len(src) && (src[i] == '_' || unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i]))) {
+				i++
+			}
+			text := src[start:i]
+			if kind != exprTokIdent {
+				text = text[1:]
+				if text == "" {
+					return nil, errors.Wrapf(errSyntax, "empty variable name at %d", start)
+				}
+			}
+			toks = append(toks, exprToken{kind: kind, text: text, pos: start})
+		default:
+			start := i
+			var op string
+			for _, candidate := range ops {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, errors.Wrapf(errSyntax, "unexpected character %q at %d", c, i)
+			}
+			i += len(op)
+			toks = append(toks, exprToken{kind: exprTokOp, text: op, pos: start})
+		}
+	}
+	toks = append(toks, exprToken{kind: exprTokEOF, pos: len(src)})
+	return toks, nil
+}
@@ -44,6 +44,11 @@ ds1 -> ds1_parse -> answer1;
 ds2 -> ds2_parse -> answer1;
 
 answer1 [type=median index=0];
+`)
+	f.Add(`
+ds1 [type=http method=GET url="https://pricesource1.com"];
+ds1_transform [type=jsontransform expr="[.data.prices[] | select(.volume > 0) | .price | tonumber] | add / length"];
+ds1 -> ds1_transform;
 `)
 	f.Add(taskRunWithVars{
 		bridgeName:        "testBridge",
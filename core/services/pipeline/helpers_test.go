@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"net/http"
+	"time"
 
 	uuid "github.com/satori/go.uuid"
 
@@ -48,6 +49,15 @@ func (t *HTTPTask) HelperSetDependencies(config Config, restrictedHTTPClient, un
 	t.unrestrictedHTTPClient = unrestrictedHTTPClient
 }
 
+func (t *HTTPTask) HelperSetCircuitBreakers(failureThreshold uint32, cooldown time.Duration) {
+	t.breakers = newCircuitBreakers(failureThreshold, cooldown)
+}
+
+// HelperSetCache gives the task a new, empty result cache
+func (t *HTTPTask) HelperSetCache(orm ORM) {
+	t.cache = newResultCache(orm, DefaultTaskResultCacheSize)
+}
+
 func (t *ETHCallTask) HelperSetDependencies(cc evm.ChainSet, config Config, specGasLimit *uint32, jobType string) {
 	t.chainSet = cc
 	t.config = config
@@ -55,6 +65,23 @@ func (t *ETHCallTask) HelperSetDependencies(cc evm.ChainSet, config Config, spec
 	t.jobType = jobType
 }
 
+// HelperShareCallBatcher makes tasks batch their calls together
+func HelperShareCallBatcher(window time.Duration, maxSize int, tasks ...*ETHCallTask) {
+	b := newCallBatcher(window, maxSize, make(chan struct{}))
+	for _, t := range tasks {
+		t.batcher = b
+	}
+}
+
+// HelperPackMulticall3Results encodes what Multicall3's aggregate3 returns
+func HelperPackMulticall3Results(success []bool, returnData [][]byte) ([]byte, error) {
+	results := make([]multicall3Result, len(success))
+	for i := range success {
+		results[i] = multicall3Result{Success: success[i], ReturnData: returnData[i]}
+	}
+	return multicall3ABI.Methods["aggregate3"].Outputs.Pack(results)
+}
+
 func (t *ETHTxTask) HelperSetDependencies(cc evm.ChainSet, keyStore ETHKeyStore, specGasLimit *uint32, jobType string) {
 	t.chainSet = cc
 	t.keyStore = keyStore
@@ -62,7 +89,20 @@ func (t *ETHTxTask) HelperSetDependencies(cc evm.ChainSet, keyStore ETHKeyStore,
 	t.jobType = jobType
 }
 
+func (t *ETHTxTask) HelperSetSimulate() {
+	t.simulate = true
+}
+
+func (t *PreviousRunsTask) HelperSetDependencies(orm ORM, specID int32) {
+	t.orm = orm
+	t.specID = specID
+}
+
 func (t *ETHGetBlockTask) HelperSetDependencies(cc evm.ChainSet, config Config) {
 	t.chainSet = cc
 	t.config = config
 }
+
+func (t *ETHGetLogsTask) HelperSetDependencies(cc evm.ChainSet) {
+	t.chainSet = cc
+}
@@ -0,0 +1,1996 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// This file implements a small, sandboxed subset of the jq language used by
+// the jsontransform task. Supported syntax:
+//
+//	.  .foo  .["foo"]  .[0]  .[-1]  .[1:3]  .[]  ?
+//	|  ,  //  as $name
+//	+ - * / %  == != < <= > >=  and or
+//	literals, (...), [...], {...}, $name, if/then/elif/else/end
+//
+// and the builtins listed in jqBuiltinArity. Numbers are arbitrary precision
+// decimals. There are no user-defined functions, recursion or I/O, and every
+// evaluation is bounded by a step budget.
+
+var (
+	ErrJQSyntax         = errors.New("jq syntax error")
+	ErrJQRuntime        = errors.New("jq runtime error")
+	ErrJQStepsExhausted = errors.New("jq step limit exhausted")
+)
+
+// DefaultJQMaxSteps bounds the work a single jq evaluation may perform.
+const DefaultJQMaxSteps = 1_000_000
+
+const (
+	// jqMaxExponent and jqMaxCoefficientBits bound the size of numbers so
+	// that a single arithmetic operation cannot consume unbounded CPU or
+	// memory.
+	jqMaxExponent        = 1000
+	jqMaxCoefficientBits = 4096
+)
+
+func jqCheckNumber(d decimal.Decimal) (decimal.Decimal, error) {
+	if exp := d.Exponent(); exp > jqMaxExponent || exp < -jqMaxExponent || d.Coefficient().BitLen() > jqMaxCoefficientBits {
+		return decimal.Decimal{}, errors.Wrap(ErrJQRuntime, "number out of range")
+	}
+	return d, nil
+}
+
+var jqOps = []string{"==", "!=", "<=", ">=", "//", "|", ",", ".", "[", "]", "(", ")", "{", "}", ":", ";", "?", "+", "-", "*", "/", "%", "<", ">"}
+
+type jqNodeKind int
+
+const (
+	jqIdentity jqNodeKind = iota
+	jqLiteral
+	jqIndex   // left[index]
+	jqSlice   // left[from:to]
+	jqIterate // left[]
+	jqTry     // left?
+	jqPipe    // left | right
+	jqComma   // left, right
+	jqAlt     // left // right
+	jqBinary  // left op right
+	jqNeg     // -left
+	jqArray   // [left]
+	jqObject  // {entries}
+	jqVarRef  // $name
+	jqBind    // left as $name | right
+	jqCall    // name(args...)
+	jqIf      // if cond then left (elif...) else right end
+	jqAnd     // left and right
+	jqOr      // left or right
+)
+
+type jqObjectEntry struct {
+	key   *jqNode
+	value *jqNode
+}
+
+type jqNode struct {
+	kind    jqNodeKind
+	op      string
+	name    string
+	value   interface{}
+	left    *jqNode
+	right   *jqNode
+	third   *jqNode
+	args    []*jqNode
+	entries []jqObjectEntry
+}
+
+type jqParser struct {
+	toks []exprToken
+	pos  int
+}
+
+func (p *jqParser) peek() exprToken {
+	return p.toks[p.pos]
+}
+
+func (p *jqParser) next() exprToken {
+	t := p.toks[p.pos]
+	if t.kind != exprTokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *jqParser) isOp(op string) bool {
+	t := p.peek()
+	return t.kind == exprTokOp && t.text == op
+}
+
+func (p *jqParser) isKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == exprTokIdent && t.text == kw
+}
+
+func (p *jqParser) expectOp(op string) error {
+	t := p.next()
+	if t.kind != exprTokOp || t.text != op {
+		return errors.Wrapf(ErrJQSyntax, "expected %q at %d, got %q", op, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *jqParser) expectKeyword(kw string) error {
+	t := p.next()
+	if t.kind != exprTokIdent || t.text != kw {
+		return errors.Wrapf(ErrJQSyntax, "expected %q at %d, got %q", kw, t.pos, t.text)
+	}
+	return nil
+}
+
+// jqProgram is a parsed jq expression that can be evaluated repeatedly.
+type jqProgram struct {
+	root *jqNode
+}
+
+// parseJQ parses a jq expression.
+func parseJQ(src string) (*jqProgram, error) {
+	toks, err := lexExpr(src, jqOps, ErrJQSyntax)
+	if err != nil {
+		return nil, err
+	}
+	p := &jqParser{toks: toks}
+	root, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != exprTokEOF {
+		return nil, errors.Wrapf(ErrJQSyntax, "unexpected %q at %d", t.text, t.pos)
+	}
+	return &jqProgram{root: root}, nil
+}
+
+func (p *jqParser) parsePipe() (*jqNode, error) {
+	left, err := p.parseComma()
+	if err != nil {
+		return nil, err
+	}
+	if p.isOp("|") {
+		p.next()
+		right, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return &jqNode{kind: jqPipe, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseComma() (*jqNode, error) {
+	left, err := p.parseAlt()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp(",") {
+		p.next()
+		right, err := p.parseAlt()
+		if err != nil {
+			return nil, err
+		}
+		left = &jqNode{kind: jqComma, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseAlt() (*jqNode, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.isOp("//") {
+		p.next()
+		right, err := p.parseAlt()
+		if err != nil {
+			return nil, err
+		}
+		return &jqNode{kind: jqAlt, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseOr() (*jqNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &jqNode{kind: jqOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseAnd() (*jqNode, error) {
+	left, err := p.parseCompare()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseCompare()
+		if err != nil {
+			return nil, err
+		}
+		left = &jqNode{kind: jqAnd, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseCompare() (*jqNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.isOp(op) {
+			p.next()
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			return &jqNode{kind: jqBinary, op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseAdditive() (*jqNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		op := p.next().text
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &jqNode{kind: jqBinary, op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseMultiplicative() (*jqNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*") || p.isOp("/") || p.isOp("%") {
+		op := p.next().text
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &jqNode{kind: jqBinary, op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *jqParser) parseUnary() (*jqNode, error) {
+	if p.isOp("-") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &jqNode{kind: jqNeg, left: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *jqParser) parsePostfix() (*jqNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch t := p.peek(); {
+		case t.kind == exprTokField:
+			p.next()
+			node = &jqNode{kind: jqIndex, left: node, right: &jqNode{kind: jqLiteral, value: t.text}}
+		case p.isOp("."):
+			// ."foo" or .[...] following another term
+			p.next()
+			t = p.peek()
+			if t.kind == exprTokString {
+				p.next()
+				node = &jqNode{kind: jqIndex, left: node, right: &jqNode{kind: jqLiteral, value: t.text}}
+			} else if p.isOp("[") {
+				p.next()
+				node, err = p.parseBracketSuffix(node)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				return nil, errors.Wrapf(ErrJQSyntax, "unexpected %q after '.' at %d", t.text, t.pos)
+			}
+		case p.isOp("["):
+			p.next()
+			node, err = p.parseBracketSuffix(node)
+			if err != nil {
+				return nil, err
+			}
+		case p.isOp("?"):
+			p.next()
+			node = &jqNode{kind: jqTry, left: node}
+		case p.isKeyword("as"):
+			// term as $name | body
+			p.next()
+			v := p.next()
+			if v.kind != exprTokVar {
+				return nil, errors.Wrapf(ErrJQSyntax, "expected variable after 'as' at %d", v.pos)
+			}
+			if err = p.expectOp("|"); err != nil {
+				return nil, err
+			}
+			body, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			return &jqNode{kind: jqBind, name: v.text, left: node, right: body}, nil
+		default:
+			return node, nil
+		}
+	}
+}
+
+// parseBracketSuffix parses the remainder of a `[...]` suffix after the
+// opening bracket has been consumed.
+func (p *jqParser) parseBracketSuffix(left *jqNode) (*jqNode, error) {
+	if p.isOp("]") {
+		p.next()
+		return &jqNode{kind: jqIterate, left: left}, nil
+	}
+	var from, to *jqNode
+	var err error
+	if !p.isOp(":") {
+		from, err = p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.isOp(":") {
+		p.next()
+		if !p.isOp("]") {
+			to, err = p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err = p.expectOp("]"); err != nil {
+			return nil, err
+		}
+		return &jqNode{kind: jqSlice, left: left, right: from, third: to}, nil
+	}
+	if err = p.expectOp("]"); err != nil {
+		return nil, err
+	}
+	return &jqNode{kind: jqIndex, left: left, right: from}, nil
+}
+
+func (p *jqParser) parsePrimary() (*jqNode, error) {
+	t := p.peek()
+	switch t.kind {
+	case exprTokNumber:
+		p.next()
+		d, err := decimal.NewFromString(t.text)
+		if err == nil {
+			d, err = jqCheckNumber(d)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(ErrJQSyntax, "invalid number %q at %d", t.text, t.pos)
+		}
+		return &jqNode{kind: jqLiteral, value: d}, nil
+	case exprTokString:
+		p.next()
+		return &jqNode{kind: jqLiteral, value: t.text}, nil
+	case exprTokVar:
+		p.next()
+		return &jqNode{kind: jqVarRef, name: t.text}, nil
+	case exprTokIdent:
+		return p.parseIdent()
+	case exprTokField:
+		p.next()
+		return &jqNode{kind: jqIndex, left: &jqNode{kind: jqIdentity}, right: &jqNode{kind: jqLiteral, value: t.text}}, nil
+	case exprTokOp:
+		switch t.text {
+		case ".":
+			p.next()
+			next := p.peek()
+			if next.kind == exprTokString {
+				p.next()
+				return &jqNode{kind: jqIndex, left: &jqNode{kind: jqIdentity}, right: &jqNode{kind: jqLiteral, value: next.text}}, nil
+			}
+			if p.isOp("[") {
+				p.next()
+				return p.parseBracketSuffix(&jqNode{kind: jqIdentity})
+			}
+			return &jqNode{kind: jqIdentity}, nil
+		case "(":
+			p.next()
+			inner, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		case "[":
+			p.next()
+			if p.isOp("]") {
+				p.next()
+				return &jqNode{kind: jqArray}, nil
+			}
+			inner, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expectOp("]"); err != nil {
+				return nil, err
+			}
+			return &jqNode{kind: jqArray, left: inner}, nil
+		case "{":
+			p.next()
+			return p.parseObject()
+		}
+	}
+	return nil, errors.Wrapf(ErrJQSyntax, "unexpected %q at %d", t.text, t.pos)
+}
+
+func (p *jqParser) parseIdent() (*jqNode, error) {
+	t := p.next()
+	switch t.text {
+	case "true":
+		return &jqNode{kind: jqLiteral, value: true}, nil
+	case "false":
+		return &jqNode{kind: jqLiteral, value: false}, nil
+	case "null":
+		return &jqNode{kind: jqLiteral, value: nil}, nil
+	case "if":
+		return p.parseIf()
+	}
+	node := &jqNode{kind: jqCall, name: t.text}
+	if p.isOp("(") {
+		p.next()
+		for {
+			arg, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			node.args = append(node.args, arg)
+			if p.isOp(";") {
+				p.next()
+				continue
+			}
+			if err = p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	arity, exists := jqBuiltinArity[t.text]
+	if !exists {
+		return nil, errors.Wrapf(ErrJQSyntax, "unknown function %q at %d", t.text, t.pos)
+	}
+	if !arity[len(node.args)] {
+		return nil, errors.Wrapf(ErrJQSyntax, "function %s/%d is not defined", t.text, len(node.args))
+	}
+	return node, nil
+}
+
+// parseIf parses the remainder of an if expression after the `if` keyword.
+func (p *jqParser) parseIf() (*jqNode, error) {
+	cond, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectKeyword("then"); err != nil {
+		return nil, err
+	}
+	then, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	node := &jqNode{kind: jqIf, left: cond, right: then}
+	switch {
+	case p.isKeyword("elif"):
+		p.next()
+		node.third, err = p.parseIf()
+		return node, err
+	case p.isKeyword("else"):
+		p.next()
+		node.third, err = p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = p.expectKeyword("end"); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// parseObject parses the remainder of an object constructor after the opening
+// brace has been consumed.
+func (p *jqParser) parseObject() (*jqNode, error) {
+	node := &jqNode{kind: jqObject}
+	if p.isOp("}") {
+		p.next()
+		return node, nil
+	}
+	for {
+		var entry jqObjectEntry
+		t := p.peek()
+		switch {
+		case t.kind == exprTokIdent || t.kind == exprTokString:
+			p.next()
+			entry.key = &jqNode{kind: jqLiteral, value: t.text}
+		case t.kind == exprTokVar:
+			// {$x} is shorthand for {x: $x}
+			p.next()
+			entry.key = &jqNode{kind: jqLiteral, value: t.text}
+			entry.value = &jqNode{kind: jqVarRef, name: t.text}
+		case p.isOp("("):
+			p.next()
+			key, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			entry.key = key
+		default:
+			return nil, errors.Wrapf(ErrJQSyntax, "unexpected %q in object at %d", t.text, t.pos)
+		}
+		if entry.value == nil {
+			if p.isOp(":") {
+				p.next()
+				value, err := p.parseAlt()
+				if err != nil {
+					return nil, err
+				}
+				entry.value = value
+			} else if entry.key.kind == jqLiteral {
+				// {foo} is shorthand for {foo: .foo}
+				entry.value = &jqNode{kind: jqIndex, left: &jqNode{kind: jqIdentity}, right: entry.key}
+			} else {
+				return nil, errors.Wrapf(ErrJQSyntax, "expected ':' in object at %d", p.peek().pos)
+			}
+		}
+		node.entries = append(node.entries, entry)
+		if p.isOp(",") {
+			p.next()
+			continue
+		}
+		if err := p.expectOp("}"); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+}
+
+// jqEnv holds variable bindings. Lookups walk the parent chain.
+type jqEnv struct {
+	parent *jqEnv
+	name   string
+	value  interface{}
+}
+
+func (e *jqEnv) lookup(name string) (interface{}, bool) {
+	for env := e; env != nil; env = env.parent {
+		if env.name == name {
+			return env.value, true
+		}
+	}
+	return nil, false
+}
+
+func (e *jqEnv) bind(name string, value interface{}) *jqEnv {
+	return &jqEnv{parent: e, name: name, value: value}
+}
+
+type jqInterpreter struct {
+	steps    int
+	maxSteps int
+}
+
+func (in *jqInterpreter) step(n int) error {
+	in.steps += n
+	if in.steps > in.maxSteps {
+		return errors.Wrapf(ErrJQStepsExhausted, "exceeded %d steps", in.maxSteps)
+	}
+	return nil
+}
+
+// run evaluates the program against input and returns every output value.
+// The input is normalized so that all numbers are decimals; outputs have
+// numbers converted back to int64, *big.Int or float64 like jsonparse does.
+func (prog *jqProgram) run(input interface{}, vars map[string]interface{}, maxSteps int) ([]interface{}, error) {
+	normalized, err := jqNormalize(input)
+	if err != nil {
+		return nil, err
+	}
+	var env *jqEnv
+	for name, value := range vars {
+		v, err := jqNormalize(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "variable $%s", name)
+		}
+		env = env.bind(name, v)
+	}
+	in := &jqInterpreter{maxSteps: maxSteps}
+	outs, err := in.eval(prog.root, normalized, env)
+	if err != nil {
+		return nil, err
+	}
+	for i, out := range outs {
+		outs[i], err = jqDenormalize(out)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return outs, nil
+}
+
+func (in *jqInterpreter) eval(n *jqNode, input interface{}, env *jqEnv) ([]interface{}, error) {
+	if err := in.step(1); err != nil {
+		return nil, err
+	}
+	switch n.kind {
+	case jqIdentity:
+		return []interface{}{input}, nil
+	case jqLiteral:
+		return []interface{}{n.value}, nil
+	case jqVarRef:
+		v, exists := env.lookup(n.name)
+		if !exists {
+			return nil, errors.Wrapf(ErrJQRuntime, "$%s is not defined", n.name)
+		}
+		return []interface{}{v}, nil
+	case jqPipe:
+		lefts, err := in.eval(n.left, input, env)
+		if err != nil {
+			return nil, err
+		}
+		var outs []interface{}
+		for _, l := range lefts {
+			rights, err := in.eval(n.right, l, env)
+			if err != nil {
+				return nil, err
+			}
+			outs = append(outs, rights...)
+		}
+		return outs, nil
+	case jqBind:
+		lefts, err := in.eval(n.left, input, env)
+		if err != nil {
+			return nil, err
+		}
+		var outs []interface{}
+		for _, l := range lefts {
+			rights, err := in.eval(n.right, input, env.bind(n.name, l))
+			if err != nil {
+				return nil, err
+			}
+			outs = append(outs, rights...)
+		}
+		return outs, nil
+	case jqComma:
+		lefts, err := in.eval(n.left, input, env)
+		if err != nil {
+			return nil, err
+		}
+		rights, err := in.eval(n.right, input, env)
+		if err != nil {
+			return nil, err
+		}
+		return append(lefts, rights...), nil
+	case jqAlt:
+		lefts, err := in.eval(n.left, input, env)
+		if err != nil && !errors.Is(err, ErrJQRuntime) {
+			return nil, err
+		}
+		var outs []interface{}
+		for _, l := range lefts {
+			if jqTruthy(l) {
+				outs = append(outs, l)
+			}
+		}
+		if len(outs) > 0 {
+			return outs, nil
+		}
+		return in.eval(n.right, input, env)
+	case jqTry:
+		outs, err := in.eval(n.left, input, env)
+		if err != nil && errors.Is(err, ErrJQRuntime) {
+			return outs, nil
+		}
+		return outs, err
+	case jqIndex:
+		return in.cartesian(n.left, n.right, input, env, func(l, r interface{}) ([]interface{}, error) {
+			v, err := jqIndexValue(l, r)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{v}, nil
+		})
+	case jqSlice:
+		bases, err := in.eval(n.left, input, env)
+		if err != nil {
+			return nil, err
+		}
+		froms, err := in.evalOptional(n.right, input, env)
+		if err != nil {
+			return nil, err
+		}
+		tos, err := in.evalOptional(n.third, input, env)
+		if err != nil {
+			return nil, err
+		}
+		var outs []interface{}
+		for _, b := range bases {
+			for _, f := range froms {
+				for _, t := range tos {
+					v, err := jqSliceValue(b, f, t)
+					if err != nil {
+						return nil, err
+					}
+					outs = append(outs, v)
+				}
+			}
+		}
+		return outs, nil
+	case jqIterate:
+		bases, err := in.eval(n.left, input, env)
+		if err != nil {
+			return nil, err
+		}
+		var outs []interface{}
+		for _, b := range bases {
+			vals, err := jqIterateValue(b)
+			if err != nil {
+				return nil, err
+			}
+			if err = in.step(len(vals)); err != nil {
+				return nil, err
+			}
+			outs = append(outs, vals...)
+		}
+		return outs, nil
+	case jqNeg:
+		vals, err := in.eval(n.left, input, env)
+		if err != nil {
+			return nil, err
+		}
+		outs := make([]interface{}, len(vals))
+		for i, v := range vals {
+			d, ok := v.(decimal.Decimal)
+			if !ok {
+				return nil, errors.Wrapf(ErrJQRuntime, "%s cannot be negated", jqTypeOf(v))
+			}
+			outs[i] = d.Neg()
+		}
+		return outs, nil
+	case jqBinary:
+		return in.cartesian(n.left, n.right, input, env, func(l, r interface{}) ([]interface{}, error) {
+			v, err := jqBinaryOp(n.op, l, r)
+			if err != nil {
+				return nil, err
+			}
+			// charge for the size of the result so repeated concatenation
+			// cannot grow values without bound
+			if err = in.step(jqShallowSize(v)); err != nil {
+				return nil, err
+			}
+			return []interface{}{v}, nil
+		})
+	case jqAnd, jqOr:
+		lefts, err := in.eval(n.left, input, env)
+		if err != nil {
+			return nil, err
+		}
+		var outs []interface{}
+		for _, l := range lefts {
+			lt := jqTruthy(l)
+			if n.kind == jqAnd && !lt || n.kind == jqOr && lt {
+				outs = append(outs, lt)
+				continue
+			}
+			rights, err := in.eval(n.right, input, env)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range rights {
+				outs = append(outs, jqTruthy(r))
+			}
+		}
+		return outs, nil
+	case jqArray:
+		if n.left == nil {
+			return []interface{}{[]interface{}{}}, nil
+		}
+		vals, err := in.eval(n.left, input, env)
+		if err != nil {
+			return nil, err
+		}
+		if vals == nil {
+			vals = []interface{}{}
+		}
+		return []interface{}{vals}, nil
+	case jqObject:
+		return in.evalObject(n.entries, input, env)
+	case jqIf:
+		conds, err := in.eval(n.left, input, env)
+		if err != nil {
+			return nil, err
+		}
+		var outs []interface{}
+		for _, c := range conds {
+			var branch []interface{}
+			if jqTruthy(c) {
+				branch, err = in.eval(n.right, input, env)
+			} else if n.third != nil {
+				branch, err = in.eval(n.third, input, env)
+			} else {
+				branch = []interface{}{input}
+			}
+			if err != nil {
+				return nil, err
+			}
+			outs = append(outs, branch...)
+		}
+		return outs, nil
+	case jqCall:
+		return in.call(n, input, env)
+	}
+	return nil, errors.Wrapf(ErrJQRuntime, "unknown node kind %d", n.kind)
+}
+
+func (in *jqInterpreter) evalOptional(n *jqNode, input interface{}, env *jqEnv) ([]interface{}, error) {
+	if n == nil {
+		return []interface{}{nil}, nil
+	}
+	return in.eval(n, input, env)
+}
+
+// cartesian evaluates both operands against the same input and applies fn to
+// every combination of their outputs, as jq does for binary operators.
+func (in *jqInterpreter) cartesian(left, right *jqNode, input interface{}, env *jqEnv, fn func(l, r interface{}) ([]interface{}, error)) ([]interface{}, error) {
+	lefts, err := in.eval(left, input, env)
+	if err != nil {
+		return nil, err
+	}
+	rights, err := in.eval(right, input, env)
+	if err != nil {
+		return nil, err
+	}
+	var outs []interface{}
+	for _, r := range rights {
+		for _, l := range lefts {
+			if err = in.step(1); err != nil {
+				return nil, err
+			}
+			vals, err := fn(l, r)
+			if err != nil {
+				return nil, err
+			}
+			outs = append(outs, vals...)
+		}
+	}
+	return outs, nil
+}
+
+func (in *jqInterpreter) evalObject(entries []jqObjectEntry, input interface{}, env *jqEnv) ([]interface{}, error) {
+	results := []map[string]interface{}{{}}
+	for _, entry := range entries {
+		keys, err := in.eval(entry.key, input, env)
+		if err != nil {
+			return nil, err
+		}
+		values, err := in.eval(entry.value, input, env)
+		if err != nil {
+			return nil, err
+		}
+		var next []map[string]interface{}
+		for _, partial := range results {
+			for _, k := range keys {
+				key, ok := k.(string)
+				if !ok {
+					return nil, errors.Wrapf(ErrJQRuntime, "object keys must be strings, got %s", jqTypeOf(k))
+				}
+				for _, v := range values {
+					if err = in.step(len(partial) + 1); err != nil {
+						return nil, err
+					}
+					m := make(map[string]interface{}, len(partial)+1)
+					for pk, pv := range partial {
+						m[pk] = pv
+					}
+					m[key] = v
+					next = append(next, m)
+				}
+			}
+		}
+		results = next
+	}
+	outs := make([]interface{}, len(results))
+	for i, m := range results {
+		outs[i] = m
+	}
+	return outs, nil
+}
+
+// evalSingle evaluates n and requires exactly one output.
+func (in *jqInterpreter) evalSingle(n *jqNode, input interface{}, env *jqEnv) (interface{}, error) {
+	vals, err := in.eval(n, input, env)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) != 1 {
+		return nil, errors.Wrapf(ErrJQRuntime, "expected a single value, got %d", len(vals))
+	}
+	return vals[0], nil
+}
+
+var jqBuiltinArity = map[string]map[int]bool{
+	"length":         {0: true},
+	"keys":           {0: true},
+	"values":         {0: true},
+	"add":            {0: true},
+	"min":            {0: true},
+	"max":            {0: true},
+	"min_by":         {1: true},
+	"max_by":         {1: true},
+	"sort":           {0: true},
+	"sort_by":        {1: true},
+	"unique":         {0: true},
+	"reverse":        {0: true},
+	"first":          {0: true, 1: true},
+	"last":           {0: true, 1: true},
+	"map":            {1: true},
+	"map_values":     {1: true},
+	"select":         {1: true},
+	"has":            {1: true},
+	"any":            {0: true, 1: true},
+	"all":            {0: true, 1: true},
+	"flatten":        {0: true},
+	"range":          {1: true, 2: true},
+	"tonumber":       {0: true},
+	"tostring":       {0: true},
+	"type":           {0: true},
+	"not":            {0: true},
+	"empty":          {0: true},
+	"error":          {0: true, 1: true},
+	"floor":          {0: true},
+	"ceil":           {0: true},
+	"round":          {0: true},
+	"abs":            {0: true},
+	"to_entries":     {0: true},
+	"from_entries":   {0: true},
+	"join":           {1: true},
+	"split":          {1: true},
+	"ascii_downcase": {0: true},
+	"ascii_upcase":   {0: true},
+}
+
+func (in *jqInterpreter) call(n *jqNode, input interface{}, env *jqEnv) ([]interface{}, error) {
+	one := func(v interface{}, err error) ([]interface{}, error) {
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{v}, nil
+	}
+
+	switch n.name {
+	case "empty":
+		return nil, nil
+	case "error":
+		msg := "error"
+		if len(n.args) == 1 {
+			v, err := in.evalSingle(n.args[0], input, env)
+			if err != nil {
+				return nil, err
+			}
+			msg = fmt.Sprint(v)
+		} else if input != nil {
+			msg = fmt.Sprint(input)
+		}
+		return nil, errors.Wrap(ErrJQRuntime, msg)
+	case "not":
+		return []interface{}{!jqTruthy(input)}, nil
+	case "type":
+		return []interface{}{jqTypeOf(input)}, nil
+	case "length":
+		return one(jqLength(input))
+	case "select":
+		conds, err := in.eval(n.args[0], input, env)
+		if err != nil {
+			return nil, err
+		}
+		var outs []interface{}
+		for _, c := range conds {
+			if jqTruthy(c) {
+				outs = append(outs, input)
+			}
+		}
+		return outs, nil
+	case "map":
+		elems, err := jqIterateValue(input)
+		if err != nil {
+			return nil, err
+		}
+		outs := []interface{}{}
+		for _, e := range elems {
+			vals, err := in.eval(n.args[0], e, env)
+			if err != nil {
+				return nil, err
+			}
+			outs = append(outs, vals...)
+		}
+		return []interface{}{outs}, nil
+	case "map_values":
+		switch v := input.(type) {
+		case []interface{}:
+			outs := []interface{}{}
+			for _, e := range v {
+				vals, err := in.eval(n.args[0], e, env)
+				if err != nil {
+					return nil, err
+				}
+				if len(vals) > 0 {
+					outs = append(outs, vals[0])
+				}
+			}
+			return []interface{}{outs}, nil
+		case map[string]interface{}:
+			out := make(map[string]interface{}, len(v))
+			for k, e := range v {
+				vals, err := in.eval(n.args[0], e, env)
+				if err != nil {
+					return nil, err
+				}
+				if len(vals) > 0 {
+					out[k] = vals[0]
+				}
+			}
+			return []interface{}{out}, nil
+		}
+		return nil, errors.Wrapf(ErrJQRuntime, "cannot iterate over %s", jqTypeOf(input))
+	case "first", "last":
+		if len(n.args) == 1 {
+			vals, err := in.eval(n.args[0], input, env)
+			if err != nil {
+				return nil, err
+			}
+			if len(vals) == 0 {
+				return nil, nil
+			}
+			if n.name == "first" {
+				return vals[:1], nil
+			}
+			return vals[len(vals)-1:], nil
+		}
+		arr, ok := input.([]interface{})
+		if !ok {
+			return nil, errors.Wrapf(ErrJQRuntime, "cannot index %s with number", jqTypeOf(input))
+		}
+		if len(arr) == 0 {
+			return []interface{}{nil}, nil
+		}
+		if n.name == "first" {
+			return []interface{}{arr[0]}, nil
+		}
+		return []interface{}{arr[len(arr)-1]}, nil
+	case "any", "all":
+		elems, err := jqIterateValue(input)
+		if err != nil {
+			return nil, err
+		}
+		result := n.name == "all"
+		for _, e := range elems {
+			vals := []interface{}{e}
+			if len(n.args) == 1 {
+				vals, err = in.eval(n.args[0], e, env)
+				if err != nil {
+					return nil, err
+				}
+			}
+			for _, v := range vals {
+				if jqTruthy(v) != result {
+					return []interface{}{!result}, nil
+				}
+			}
+		}
+		return []interface{}{result}, nil
+	case "range":
+		var from, to decimal.Decimal
+		bounds := make([]decimal.Decimal, len(n.args))
+		for i, arg := range n.args {
+			v, err := in.evalSingle(arg, input, env)
+			if err != nil {
+				return nil, err
+			}
+			d, ok := v.(decimal.Decimal)
+			if !ok {
+				return nil, errors.Wrapf(ErrJQRuntime, "range bounds must be numbers, got %s", jqTypeOf(v))
+			}
+			bounds[i] = d
+		}
+		if len(bounds) == 1 {
+			to = bounds[0]
+		} else {
+			from, to = bounds[0], bounds[1]
+		}
+		var outs []interface{}
+		for i := from; i.LessThan(to); i = i.Add(decimal.NewFromInt(1)) {
+			if err := in.step(1); err != nil {
+				return nil, err
+			}
+			outs = append(outs, i)
+		}
+		return outs, nil
+	case "sort_by", "min_by", "max_by":
+		arr, ok := input.([]interface{})
+		if !ok {
+			return nil, errors.Wrapf(ErrJQRuntime, "cannot sort %s", jqTypeOf(input))
+		}
+		keys := make([]interface{}, len(arr))
+		for i, e := range arr {
+			vals, err := in.eval(n.args[0], e, env)
+			if err != nil {
+				return nil, err
+			}
+			keys[i] = vals
+		}
+		idx := make([]int, len(arr))
+		for i := range idx {
+			idx[i] = i
+		}
+		if err := in.step(len(arr)); err != nil {
+			return nil, err
+		}
+		sort.SliceStable(idx, func(i, j int) bool {
+			return jqCompare(keys[idx[i]], keys[idx[j]]) < 0
+		})
+		switch n.name {
+		case "min_by":
+			if len(arr) == 0 {
+				return []interface{}{nil}, nil
+			}
+			return []interface{}{arr[idx[0]]}, nil
+		case "max_by":
+			if len(arr) == 0 {
+				return []interface{}{nil}, nil
+			}
+			return []interface{}{arr[idx[len(idx)-1]]}, nil
+		}
+		sorted := make([]interface{}, len(arr))
+		for i, j := range idx {
+			sorted[i] = arr[j]
+		}
+		return []interface{}{sorted}, nil
+	case "has":
+		key, err := in.evalSingle(n.args[0], input, env)
+		if err != nil {
+			return nil, err
+		}
+		switch v := input.(type) {
+		case map[string]interface{}:
+			k, ok := key.(string)
+			if !ok {
+				return nil, errors.Wrapf(ErrJQRuntime, "cannot check whether object has a key of type %s", jqTypeOf(key))
+			}
+			_, exists := v[k]
+			return []interface{}{exists}, nil
+		case []interface{}:
+			d, ok := key.(decimal.Decimal)
+			if !ok {
+				return nil, errors.Wrapf(ErrJQRuntime, "cannot check whether array has a key of type %s", jqTypeOf(key))
+			}
+			return []interface{}{!d.IsNegative() && d.LessThan(decimal.NewFromInt(int64(len(v))))}, nil
+		}
+		return nil, errors.Wrapf(ErrJQRuntime, "cannot check whether %s has a key", jqTypeOf(input))
+	case "join", "split":
+		sep, err := in.evalSingle(n.args[0], input, env)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := sep.(string)
+		if !ok {
+			return nil, errors.Wrapf(ErrJQRuntime, "%s separator must be a string", n.name)
+		}
+		if n.name == "split" {
+			str, ok := input.(string)
+			if !ok {
+				return nil, errors.Wrapf(ErrJQRuntime, "cannot split %s", jqTypeOf(input))
+			}
+			parts := strings.Split(str, s)
+			outs := make([]interface{}, len(parts))
+			for i, part := range parts {
+				outs[i] = part
+			}
+			return []interface{}{outs}, nil
+		}
+		arr, ok := input.([]interface{})
+		if !ok {
+			return nil, errors.Wrapf(ErrJQRuntime, "cannot join %s", jqTypeOf(input))
+		}
+		strs := make([]string, len(arr))
+		for i, e := range arr {
+			switch v := e.(type) {
+			case nil:
+			case string:
+				strs[i] = v
+			case decimal.Decimal:
+				strs[i] = v.String()
+			case bool:
+				strs[i] = strconv.FormatBool(v)
+			default:
+				return nil, errors.Wrapf(ErrJQRuntime, "cannot join with %s", jqTypeOf(e))
+			}
+		}
+		return []interface{}{strings.Join(strs, s)}, nil
+	}
+	return one(jqSimpleBuiltin(n.name, input))
+}
+
+// jqSimpleBuiltin implements builtins that take no arguments and produce
+// exactly one output.
+func jqSimpleBuiltin(name string, input interface{}) (interface{}, error) {
+	switch name {
+	case "keys":
+		switch v := input.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			outs := make([]interface{}, len(keys))
+			for i, k := range keys {
+				outs[i] = k
+			}
+			return outs, nil
+		case []interface{}:
+			outs := make([]interface{}, len(v))
+			for i := range v {
+				outs[i] = decimal.NewFromInt(int64(i))
+			}
+			return outs, nil
+		}
+		return nil, errors.Wrapf(ErrJQRuntime, "%s has no keys", jqTypeOf(input))
+	case "values":
+		vals, err := jqIterateValue(input)
+		if err != nil {
+			return nil, err
+		}
+		if vals == nil {
+			vals = []interface{}{}
+		}
+		return vals, nil
+	case "add":
+		vals, err := jqIterateValue(input)
+		if err != nil {
+			return nil, err
+		}
+		var acc interface{}
+		for _, v := range vals {
+			if acc, err = jqBinaryOp("+", acc, v); err != nil {
+				return nil, err
+			}
+		}
+		return acc, nil
+	case "min", "max":
+		arr, ok := input.([]interface{})
+		if !ok {
+			return nil, errors.Wrapf(ErrJQRuntime, "cannot compute %s of %s", name, jqTypeOf(input))
+		}
+		if len(arr) == 0 {
+			return nil, nil
+		}
+		best := arr[0]
+		for _, v := range arr[1:] {
+			c := jqCompare(v, best)
+			if name == "min" && c < 0 || name == "max" && c >= 0 {
+				best = v
+			}
+		}
+		return best, nil
+	case "sort", "unique":
+		arr, ok := input.([]interface{})
+		if !ok {
+			return nil, errors.Wrapf(ErrJQRuntime, "cannot sort %s", jqTypeOf(input))
+		}
+		sorted := make([]interface{}, len(arr))
+		copy(sorted, arr)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return jqCompare(sorted[i], sorted[j]) < 0
+		})
+		if name == "unique" {
+			uniq := []interface{}{}
+			for i, v := range sorted {
+				if i == 0 || jqCompare(v, sorted[i-1]) != 0 {
+					uniq = append(uniq, v)
+				}
+			}
+			return uniq, nil
+		}
+		return sorted, nil
+	case "reverse":
+		switch v := input.(type) {
+		case nil:
+			return []interface{}{}, nil
+		case string:
+			r := []rune(v)
+			for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+				r[i], r[j] = r[j], r[i]
+			}
+			return string(r), nil
+		case []interface{}:
+			out := make([]interface{}, len(v))
+			for i, e := range v {
+				out[len(v)-1-i] = e
+			}
+			return out, nil
+		}
+		return nil, errors.Wrapf(ErrJQRuntime, "cannot reverse %s", jqTypeOf(input))
+	case "flatten":
+		arr, ok := input.([]interface{})
+		if !ok {
+			return nil, errors.Wrapf(ErrJQRuntime, "cannot flatten %s", jqTypeOf(input))
+		}
+		return jqFlatten(arr), nil
+	case "tonumber":
+		switch v := input.(type) {
+		case decimal.Decimal:
+			return v, nil
+		case string:
+			d, err := decimal.NewFromString(strings.TrimSpace(v))
+			if err != nil {
+				return nil, errors.Wrapf(ErrJQRuntime, "cannot parse %q as a number", v)
+			}
+			return jqCheckNumber(d)
+		}
+		return nil, errors.Wrapf(ErrJQRuntime, "%s cannot be parsed as a number", jqTypeOf(input))
+	case "tostring":
+		if s, ok := input.(string); ok {
+			return s, nil
+		}
+		denormalized, err := jqDenormalize(input)
+		if err != nil {
+			return nil, err
+		}
+		bs, err := json.Marshal(denormalized)
+		if err != nil {
+			return nil, errors.Wrap(ErrJQRuntime, err.Error())
+		}
+		return string(bs), nil
+	case "floor", "ceil", "round", "abs":
+		d, ok := input.(decimal.Decimal)
+		if !ok {
+			return nil, errors.Wrapf(ErrJQRuntime, "%s (%s) number required", jqTypeOf(input), name)
+		}
+		switch name {
+		case "floor":
+			return d.Floor(), nil
+		case "ceil":
+			return d.Ceil(), nil
+		case "round":
+			return d.Round(0), nil
+		}
+		return d.Abs(), nil
+	case "to_entries":
+		m, ok := input.(map[string]interface{})
+		if !ok {
+			return nil, errors.Wrapf(ErrJQRuntime, "%s has no keys", jqTypeOf(input))
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		outs := make([]interface{}, len(keys))
+		for i, k := range keys {
+			outs[i] = map[string]interface{}{"key": k, "value": m[k]}
+		}
+		return outs, nil
+	case "from_entries":
+		arr, ok := input.([]interface{})
+		if !ok {
+			return nil, errors.Wrapf(ErrJQRuntime, "cannot use %s as entries", jqTypeOf(input))
+		}
+		out := make(map[string]interface{}, len(arr))
+		for _, e := range arr {
+			entry, ok := e.(map[string]interface{})
+			if !ok {
+				return nil, errors.Wrapf(ErrJQRuntime, "cannot use %s as an entry", jqTypeOf(e))
+			}
+			var key string
+			switch k := entry["key"].(type) {
+			case string:
+				key = k
+			case decimal.Decimal:
+				key = k.String()
+			default:
+				return nil, errors.Wrapf(ErrJQRuntime, "entry key must be a string, got %s", jqTypeOf(k))
+			}
+			out[key] = entry["value"]
+		}
+		return out, nil
+	case "ascii_downcase", "ascii_upcase":
+		s, ok := input.(string)
+		if !ok {
+			return nil, errors.Wrapf(ErrJQRuntime, "%s cannot be case-converted", jqTypeOf(input))
+		}
+		if name == "ascii_downcase" {
+			return strings.ToLower(s), nil
+		}
+		return strings.ToUpper(s), nil
+	}
+	return nil, errors.Wrapf(ErrJQRuntime, "unknown function %q", name)
+}
+
+func jqFlatten(arr []interface{}) []interface{} {
+	out := []interface{}{}
+	for _, e := range arr {
+		if inner, ok := e.([]interface{}); ok {
+			out = append(out, jqFlatten(inner)...)
+		} else {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func jqShallowSize(v interface{}) int {
+	switch x := v.(type) {
+	case string:
+		return len(x)
+	case []interface{}:
+		return len(x)
+	case map[string]interface{}:
+		return len(x)
+	}
+	return 0
+}
+
+func jqTruthy(v interface{}) bool {
+	switch b := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return b
+	}
+	return true
+}
+
+func jqTypeOf(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case decimal.Decimal:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func jqLength(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case nil:
+		return decimal.Zero, nil
+	case bool:
+		return nil, errors.Wrap(ErrJQRuntime, "boolean has no length")
+	case decimal.Decimal:
+		return x.Abs(), nil
+	case string:
+		return decimal.NewFromInt(int64(len([]rune(x)))), nil
+	case []interface{}:
+		return decimal.NewFromInt(int64(len(x))), nil
+	case map[string]interface{}:
+		return decimal.NewFromInt(int64(len(x))), nil
+	}
+	return nil, errors.Wrapf(ErrJQRuntime, "%s has no length", jqTypeOf(v))
+}
+
+func jqIterateValue(v interface{}) ([]interface{}, error) {
+	switch x := v.(type) {
+	case []interface{}:
+		return x, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		vals := make([]interface{}, len(keys))
+		for i, k := range keys {
+			vals[i] = x[k]
+		}
+		return vals, nil
+	}
+	return nil, errors.Wrapf(ErrJQRuntime, "cannot iterate over %s", jqTypeOf(v))
+}
+
+func jqIndexValue(base, index interface{}) (interface{}, error) {
+	switch b := base.(type) {
+	case nil:
+		switch index.(type) {
+		case string, decimal.Decimal, nil:
+			return nil, nil
+		}
+	case map[string]interface{}:
+		if k, ok := index.(string); ok {
+			return b[k], nil
+		}
+	case []interface{}:
+		if d, ok := index.(decimal.Decimal); ok {
+			i := jqInt(d.Floor())
+			if i < 0 {
+				i += len(b)
+			}
+			if i < 0 || i >= len(b) {
+				return nil, nil
+			}
+			return b[i], nil
+		}
+	}
+	return nil, errors.Wrapf(ErrJQRuntime, "cannot index %s with %s", jqTypeOf(base), jqTypeOf(index))
+}
+
+func jqSliceValue(base, from, to interface{}) (interface{}, error) {
+	var length int
+	switch b := base.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		length = len(b)
+	case string:
+		length = len([]rune(b))
+	default:
+		return nil, errors.Wrapf(ErrJQRuntime, "cannot slice %s", jqTypeOf(base))
+	}
+	bound := func(v interface{}, def int) (int, error) {
+		if v == nil {
+			return def, nil
+		}
+		d, ok := v.(decimal.Decimal)
+		if !ok {
+			return 0, errors.Wrapf(ErrJQRuntime, "slice indices must be numbers, got %s", jqTypeOf(v))
+		}
+		i := jqInt(d.Floor())
+		if i < 0 {
+			i += length
+		}
+		if i < 0 {
+			i = 0
+		}
+		if i > length {
+			i = length
+		}
+		return i, nil
+	}
+	start, err := bound(from, 0)
+	if err != nil {
+		return nil, err
+	}
+	end, err := bound(to, length)
+	if err != nil {
+		return nil, err
+	}
+	if end < start {
+		end = start
+	}
+	switch b := base.(type) {
+	case []interface{}:
+		out := make([]interface{}, end-start)
+		copy(out, b[start:end])
+		return out, nil
+	case string:
+		return string([]rune(b)[start:end]), nil
+	}
+	return nil, nil
+}
+
+// jqInt clamps d to the int range.
+func jqInt(d decimal.Decimal) int {
+	if d.GreaterThan(decimal.NewFromInt(math.MaxInt32)) {
+		return math.MaxInt32
+	}
+	if d.LessThan(decimal.NewFromInt(math.MinInt32)) {
+		return math.MinInt32
+	}
+	return int(d.IntPart())
+}
+
+// jqDivisionPrecision is the number of decimal places kept by "/".
+const jqDivisionPrecision = 18
+
+func jqBinaryOp(op string, l, r interface{}) (interface{}, error) {
+	switch op {
+	case "==":
+		return jqCompare(l, r) == 0, nil
+	case "!=":
+		return jqCompare(l, r) != 0, nil
+	case "<":
+		return jqCompare(l, r) < 0, nil
+	case "<=":
+		return jqCompare(l, r) <= 0, nil
+	case ">":
+		return jqCompare(l, r) > 0, nil
+	case ">=":
+		return jqCompare(l, r) >= 0, nil
+	}
+
+	if op == "+" {
+		if l == nil {
+			return r, nil
+		}
+		if r == nil {
+			return l, nil
+		}
+	}
+
+	switch lv := l.(type) {
+	case decimal.Decimal:
+		rv, ok := r.(decimal.Decimal)
+		if !ok {
+			break
+		}
+		switch op {
+		case "+":
+			return jqCheckNumber(lv.Add(rv))
+		case "-":
+			return jqCheckNumber(lv.Sub(rv))
+		case "*":
+			return jqCheckNumber(lv.Mul(rv))
+		case "/":
+			if rv.IsZero() {
+				return nil, errors.Wrap(ErrJQRuntime, "division by zero")
+			}
+			return jqCheckNumber(lv.DivRound(rv, jqDivisionPrecision))
+		case "%":
+			if rv.Truncate(0).IsZero() {
+				return nil, errors.Wrap(ErrJQRuntime, "modulo by zero")
+			}
+			return jqCheckNumber(lv.Truncate(0).Mod(rv.Truncate(0)))
+		}
+	case string:
+		switch rv := r.(type) {
+		case string:
+			switch op {
+			case "+":
+				return lv + rv, nil
+			case "/":
+				parts := strings.Split(lv, rv)
+				outs := make([]interface{}, len(parts))
+				for i, part := range parts {
+					outs[i] = part
+				}
+				return outs, nil
+			}
+		}
+	case []interface{}:
+		rv, ok := r.([]interface{})
+		if !ok {
+			break
+		}
+		switch op {
+		case "+":
+			out := make([]interface{}, 0, len(lv)+len(rv))
+			return append(append(out, lv...), rv...), nil
+		case "-":
+			out := []interface{}{}
+			for _, e := range lv {
+				found := false
+				for _, x := range rv {
+					if jqCompare(e, x) == 0 {
+						found = true
+						break
+					}
+				}
+				if !found {
+					out = append(out, e)
+				}
+			}
+			return out, nil
+		}
+	case map[string]interface{}:
+		rv, ok := r.(map[string]interface{})
+		if !ok || (op != "+" && op != "*") {
+			break
+		}
+		out := make(map[string]interface{}, len(lv)+len(rv))
+		for k, v := range lv {
+			out[k] = v
+		}
+		for k, v := range rv {
+			if op == "*" {
+				lsub, lok := out[k].(map[string]interface{})
+				rsub, rok := v.(map[string]interface{})
+				if lok && rok {
+					merged, err := jqBinaryOp("*", lsub, rsub)
+					if err != nil {
+						return nil, err
+					}
+					out[k] = merged
+					continue
+				}
+			}
+			out[k] = v
+		}
+		return out, nil
+	}
+	return nil, errors.Wrapf(ErrJQRuntime, "%s (%s) and %s cannot be combined with %q", jqTypeOf(l), jqPreview(l), jqTypeOf(r), op)
+}
+
+func jqPreview(v interface{}) string {
+	denormalized, err := jqDenormalize(v)
+	if err != nil {
+		return "?"
+	}
+	bs, err := json.Marshal(denormalized)
+	if err != nil {
+		return "?"
+	}
+	if len(bs) > 32 {
+		return string(bs[:29]) + "..."
+	}
+	return string(bs)
+}
+
+// jqTypeOrder is jq's ordering of values of different types.
+func jqTypeOrder(v interface{}) int {
+	switch b := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if b {
+			return 2
+		}
+		return 1
+	case decimal.Decimal:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	case map[string]interface{}:
+		return 6
+	}
+	return 7
+}
+
+// jqCompare totally orders jq values the same way jq's sort does.
+func jqCompare(l, r interface{}) int {
+	lo, ro := jqTypeOrder(l), jqTypeOrder(r)
+	if lo != ro {
+		if lo < ro {
+			return -1
+		}
+		return 1
+	}
+	switch lv := l.(type) {
+	case decimal.Decimal:
+		return lv.Cmp(r.(decimal.Decimal))
+	case string:
+		return strings.Compare(lv, r.(string))
+	case []interface{}:
+		rv := r.([]interface{})
+		for i := 0; i < len(lv) && i < len(rv); i++ {
+			if c := jqCompare(lv[i], rv[i]); c != 0 {
+				return c
+			}
+		}
+		return jqCompareInts(len(lv), len(rv))
+	case map[string]interface{}:
+		rv := r.(map[string]interface{})
+		lkeys, _ := jqSimpleBuiltin("keys", lv)
+		rkeys, _ := jqSimpleBuiltin("keys", rv)
+		if c := jqCompare(lkeys, rkeys); c != 0 {
+			return c
+		}
+		for _, k := range lkeys.([]interface{}) {
+			if c := jqCompare(lv[k.(string)], rv[k.(string)]); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+func jqCompareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// jqNormalize converts an arbitrary Go value (as produced by other pipeline
+// tasks) into the jq value model: nil, bool, string, decimal.Decimal,
+// []interface{} and map[string]interface{}.
+func jqNormalize(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case nil, bool, string:
+		return x, nil
+	case decimal.Decimal:
+		d, err := jqCheckNumber(x)
+		if err != nil {
+			return nil, errors.Wrap(ErrBadInput, err.Error())
+		}
+		return d, nil
+	case *decimal.Decimal:
+		if x == nil {
+			return nil, nil
+		}
+		return jqNormalize(*x)
+	case json.Number:
+		d, err := decimal.NewFromString(x.String())
+		if err == nil {
+			d, err = jqCheckNumber(d)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(ErrBadInput, "invalid number %q", x.String())
+		}
+		return d, nil
+	case *big.Int:
+		if x == nil {
+			return nil, nil
+		}
+		return jqNormalize(*x)
+	case big.Int:
+		d, err := jqCheckNumber(decimal.NewFromBigInt(&x, 0))
+		if err != nil {
+			return nil, errors.Wrap(ErrBadInput, err.Error())
+		}
+		return d, nil
+	case float64:
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return nil, errors.Wrapf(ErrBadInput, "invalid number %v", x)
+		}
+		return decimal.NewFromFloat(x), nil
+	case float32:
+		if math.IsNaN(float64(x)) || math.IsInf(float64(x), 0) {
+			return nil, errors.Wrapf(ErrBadInput, "invalid number %v", x)
+		}
+		return decimal.NewFromFloat32(x), nil
+	case int:
+		return decimal.NewFromInt(int64(x)), nil
+	case int8:
+		return decimal.NewFromInt(int64(x)), nil
+	case int16:
+		return decimal.NewFromInt(int64(x)), nil
+	case int32:
+		return decimal.NewFromInt(int64(x)), nil
+	case int64:
+		return decimal.NewFromInt(x), nil
+	case uint:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(uint64(x)), 0), nil
+	case uint8:
+		return decimal.NewFromInt(int64(x)), nil
+	case uint16:
+		return decimal.NewFromInt(int64(x)), nil
+	case uint32:
+		return decimal.NewFromInt(int64(x)), nil
+	case uint64:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(x), 0), nil
+	case []byte:
+		return string(x), nil
+	case []interface{}:
+		out := make([]interface{}, len(x))
+		for i, e := range x {
+			n, err := jqNormalize(e)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = n
+		}
+		return out, nil
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			n, err := jqNormalize(e)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = n
+		}
+		return out, nil
+	case error:
+		return nil, errors.Wrapf(ErrBadInput, "cannot use error %q as a value", x.Error())
+	}
+
+	// Fall back to a JSON round trip for anything else (structs, typed
+	// slices and maps, addresses, hashes...).
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, nil
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrapf(ErrBadInput, "cannot convert %T to a JSON value: %v", v, err)
+	}
+	return jqDecode(bs)
+}
+
+// jqDecode parses JSON text into the jq value model.
+func jqDecode(bs []byte) (interface{}, error) {
+	var decoded interface{}
+	d := json.NewDecoder(bytes.NewReader(bs))
+	d.UseNumber()
+	if err := d.Decode(&decoded); err != nil {
+		return nil, errors.Wrapf(ErrBadInput, "invalid JSON: %v", err)
+	}
+	if d.More() {
+		return nil, errors.Wrap(ErrBadInput, "invalid JSON: trailing data")
+	}
+	return jqNormalize(decoded)
+}
+
+// jqDenormalize converts decimals back into the number types that
+// reinterpetJsonNumbers produces, so outputs look like those of jsonparse.
+func jqDenormalize(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case decimal.Decimal:
+		if x.Exponent() >= 0 || x.Equal(x.Truncate(0)) {
+			return getJsonNumberValue(json.Number(x.Truncate(0).String()))
+		}
+		return getJsonNumberValue(json.Number(x.String()))
+	case []interface{}:
+		out := make([]interface{}, len(x))
+		for i, e := range x {
+			d, err := jqDenormalize(e)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = d
+		}
+		return out, nil
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			d, err := jqDenormalize(e)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = d
+		}
+		return out, nil
+	}
+	return v, nil
+}
@@ -0,0 +1,29 @@
+//go:build go1.18
+
+package pipeline
+
+import (
+	"testing"
+)
+
+func FuzzJQ(f *testing.F) {
+	f.Add(`.data.prices[0].p`, `{"data":{"prices":[{"p":"1.5"}]}}`)
+	f.Add(`[.[] | select(. > 1)] | add / length`, `[1, 2, 3]`)
+	f.Add(`.a as $x | {b: $x, c: [.d[1:]]} // null`, `{"a": 1, "d": "abc"}`)
+	f.Add(`if .a then .b elif .c then .d else empty end`, `{"c": true}`)
+	f.Add(`to_entries | map(.value) | sort | unique | reverse`, `{"x": 3, "y": 1}`)
+	f.Fuzz(func(t *testing.T, expr string, data string) {
+		if len(expr) > 10_000 || len(data) > 100_000 {
+			t.Skip()
+		}
+		prog, err := parseJQ(expr)
+		if err != nil {
+			t.Skip()
+		}
+		input, err := jqDecode([]byte(data))
+		if err != nil {
+			t.Skip()
+		}
+		_, _ = prog.run(input, nil, 10_000)
+	})
+}
@@ -88,6 +88,34 @@ func (_m *Config) DefaultHTTPTimeout() models.Duration {
 	return r0
 }
 
+// JobPipelineHTTPCircuitBreakerCooldown provides a mock function with given fields:
+func (_m *Config) JobPipelineHTTPCircuitBreakerCooldown() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// JobPipelineHTTPCircuitBreakerFailureThreshold provides a mock function with given fields:
+func (_m *Config) JobPipelineHTTPCircuitBreakerFailureThreshold() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
 // JobPipelineMaxRunDuration provides a mock function with given fields:
 func (_m *Config) JobPipelineMaxRunDuration() time.Duration {
 	ret := _m.Called()
@@ -85,6 +85,20 @@ func (_m *ORM) CreateSpec(_a0 pipeline.Pipeline, maxTaskTimeout models.Interval,
 	return r0, r1
 }
 
+// DeleteCachedTaskResultsOlderThan provides a mock function with given fields: ctx, threshold
+func (_m *ORM) DeleteCachedTaskResultsOlderThan(ctx context.Context, threshold time.Duration) error {
+	ret := _m.Called(ctx, threshold)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) error); ok {
+		r0 = rf(ctx, threshold)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteRun provides a mock function with given fields: id
 func (_m *ORM) DeleteRun(id int64) error {
 	ret := _m.Called(id)
@@ -157,6 +171,36 @@ func (_m *ORM) GetAllRuns() ([]pipeline.Run, error) {
 	return r0, r1
 }
 
+// GetCachedTaskResult provides a mock function with given fields: ctx, key, since
+func (_m *ORM) GetCachedTaskResult(ctx context.Context, key []byte, since time.Time) ([]byte, time.Time, error) {
+	ret := _m.Called(ctx, key, since)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, time.Time) []byte); ok {
+		r0 = rf(ctx, key, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 time.Time
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, time.Time) time.Time); ok {
+		r1 = rf(ctx, key, since)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, []byte, time.Time) error); ok {
+		r2 = rf(ctx, key, since)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetQ provides a mock function with given fields:
 func (_m *ORM) GetQ() pg.Q {
 	ret := _m.Called()
@@ -171,6 +215,52 @@ func (_m *ORM) GetQ() pg.Q {
 	return r0
 }
 
+// GetRecentRunOutputs provides a mock function with given fields: ctx, pipelineSpecID, outputIndex, limit
+func (_m *ORM) GetRecentRunOutputs(ctx context.Context, pipelineSpecID int32, outputIndex int, limit int) ([]pipeline.JSONSerializable, error) {
+	ret := _m.Called(ctx, pipelineSpecID, outputIndex, limit)
+
+	var r0 []pipeline.JSONSerializable
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int, int) []pipeline.JSONSerializable); ok {
+		r0 = rf(ctx, pipelineSpecID, outputIndex, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.JSONSerializable)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int, int) error); ok {
+		r1 = rf(ctx, pipelineSpecID, outputIndex, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecentTaskRunOutputs provides a mock function with given fields: ctx, pipelineSpecID, dotID, limit
+func (_m *ORM) GetRecentTaskRunOutputs(ctx context.Context, pipelineSpecID int32, dotID string, limit int) ([]pipeline.JSONSerializable, error) {
+	ret := _m.Called(ctx, pipelineSpecID, dotID, limit)
+
+	var r0 []pipeline.JSONSerializable
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string, int) []pipeline.JSONSerializable); ok {
+		r0 = rf(ctx, pipelineSpecID, dotID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.JSONSerializable)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int32, string, int) error); ok {
+		r1 = rf(ctx, pipelineSpecID, dotID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUnfinishedRuns provides a mock function with given fields: _a0, _a1, _a2
 func (_m *ORM) GetUnfinishedRuns(_a0 context.Context, _a1 time.Time, _a2 func(pipeline.Run) error) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -346,6 +436,20 @@ func (_m *ORM) UpdateTaskRunResult(taskID uuid.UUID, result pipeline.Result) (pi
 	return r0, r1, r2
 }
 
+// UpsertCachedTaskResult provides a mock function with given fields: ctx, key, value, fetchedAt
+func (_m *ORM) UpsertCachedTaskResult(ctx context.Context, key []byte, value []byte, fetchedAt time.Time) error {
+	ret := _m.Called(ctx, key, value, fetchedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, time.Time) error); ok {
+		r0 = rf(ctx, key, value, fetchedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewORM interface {
 	mock.TestingT
 	Cleanup(func())
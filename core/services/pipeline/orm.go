@@ -91,6 +91,20 @@ type ORM interface {
 	FindRun(id int64) (Run, error)
 	GetAllRuns() ([]Run, error)
 	GetUnfinishedRuns(context.Context, time.Time, func(run Run) error) error
+
+	// GetRecentRunOutputs returns the outputs at outputIndex of the limit most
+	// recently completed runs of a spec, most recent first.
+	GetRecentRunOutputs(ctx context.Context, pipelineSpecID int32, outputIndex int, limit int) ([]JSONSerializable, error)
+	// GetRecentTaskRunOutputs returns the outputs of the task dotID in the
+	// limit most recently completed runs of a spec, most recent first. Only
+	// runs whose task runs were saved are considered.
+	GetRecentTaskRunOutputs(ctx context.Context, pipelineSpecID int32, dotID string, limit int) ([]JSONSerializable, error)
+
+	// GetCachedTaskResult returns the cached task result for key if it was
+	// fetched after since, or sql.ErrNoRows.
+	GetCachedTaskResult(ctx context.Context, key []byte, since time.Time) (value []byte, fetchedAt time.Time, err error)
+	UpsertCachedTaskResult(ctx context.Context, key []byte, value []byte, fetchedAt time.Time) error
+	DeleteCachedTaskResultsOlderThan(ctx context.Context, threshold time.Duration) error
 	GetQ() pg.Q
 }
 
@@ -535,6 +549,52 @@ func (o *orm) GetAllRuns() (runs []Run, err error) {
 	return runs, err
 }
 
+func (o *orm) GetRecentRunOutputs(ctx context.Context, pipelineSpecID int32, outputIndex int, limit int) (outputs []JSONSerializable, err error) {
+	q := o.q.WithOpts(pg.WithParentCtx(ctx))
+	err = q.Select(&outputs, `SELECT outputs -> $2::int FROM pipeline_runs
+WHERE pipeline_spec_id = $1 AND state = $3
+ORDER BY id DESC
+LIMIT $4`, pipelineSpecID, outputIndex, RunStatusCompleted, limit)
+	return outputs, errors.Wrap(err, "failed to load recent run outputs")
+}
+
+func (o *orm) GetRecentTaskRunOutputs(ctx context.Context, pipelineSpecID int32, dotID string, limit int) (outputs []JSONSerializable, err error) {
+	q := o.q.WithOpts(pg.WithParentCtx(ctx))
+	err = q.Select(&outputs, `SELECT pipeline_task_runs.output FROM pipeline_task_runs
+INNER JOIN pipeline_runs ON pipeline_runs.id = pipeline_task_runs.pipeline_run_id
+WHERE pipeline_runs.pipeline_spec_id = $1 AND pipeline_runs.state = $2 AND pipeline_task_runs.dot_id = $3 AND pipeline_task_runs.error IS NULL
+ORDER BY pipeline_runs.id DESC
+LIMIT $4`, pipelineSpecID, RunStatusCompleted, dotID, limit)
+	return outputs, errors.Wrap(err, "failed to load recent task run outputs")
+}
+
+func (o *orm) GetCachedTaskResult(ctx context.Context, key []byte, since time.Time) (value []byte, fetchedAt time.Time, err error) {
+	q := o.q.WithOpts(pg.WithParentCtx(ctx))
+	var row struct {
+		Value     []byte
+		FetchedAt time.Time
+	}
+	err = q.Get(&row, `SELECT value, fetched_at FROM pipeline_task_result_cache WHERE key = $1 AND fetched_at > $2`, key, since)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "failed to load cached task result")
+	}
+	return row.Value, row.FetchedAt, nil
+}
+
+func (o *orm) UpsertCachedTaskResult(ctx context.Context, key []byte, value []byte, fetchedAt time.Time) error {
+	q := o.q.WithOpts(pg.WithParentCtx(ctx))
+	err := q.ExecQ(`INSERT INTO pipeline_task_result_cache (key, value, fetched_at) VALUES ($1, $2, $3)
+ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, fetched_at = EXCLUDED.fetched_at
+WHERE pipeline_task_result_cache.fetched_at < EXCLUDED.fetched_at`, key, value, fetchedAt)
+	return errors.Wrap(err, "failed to upsert cached task result")
+}
+
+func (o *orm) DeleteCachedTaskResultsOlderThan(ctx context.Context, threshold time.Duration) error {
+	q := o.q.WithOpts(pg.WithParentCtxInheritTimeout(ctx))
+	err := q.ExecQ(`DELETE FROM pipeline_task_result_cache WHERE fetched_at < $1`, time.Now().Add(-threshold))
+	return errors.Wrap(err, "failed to delete old cached task results")
+}
+
 func (o *orm) GetUnfinishedRuns(ctx context.Context, now time.Time, fn func(run Run) error) error {
 	q := o.q.WithOpts(pg.WithParentCtx(ctx))
 	return pg.Batch(func(offset, limit uint) (count uint, err error) {
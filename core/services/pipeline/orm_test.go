@@ -1,6 +1,8 @@
 package pipeline_test
 
 import (
+	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 
@@ -700,6 +702,65 @@ func Test_GetUnfinishedRuns_DirectRequest(t *testing.T) {
 	require.Equal(t, 1, counter)
 }
 
+func Test_PipelineORM_GetRecentOutputs(t *testing.T) {
+	db, orm := setupLiteORM(t)
+	ps := cltest.MustInsertPipelineSpec(t, db)
+	other := cltest.MustInsertPipelineSpec(t, db)
+
+	insertRun := func(specID int32, state pipeline.RunStatus, i int) {
+		now := time.Now()
+		run := pipeline.Run{
+			PipelineSpecID: specID,
+			State:          state,
+			Outputs:        pipeline.JSONSerializable{Val: []interface{}{i, nil}, Valid: true},
+			AllErrors:      pipeline.RunErrors{null.String{}, null.String{}},
+			FatalErrors:    pipeline.RunErrors{null.String{}, null.String{}},
+			CreatedAt:      now,
+			FinishedAt:     null.TimeFrom(now),
+			PipelineTaskRuns: []pipeline.TaskRun{{
+				ID:         uuid.NewV4(),
+				Type:       pipeline.TaskTypeMultiply,
+				DotID:      "ds1_multiply",
+				Output:     pipeline.JSONSerializable{Val: fmt.Sprint(i * 10), Valid: true},
+				CreatedAt:  now,
+				FinishedAt: null.TimeFrom(now),
+			}},
+		}
+		if state == pipeline.RunStatusErrored {
+			run.FatalErrors[0] = null.StringFrom("oh no")
+		}
+		require.NoError(t, orm.InsertFinishedRun(&run, true))
+	}
+	for i := 1; i <= 4; i++ {
+		insertRun(ps.ID, pipeline.RunStatusCompleted, i)
+	}
+	insertRun(ps.ID, pipeline.RunStatusErrored, 5)
+	insertRun(other.ID, pipeline.RunStatusCompleted, 6)
+
+	outputs, err := orm.GetRecentRunOutputs(testutils.Context(t), ps.ID, 0, 3)
+	require.NoError(t, err)
+	require.Len(t, outputs, 3)
+	for i, expected := range []int64{4, 3, 2} {
+		assert.Equal(t, expected, outputs[i].Val)
+	}
+
+	outputs, err = orm.GetRecentRunOutputs(testutils.Context(t), ps.ID, 1, 3)
+	require.NoError(t, err)
+	require.Len(t, outputs, 3)
+	assert.False(t, outputs[0].Valid)
+
+	outputs, err = orm.GetRecentTaskRunOutputs(testutils.Context(t), ps.ID, "ds1_multiply", 10)
+	require.NoError(t, err)
+	require.Len(t, outputs, 4)
+	for i, expected := range []string{"40", "30", "20", "10"} {
+		assert.Equal(t, expected, outputs[i].Val)
+	}
+
+	outputs, err = orm.GetRecentTaskRunOutputs(testutils.Context(t), ps.ID, "nonexistent", 10)
+	require.NoError(t, err)
+	assert.Empty(t, outputs)
+}
+
 func Test_Prune(t *testing.T) {
 	t.Parallel()
 
@@ -755,3 +816,39 @@ func Test_Prune(t *testing.T) {
 	assert.Equal(t, 3, cnt)
 
 }
+
+func Test_PipelineORM_CachedTaskResults(t *testing.T) {
+	_, orm := setupLiteORM(t)
+	ctx := testutils.Context(t)
+
+	key := utils.NewHash().Bytes()
+	fetchedAt := time.Now().Add(-time.Minute)
+
+	_, _, err := orm.GetCachedTaskResult(ctx, key, fetchedAt.Add(-time.Hour))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, orm.UpsertCachedTaskResult(ctx, key, []byte("first"), fetchedAt))
+
+	value, gotFetchedAt, err := orm.GetCachedTaskResult(ctx, key, fetchedAt.Add(-time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), value)
+	assert.WithinDuration(t, fetchedAt, gotFetchedAt, time.Millisecond)
+
+	_, _, err = orm.GetCachedTaskResult(ctx, key, fetchedAt.Add(time.Second))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// an older result does not replace a newer one
+	require.NoError(t, orm.UpsertCachedTaskResult(ctx, key, []byte("older"), fetchedAt.Add(-time.Minute)))
+	require.NoError(t, orm.UpsertCachedTaskResult(ctx, key, []byte("newer"), fetchedAt.Add(time.Second)))
+	value, _, err = orm.GetCachedTaskResult(ctx, key, fetchedAt)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("newer"), value)
+
+	require.NoError(t, orm.DeleteCachedTaskResultsOlderThan(ctx, time.Hour))
+	_, _, err = orm.GetCachedTaskResult(ctx, key, fetchedAt)
+	require.NoError(t, err)
+
+	require.NoError(t, orm.DeleteCachedTaskResultsOlderThan(ctx, time.Second))
+	_, _, err = orm.GetCachedTaskResult(ctx, key, fetchedAt.Add(-time.Hour))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
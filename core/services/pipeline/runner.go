@@ -12,6 +12,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	uuid "github.com/satori/go.uuid"
+	"go.uber.org/multierr"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/core/bridges"
@@ -38,6 +39,8 @@ type Runner interface {
 	// We expect spec.JobID and spec.JobName to be set for logging/prometheus.
 	// ExecuteRun executes a new run in-memory according to a spec and returns the results.
 	ExecuteRun(ctx context.Context, spec Spec, vars Vars, l logger.Logger) (run Run, trrs TaskRunResults, err error)
+	// SimulateRun executes a new run in-memory like ExecuteRun, but without side effects, see runner.SimulateRun.
+	SimulateRun(ctx context.Context, spec Spec, vars Vars, l logger.Logger) (run Run, trrs TaskRunResults, err error)
 	// InsertFinishedRun saves the run results in the database.
 	InsertFinishedRun(run *Run, saveSuccessfulTaskRuns bool, qopts ...pg.QOpt) error
 	InsertFinishedRuns(runs []*Run, saveSuccessfulTaskRuns bool, qopts ...pg.QOpt) error
@@ -61,6 +64,9 @@ type runner struct {
 	lggr                   logger.Logger
 	httpClient             *http.Client
 	unrestrictedHTTPClient *http.Client
+	httpBreakers           *circuitBreakers
+	resultCache            *resultCache
+	callBatcher            *callBatcher
 
 	// test helper
 	runFinished func(*Run)
@@ -114,7 +120,10 @@ func NewRunner(orm ORM, btORM bridges.ORM, cfg Config, chainSet evm.ChainSet, et
 		lggr:                   lggr.Named("PipelineRunner"),
 		httpClient:             httpClient,
 		unrestrictedHTTPClient: unrestrictedHTTPClient,
+		httpBreakers:           newCircuitBreakers(cfg.JobPipelineHTTPCircuitBreakerFailureThreshold(), cfg.JobPipelineHTTPCircuitBreakerCooldown()),
+		resultCache:            newResultCache(orm, DefaultTaskResultCacheSize),
 	}
+	r.callBatcher = newCallBatcher(DefaultCallBatchWindow, DefaultCallBatchSize, r.chStop)
 	r.runReaperWorker = utils.NewSleeperTask(
 		utils.SleeperFuncTask(r.runReaper, "PipelineRunnerReaper"),
 	)
@@ -225,6 +234,71 @@ func (r *runner) ExecuteRun(
 	return run, taskRunResults, nil
 }
 
+// SimulateRun executes a new run in-memory like ExecuteRun, but ethtx tasks
+// return the transaction they would have created instead of queuing it, and
+// neither bridge responses nor the results of tasks with a cacheTTL are
+// cached. Async tasks are returned as pending rather than failing the
+// simulation.
+func (r *runner) SimulateRun(
+	ctx context.Context,
+	spec Spec,
+	vars Vars,
+	l logger.Logger,
+) (Run, TaskRunResults, error) {
+	run := NewRun(spec, vars)
+
+	pipeline, err := r.initializePipeline(&run)
+	if err != nil {
+		return run, nil, err
+	}
+
+	simulatePipeline(pipeline)
+
+	taskRunResults := r.run(ctx, pipeline, &run, vars, l)
+
+	return run, taskRunResults, nil
+}
+
+// simulatePipeline makes the tasks of pipeline that have side effects only
+// pretend to have them, see SimulateRun.
+func simulatePipeline(pipeline *Pipeline) {
+	for _, task := range pipeline.Tasks {
+		switch task.Type() {
+		case TaskTypeETHTx:
+			task.(*ETHTxTask).simulate = true
+		case TaskTypeBridge:
+			task.(*BridgeTask).simulate = true
+		case TaskTypeHTTP:
+			task.(*HTTPTask).cache = task.(*HTTPTask).cache.readOnly()
+		case TaskTypeETHCall:
+			task.(*ETHCallTask).cache = task.(*ETHCallTask).cache.readOnly()
+		case TaskTypeForEach:
+			task.(*ForEachTask).simulate = true
+		default:
+		}
+	}
+}
+
+// subpipelineRunner returns a function that executes sub-pipelines of a run
+// of spec, e.g. for the foreach task, in memory.
+func (r *runner) subpipelineRunner(spec Spec) subpipelineRunner {
+	return func(ctx context.Context, source string, vars Vars, simulate bool, l logger.Logger) (Run, error) {
+		spec.DotDagSource = source
+		run := NewRun(spec, vars)
+
+		pipeline, err := r.initializePipeline(&run)
+		if err != nil {
+			return run, err
+		}
+		if simulate {
+			simulatePipeline(pipeline)
+		}
+
+		r.run(ctx, pipeline, &run, vars, l)
+		return run, nil
+	}
+}
+
 func (r *runner) initializePipeline(run *Run) (*Pipeline, error) {
 	pipeline, err := Parse(run.PipelineSpec.DotDagSource)
 	if err != nil {
@@ -240,6 +314,8 @@ func (r *runner) initializePipeline(run *Run) (*Pipeline, error) {
 			task.(*HTTPTask).config = r.config
 			task.(*HTTPTask).httpClient = r.httpClient
 			task.(*HTTPTask).unrestrictedHTTPClient = r.unrestrictedHTTPClient
+			task.(*HTTPTask).breakers = r.httpBreakers
+			task.(*HTTPTask).cache = r.resultCache
 		case TaskTypeBridge:
 			task.(*BridgeTask).config = r.config
 			task.(*BridgeTask).orm = r.btORM
@@ -248,14 +324,19 @@ func (r *runner) initializePipeline(run *Run) (*Pipeline, error) {
 			// must use the unrestrictedHTTPClient because some node operators
 			// may run external adapters on their own hardware
 			task.(*BridgeTask).httpClient = r.unrestrictedHTTPClient
+			task.(*BridgeTask).breakers = r.httpBreakers
 		case TaskTypeETHCall:
 			task.(*ETHCallTask).chainSet = r.chainSet
 			task.(*ETHCallTask).config = r.config
 			task.(*ETHCallTask).specGasLimit = run.PipelineSpec.GasLimit
 			task.(*ETHCallTask).jobType = run.PipelineSpec.JobType
+			task.(*ETHCallTask).cache = r.resultCache
+			task.(*ETHCallTask).batcher = r.callBatcher
 		case TaskTypeETHGetBlock:
 			task.(*ETHGetBlockTask).chainSet = r.chainSet
 			task.(*ETHGetBlockTask).config = r.config
+		case TaskTypeETHGetLogs:
+			task.(*ETHGetLogsTask).chainSet = r.chainSet
 		case TaskTypeVRF:
 			task.(*VRFTask).keyStore = r.vrfKeyStore
 		case TaskTypeVRFV2:
@@ -270,6 +351,11 @@ func (r *runner) initializePipeline(run *Run) (*Pipeline, error) {
 			task.(*ETHTxTask).specGasLimit = run.PipelineSpec.GasLimit
 			task.(*ETHTxTask).jobType = run.PipelineSpec.JobType
 			task.(*ETHTxTask).forwardingAllowed = run.PipelineSpec.ForwardingAllowed
+		case TaskTypeForEach:
+			task.(*ForEachTask).runSubpipeline = r.subpipelineRunner(run.PipelineSpec)
+		case TaskTypePreviousRuns:
+			task.(*PreviousRunsTask).orm = r.orm
+			task.(*PreviousRunsTask).specID = run.PipelineSpec.ID
 		default:
 		}
 	}
@@ -610,7 +696,10 @@ func (r *runner) runReaper() {
 	ctx, cancel := utils.ContextFromChanWithDeadline(r.chStop, r.config.JobPipelineReaperInterval())
 	defer cancel()
 
-	err := r.orm.DeleteRunsOlderThan(ctx, r.config.JobPipelineReaperThreshold())
+	err := multierr.Combine(
+		r.orm.DeleteRunsOlderThan(ctx, r.config.JobPipelineReaperThreshold()),
+		r.orm.DeleteCachedTaskResultsOlderThan(ctx, MaxCacheTTL),
+	)
 	if err != nil {
 		r.lggr.Errorw("Pipeline run reaper failed", "error", err)
 	} else {
@@ -33,6 +33,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/pg"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/smartcontractkit/sqlx"
@@ -938,3 +939,56 @@ en->de
 	require.NoError(t, err)
 	assert.Equal(t, inputBytes, result.Value)
 }
+
+func Test_PipelineRunner_SimulateRun_DoesNotWriteCaches(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	lggr := logger.TestLogger(t)
+
+	btcUSDPairing := utils.MustUnmarshalToMap(`{"data":{"coin":"BTC","market":"USD"}}`)
+	s1 := httptest.NewServer(fakePriceResponder(t, btcUSDPairing, decimal.NewFromInt(9700), "", nil))
+	defer s1.Close()
+	s2 := httptest.NewServer(fakeStringResponder(t, "9600"))
+	defer s2.Close()
+
+	_, bt := cltest.MustCreateBridge(t, db, cltest.BridgeOpts{URL: s1.URL}, cfg)
+	btORM := bridges.NewORM(db, lggr, cfg)
+	orm := pipeline.NewORM(db, lggr, cfg)
+	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: cfg})
+	c := clhttptest.NewTestLocalOnlyHTTPClient()
+	r := pipeline.NewRunner(orm, btORM, cfg, cc, nil, nil, lggr, c, c)
+
+	source := fmt.Sprintf(`
+ds1 [type=bridge name="%s" cacheTTL="30s" requestData=<{"data": {"coin": "BTC", "market": "USD"}}>]
+ds2 [type=http method=GET url="%s" cacheTTL="1m" cacheStore="db"]
+`, bt.Name.String(), s2.URL)
+	p, err := pipeline.Parse(source)
+	require.NoError(t, err)
+	specID, err := orm.CreateSpec(*p, *models.NewInterval(time.Minute), pg.WithParentCtx(testutils.Context(t)))
+	require.NoError(t, err)
+
+	finishedAt := time.Now().Add(-time.Minute).UTC().Round(time.Second)
+	require.NoError(t, btORM.UpsertBridgeResponse("ds1", specID, []byte("9500")))
+	_, err = db.Exec(`UPDATE bridge_last_value SET finished_at = $1 WHERE dot_id = 'ds1' AND spec_id = $2`, finishedAt, specID)
+	require.NoError(t, err)
+
+	spec := pipeline.Spec{ID: specID, DotDagSource: source}
+	_, trrs, err := r.SimulateRun(testutils.Context(t), spec, pipeline.NewVarsFrom(nil), lggr)
+	require.NoError(t, err)
+	require.Len(t, trrs, 2)
+	for _, trr := range trrs {
+		require.NoError(t, trr.Result.Error, trr.Task.DotID())
+	}
+
+	var cached struct {
+		Value      []byte
+		FinishedAt time.Time `db:"finished_at"`
+	}
+	require.NoError(t, db.Get(&cached, `SELECT value, finished_at FROM bridge_last_value WHERE dot_id = 'ds1' AND spec_id = $1`, specID))
+	assert.Equal(t, "9500", string(cached.Value))
+	assert.True(t, finishedAt.Equal(cached.FinishedAt), "bridge cache row was updated")
+
+	var count int
+	require.NoError(t, db.Get(&count, `SELECT count(*) FROM pipeline_task_result_cache`))
+	assert.Zero(t, count)
+}
@@ -224,7 +224,7 @@ func (s *scheduler) Run() {
 		}
 
 		// if task hasn't reached it's max retry count yet, we schedule it again
-		if result.Attempts < uint(result.Task.TaskRetries()) && result.Result.Error != nil {
+		if result.Attempts < uint(result.Task.TaskRetries()) && result.Result.Error != nil && !retriesInRun(result.Task) {
 			// we immediately increase the in-flight counter so the pipeline doesn't terminate
 			// while we wait for the next retry
 			s.waiting++
@@ -279,6 +279,13 @@ func (s *scheduler) Run() {
 	close(s.taskCh)
 }
 
+// retriesInRun reports whether task spends its retries within a single Run
+// (e.g. the http task), in which case the scheduler must not retry it again.
+func retriesInRun(task Task) bool {
+	_, ok := task.(interface{ retriesInRun() })
+	return ok
+}
+
 func (s *scheduler) markRemaining(err error) {
 	now := time.Now()
 	for _, task := range s.pipeline.Tasks {
@@ -0,0 +1,1249 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// This file implements the small imperative language executed by the script
+// task. A script is a sequence of statements:
+//
+//	let x = expr;             declare a variable in the current block
+//	x = expr;  x[i].k = expr; assign (containers are copied, never mutated in place)
+//	if cond { ... } else if cond { ... } else { ... }
+//	for x in expr { ... }     iterate over array elements or sorted object keys
+//	while cond { ... }
+//	break;  continue;  return expr;
+//	expr;
+//
+// Expressions support number (arbitrary precision decimal), string, bool,
+// null, array and object literals, indexing (a[i], a.k), the operators
+// || && == != < <= > >= + - * / % ! and unary -, and the builtins listed in
+// scriptBuiltins. Values use the same model as the jq interpreter in jq.go.
+//
+// Execution is deterministic and has no access to I/O. Every statement and
+// expression consumes a step, and every value allocated is charged against a
+// memory budget; exceeding either aborts the script. The script is also
+// aborted when its context is done, e.g. when the task exceeds
+// MaxTaskDuration or the run is cancelled.
+
+var (
+	ErrScriptSyntax         = errors.New("script syntax error")
+	ErrScriptRuntime        = errors.New("script runtime error")
+	ErrScriptStepsExhausted = errors.New("script step limit exhausted")
+	ErrScriptMemoryExceeded = errors.New("script memory limit exceeded")
+)
+
+const (
+	DefaultScriptMaxSteps  = 100_000
+	DefaultScriptMaxMemory = 1 << 20 // 1 MiB
+	// MaxScriptMaxSteps and MaxScriptMaxMemory cap the maxSteps and
+	// maxMemory of a script task, whatever its job spec sets
+	MaxScriptMaxSteps  = 10_000_000
+	MaxScriptMaxMemory = 64 << 20 // 64 MiB
+
+	// scriptCtxCheckSteps is how many steps a script runs between checks of
+	// its context
+	scriptCtxCheckSteps = 1000
+)
+
+var scriptOps = []string{"==", "!=", "<=", ">=", "&&", "||", ".", "[", "]", "(", ")", "{", "}", ":", ";", ",", "+", "-", "*", "/", "%", "<", ">", "!", "="}
+
+var scriptKeywords = map[string]bool{
+	"let": true, "if": true, "else": true, "for": true, "in": true, "while": true,
+	"break": true, "continue": true, "return": true, "true": true, "false": true, "null": true,
+}
+
+type scriptStmtKind int
+
+const (
+	scriptLet scriptStmtKind = iota
+	scriptAssign
+	scriptIf
+	scriptFor
+	scriptWhile
+	scriptBreak
+	scriptContinue
+	scriptReturn
+	scriptExprStmt
+)
+
+type scriptStmt struct {
+	kind   scriptStmtKind
+	name   string
+	target *scriptExpr // for assignments
+	expr   *scriptExpr
+	body   []*scriptStmt
+	elseIf *scriptStmt   // else if ...
+	orElse []*scriptStmt // else { ... }
+}
+
+type scriptExprKind int
+
+const (
+	scriptLiteral scriptExprKind = iota
+	scriptIdent
+	scriptIndex
+	scriptCall
+	scriptUnary
+	scriptBinary
+	scriptArray
+	scriptObject
+)
+
+type scriptExpr struct {
+	kind  scriptExprKind
+	op    string
+	name  string
+	value interface{}
+	left  *scriptExpr
+	right *scriptExpr
+	args  []*scriptExpr
+	keys  []string
+	pos   int
+}
+
+// scriptProgram is a parsed script that can be executed repeatedly.
+type scriptProgram struct {
+	stmts []*scriptStmt
+}
+
+type scriptParser struct {
+	toks []exprToken
+	pos  int
+}
+
+// parseScript parses the source of a script.
+func parseScript(src string) (*scriptProgram, error) {
+	toks, err := lexExpr(src, scriptOps, ErrScriptSyntax)
+	if err != nil {
+		return nil, err
+	}
+	p := &scriptParser{toks: toks}
+	var stmts []*scriptStmt
+	for p.peek().kind != exprTokEOF {
+		stmt, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+	}
+	return &scriptProgram{stmts: stmts}, nil
+}
+
+func (p *scriptParser) peek() exprToken {
+	return p.toks[p.pos]
+}
+
+func (p *scriptParser) next() exprToken {
+	t := p.toks[p.pos]
+	if t.kind != exprTokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *scriptParser) isOp(op string) bool {
+	t := p.peek()
+	return t.kind == exprTokOp && t.text == op
+}
+
+func (p *scriptParser) isKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == exprTokIdent && t.text == kw
+}
+
+func (p *scriptParser) expectOp(op string) error {
+	t := p.next()
+	if t.kind != exprTokOp || t.text != op {
+		return errors.Wrapf(ErrScriptSyntax, "expected %q at %d, got %q", op, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *scriptParser) expectName() (string, error) {
+	t := p.next()
+	if t.kind != exprTokIdent || scriptKeywords[t.text] {
+		return "", errors.Wrapf(ErrScriptSyntax, "expected a name at %d, got %q", t.pos, t.text)
+	}
+	return t.text, nil
+}
+
+func (p *scriptParser) parseBlock() ([]*scriptStmt, error) {
+	if err := p.expectOp("{"); err != nil {
+		return nil, err
+	}
+	var stmts []*scriptStmt
+	for !p.isOp("}") {
+		if p.peek().kind == exprTokEOF {
+			return nil, errors.Wrap(ErrScriptSyntax, "unterminated block")
+		}
+		stmt, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+	}
+	p.next()
+	return stmts, nil
+}
+
+func (p *scriptParser) parseStmt() (*scriptStmt, error) {
+	t := p.peek()
+	if t.kind == exprTokIdent {
+		switch t.text {
+		case "let":
+			p.next()
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expectOp("="); err != nil {
+				return nil, err
+			}
+			expr, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return &scriptStmt{kind: scriptLet, name: name, expr: expr}, p.expectOp(";")
+		case "if":
+			p.next()
+			return p.parseIf()
+		case "for":
+			p.next()
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if !p.isKeyword("in") {
+				return nil, errors.Wrapf(ErrScriptSyntax, "expected \"in\" at %d", p.peek().pos)
+			}
+			p.next()
+			expr, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			body, err := p.parseBlock()
+			if err != nil {
+				return nil, err
+			}
+			return &scriptStmt{kind: scriptFor, name: name, expr: expr, body: body}, nil
+		case "while":
+			p.next()
+			cond, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			body, err := p.parseBlock()
+			if err != nil {
+				return nil, err
+			}
+			return &scriptStmt{kind: scriptWhile, expr: cond, body: body}, nil
+		case "break":
+			p.next()
+			return &scriptStmt{kind: scriptBreak}, p.expectOp(";")
+		case "continue":
+			p.next()
+			return &scriptStmt{kind: scriptContinue}, p.expectOp(";")
+		case "return":
+			p.next()
+			stmt := &scriptStmt{kind: scriptReturn}
+			if !p.isOp(";") {
+				expr, err := p.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				stmt.expr = expr
+			}
+			return stmt, p.expectOp(";")
+		}
+	}
+
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.isOp("=") {
+		eq := p.next()
+		if !scriptIsAssignable(expr) {
+			return nil, errors.Wrapf(ErrScriptSyntax, "cannot assign at %d", eq.pos)
+		}
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &scriptStmt{kind: scriptAssign, target: expr, expr: value}, p.expectOp(";")
+	}
+	return &scriptStmt{kind: scriptExprStmt, expr: expr}, p.expectOp(";")
+}
+
+func scriptIsAssignable(e *scriptExpr) bool {
+	switch e.kind {
+	case scriptIdent:
+		return true
+	case scriptIndex:
+		return scriptIsAssignable(e.left)
+	}
+	return false
+}
+
+// parseIf parses the remainder of an if statement after the `if` keyword.
+func (p *scriptParser) parseIf() (*scriptStmt, error) {
+	cond, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.parseBlock()
+	if err != nil {
+		return nil, err
+	}
+	stmt := &scriptStmt{kind: scriptIf, expr: cond, body: body}
+	if p.isKeyword("else") {
+		p.next()
+		if p.isKeyword("if") {
+			p.next()
+			stmt.elseIf, err = p.parseIf()
+		} else {
+			stmt.orElse, err = p.parseBlock()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+var scriptBinaryPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *scriptParser) parseExpr() (*scriptExpr, error) {
+	return p.parseBinary(0)
+}
+
+func (p *scriptParser) parseBinary(level int) (*scriptExpr, error) {
+	if level == len(scriptBinaryPrecedence) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		matched := false
+		for _, op := range scriptBinaryPrecedence[level] {
+			if t.kind == exprTokOp && t.text == op {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &scriptExpr{kind: scriptBinary, op: t.text, left: left, right: right, pos: t.pos}
+	}
+}
+
+func (p *scriptParser) parseUnary() (*scriptExpr, error) {
+	if p.isOp("-") || p.isOp("!") {
+		t := p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &scriptExpr{kind: scriptUnary, op: t.text, left: operand, pos: t.pos}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *scriptParser) parsePostfix() (*scriptExpr, error) {
+	e, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == exprTokField:
+			p.next()
+			e = &scriptExpr{kind: scriptIndex, left: e, right: &scriptExpr{kind: scriptLiteral, value: t.text}, pos: t.pos}
+		case p.isOp("["):
+			p.next()
+			index, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expectOp("]"); err != nil {
+				return nil, err
+			}
+			e = &scriptExpr{kind: scriptIndex, left: e, right: index, pos: t.pos}
+		default:
+			return e, nil
+		}
+	}
+}
+
+func (p *scriptParser) parsePrimary() (*scriptExpr, error) {
+	t := p.next()
+	switch t.kind {
+	case exprTokNumber:
+		d, err := decimal.NewFromString(t.text)
+		if err == nil {
+			d, err = jqCheckNumber(d)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(ErrScriptSyntax, "invalid number %q at %d", t.text, t.pos)
+		}
+		return &scriptExpr{kind: scriptLiteral, value: d, pos: t.pos}, nil
+	case exprTokString:
+		return &scriptExpr{kind: scriptLiteral, value: t.text, pos: t.pos}, nil
+	case exprTokIdent:
+		switch t.text {
+		case "true":
+			return &scriptExpr{kind: scriptLiteral, value: true, pos: t.pos}, nil
+		case "false":
+			return &scriptExpr{kind: scriptLiteral, value: false, pos: t.pos}, nil
+		case "null":
+			return &scriptExpr{kind: scriptLiteral, value: nil, pos: t.pos}, nil
+		}
+		if scriptKeywords[t.text] {
+			return nil, errors.Wrapf(ErrScriptSyntax, "unexpected keyword %q at %d", t.text, t.pos)
+		}
+		if !p.isOp("(") {
+			return &scriptExpr{kind: scriptIdent, name: t.text, pos: t.pos}, nil
+		}
+		p.next()
+		if _, exists := scriptBuiltins[t.text]; !exists {
+			return nil, errors.Wrapf(ErrScriptSyntax, "unknown function %q at %d", t.text, t.pos)
+		}
+		call := &scriptExpr{kind: scriptCall, name: t.text, pos: t.pos}
+		for !p.isOp(")") {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if !p.isOp(")") {
+				if err = p.expectOp(","); err != nil {
+					return nil, err
+				}
+			}
+		}
+		p.next()
+		return call, nil
+	case exprTokOp:
+		switch t.text {
+		case "(":
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return e, p.expectOp(")")
+		case "[":
+			arr := &scriptExpr{kind: scriptArray, pos: t.pos}
+			for !p.isOp("]") {
+				elem, err := p.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				arr.args = append(arr.args, elem)
+				if !p.isOp("]") {
+					if err = p.expectOp(","); err != nil {
+						return nil, err
+					}
+				}
+			}
+			p.next()
+			return arr, nil
+		case "{":
+			obj := &scriptExpr{kind: scriptObject, pos: t.pos}
+			for !p.isOp("}") {
+				k := p.next()
+				if k.kind != exprTokIdent && k.kind != exprTokString {
+					return nil, errors.Wrapf(ErrScriptSyntax, "expected an object key at %d, got %q", k.pos, k.text)
+				}
+				if err := p.expectOp(":"); err != nil {
+					return nil, err
+				}
+				value, err := p.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				obj.keys = append(obj.keys, k.text)
+				obj.args = append(obj.args, value)
+				if !p.isOp("}") {
+					if err = p.expectOp(","); err != nil {
+						return nil, err
+					}
+				}
+			}
+			p.next()
+			return obj, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrScriptSyntax, "unexpected %q at %d", t.text, t.pos)
+}
+
+// scriptScope is a block scope. Lookups and assignments walk the parent chain.
+type scriptScope struct {
+	parent *scriptScope
+	vars   map[string]interface{}
+}
+
+func newScriptScope(parent *scriptScope) *scriptScope {
+	return &scriptScope{parent: parent, vars: map[string]interface{}{}}
+}
+
+func (s *scriptScope) lookup(name string) (interface{}, bool) {
+	for scope := s; scope != nil; scope = scope.parent {
+		if v, exists := scope.vars[name]; exists {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func (s *scriptScope) assign(name string, value interface{}) bool {
+	for scope := s; scope != nil; scope = scope.parent {
+		if _, exists := scope.vars[name]; exists {
+			scope.vars[name] = value
+			return true
+		}
+	}
+	return false
+}
+
+type scriptControl int
+
+const (
+	scriptNext scriptControl = iota
+	scriptDoBreak
+	scriptDoContinue
+	scriptDoReturn
+)
+
+type scriptInterpreter struct {
+	ctx       context.Context
+	steps     int
+	maxSteps  int
+	memory    int
+	maxMemory int
+	result    interface{}
+}
+
+func (in *scriptInterpreter) step() error {
+	return in.stepN(1)
+}
+
+// stepN charges n steps, e.g. for work proportional to the size of a value.
+func (in *scriptInterpreter) stepN(n int) error {
+	before := in.steps
+	in.steps += n
+	if in.steps > in.maxSteps {
+		return errors.Wrapf(ErrScriptStepsExhausted, "exceeded %d steps", in.maxSteps)
+	}
+	if in.steps/scriptCtxCheckSteps != before/scriptCtxCheckSteps {
+		if err := in.ctx.Err(); err != nil {
+			return errors.Wrapf(err, "script aborted after %d steps", in.steps)
+		}
+	}
+	return nil
+}
+
+// alloc charges the approximate shallow size of a newly created value
+// against the memory budget.
+func (in *scriptInterpreter) alloc(v interface{}) error {
+	size := 16
+	switch x := v.(type) {
+	case string:
+		size += len(x)
+	case decimal.Decimal:
+		size += len(x.Coefficient().Bits()) * 8
+	case []interface{}:
+		size += 16 * len(x)
+	case map[string]interface{}:
+		size += 48 * len(x)
+		for k := range x {
+			size += len(k)
+		}
+	}
+	in.memory += size
+	if in.memory > in.maxMemory {
+		return errors.Wrapf(ErrScriptMemoryExceeded, "exceeded %d bytes", in.maxMemory)
+	}
+	return nil
+}
+
+// run executes the script with the given global variables until ctx is done
+// and returns the value passed to `return` (or nil). globals must already be
+// normalized with jqNormalize; the returned value is denormalized.
+func (prog *scriptProgram) run(ctx context.Context, globals map[string]interface{}, maxSteps, maxMemory int) (interface{}, error) {
+	in := &scriptInterpreter{ctx: ctx, maxSteps: maxSteps, maxMemory: maxMemory}
+	scope := newScriptScope(nil)
+	for name, value := range globals {
+		scope.vars[name] = value
+	}
+	ctrl, err := in.execBlock(prog.stmts, newScriptScope(scope))
+	if err != nil {
+		return nil, err
+	}
+	if ctrl == scriptDoBreak || ctrl == scriptDoContinue {
+		return nil, errors.Wrap(ErrScriptRuntime, "break or continue outside of a loop")
+	}
+	return jqDenormalize(in.result)
+}
+
+func (in *scriptInterpreter) execBlock(stmts []*scriptStmt, scope *scriptScope) (scriptControl, error) {
+	for _, stmt := range stmts {
+		ctrl, err := in.exec(stmt, scope)
+		if err != nil || ctrl != scriptNext {
+			return ctrl, err
+		}
+	}
+	return scriptNext, nil
+}
+
+func (in *scriptInterpreter) exec(stmt *scriptStmt, scope *scriptScope) (scriptControl, error) {
+	if err := in.step(); err != nil {
+		return scriptNext, err
+	}
+	switch stmt.kind {
+	case scriptLet:
+		v, err := in.eval(stmt.expr, scope)
+		if err != nil {
+			return scriptNext, err
+		}
+		if _, exists := scope.vars[stmt.name]; exists {
+			return scriptNext, errors.Wrapf(ErrScriptRuntime, "%s is already declared in this block", stmt.name)
+		}
+		scope.vars[stmt.name] = v
+	case scriptAssign:
+		v, err := in.eval(stmt.expr, scope)
+		if err != nil {
+			return scriptNext, err
+		}
+		if err = in.assign(stmt.target, v, scope); err != nil {
+			return scriptNext, err
+		}
+	case scriptIf:
+		for s := stmt; s != nil; s = s.elseIf {
+			cond, err := in.eval(s.expr, scope)
+			if err != nil {
+				return scriptNext, err
+			}
+			if jqTruthy(cond) {
+				return in.execBlock(s.body, newScriptScope(scope))
+			}
+			if s.elseIf == nil && s.orElse != nil {
+				return in.execBlock(s.orElse, newScriptScope(scope))
+			}
+		}
+	case scriptFor:
+		coll, err := in.eval(stmt.expr, scope)
+		if err != nil {
+			return scriptNext, err
+		}
+		var elems []interface{}
+		switch c := coll.(type) {
+		case []interface{}:
+			elems = c
+		case map[string]interface{}:
+			keys, _ := jqSimpleBuiltin("keys", c)
+			elems = keys.([]interface{})
+		default:
+			return scriptNext, errors.Wrapf(ErrScriptRuntime, "cannot iterate over %s", jqTypeOf(coll))
+		}
+		for _, e := range elems {
+			body := newScriptScope(scope)
+			body.vars[stmt.name] = e
+			ctrl, err := in.execBlock(stmt.body, body)
+			if err != nil {
+				return scriptNext, err
+			}
+			if ctrl == scriptDoBreak {
+				break
+			}
+			if ctrl == scriptDoReturn {
+				return ctrl, nil
+			}
+		}
+	case scriptWhile:
+		for {
+			cond, err := in.eval(stmt.expr, scope)
+			if err != nil {
+				return scriptNext, err
+			}
+			if !jqTruthy(cond) {
+				break
+			}
+			ctrl, err := in.execBlock(stmt.body, newScriptScope(scope))
+			if err != nil {
+				return scriptNext, err
+			}
+			if ctrl == scriptDoBreak {
+				break
+			}
+			if ctrl == scriptDoReturn {
+				return ctrl, nil
+			}
+		}
+	case scriptBreak:
+		return scriptDoBreak, nil
+	case scriptContinue:
+		return scriptDoContinue, nil
+	case scriptReturn:
+		if stmt.expr != nil {
+			v, err := in.eval(stmt.expr, scope)
+			if err != nil {
+				return scriptNext, err
+			}
+			in.result = v
+		}
+		return scriptDoReturn, nil
+	case scriptExprStmt:
+		if _, err := in.eval(stmt.expr, scope); err != nil {
+			return scriptNext, err
+		}
+	}
+	return scriptNext, nil
+}
+
+// assign stores value at target. Containers along an index path are copied
+// rather than mutated, so values shared with inputs or other variables are
+// never modified.
+func (in *scriptInterpreter) assign(target *scriptExpr, value interface{}, scope *scriptScope) error {
+	if target.kind == scriptIdent {
+		if !scope.assign(target.name, value) {
+			return errors.Wrapf(ErrScriptRuntime, "%s is not declared", target.name)
+		}
+		return nil
+	}
+
+	container, err := in.eval(target.left, scope)
+	if err != nil {
+		return err
+	}
+	index, err := in.eval(target.right, scope)
+	if err != nil {
+		return err
+	}
+	var updated interface{}
+	switch c := container.(type) {
+	case []interface{}:
+		d, ok := index.(decimal.Decimal)
+		if !ok || !d.Equal(d.Truncate(0)) {
+			return errors.Wrapf(ErrScriptRuntime, "array index must be an integer, got %s", jqTypeOf(index))
+		}
+		i := jqInt(d)
+		if i < 0 {
+			i += len(c)
+		}
+		if i < 0 || i >= len(c) {
+			return errors.Wrapf(ErrScriptRuntime, "array index %s out of range", d)
+		}
+		arr := make([]interface{}, len(c))
+		copy(arr, c)
+		arr[i] = value
+		updated = arr
+	case map[string]interface{}:
+		k, ok := index.(string)
+		if !ok {
+			return errors.Wrapf(ErrScriptRuntime, "object key must be a string, got %s", jqTypeOf(index))
+		}
+		m := make(map[string]interface{}, len(c)+1)
+		for ck, cv := range c {
+			m[ck] = cv
+		}
+		m[k] = value
+		updated = m
+	default:
+		return errors.Wrapf(ErrScriptRuntime, "cannot assign into %s", jqTypeOf(container))
+	}
+	if err = in.stepN(jqShallowSize(updated)); err != nil {
+		return err
+	}
+	if err = in.alloc(updated); err != nil {
+		return err
+	}
+	return in.assign(target.left, updated, scope)
+}
+
+func (in *scriptInterpreter) eval(e *scriptExpr, scope *scriptScope) (interface{}, error) {
+	if err := in.step(); err != nil {
+		return nil, err
+	}
+	switch e.kind {
+	case scriptLiteral:
+		return e.value, nil
+	case scriptIdent:
+		v, exists := scope.lookup(e.name)
+		if !exists {
+			return nil, errors.Wrapf(ErrScriptRuntime, "%s is not declared (at %d)", e.name, e.pos)
+		}
+		return v, nil
+	case scriptIndex:
+		container, err := in.eval(e.left, scope)
+		if err != nil {
+			return nil, err
+		}
+		index, err := in.eval(e.right, scope)
+		if err != nil {
+			return nil, err
+		}
+		v, err := jqIndexValue(container, index)
+		if err != nil {
+			return nil, errors.Wrapf(ErrScriptRuntime, "cannot index %s with %s (at %d)", jqTypeOf(container), jqTypeOf(index), e.pos)
+		}
+		return v, nil
+	case scriptUnary:
+		v, err := in.eval(e.left, scope)
+		if err != nil {
+			return nil, err
+		}
+		if e.op == "!" {
+			return !jqTruthy(v), nil
+		}
+		d, ok := v.(decimal.Decimal)
+		if !ok {
+			return nil, errors.Wrapf(ErrScriptRuntime, "%s cannot be negated (at %d)", jqTypeOf(v), e.pos)
+		}
+		return d.Neg(), nil
+	case scriptBinary:
+		left, err := in.eval(e.left, scope)
+		if err != nil {
+			return nil, err
+		}
+		// short circuit
+		switch e.op {
+		case "&&":
+			if !jqTruthy(left) {
+				return false, nil
+			}
+		case "||":
+			if jqTruthy(left) {
+				return true, nil
+			}
+		}
+		right, err := in.eval(e.right, scope)
+		if err != nil {
+			return nil, err
+		}
+		if err = in.stepN(jqShallowSize(left) + jqShallowSize(right)); err != nil {
+			return nil, err
+		}
+		v, err := scriptBinaryOp(e.op, left, right)
+		if err != nil {
+			return nil, errors.Wrapf(err, "at %d", e.pos)
+		}
+		return v, in.alloc(v)
+	case scriptArray:
+		arr := make([]interface{}, len(e.args))
+		for i, arg := range e.args {
+			v, err := in.eval(arg, scope)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = v
+		}
+		return arr, in.alloc(arr)
+	case scriptObject:
+		obj := make(map[string]interface{}, len(e.args))
+		for i, arg := range e.args {
+			v, err := in.eval(arg, scope)
+			if err != nil {
+				return nil, err
+			}
+			obj[e.keys[i]] = v
+		}
+		return obj, in.alloc(obj)
+	case scriptCall:
+		args := make([]interface{}, len(e.args))
+		for i, arg := range e.args {
+			v, err := in.eval(arg, scope)
+			if err != nil {
+				return nil, err
+			}
+			if err = in.stepN(jqShallowSize(v)); err != nil {
+				return nil, err
+			}
+			args[i] = v
+		}
+		fn := scriptBuiltins[e.name]
+		if len(args) < fn.minArgs || fn.maxArgs >= 0 && len(args) > fn.maxArgs {
+			return nil, errors.Wrapf(ErrScriptRuntime, "wrong number of arguments to %s (at %d)", e.name, e.pos)
+		}
+		v, err := fn.fn(in, args)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s (at %d)", e.name, e.pos)
+		}
+		return v, in.alloc(v)
+	}
+	return nil, errors.Wrapf(ErrScriptRuntime, "unknown expression kind %d", e.kind)
+}
+
+func scriptBinaryOp(op string, l, r interface{}) (interface{}, error) {
+	switch op {
+	case "&&", "||":
+		return jqTruthy(r), nil
+	case "==", "!=", "<", "<=", ">", ">=":
+		return jqBinaryOp(op, l, r)
+	case "+":
+		switch lv := l.(type) {
+		case string:
+			if rv, ok := r.(string); ok {
+				return lv + rv, nil
+			}
+		case []interface{}:
+			if rv, ok := r.([]interface{}); ok {
+				out := make([]interface{}, 0, len(lv)+len(rv))
+				return append(append(out, lv...), rv...), nil
+			}
+		}
+	}
+	ld, lok := l.(decimal.Decimal)
+	rd, rok := r.(decimal.Decimal)
+	if !lok || !rok {
+		return nil, errors.Wrapf(ErrScriptRuntime, "%s and %s cannot be combined with %q", jqTypeOf(l), jqTypeOf(r), op)
+	}
+	v, err := jqBinaryOp(op, ld, rd)
+	if err != nil {
+		return nil, scriptWrapJQError(err)
+	}
+	return v, nil
+}
+
+// scriptWrapJQError re-wraps an error from the shared jq helpers as a script
+// runtime error.
+func scriptWrapJQError(err error) error {
+	return errors.Wrap(ErrScriptRuntime, strings.TrimSuffix(err.Error(), ": "+ErrJQRuntime.Error()))
+}
+
+type scriptBuiltin struct {
+	minArgs int
+	maxArgs int // -1 for variadic
+	fn      func(in *scriptInterpreter, args []interface{}) (interface{}, error)
+}
+
+var scriptBuiltins = map[string]scriptBuiltin{
+	"len":      {1, 1, scriptUnaryJQ("length")},
+	"abs":      {1, 1, scriptUnaryJQ("abs")},
+	"floor":    {1, 1, scriptUnaryJQ("floor")},
+	"ceil":     {1, 1, scriptUnaryJQ("ceil")},
+	"keys":     {1, 1, scriptUnaryJQ("keys")},
+	"values":   {1, 1, scriptUnaryJQ("values")},
+	"sort":     {1, 1, scriptUnaryJQ("sort")},
+	"reverse":  {1, 1, scriptUnaryJQ("reverse")},
+	"number":   {1, 1, scriptUnaryJQ("tonumber")},
+	"string":   {1, 1, scriptUnaryJQ("tostring")},
+	"type":     {1, 1, scriptUnaryJQ("type")},
+	"lower":    {1, 1, scriptUnaryJQ("ascii_downcase")},
+	"upper":    {1, 1, scriptUnaryJQ("ascii_upcase")},
+	"round":    {1, 2, scriptRound},
+	"min":      {1, -1, scriptMinMax(-1)},
+	"max":      {1, -1, scriptMinMax(1)},
+	"sum":      {1, 1, scriptSum},
+	"mean":     {1, 1, scriptMean},
+	"median":   {1, 1, scriptMedian},
+	"has":      {2, 2, scriptHas},
+	"contains": {2, 2, scriptContains},
+	"append":   {1, -1, scriptAppend},
+	"slice":    {2, 3, scriptSlice},
+	"range":    {1, 2, scriptRange},
+	"split":    {2, 2, scriptSplit},
+	"join":     {2, 2, scriptJoin},
+	"pow":      {2, 2, scriptPow},
+	"error":    {1, 1, scriptError},
+}
+
+func scriptUnaryJQ(name string) func(*scriptInterpreter, []interface{}) (interface{}, error) {
+	return func(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+		v, err := jqSimpleBuiltinOrLength(name, args[0])
+		if err != nil {
+			return nil, scriptWrapJQError(err)
+		}
+		return v, nil
+	}
+}
+
+func jqSimpleBuiltinOrLength(name string, v interface{}) (interface{}, error) {
+	switch name {
+	case "length":
+		return jqLength(v)
+	case "type":
+		return jqTypeOf(v), nil
+	}
+	return jqSimpleBuiltin(name, v)
+}
+
+func scriptNumber(v interface{}) (decimal.Decimal, error) {
+	d, ok := v.(decimal.Decimal)
+	if !ok {
+		return decimal.Decimal{}, errors.Wrapf(ErrScriptRuntime, "expected a number, got %s", jqTypeOf(v))
+	}
+	return d, nil
+}
+
+func scriptNumbers(v interface{}) ([]decimal.Decimal, error) {
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.Wrapf(ErrScriptRuntime, "expected an array, got %s", jqTypeOf(v))
+	}
+	out := make([]decimal.Decimal, len(arr))
+	for i, e := range arr {
+		d, err := scriptNumber(e)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = d
+	}
+	return out, nil
+}
+
+func scriptRound(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+	d, err := scriptNumber(args[0])
+	if err != nil {
+		return nil, err
+	}
+	places := int32(0)
+	if len(args) == 2 {
+		p, err := scriptNumber(args[1])
+		if err != nil {
+			return nil, err
+		}
+		if p.LessThan(decimal.NewFromInt(-jqMaxExponent)) || p.GreaterThan(decimal.NewFromInt(jqMaxExponent)) {
+			return nil, errors.Wrap(ErrScriptRuntime, "number of places out of range")
+		}
+		places = int32(p.IntPart())
+	}
+	return d.Round(places), nil
+}
+
+func scriptMinMax(sign int) func(*scriptInterpreter, []interface{}) (interface{}, error) {
+	return func(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+		vals := args
+		if len(args) == 1 {
+			arr, ok := args[0].([]interface{})
+			if !ok {
+				return nil, errors.Wrapf(ErrScriptRuntime, "expected an array, got %s", jqTypeOf(args[0]))
+			}
+			vals = arr
+		}
+		if len(vals) == 0 {
+			return nil, nil
+		}
+		best := vals[0]
+		for _, v := range vals[1:] {
+			if jqCompare(v, best)*sign > 0 {
+				best = v
+			}
+		}
+		return best, nil
+	}
+}
+
+func scriptSum(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+	nums, err := scriptNumbers(args[0])
+	if err != nil {
+		return nil, err
+	}
+	sum := decimal.Zero
+	for _, d := range nums {
+		if sum, err = jqCheckNumber(sum.Add(d)); err != nil {
+			return nil, errors.Wrap(ErrScriptRuntime, "number out of range")
+		}
+	}
+	return sum, nil
+}
+
+func scriptMean(in *scriptInterpreter, args []interface{}) (interface{}, error) {
+	nums, err := scriptNumbers(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(nums) == 0 {
+		return nil, errors.Wrap(ErrScriptRuntime, "mean of an empty array")
+	}
+	sum, err := scriptSum(in, args)
+	if err != nil {
+		return nil, err
+	}
+	return sum.(decimal.Decimal).DivRound(decimal.NewFromInt(int64(len(nums))), jqDivisionPrecision), nil
+}
+
+func scriptMedian(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+	nums, err := scriptNumbers(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(nums) == 0 {
+		return nil, errors.Wrap(ErrScriptRuntime, "median of an empty array")
+	}
+	sort.Slice(nums, func(i, j int) bool {
+		return nums[i].LessThan(nums[j])
+	})
+	k := len(nums) / 2
+	if len(nums)%2 == 1 {
+		return nums[k], nil
+	}
+	return nums[k].Add(nums[k-1]).Div(decimal.NewFromInt(2)), nil
+}
+
+func scriptHas(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+	switch c := args[0].(type) {
+	case map[string]interface{}:
+		k, ok := args[1].(string)
+		if !ok {
+			return nil, errors.Wrapf(ErrScriptRuntime, "object key must be a string, got %s", jqTypeOf(args[1]))
+		}
+		_, exists := c[k]
+		return exists, nil
+	case []interface{}:
+		d, err := scriptNumber(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return !d.IsNegative() && d.LessThan(decimal.NewFromInt(int64(len(c)))), nil
+	}
+	return nil, errors.Wrapf(ErrScriptRuntime, "cannot check whether %s has a key", jqTypeOf(args[0]))
+}
+
+func scriptContains(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+	switch c := args[0].(type) {
+	case string:
+		s, ok := args[1].(string)
+		if !ok {
+			return nil, errors.Wrapf(ErrScriptRuntime, "expected a string, got %s", jqTypeOf(args[1]))
+		}
+		return strings.Contains(c, s), nil
+	case []interface{}:
+		for _, e := range c {
+			if jqCompare(e, args[1]) == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return nil, errors.Wrapf(ErrScriptRuntime, "cannot search %s", jqTypeOf(args[0]))
+}
+
+func scriptAppend(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+	arr, ok := args[0].([]interface{})
+	if !ok {
+		return nil, errors.Wrapf(ErrScriptRuntime, "expected an array, got %s", jqTypeOf(args[0]))
+	}
+	out := make([]interface{}, 0, len(arr)+len(args)-1)
+	return append(append(out, arr...), args[1:]...), nil
+}
+
+func scriptSlice(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+	var to interface{}
+	if len(args) == 3 {
+		to = args[2]
+	}
+	v, err := jqSliceValue(args[0], args[1], to)
+	if err != nil {
+		return nil, errors.Wrap(ErrScriptRuntime, fmt.Sprintf("cannot slice %s", jqTypeOf(args[0])))
+	}
+	return v, nil
+}
+
+func scriptRange(in *scriptInterpreter, args []interface{}) (interface{}, error) {
+	from, to := decimal.Zero, decimal.Zero
+	bounds := make([]decimal.Decimal, len(args))
+	for i, a := range args {
+		d, err := scriptNumber(a)
+		if err != nil {
+			return nil, err
+		}
+		bounds[i] = d
+	}
+	if len(bounds) == 1 {
+		to = bounds[0]
+	} else {
+		from, to = bounds[0], bounds[1]
+	}
+	out := []interface{}{}
+	for i := from; i.LessThan(to); i = i.Add(decimal.NewFromInt(1)) {
+		if err := in.step(); err != nil {
+			return nil, err
+		}
+		out = append(out, i)
+	}
+	return out, nil
+}
+
+func scriptSplit(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+	s, ok1 := args[0].(string)
+	sep, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return nil, errors.Wrap(ErrScriptRuntime, "split expects two strings")
+	}
+	parts := strings.Split(s, sep)
+	out := make([]interface{}, len(parts))
+	for i, p := range parts {
+		out[i] = p
+	}
+	return out, nil
+}
+
+func scriptJoin(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+	arr, ok1 := args[0].([]interface{})
+	sep, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return nil, errors.Wrap(ErrScriptRuntime, "join expects an array and a string")
+	}
+	strs := make([]string, len(arr))
+	for i, e := range arr {
+		s, err := jqSimpleBuiltin("tostring", e)
+		if err != nil {
+			return nil, errors.Wrap(ErrScriptRuntime, "cannot join value")
+		}
+		strs[i] = s.(string)
+	}
+	return strings.Join(strs, sep), nil
+}
+
+func scriptPow(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+	base, err := scriptNumber(args[0])
+	if err != nil {
+		return nil, err
+	}
+	exp, err := scriptNumber(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if !exp.Equal(exp.Truncate(0)) || exp.IsNegative() || exp.GreaterThan(decimal.NewFromInt(256)) {
+		return nil, errors.Wrap(ErrScriptRuntime, "exponent must be an integer between 0 and 256")
+	}
+	result := decimal.NewFromInt(1)
+	for i := int64(0); i < exp.IntPart(); i++ {
+		if result, err = jqCheckNumber(result.Mul(base)); err != nil {
+			return nil, errors.Wrap(ErrScriptRuntime, "number out of range")
+		}
+	}
+	return result, nil
+}
+
+func scriptError(_ *scriptInterpreter, args []interface{}) (interface{}, error) {
+	msg, ok := args[0].(string)
+	if !ok {
+		msg = jqPreview(args[0])
+	}
+	return nil, errors.Wrap(ErrScriptRuntime, msg)
+}
@@ -0,0 +1,29 @@
+//go:build go1.18
+
+package pipeline
+
+import (
+	"context"
+	"testing"
+)
+
+func FuzzScript(f *testing.F) {
+	f.Add(`let p = []; for x in inputs { p = append(p, number(x)); } return median(p);`)
+	f.Add(`let o = {a: [1, 2]}; o.a[0] = -o.a[1] % 3; return o;`)
+	f.Add(`let i = 0; while i < 10 { i = i + 1; if i == 5 { break; } } return i;`)
+	f.Add(`if params.x { return "a" + "b"; } else if !params.y { return slice([1, 2, 3], -2); } else { error("z"); }`)
+	f.Fuzz(func(t *testing.T, src string) {
+		if len(src) > 10_000 {
+			t.Skip()
+		}
+		prog, err := parseScript(src)
+		if err != nil {
+			t.Skip()
+		}
+		globals, err := scriptGlobals(NewVarsFrom(nil), []Result{{Value: "1.5"}, {Value: 2}}, map[string]interface{}{"x": false})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = prog.run(context.Background(), globals, 10_000, 1<<16)
+	})
+}
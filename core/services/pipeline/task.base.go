@@ -22,6 +22,9 @@ type BaseTask struct {
 	MinBackoff time.Duration `mapstructure:"minBackoff"`
 	MaxBackoff time.Duration `mapstructure:"maxBackoff"`
 
+	CacheTTL   time.Duration `mapstructure:"cacheTTL"`
+	CacheStore string        `mapstructure:"cacheStore"`
+
 	uuid uuid.UUID
 }
 
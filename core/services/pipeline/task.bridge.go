@@ -49,6 +49,9 @@ var (
 	)
 )
 
+// Failed requests are retried like those of the http task (see HTTPTask),
+// before falling back to the cached response if cacheTTL is set.
+//
 // Return types:
 //
 //	string
@@ -60,11 +63,15 @@ type BridgeTask struct {
 	IncludeInputAtKey string `json:"includeInputAtKey"`
 	Async             string `json:"async"`
 	CacheTTL          string `json:"cacheTTL"`
+	Backoff           string `json:"backoff"`
+	RetryOn           string `json:"retryOn"`
 
 	specId     int32
 	orm        bridges.ORM
 	config     Config
 	httpClient *http.Client
+	breakers   *circuitBreakers
+	simulate   bool
 }
 
 var _ Task = (*BridgeTask)(nil)
@@ -77,6 +84,8 @@ func (t *BridgeTask) Type() TaskType {
 	return TaskTypeBridge
 }
 
+func (t *BridgeTask) retriesInRun() {}
+
 func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
 	inputValues, err := CheckInputs(inputs, -1, -1, 0)
 	if err != nil {
@@ -88,12 +97,16 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		requestData       MapParam
 		includeInputAtKey StringParam
 		cacheTTL          Uint64Param
+		initialBackoff    DurationParam
+		retryOn           httpRetryOnParam
 	)
 	err = multierr.Combine(
 		errors.Wrap(ResolveParam(&name, From(NonemptyString(t.Name))), "name"),
 		errors.Wrap(ResolveParam(&requestData, From(VarExpr(t.RequestData, vars), JSONWithVarExprs(t.RequestData, vars, false), nil)), "requestData"),
 		errors.Wrap(ResolveParam(&includeInputAtKey, From(t.IncludeInputAtKey)), "includeInputAtKey"),
 		errors.Wrap(ResolveParam(&cacheTTL, From(ValidDurationInSeconds(t.CacheTTL), t.config.BridgeCacheTTL().Seconds())), "cacheTTL"),
+		errors.Wrap(ResolveParam(&initialBackoff, From(NonemptyString(t.Backoff), t.TaskMinBackoff())), "backoff"),
+		errors.Wrap(ResolveParam(&retryOn, From(NonemptyString(t.RetryOn), defaultHTTPRetryOn)), "retryOn"),
 	)
 	if err != nil {
 		return Result{Error: err}, runInfo
@@ -146,9 +159,6 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		"url", url.String(),
 	)
 
-	requestCtx, cancel := httpRequestCtx(ctx, t, t.config)
-	defer cancel()
-
 	// cacheTTL should not exceed stalenessCap.
 	cacheDuration := time.Duration(cacheTTL) * time.Second
 	if cacheDuration > stalenessCap {
@@ -157,7 +167,10 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 	}
 
 	var cachedResponse bool
-	responseBytes, statusCode, headers, elapsed, err := makeHTTPRequest(requestCtx, lggr, "POST", URLParam(url), []string{}, requestData, t.httpClient, t.config.DefaultHTTPLimit())
+	policy := newHTTPRetryPolicy(t, initialBackoff, retryOn)
+	responseBytes, statusCode, headers, elapsed, err := makeHTTPRequestWithRetries(ctx, lggr, t, t.config, url, policy, t.breakers, func(requestCtx context.Context) ([]byte, int, http.Header, time.Duration, error) {
+		return makeHTTPRequest(requestCtx, lggr, "POST", url, []string{}, requestData, t.httpClient, t.config.DefaultHTTPLimit())
+	})
 	if err != nil {
 		promBridgeErrors.WithLabelValues(t.Name).Inc()
 		if cacheTTL == 0 {
@@ -198,7 +211,7 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		}
 	}
 
-	if !cachedResponse && cacheTTL > 0 {
+	if !cachedResponse && cacheTTL > 0 && !t.simulate {
 		err := t.orm.UpsertBridgeResponse(t.dotID, t.specId, responseBytes)
 		if err != nil {
 			lggr.Errorw("Bridge task: failed to upsert response in bridge cache", "err", err)
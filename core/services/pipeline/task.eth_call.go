@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -17,6 +18,21 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
+// If `cacheTTL` is set, the result of a call is reused by the ethcall tasks of
+// every job that make the same call within cacheTTL (see resultCache).
+//
+// If `batch` is set, the call is coalesced with the concurrent calls of other
+// ethcall tasks on the same chain, from this run or others, into a single
+// request (see callBatcher): "rpc" sends them as a JSON-RPC batch, "multicall"
+// aggregates them into one eth_call to the Multicall3 contract at
+// `multicallAddress` (DefaultMulticall3Address by default). Calls that set
+// `from` or gas prices can't go through Multicall3, so they are sent in an
+// "rpc" batch instead. Without batching, calls are made directly.
+//
+// NOTE: Through Multicall3 the contract is called by Multicall3, so it sees
+// the Multicall3 address as msg.sender rather than the zero address. Only
+// calls whose result doesn't depend on the sender should use "multicall".
+//
 // Return types:
 //
 //	[]byte
@@ -32,11 +48,15 @@ type ETHCallTask struct {
 	GasUnlimited        string `json:"gasUnlimited"`
 	ExtractRevertReason bool   `json:"extractRevertReason"`
 	EVMChainID          string `json:"evmChainID" mapstructure:"evmChainID"`
+	Batch               string `json:"batch"`
+	MulticallAddress    string `json:"multicallAddress"`
 
 	specGasLimit *uint32
 	chainSet     evm.ChainSet
 	config       Config
 	jobType      string
+	cache        *resultCache
+	batcher      *callBatcher
 }
 
 var _ Task = (*ETHCallTask)(nil)
@@ -54,6 +74,8 @@ func (t *ETHCallTask) Type() TaskType {
 	return TaskTypeETHCall
 }
 
+func (t *ETHCallTask) cacheable() {}
+
 func (t *ETHCallTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
 	_, err := CheckInputs(inputs, -1, -1, 0)
 	if err != nil {
@@ -70,6 +92,8 @@ func (t *ETHCallTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, in
 		gasFeeCap    MaybeBigIntParam
 		gasUnlimited BoolParam
 		chainID      StringParam
+		batch        StringParam
+		multicall    AddressParam
 	)
 	err = multierr.Combine(
 		errors.Wrap(ResolveParam(&contractAddr, From(VarExpr(t.Contract, vars), NonemptyString(t.Contract))), "contract"),
@@ -81,12 +105,19 @@ func (t *ETHCallTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, in
 		errors.Wrap(ResolveParam(&gasFeeCap, From(VarExpr(t.GasFeeCap, vars), t.GasFeeCap)), "gasFeeCap"),
 		errors.Wrap(ResolveParam(&chainID, From(VarExpr(t.EVMChainID, vars), NonemptyString(t.EVMChainID), "")), "evmChainID"),
 		errors.Wrap(ResolveParam(&gasUnlimited, From(VarExpr(t.GasUnlimited, vars), NonemptyString(t.GasUnlimited), false)), "gasUnlimited"),
+		errors.Wrap(ResolveParam(&batch, From(NonemptyString(t.Batch), "")), "batch"),
+		errors.Wrap(ResolveParam(&multicall, From(VarExpr(t.MulticallAddress, vars), NonemptyString(t.MulticallAddress), DefaultMulticall3Address)), "multicallAddress"),
 	)
 	if err != nil {
 		return Result{Error: err}, runInfo
 	} else if len(data) == 0 {
 		return Result{Error: errors.Wrapf(ErrBadInput, "data param must not be empty")}, runInfo
 	}
+	switch batch {
+	case "", CallBatchRPC, CallBatchMulticall:
+	default:
+		return Result{Error: errors.Wrapf(ErrBadInput, `batch must be "%s" or "%s", got "%s"`, CallBatchRPC, CallBatchMulticall, batch)}, runInfo
+	}
 
 	chain, err := getChainByString(t.chainSet, string(chainID))
 	if err != nil {
@@ -115,13 +146,21 @@ func (t *ETHCallTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, in
 		GasFeeCap: gasFeeCap.BigInt(),
 	}
 
+	cacheKey, err := taskCacheKey(t.Type(), chain.ID().String(), call.To, call.From, hexutil.Bytes(call.Data), call.Gas, call.GasPrice, call.GasTipCap, call.GasFeeCap)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	if cached, ok := t.cache.get(ctx, lggr, t, cacheKey); ok {
+		return Result{Value: cached}, runInfo
+	}
+
 	lggr = lggr.With("gas", call.Gas).
 		With("gasPrice", call.GasPrice).
 		With("gasTipCap", call.GasTipCap).
 		With("gasFeeCap", call.GasFeeCap)
 
 	start := time.Now()
-	resp, err := chain.Client().CallContract(ctx, call, nil)
+	resp, err := t.callContract(ctx, chain, string(batch), common.Address(multicall), call)
 	elapsed := time.Since(start)
 	if err != nil {
 		if t.ExtractRevertReason {
@@ -140,5 +179,17 @@ func (t *ETHCallTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, in
 
 	promETHCallTime.WithLabelValues(t.DotID()).Set(float64(elapsed))
 
+	t.cache.put(ctx, lggr, t, cacheKey, resp)
+
 	return Result{Value: resp}, runInfo
 }
+
+func (t *ETHCallTask) callContract(ctx context.Context, chain evm.Chain, batch string, multicall common.Address, call ethereum.CallMsg) ([]byte, error) {
+	if batch == "" || t.batcher == nil {
+		return chain.Client().CallContract(ctx, call, nil)
+	}
+	if batch == CallBatchMulticall && !canMulticall(call) {
+		batch = CallBatchRPC
+	}
+	return t.batcher.call(ctx, chain, batch, multicall, call)
+}
@@ -2,16 +2,21 @@ package pipeline_test
 
 import (
 	"math/big"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink/core/chains/evm"
+	evmclient "github.com/smartcontractkit/chainlink/core/chains/evm/client"
 	evmmocks "github.com/smartcontractkit/chainlink/core/chains/evm/mocks"
 	txmmocks "github.com/smartcontractkit/chainlink/core/chains/evm/txmgr/mocks"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
@@ -283,3 +288,126 @@ func TestETHCallTask(t *testing.T) {
 		})
 	}
 }
+
+func TestETHCallTask_Batch(t *testing.T) {
+	t.Parallel()
+
+	contract := common.HexToAddress("0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF")
+	cfg := configtest.NewGeneralConfig(t, nil)
+
+	newTasks := func(batch string, datas ...string) []*pipeline.ETHCallTask {
+		var tasks []*pipeline.ETHCallTask
+		for _, data := range datas {
+			tasks = append(tasks, &pipeline.ETHCallTask{
+				BaseTask:            pipeline.NewBaseTask(0, "ethcall", nil, nil, 0),
+				Contract:            contract.Hex(),
+				Data:                `"` + data + `"`,
+				Batch:               batch,
+				ExtractRevertReason: true,
+			})
+		}
+		return tasks
+	}
+
+	runAll := func(t *testing.T, ethClient *evmmocks.Client, tasks []*pipeline.ETHCallTask) []pipeline.Result {
+		cc := cltest.NewChainSetMockWithOneChain(t, ethClient, evmtest.NewChainScopedConfig(t, cfg))
+		results := make([]pipeline.Result, len(tasks))
+		var wg sync.WaitGroup
+		for i, task := range tasks {
+			i, task := i, task
+			task.HelperSetDependencies(cc, cfg, nil, pipeline.DirectRequestJobType)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _ = task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+			}()
+		}
+		wg.Wait()
+		return results
+	}
+
+	t.Run("rpc", func(t *testing.T) {
+		ethClient := evmmocks.NewClient(t)
+		ethClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(reqs []rpc.BatchElem) bool {
+			return len(reqs) == 3
+		})).Run(func(args mock.Arguments) {
+			reqs := args.Get(1).([]rpc.BatchElem)
+			for i, req := range reqs {
+				require.Equal(t, "eth_call", req.Method)
+				data := req.Args[0].(map[string]interface{})["data"].(hexutil.Bytes)
+				if data[0] == 0xff {
+					reqs[i].Error = errors.New("execution reverted")
+					continue
+				}
+				// echo the calldata back
+				*req.Result.(*hexutil.Bytes) = data
+			}
+		}).Return(nil).Once()
+
+		tasks := newTasks(pipeline.CallBatchRPC, "0x01", "0x02", "0xff")
+		pipeline.HelperShareCallBatcher(time.Second, 3, tasks...)
+		results := runAll(t, ethClient, tasks)
+
+		require.NoError(t, results[0].Error)
+		assert.Equal(t, []byte{0x01}, results[0].Value)
+		require.NoError(t, results[1].Error)
+		assert.Equal(t, []byte{0x02}, results[1].Value)
+		require.Error(t, results[2].Error)
+		assert.Contains(t, results[2].Error.Error(), "execution reverted")
+	})
+
+	t.Run("multicall", func(t *testing.T) {
+		packed, err := pipeline.HelperPackMulticall3Results([]bool{true, false}, [][]byte{{0x01}, {0xde, 0xad}})
+		require.NoError(t, err)
+
+		ethClient := evmmocks.NewClient(t)
+		ethClient.On("CallContract", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+			return *msg.To == pipeline.DefaultMulticall3Address
+		}), (*big.Int)(nil)).Return(packed, nil).Once()
+
+		tasks := newTasks(pipeline.CallBatchMulticall, "0x01", "0x02")
+		pipeline.HelperShareCallBatcher(time.Second, 2, tasks...)
+		results := runAll(t, ethClient, tasks)
+
+		require.NoError(t, results[0].Error)
+		assert.Equal(t, []byte{0x01}, results[0].Value)
+		require.Error(t, results[1].Error)
+		rpcErr, err := evmclient.ExtractRPCError(results[1].Error)
+		require.NoError(t, err)
+		assert.Equal(t, "0xdead", rpcErr.Data)
+	})
+
+	t.Run("a lone call is sent as is", func(t *testing.T) {
+		ethClient := evmmocks.NewClient(t)
+		ethClient.On("CallContract", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+			return *msg.To == contract
+		}), (*big.Int)(nil)).Return([]byte{0x01}, nil).Once()
+
+		tasks := newTasks(pipeline.CallBatchMulticall, "0x01")
+		pipeline.HelperShareCallBatcher(time.Millisecond, 10, tasks...)
+		results := runAll(t, ethClient, tasks)
+
+		require.NoError(t, results[0].Error)
+		assert.Equal(t, []byte{0x01}, results[0].Value)
+	})
+
+	t.Run("calls are only batched when the task opts in", func(t *testing.T) {
+		ethClient := evmmocks.NewClient(t)
+		ethClient.On("CallContract", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+			return *msg.To == contract
+		}), (*big.Int)(nil)).Return([]byte{0x01}, nil).Twice()
+
+		tasks := newTasks("", "0x01", "0x02")
+		pipeline.HelperShareCallBatcher(time.Second, 2, tasks...)
+		results := runAll(t, ethClient, tasks)
+
+		require.NoError(t, results[0].Error)
+		require.NoError(t, results[1].Error)
+	})
+
+	t.Run("unknown batch mode", func(t *testing.T) {
+		tasks := newTasks("sometimes", "0x01")
+		results := runAll(t, evmmocks.NewClient(t), tasks)
+		require.Equal(t, pipeline.ErrBadInput, errors.Cause(results[0].Error))
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/core/chains/evm"
+	evmtypes "github.com/smartcontractkit/chainlink/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/core/logger"
 )
 
@@ -55,17 +56,9 @@ func (t *ETHGetBlockTask) Run(ctx context.Context, lggr logger.Logger, vars Vars
 	if err != nil {
 		return Result{Error: err}, runInfo
 	}
-	// Use the headtracker's view of the latest block, this is very fast since
-	// it doesn't make any external network requests, and it is the
-	// headtracker's job to ensure it has an up-to-date view of the chain based
-	// on responses from all available RPC nodes
-	latestHead := chain.HeadTracker().LatestChain()
-	if latestHead == nil {
-		logger.Sugared(lggr).AssumptionViolation("HeadTracker unexpectedly returned nil head, falling back to RPC call")
-		latestHead, err = chain.Client().HeadByNumber(ctx, nil)
-		if err != nil {
-			return Result{Error: err}, runInfo
-		}
+	latestHead, err := getLatestHead(ctx, lggr, chain)
+	if err != nil {
+		return Result{Error: err}, runInfo
 	}
 
 	h := make(map[string]interface{})
@@ -80,3 +73,23 @@ func (t *ETHGetBlockTask) Run(ctx context.Context, lggr logger.Logger, vars Vars
 
 	return Result{Value: h}, runInfo
 }
+
+func getLatestHead(ctx context.Context, lggr logger.Logger, chain evm.Chain) (*evmtypes.Head, error) {
+	// Use the headtracker's view of the latest block, this is very fast since
+	// it doesn't make any external network requests, and it is the
+	// headtracker's job to ensure it has an up-to-date view of the chain based
+	// on responses from all available RPC nodes
+	latestHead := chain.HeadTracker().LatestChain()
+	if latestHead == nil {
+		logger.Sugared(lggr).AssumptionViolation("HeadTracker unexpectedly returned nil head, falling back to RPC call")
+		head, err := chain.Client().HeadByNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		if head == nil {
+			return nil, errors.New("RPC node returned no latest head")
+		}
+		return head, nil
+	}
+	return latestHead, nil
+}
@@ -0,0 +1,292 @@
+package pipeline
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"golang.org/x/exp/slices"
+
+	"github.com/smartcontractkit/chainlink/core/chains/evm"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pg"
+)
+
+// MaxGetLogsBlockRange caps the number of blocks an ethgetlogs task may search
+const MaxGetLogsBlockRange = 10_000
+
+// ETHGetLogsTask fetches the logs of the event `abi` emitted by the contract
+// at `address` in a range of blocks, and decodes them. The range is either
+// `fromBlock` to `toBlock` (default: the latest block), or the `lastBlocks`
+// blocks up to the latest one, inclusive.
+//
+// `topics` optionally filters on the indexed arguments of the event: its
+// first element filters the first indexed argument, etc. Each element is
+// null to match any value, a value, or an array of values to match any of
+// them, e.g.:
+//
+//	transfers [type=ethgetlogs
+//	           address="0x514910771AF9Ca656af840dff83E8264EcF986CA"
+//	           abi="Transfer(address indexed from, address indexed to, uint256 value)"
+//	           topics=<[null, "$(jobSpec.wallet)"]>
+//	           lastBlocks=100]
+//
+// If the chain's log poller has a filter registered for the event and
+// address, and has indexed the whole range with it, the logs are read from its
+// database. Otherwise they are fetched from the RPC node with eth_getLogs.
+//
+// Return types:
+//
+//	[]interface{} of map[string]interface{}, in the order they were emitted
+//
+// Fields:
+//   - address: common.Address
+//   - blockNumber: int64
+//   - blockHash: common.Hash
+//   - txHash: common.Hash
+//   - logIndex: int64
+//   - args: map[string]interface{} of the decoded event arguments, with any
+//     geth/abigen value type
+type ETHGetLogsTask struct {
+	BaseTask   `mapstructure:",squash"`
+	Address    string `json:"address"`
+	ABI        string `json:"abi"`
+	Topics     string `json:"topics"`
+	FromBlock  string `json:"fromBlock"`
+	ToBlock    string `json:"toBlock"`
+	LastBlocks string `json:"lastBlocks"`
+	EVMChainID string `json:"evmChainID" mapstructure:"evmChainID"`
+
+	chainSet evm.ChainSet
+}
+
+var _ Task = (*ETHGetLogsTask)(nil)
+
+func (t *ETHGetLogsTask) Type() TaskType {
+	return TaskTypeETHGetLogs
+}
+
+func (t *ETHGetLogsTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, -1, -1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var (
+		address    AddressParam
+		theABI     BytesParam
+		topics     SliceParam
+		fromBlock  MaybeUint64Param
+		toBlock    MaybeUint64Param
+		lastBlocks MaybeUint64Param
+		chainID    StringParam
+	)
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&address, From(VarExpr(t.Address, vars), NonemptyString(t.Address))), "address"),
+		errors.Wrap(ResolveParam(&theABI, From(NonemptyString(t.ABI))), "abi"),
+		errors.Wrap(ResolveParam(&topics, From(VarExpr(t.Topics, vars), JSONWithVarExprs(t.Topics, vars, false), nil)), "topics"),
+		errors.Wrap(ResolveParam(&fromBlock, From(VarExpr(t.FromBlock, vars), t.FromBlock)), "fromBlock"),
+		errors.Wrap(ResolveParam(&toBlock, From(VarExpr(t.ToBlock, vars), t.ToBlock)), "toBlock"),
+		errors.Wrap(ResolveParam(&lastBlocks, From(VarExpr(t.LastBlocks, vars), t.LastBlocks)), "lastBlocks"),
+		errors.Wrap(ResolveParam(&chainID, From(VarExpr(t.EVMChainID, vars), NonemptyString(t.EVMChainID), "")), "evmChainID"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	name, args, indexedArgs, err := parseETHABIString([]byte(theABI), true)
+	if err != nil {
+		return Result{Error: errors.Wrap(ErrBadInput, err.Error())}, runInfo
+	}
+	eventSig := abi.NewEvent(name, name, false, args).ID
+
+	topicFilters, err := parseTopicFilters(topics, indexedArgs)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "topics")}, runInfo
+	}
+
+	chain, err := getChainByString(t.chainSet, string(chainID))
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	from, to, err := t.blockRange(ctx, lggr, chain, fromBlock, toBlock, lastBlocks)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	logs, err := t.getLogs(ctx, lggr, chain, common.Address(address), eventSig, topicFilters, from, to)
+	if err != nil {
+		return Result{Error: err}, retryableRunInfo()
+	}
+
+	decoded := make([]interface{}, 0, len(logs))
+	for _, log := range logs {
+		if len(log.Topics) != len(indexedArgs)+1 {
+			return Result{Error: errors.Wrapf(ErrBadInput, "log %v of tx %v: topic/field count mismatch", log.Index, log.TxHash)}, runInfo
+		}
+		decodedArgs := make(map[string]interface{})
+		if len(log.Data) > 0 {
+			if err = args.UnpackIntoMap(decodedArgs, log.Data); err != nil {
+				return Result{Error: errors.Wrapf(ErrBadInput, "log %v of tx %v: %v", log.Index, log.TxHash, err)}, runInfo
+			}
+		}
+		if err = abi.ParseTopicsIntoMap(decodedArgs, indexedArgs, log.Topics[1:]); err != nil {
+			return Result{Error: errors.Wrapf(ErrBadInput, "log %v of tx %v: %v", log.Index, log.TxHash, err)}, runInfo
+		}
+		decoded = append(decoded, map[string]interface{}{
+			"address":     log.Address,
+			"blockNumber": int64(log.BlockNumber),
+			"blockHash":   log.BlockHash,
+			"txHash":      log.TxHash,
+			"logIndex":    int64(log.Index),
+			"args":        decodedArgs,
+		})
+	}
+	return Result{Value: decoded}, runInfo
+}
+
+func (t *ETHGetLogsTask) blockRange(ctx context.Context, lggr logger.Logger, chain evm.Chain, fromBlock, toBlock, lastBlocks MaybeUint64Param) (from, to int64, err error) {
+	fromN, fromSet := fromBlock.Uint64()
+	toN, toSet := toBlock.Uint64()
+	lastN, lastSet := lastBlocks.Uint64()
+
+	switch {
+	case lastSet && (fromSet || toSet):
+		return 0, 0, errors.Wrap(ErrBadInput, "lastBlocks can't be used together with fromBlock or toBlock")
+	case lastSet && lastN == 0:
+		return 0, 0, errors.Wrap(ErrBadInput, "lastBlocks must be at least 1")
+	case !lastSet && !fromSet:
+		return 0, 0, errors.Wrap(ErrBadInput, "either fromBlock or lastBlocks must be set")
+	}
+
+	if !toSet {
+		head, err := getLatestHead(ctx, lggr, chain)
+		if err != nil {
+			return 0, 0, err
+		}
+		toN = uint64(head.Number)
+	}
+	if lastSet {
+		fromN = 0
+		if toN+1 > lastN {
+			fromN = toN + 1 - lastN
+		}
+	}
+
+	if fromN > toN {
+		return 0, 0, errors.Wrapf(ErrBadInput, "fromBlock %v is after toBlock %v", fromN, toN)
+	}
+	if toN-fromN+1 > MaxGetLogsBlockRange {
+		return 0, 0, errors.Wrapf(ErrBadInput, "block range %v-%v is longer than %v blocks", fromN, toN, MaxGetLogsBlockRange)
+	}
+	return int64(fromN), int64(toN), nil
+}
+
+func (t *ETHGetLogsTask) getLogs(ctx context.Context, lggr logger.Logger, chain evm.Chain, address common.Address, eventSig common.Hash, topicFilters [][]common.Hash, from, to int64) ([]types.Log, error) {
+	lp := chain.LogPoller()
+	if indexedFrom, ok := lp.IndexedFrom(eventSig, address); ok {
+		logs, err := getLogPollerLogs(ctx, lp, address, eventSig, topicFilters, indexedFrom, from, to)
+		if err == nil {
+			return logs, nil
+		}
+		lggr.Debugw("Can't read logs from the log poller, falling back to eth_getLogs", "err", err)
+	}
+
+	logs, err := chain.Client().FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: big.NewInt(from),
+		ToBlock:   big.NewInt(to),
+		Addresses: []common.Address{address},
+		Topics:    append([][]common.Hash{{eventSig}}, topicFilters...),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "eth_getLogs failed")
+	}
+	filtered := logs[:0]
+	for _, log := range logs {
+		if !log.Removed {
+			filtered = append(filtered, log)
+		}
+	}
+	return filtered, nil
+}
+
+// getLogPollerLogs reads the logs from the log poller's database, provided it
+// has indexed every block in the range: the logs of the event have been
+// indexed since indexedFrom, and it has polled up to to.
+func getLogPollerLogs(ctx context.Context, lp logpoller.LogPoller, address common.Address, eventSig common.Hash, topicFilters [][]common.Hash, indexedFrom, from, to int64) ([]types.Log, error) {
+	if indexedFrom > from {
+		return nil, errors.Errorf("log poller has only indexed the event since block %v, after fromBlock %v", indexedFrom, from)
+	}
+	latest, err := lp.LatestBlock(pg.WithParentCtx(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get latest log poller block")
+	}
+	if latest < to {
+		return nil, errors.Errorf("log poller is at block %v, behind toBlock %v", latest, to)
+	}
+	lpLogs, err := lp.Logs(from, to, eventSig, address, pg.WithParentCtx(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get log poller logs")
+	}
+	logs := make([]types.Log, 0, len(lpLogs))
+	for _, lpLog := range lpLogs {
+		log := lpLog.ToGethLog()
+		if matchesTopicFilters(log.Topics, topicFilters) {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+// matchesTopicFilters reports whether the indexed topics of a log, following
+// the event signature, match topicFilters the way eth_getLogs does.
+func matchesTopicFilters(topics []common.Hash, topicFilters [][]common.Hash) bool {
+	for i, filter := range topicFilters {
+		if len(filter) == 0 {
+			continue
+		}
+		if i+1 >= len(topics) || !slices.Contains(filter, topics[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTopicFilters converts the topics attribute into eth_getLogs topic
+// filters for the indexed arguments, each of which is nil to match anything.
+func parseTopicFilters(topics []interface{}, indexedArgs abi.Arguments) ([][]common.Hash, error) {
+	if len(topics) > len(indexedArgs) {
+		return nil, errors.Wrapf(ErrBadInput, "got %v topic filters for %v indexed arguments", len(topics), len(indexedArgs))
+	}
+	filters := make([][]common.Hash, len(topics))
+	for i, topic := range topics {
+		if topic == nil {
+			continue
+		}
+		values, isSlice := topic.([]interface{})
+		if !isSlice {
+			values = []interface{}{topic}
+		}
+		query := make([]interface{}, len(values))
+		for j, value := range values {
+			converted, err := convertToETHABIType(value, indexedArgs[i].Type)
+			if err != nil {
+				return nil, errors.Wrapf(ErrBadInput, "topic filter %v (%v): %v", i, indexedArgs[i].Name, err)
+			}
+			query[j] = converted
+		}
+		hashes, err := abi.MakeTopics(query)
+		if err != nil {
+			return nil, errors.Wrapf(ErrBadInput, "topic filter %v (%v): %v", i, indexedArgs[i].Name, err)
+		}
+		filters[i] = hashes[0]
+	}
+	return filters, nil
+}
@@ -0,0 +1,309 @@
+package pipeline_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	htmocks "github.com/smartcontractkit/chainlink/core/chains/evm/headtracker/mocks"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/logpoller"
+	lpmocks "github.com/smartcontractkit/chainlink/core/chains/evm/logpoller/mocks"
+	evmmocks "github.com/smartcontractkit/chainlink/core/chains/evm/mocks"
+	evmtypes "github.com/smartcontractkit/chainlink/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/evmtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+func TestETHGetLogsTask(t *testing.T) {
+	t.Parallel()
+
+	const transferABI = "Transfer(address indexed from, address indexed to, uint256 value)"
+	transferSig := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	contract := testutils.NewAddress()
+	alice := testutils.NewAddress()
+	bob := testutils.NewAddress()
+	carol := testutils.NewAddress()
+
+	newLog := func(blockNumber int64, logIndex int64, from, to common.Address, value int64) logpoller.Log {
+		return logpoller.Log{
+			LogIndex:    logIndex,
+			BlockHash:   utils.NewHash(),
+			BlockNumber: blockNumber,
+			Topics:      pq.ByteaArray{transferSig.Bytes(), common.BytesToHash(from.Bytes()).Bytes(), common.BytesToHash(to.Bytes()).Bytes()},
+			EventSig:    transferSig,
+			Address:     contract,
+			TxHash:      utils.NewHash(),
+			Data:        common.BigToHash(big.NewInt(value)).Bytes(),
+		}
+	}
+	log1 := newLog(90, 0, alice, bob, 1)
+	log2 := newLog(95, 3, carol, bob, 2)
+	log3 := newLog(99, 1, alice, carol, 3)
+
+	assertDecoded := func(t *testing.T, expected []logpoller.Log, value interface{}) {
+		t.Helper()
+		decoded, ok := value.([]interface{})
+		require.True(t, ok, "expected %T to be []interface{}", value)
+		require.Len(t, decoded, len(expected))
+		for i, log := range expected {
+			d := decoded[i].(map[string]interface{})
+			assert.Equal(t, log.Address, d["address"])
+			assert.Equal(t, log.BlockNumber, d["blockNumber"])
+			assert.Equal(t, log.BlockHash, d["blockHash"])
+			assert.Equal(t, log.TxHash, d["txHash"])
+			assert.Equal(t, log.LogIndex, d["logIndex"])
+			args := d["args"].(map[string]interface{})
+			assert.Equal(t, common.BytesToAddress(log.Topics[1]), args["from"])
+			assert.Equal(t, common.BytesToAddress(log.Topics[2]), args["to"])
+			assert.Equal(t, new(big.Int).SetBytes(log.Data), args["value"])
+		}
+	}
+
+	t.Run("reads logs from the log poller if it has a matching filter", func(t *testing.T) {
+		lp := lpmocks.NewLogPoller(t)
+		chain := evmmocks.NewChain(t)
+		chain.On("LogPoller").Return(lp)
+		cc := evmtest.NewMockChainSetWithChain(t, chain)
+
+		lp.On("IndexedFrom", transferSig, contract).Return(int64(50), true)
+		lp.On("LatestBlock", mock.Anything).Return(int64(120), nil)
+		lp.On("Logs", int64(90), int64(100), transferSig, contract, mock.Anything).Return([]logpoller.Log{log1, log2, log3}, nil)
+
+		task := pipeline.ETHGetLogsTask{
+			BaseTask:  pipeline.NewBaseTask(0, "getlogs", nil, nil, 0),
+			Address:   contract.Hex(),
+			ABI:       transferABI,
+			FromBlock: "90",
+			ToBlock:   "100",
+		}
+		task.HelperSetDependencies(cc)
+
+		res, ri := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.NoError(t, res.Error)
+		assert.False(t, ri.IsRetryable)
+		assertDecoded(t, []logpoller.Log{log1, log2, log3}, res.Value)
+	})
+
+	t.Run("filters log poller logs by topic", func(t *testing.T) {
+		lp := lpmocks.NewLogPoller(t)
+		chain := evmmocks.NewChain(t)
+		chain.On("LogPoller").Return(lp)
+		cc := evmtest.NewMockChainSetWithChain(t, chain)
+
+		lp.On("IndexedFrom", transferSig, contract).Return(int64(50), true)
+		lp.On("LatestBlock", mock.Anything).Return(int64(100), nil)
+		lp.On("Logs", int64(90), int64(100), transferSig, contract, mock.Anything).Return([]logpoller.Log{log1, log2, log3}, nil)
+
+		task := pipeline.ETHGetLogsTask{
+			BaseTask:  pipeline.NewBaseTask(0, "getlogs", nil, nil, 0),
+			Address:   contract.Hex(),
+			ABI:       transferABI,
+			Topics:    `[["$(alice)", "$(carol)"], "$(bob)"]`,
+			FromBlock: "90",
+			ToBlock:   "100",
+		}
+		task.HelperSetDependencies(cc)
+
+		vars := pipeline.NewVarsFrom(map[string]interface{}{"alice": alice.Hex(), "bob": bob.Hex(), "carol": carol.Hex()})
+		res, _ := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+		require.NoError(t, res.Error)
+		assertDecoded(t, []logpoller.Log{log1, log2}, res.Value)
+	})
+
+	t.Run("falls back to eth_getLogs if the log poller has no matching filter", func(t *testing.T) {
+		lp := lpmocks.NewLogPoller(t)
+		ethClient := evmmocks.NewClient(t)
+		headTracker := htmocks.NewHeadTracker(t)
+		chain := evmmocks.NewChain(t)
+		chain.On("LogPoller").Return(lp)
+		chain.On("Client").Return(ethClient)
+		chain.On("HeadTracker").Return(headTracker)
+		cc := evmtest.NewMockChainSetWithChain(t, chain)
+
+		lp.On("IndexedFrom", transferSig, contract).Return(int64(0), false)
+		headTracker.On("LatestChain").Return(&evmtypes.Head{Number: 99})
+		removed := log2.ToGethLog()
+		removed.Removed = true
+		ethClient.On("FilterLogs", mock.Anything, ethereum.FilterQuery{
+			FromBlock: big.NewInt(90),
+			ToBlock:   big.NewInt(99),
+			Addresses: []common.Address{contract},
+			Topics:    [][]common.Hash{{transferSig}, {common.BytesToHash(alice.Bytes())}},
+		}).Return([]types.Log{log1.ToGethLog(), removed, log3.ToGethLog()}, nil)
+
+		task := pipeline.ETHGetLogsTask{
+			BaseTask:   pipeline.NewBaseTask(0, "getlogs", nil, nil, 0),
+			Address:    contract.Hex(),
+			ABI:        transferABI,
+			Topics:     `["$(alice)"]`,
+			LastBlocks: "10",
+		}
+		task.HelperSetDependencies(cc)
+
+		vars := pipeline.NewVarsFrom(map[string]interface{}{"alice": alice.Hex()})
+		res, _ := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+		require.NoError(t, res.Error)
+		assertDecoded(t, []logpoller.Log{log1, log3}, res.Value)
+	})
+
+	t.Run("falls back to eth_getLogs if the log poller is behind", func(t *testing.T) {
+		lp := lpmocks.NewLogPoller(t)
+		ethClient := evmmocks.NewClient(t)
+		chain := evmmocks.NewChain(t)
+		chain.On("LogPoller").Return(lp)
+		chain.On("Client").Return(ethClient)
+		cc := evmtest.NewMockChainSetWithChain(t, chain)
+
+		lp.On("IndexedFrom", transferSig, contract).Return(int64(50), true)
+		lp.On("LatestBlock", mock.Anything).Return(int64(95), nil)
+		ethClient.On("FilterLogs", mock.Anything, mock.Anything).Return([]types.Log{log3.ToGethLog()}, nil)
+
+		task := pipeline.ETHGetLogsTask{
+			BaseTask:  pipeline.NewBaseTask(0, "getlogs", nil, nil, 0),
+			Address:   contract.Hex(),
+			ABI:       transferABI,
+			FromBlock: "90",
+			ToBlock:   "100",
+		}
+		task.HelperSetDependencies(cc)
+
+		res, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.NoError(t, res.Error)
+		assertDecoded(t, []logpoller.Log{log3}, res.Value)
+	})
+
+	t.Run("falls back to eth_getLogs if the log poller has not indexed the start of the range", func(t *testing.T) {
+		lp := lpmocks.NewLogPoller(t)
+		ethClient := evmmocks.NewClient(t)
+		chain := evmmocks.NewChain(t)
+		chain.On("LogPoller").Return(lp)
+		chain.On("Client").Return(ethClient)
+		cc := evmtest.NewMockChainSetWithChain(t, chain)
+
+		lp.On("IndexedFrom", transferSig, contract).Return(int64(95), true)
+		ethClient.On("FilterLogs", mock.Anything, ethereum.FilterQuery{
+			FromBlock: big.NewInt(90),
+			ToBlock:   big.NewInt(100),
+			Addresses: []common.Address{contract},
+			Topics:    [][]common.Hash{{transferSig}},
+		}).Return([]types.Log{log1.ToGethLog(), log3.ToGethLog()}, nil)
+
+		task := pipeline.ETHGetLogsTask{
+			BaseTask:  pipeline.NewBaseTask(0, "getlogs", nil, nil, 0),
+			Address:   contract.Hex(),
+			ABI:       transferABI,
+			FromBlock: "90",
+			ToBlock:   "100",
+		}
+		task.HelperSetDependencies(cc)
+
+		res, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.NoError(t, res.Error)
+		assertDecoded(t, []logpoller.Log{log1, log3}, res.Value)
+	})
+
+	t.Run("fails if there is no latest head", func(t *testing.T) {
+		ethClient := evmmocks.NewClient(t)
+		headTracker := htmocks.NewHeadTracker(t)
+		chain := evmmocks.NewChain(t)
+		chain.On("Client").Return(ethClient)
+		chain.On("HeadTracker").Return(headTracker)
+		cc := evmtest.NewMockChainSetWithChain(t, chain)
+
+		headTracker.On("LatestChain").Return(nil)
+		ethClient.On("HeadByNumber", mock.Anything, (*big.Int)(nil)).Return(nil, nil)
+
+		task := pipeline.ETHGetLogsTask{
+			BaseTask:   pipeline.NewBaseTask(0, "getlogs", nil, nil, 0),
+			Address:    contract.Hex(),
+			ABI:        transferABI,
+			LastBlocks: "10",
+		}
+		task.HelperSetDependencies(cc)
+
+		res, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.EqualError(t, res.Error, "RPC node returned no latest head")
+	})
+
+	t.Run("eth_getLogs errors are retryable", func(t *testing.T) {
+		lp := lpmocks.NewLogPoller(t)
+		ethClient := evmmocks.NewClient(t)
+		chain := evmmocks.NewChain(t)
+		chain.On("LogPoller").Return(lp)
+		chain.On("Client").Return(ethClient)
+		cc := evmtest.NewMockChainSetWithChain(t, chain)
+
+		lp.On("IndexedFrom", transferSig, contract).Return(int64(0), false)
+		ethClient.On("FilterLogs", mock.Anything, mock.Anything).Return(nil, errors.New("connection reset"))
+
+		task := pipeline.ETHGetLogsTask{
+			BaseTask:  pipeline.NewBaseTask(0, "getlogs", nil, nil, 0),
+			Address:   contract.Hex(),
+			ABI:       transferABI,
+			FromBlock: "90",
+			ToBlock:   "100",
+		}
+		task.HelperSetDependencies(cc)
+
+		res, ri := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.Error(t, res.Error)
+		assert.Contains(t, res.Error.Error(), "connection reset")
+		assert.True(t, ri.IsRetryable)
+	})
+
+	t.Run("rejects bad inputs", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			abi        string
+			topics     string
+			fromBlock  string
+			toBlock    string
+			lastBlocks string
+			wantErr    string
+		}{
+			{"no range", transferABI, "", "", "", "", "either fromBlock or lastBlocks must be set"},
+			{"range and lastBlocks", transferABI, "", "90", "", "10", "lastBlocks can't be used together with fromBlock or toBlock"},
+			{"zero lastBlocks", transferABI, "", "", "", "0", "lastBlocks must be at least 1"},
+			{"reversed range", transferABI, "", "100", "90", "", "fromBlock 100 is after toBlock 90"},
+			{"range too long", transferABI, "", "1", "10001", "", "block range 1-10001 is longer than 10000 blocks"},
+			{"too many topics", transferABI, `[null, null, null]`, "90", "100", "", "got 3 topic filters for 2 indexed arguments"},
+			{"bad topic", transferABI, `["foo"]`, "90", "100", "", "topic filter 0 (from)"},
+			{"bad abi", "Transfer(address indexed from", "", "90", "100", "", "bad ABI specification"},
+		}
+
+		for _, test := range tests {
+			test := test
+			t.Run(test.name, func(t *testing.T) {
+				cc := evmmocks.NewChainSet(t)
+				cc.On("Default").Return(evmmocks.NewChain(t), nil).Maybe()
+
+				task := pipeline.ETHGetLogsTask{
+					BaseTask:   pipeline.NewBaseTask(0, "getlogs", nil, nil, 0),
+					Address:    contract.Hex(),
+					ABI:        test.abi,
+					Topics:     test.topics,
+					FromBlock:  test.fromBlock,
+					ToBlock:    test.toBlock,
+					LastBlocks: test.lastBlocks,
+				}
+				task.HelperSetDependencies(cc)
+
+				res, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+				require.Error(t, res.Error)
+				assert.ErrorIs(t, res.Error, pipeline.ErrBadInput)
+				assert.Contains(t, res.Error.Error(), test.wantErr)
+			})
+		}
+	})
+}
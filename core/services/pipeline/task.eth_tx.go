@@ -2,11 +2,16 @@ package pipeline
 
 import (
 	"context"
+	"encoding/json"
+	"math"
 	"math/big"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
@@ -22,6 +27,7 @@ import (
 // Return types:
 //
 //	nil
+//	map[string]interface{} (when simulated, the transaction that would have been created)
 type ETHTxTask struct {
 	BaseTask         `mapstructure:",squash"`
 	From             string `json:"from"`
@@ -36,12 +42,32 @@ type ETHTxTask struct {
 	FailOnRevert    string `json:"failOnRevert"`
 	EVMChainID      string `json:"evmChainID" mapstructure:"evmChainID"`
 	TransmitChecker string `json:"transmitChecker"`
+	// BatchForwarder, if set, is the address of a BatchForwarder contract
+	// through which the transaction is sent, batched with the other
+	// transactions of the sending key that use it
+	BatchForwarder string `json:"batchForwarder"`
+	// TxType, if set, is the EIP-2718 type of the transaction, e.g. 0x1 for
+	// an access list transaction
+	TxType string `json:"txType"`
+	// AccessList, if set, is the EIP-2930 access list of the transaction: a
+	// JSON list of {"address": ..., "storageKeys": [...]}
+	AccessList string `json:"accessList"`
+	// PrivateSubmission, if set, overrides whether the transaction is sent to
+	// the private relay of the chain instead of the public mempool
+	PrivateSubmission string `json:"privateSubmission"`
+	// InclusionDeadlineBlocks and InclusionDeadline, if set, are the number
+	// of blocks after the transaction is first broadcast and the duration
+	// after it is created that it should be included within. The transaction
+	// is bumped more aggressively as its deadline approaches.
+	InclusionDeadlineBlocks string `json:"inclusionDeadlineBlocks"`
+	InclusionDeadline       string `json:"inclusionDeadline"`
 
 	forwardingAllowed bool
 	specGasLimit      *uint32
 	keyStore          ETHKeyStore
 	chainSet          evm.ChainSet
 	jobType           string
+	simulate          bool
 }
 
 //go:generate mockery --quiet --name ETHKeyStore --output ./mocks/ --case=underscore
@@ -85,6 +111,12 @@ func (t *ETHTxTask) Run(_ context.Context, lggr logger.Logger, vars Vars, inputs
 		maybeMinConfirmations MaybeUint64Param
 		transmitCheckerMap    MapParam
 		failOnRevert          BoolParam
+		batchForwarder        AddressParam
+		txTypeParam           StringParam
+		accessListParam       SliceParam
+		privateSubmission     BoolParam
+		deadlineBlocks        Uint64Param
+		deadlineDuration      DurationParam
 	)
 	err = multierr.Combine(
 		errors.Wrap(ResolveParam(&fromAddrs, From(VarExpr(t.From, vars), JSONWithVarExprs(t.From, vars, false), NonemptyString(t.From), nil)), "from"),
@@ -95,6 +127,12 @@ func (t *ETHTxTask) Run(_ context.Context, lggr logger.Logger, vars Vars, inputs
 		errors.Wrap(ResolveParam(&maybeMinConfirmations, From(t.MinConfirmations)), "minConfirmations"),
 		errors.Wrap(ResolveParam(&transmitCheckerMap, From(VarExpr(t.TransmitChecker, vars), JSONWithVarExprs(t.TransmitChecker, vars, false), MapParam{})), "transmitChecker"),
 		errors.Wrap(ResolveParam(&failOnRevert, From(NonemptyString(t.FailOnRevert), false)), "failOnRevert"),
+		errors.Wrap(ResolveParam(&batchForwarder, From(VarExpr(t.BatchForwarder, vars), NonemptyString(t.BatchForwarder), utils.ZeroAddress)), "batchForwarder"),
+		errors.Wrap(ResolveParam(&txTypeParam, From(VarExpr(t.TxType, vars), NonemptyString(t.TxType), "")), "txType"),
+		errors.Wrap(ResolveParam(&accessListParam, From(VarExpr(t.AccessList, vars), JSONWithVarExprs(t.AccessList, vars, false), nil)), "accessList"),
+		errors.Wrap(ResolveParam(&privateSubmission, From(VarExpr(t.PrivateSubmission, vars), NonemptyString(t.PrivateSubmission), false)), "privateSubmission"),
+		errors.Wrap(ResolveParam(&deadlineBlocks, From(VarExpr(t.InclusionDeadlineBlocks, vars), NonemptyString(t.InclusionDeadlineBlocks), 0)), "inclusionDeadlineBlocks"),
+		errors.Wrap(ResolveParam(&deadlineDuration, From(VarExpr(t.InclusionDeadline, vars), NonemptyString(t.InclusionDeadline), time.Duration(0))), "inclusionDeadline"),
 	)
 	if err != nil {
 		return Result{Error: err}, runInfo
@@ -110,6 +148,11 @@ func (t *ETHTxTask) Run(_ context.Context, lggr logger.Logger, vars Vars, inputs
 	if err != nil {
 		return Result{Error: err}, runInfo
 	}
+	if txMeta.Priority == "" {
+		txMeta.Priority = SelectTxPriority(t.jobType)
+	} else if _, err = txmgr.ParseEthTxPriority(string(txMeta.Priority)); err != nil {
+		return Result{Error: errors.Wrap(err, "txMeta")}, runInfo
+	}
 	txMeta.FailOnRevert = null.BoolFrom(bool(failOnRevert))
 	setJobIDOnMeta(lggr, vars, txMeta)
 
@@ -118,6 +161,24 @@ func (t *ETHTxTask) Run(_ context.Context, lggr logger.Logger, vars Vars, inputs
 		return Result{Error: err}, runInfo
 	}
 
+	var txType clnull.Int64
+	if txTypeParam != "" {
+		typ, err2 := txmgr.ParseEthTxType(string(txTypeParam))
+		if err2 != nil {
+			return Result{Error: errors.Wrapf(ErrBadInput, "txType: %v", err2)}, runInfo
+		}
+		txType = clnull.Int64From(int64(typ))
+	}
+
+	if deadlineBlocks > math.MaxUint32 {
+		return Result{Error: errors.Wrapf(ErrBadInput, "inclusionDeadlineBlocks: %d is too large", deadlineBlocks)}, runInfo
+	}
+
+	accessList, err := decodeAccessList(accessListParam)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
 	fromAddr, err := t.keyStore.GetRoundRobinAddress(chain.ID(), fromAddrs...)
 	if err != nil {
 		err = errors.Wrap(err, "ETHTxTask failed to get fromAddress")
@@ -127,6 +188,9 @@ func (t *ETHTxTask) Run(_ context.Context, lggr logger.Logger, vars Vars, inputs
 
 	// TODO(sc-55115): Allow job specs to pass in the strategy that they want
 	strategy := txmgr.NewSendEveryStrategy()
+	if common.Address(batchForwarder) != utils.ZeroAddress {
+		strategy = txmgr.NewBatchingStrategy(strategy, common.Address(batchForwarder))
+	}
 
 	forwarderAddress := common.Address{}
 	if t.forwardingAllowed {
@@ -146,6 +210,16 @@ func (t *ETHTxTask) Run(_ context.Context, lggr logger.Logger, vars Vars, inputs
 		ForwarderAddress: forwarderAddress,
 		Strategy:         strategy,
 		Checker:          transmitChecker,
+		TxType:           txType,
+		AccessList:       accessList,
+		InclusionDeadline: txmgr.InclusionDeadline{
+			Blocks:   uint32(deadlineBlocks),
+			Duration: time.Duration(deadlineDuration),
+		},
+	}
+
+	if t.PrivateSubmission != "" {
+		newTx.PrivateSubmission = (*bool)(&privateSubmission)
 	}
 
 	if minOutgoingConfirmations > 0 {
@@ -154,6 +228,10 @@ func (t *ETHTxTask) Run(_ context.Context, lggr logger.Logger, vars Vars, inputs
 		newTx.MinConfirmations = clnull.Uint32From(uint32(minOutgoingConfirmations))
 	}
 
+	if t.simulate {
+		return Result{Value: simulatedTx(chain.ID(), newTx)}, runInfo
+	}
+
 	_, err = txManager.CreateEthTransaction(newTx)
 	if err != nil {
 		return Result{Error: errors.Wrapf(ErrTaskRunFailed, "while creating transaction: %v", err)}, retryableRunInfo()
@@ -166,6 +244,45 @@ func (t *ETHTxTask) Run(_ context.Context, lggr logger.Logger, vars Vars, inputs
 	return Result{Value: nil}, runInfo
 }
 
+// simulatedTx describes the transaction an ethtx task would have created
+func simulatedTx(chainID *big.Int, tx txmgr.NewTx) map[string]interface{} {
+	simulated := map[string]interface{}{
+		"evmChainID": chainID.String(),
+		"from":       tx.FromAddress.Hex(),
+		"to":         tx.ToAddress.Hex(),
+		"data":       hexutil.Encode(tx.EncodedPayload),
+		"gasLimit":   tx.GasLimit,
+	}
+	if tx.ForwarderAddress != (common.Address{}) {
+		simulated["forwarder"] = tx.ForwarderAddress.Hex()
+	}
+	if s, ok := tx.Strategy.(txmgr.BatchingStrategy); ok {
+		simulated["batchForwarder"] = s.ForwarderAddress.Hex()
+	}
+	if tx.Meta != nil && tx.Meta.Priority != "" {
+		simulated["priority"] = string(tx.Meta.Priority)
+	}
+	if tx.MinConfirmations.Valid {
+		simulated["minConfirmations"] = tx.MinConfirmations.Uint32
+	}
+	if tx.TxType.Valid {
+		simulated["txType"] = hexutil.EncodeUint64(uint64(tx.TxType.Int64))
+	}
+	if tx.AccessList.Valid {
+		simulated["accessList"] = tx.AccessList.AccessList
+	}
+	if tx.PrivateSubmission != nil {
+		simulated["privateSubmission"] = *tx.PrivateSubmission
+	}
+	if d := tx.InclusionDeadline; d.Blocks > 0 {
+		simulated["inclusionDeadlineBlocks"] = d.Blocks
+	}
+	if d := tx.InclusionDeadline; d.Duration > 0 {
+		simulated["inclusionDeadline"] = d.Duration.String()
+	}
+	return simulated
+}
+
 func decodeMeta(metaMap MapParam) (*txmgr.EthTxMeta, error) {
 	var txMeta txmgr.EthTxMeta
 	metaDecoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
@@ -200,6 +317,23 @@ func decodeMeta(metaMap MapParam) (*txmgr.EthTxMeta, error) {
 	return &txMeta, nil
 }
 
+// decodeAccessList converts the list of {address, storageKeys} objects of the
+// accessList attribute to an EIP-2930 access list
+func decodeAccessList(accessListParam SliceParam) (txmgr.NullableEIP2930AccessList, error) {
+	if len(accessListParam) == 0 {
+		return txmgr.NullableEIP2930AccessList{}, nil
+	}
+	b, err := json.Marshal(accessListParam)
+	if err != nil {
+		return txmgr.NullableEIP2930AccessList{}, errors.Wrapf(ErrBadInput, "accessList: %v", err)
+	}
+	var accessList gethTypes.AccessList
+	if err = json.Unmarshal(b, &accessList); err != nil {
+		return txmgr.NullableEIP2930AccessList{}, errors.Wrapf(ErrBadInput, "accessList: %v", err)
+	}
+	return txmgr.NullableEIP2930AccessListFrom(accessList), nil
+}
+
 func decodeTransmitChecker(checkerMap MapParam) (txmgr.TransmitCheckerSpec, error) {
 	var transmitChecker txmgr.TransmitCheckerSpec
 	checkerDecoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
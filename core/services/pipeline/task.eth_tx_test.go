@@ -1,9 +1,12 @@
 package pipeline_test
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -575,4 +578,248 @@ func TestETHTxTask(t *testing.T) {
 	}
 }
 
+func TestETHTxTask_Simulate(t *testing.T) {
+	t.Parallel()
+
+	from := common.HexToAddress("0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c")
+	task := pipeline.ETHTxTask{
+		BaseTask:         pipeline.NewBaseTask(0, "ethtx", nil, nil, 0),
+		From:             `[ "0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c" ]`,
+		To:               "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF",
+		Data:             "foobar",
+		GasLimit:         "12345",
+		MinConfirmations: "3",
+		EVMChainID:       "0",
+	}
+
+	keyStore := keystoremocks.NewEth(t)
+	txManager := txmmocks.NewTxManager(t)
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, nil)
+	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: cfg,
+		TxManager: txManager, KeyStore: keyStore})
+
+	keyStore.On("GetRoundRobinAddress", testutils.FixtureChainID, from).Return(from, nil)
+	task.HelperSetDependencies(cc, keyStore, nil, pipeline.DirectRequestJobType)
+	task.HelperSetSimulate()
+
+	// the tx manager mock fails the test if a transaction is created
+	result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+	assert.False(t, runInfo.IsPending)
+	require.NoError(t, result.Error)
+	require.Equal(t, map[string]interface{}{
+		"evmChainID":       testutils.FixtureChainID.String(),
+		"from":             from.Hex(),
+		"to":               "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF",
+		"data":             "0x666f6f626172",
+		"gasLimit":         uint32(12345),
+		"minConfirmations": uint32(3),
+	}, result.Value)
+}
+
+func TestETHTxTask_BatchForwarder(t *testing.T) {
+	t.Parallel()
+
+	from := common.HexToAddress("0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c")
+	to := common.HexToAddress("0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF")
+	forwarder := common.HexToAddress("0x2E396ecbc8223Ebc16EC45136228AE5EDB649943")
+	task := pipeline.ETHTxTask{
+		BaseTask:         pipeline.NewBaseTask(0, "ethtx", nil, nil, 0),
+		From:             `[ "0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c" ]`,
+		To:               to.Hex(),
+		Data:             "foobar",
+		GasLimit:         "12345",
+		MinConfirmations: "0",
+		EVMChainID:       "0",
+		BatchForwarder:   "$(forwarder)",
+	}
+
+	keyStore := keystoremocks.NewEth(t)
+	txManager := txmmocks.NewTxManager(t)
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, nil)
+	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: cfg,
+		TxManager: txManager, KeyStore: keyStore})
+
+	keyStore.On("GetRoundRobinAddress", testutils.FixtureChainID, from).Return(from, nil)
+	txManager.On("CreateEthTransaction", mock.MatchedBy(func(newTx txmgr.NewTx) bool {
+		s, ok := newTx.Strategy.(txmgr.BatchingStrategy)
+		return ok && s.ForwarderAddress == forwarder && newTx.ToAddress == to
+	})).Return(txmgr.EthTx{}, nil)
+	task.HelperSetDependencies(cc, keyStore, nil, pipeline.DirectRequestJobType)
+
+	vars := pipeline.NewVarsFrom(map[string]interface{}{"forwarder": forwarder.Hex()})
+	result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+	assert.False(t, runInfo.IsPending)
+	require.NoError(t, result.Error)
+}
+
+func TestETHTxTask_AccessList(t *testing.T) {
+	t.Parallel()
+
+	from := common.HexToAddress("0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c")
+	to := common.HexToAddress("0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF")
+	storageKey := common.HexToHash("0x01")
+	newTask := func(txType string) pipeline.ETHTxTask {
+		return pipeline.ETHTxTask{
+			BaseTask:         pipeline.NewBaseTask(0, "ethtx", nil, nil, 0),
+			From:             `[ "0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c" ]`,
+			To:               to.Hex(),
+			Data:             "foobar",
+			GasLimit:         "12345",
+			MinConfirmations: "0",
+			EVMChainID:       "0",
+			TxType:           txType,
+			AccessList:       `[{"address": "$(contract)", "storageKeys": ["` + storageKey.Hex() + `"]}]`,
+		}
+	}
+	vars := pipeline.NewVarsFrom(map[string]interface{}{"contract": to.Hex()})
+
+	keyStore := keystoremocks.NewEth(t)
+	txManager := txmmocks.NewTxManager(t)
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, nil)
+	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: cfg,
+		TxManager: txManager, KeyStore: keyStore})
+
+	t.Run("access list transaction", func(t *testing.T) {
+		task := newTask("0x1")
+		keyStore.On("GetRoundRobinAddress", testutils.FixtureChainID, from).Return(from, nil).Once()
+		txManager.On("CreateEthTransaction", mock.MatchedBy(func(newTx txmgr.NewTx) bool {
+			return newTx.TxType == clnull.Int64From(int64(txmgr.AccessListTxType)) &&
+				newTx.AccessList.Valid &&
+				reflect.DeepEqual(newTx.AccessList.AccessList, gethTypes.AccessList{{Address: to, StorageKeys: []common.Hash{storageKey}}})
+		})).Return(txmgr.EthTx{}, nil).Once()
+		task.HelperSetDependencies(cc, keyStore, nil, pipeline.DirectRequestJobType)
+
+		result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+		assert.False(t, runInfo.IsPending)
+		require.NoError(t, result.Error)
+	})
+
+	t.Run("unsupported transaction type", func(t *testing.T) {
+		task := newTask("0x3")
+		task.HelperSetDependencies(cc, keyStore, nil, pipeline.DirectRequestJobType)
+
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+		require.Error(t, result.Error)
+		assert.True(t, errors.Is(result.Error, pipeline.ErrBadInput))
+		assert.Contains(t, result.Error.Error(), "unsupported transaction type 0x3")
+	})
+}
+
+func TestETHTxTask_PrivateSubmission(t *testing.T) {
+	t.Parallel()
+
+	from := common.HexToAddress("0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c")
+	newTask := func(privateSubmission string) pipeline.ETHTxTask {
+		return pipeline.ETHTxTask{
+			BaseTask:          pipeline.NewBaseTask(0, "ethtx", nil, nil, 0),
+			From:              `[ "0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c" ]`,
+			To:                "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF",
+			Data:              "foobar",
+			GasLimit:          "12345",
+			MinConfirmations:  "0",
+			EVMChainID:        "0",
+			PrivateSubmission: privateSubmission,
+		}
+	}
+	vars := pipeline.NewVarsFrom(map[string]interface{}{"private": true})
+
+	keyStore := keystoremocks.NewEth(t)
+	txManager := txmmocks.NewTxManager(t)
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, nil)
+	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: cfg,
+		TxManager: txManager, KeyStore: keyStore})
+
+	tests := []struct {
+		name              string
+		privateSubmission string
+		expected          *bool
+	}{
+		{"unset uses the chain default", "", nil},
+		{"private", "true", ptr(true)},
+		{"public", "false", ptr(false)},
+		{"from vars", "$(private)", ptr(true)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			task := newTask(test.privateSubmission)
+			keyStore.On("GetRoundRobinAddress", testutils.FixtureChainID, from).Return(from, nil).Once()
+			txManager.On("CreateEthTransaction", mock.MatchedBy(func(newTx txmgr.NewTx) bool {
+				return reflect.DeepEqual(newTx.PrivateSubmission, test.expected)
+			})).Return(txmgr.EthTx{}, nil).Once()
+			task.HelperSetDependencies(cc, keyStore, nil, pipeline.DirectRequestJobType)
+
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+			assert.False(t, runInfo.IsPending)
+			require.NoError(t, result.Error)
+		})
+	}
+}
+
+func TestETHTxTask_InclusionDeadline(t *testing.T) {
+	t.Parallel()
+
+	from := common.HexToAddress("0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c")
+	newTask := func(blocks, duration string) pipeline.ETHTxTask {
+		return pipeline.ETHTxTask{
+			BaseTask:                pipeline.NewBaseTask(0, "ethtx", nil, nil, 0),
+			From:                    `[ "0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c" ]`,
+			To:                      "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF",
+			Data:                    "foobar",
+			GasLimit:                "12345",
+			MinConfirmations:        "0",
+			EVMChainID:              "0",
+			InclusionDeadlineBlocks: blocks,
+			InclusionDeadline:       duration,
+		}
+	}
+	vars := pipeline.NewVarsFrom(map[string]interface{}{"blocks": 3, "deadline": "45s"})
+
+	keyStore := keystoremocks.NewEth(t)
+	txManager := txmmocks.NewTxManager(t)
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewGeneralConfig(t, nil)
+	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: cfg,
+		TxManager: txManager, KeyStore: keyStore})
+
+	tests := []struct {
+		name     string
+		blocks   string
+		duration string
+		expected txmgr.InclusionDeadline
+	}{
+		{"unset", "", "", txmgr.InclusionDeadline{}},
+		{"blocks", "5", "", txmgr.InclusionDeadline{Blocks: 5}},
+		{"duration", "", "2m", txmgr.InclusionDeadline{Duration: 2 * time.Minute}},
+		{"from vars", "$(blocks)", "$(deadline)", txmgr.InclusionDeadline{Blocks: 3, Duration: 45 * time.Second}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			task := newTask(test.blocks, test.duration)
+			keyStore.On("GetRoundRobinAddress", testutils.FixtureChainID, from).Return(from, nil).Once()
+			txManager.On("CreateEthTransaction", mock.MatchedBy(func(newTx txmgr.NewTx) bool {
+				return newTx.InclusionDeadline == test.expected
+			})).Return(txmgr.EthTx{}, nil).Once()
+			task.HelperSetDependencies(cc, keyStore, nil, pipeline.DirectRequestJobType)
+
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+			assert.False(t, runInfo.IsPending)
+			require.NoError(t, result.Error)
+		})
+	}
+
+	t.Run("rejects an invalid duration", func(t *testing.T) {
+		task := newTask("", "soon")
+		task.HelperSetDependencies(cc, keyStore, nil, pipeline.DirectRequestJobType)
+
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+		require.Error(t, result.Error)
+		assert.True(t, errors.Is(result.Error, pipeline.ErrBadInput))
+		assert.Contains(t, result.Error.Error(), "inclusionDeadline")
+	})
+}
+
 func ptr[T any](t T) *T { return &t }
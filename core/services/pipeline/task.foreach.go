@@ -0,0 +1,182 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+const (
+	// DefaultForEachConcurrency is the number of elements a foreach task
+	// processes at the same time unless its `concurrency` is set
+	DefaultForEachConcurrency = 10
+	// MaxForEachValues caps the number of elements a foreach task processes
+	MaxForEachValues = 1000
+)
+
+// ForEachTask runs a sub-pipeline once for each element of its `values` (by
+// default, its single input, which must be an array), up to `concurrency` of
+// them at the same time, and returns the array of their results.
+//
+// The sub-pipeline is a DOT fragment, in which $(element) is the current
+// element and $(index) its index. The variables of the enclosing pipeline,
+// such as $(jobSpec) or the results of the tasks the foreach task depends on,
+// are visible too. E.g.:
+//
+//	prices [type=foreach values="$(decode_markets)" subpipeline="fetch [type=http method=GET url=\"$(element.url)\"]; parse [type=jsonparse path=\"price\" data=\"$(fetch)\"]; fetch -> parse;"]
+//
+// The sub-pipeline must be written on a single line, with its quotes escaped.
+//
+// The result for an element is the output of the final task of the
+// sub-pipeline, or the array of the outputs of its final tasks if it has
+// several. If the sub-pipeline fails for more than `allowedFaults` (default
+// 0) elements the task fails; otherwise the results of the failed elements
+// are null.
+//
+// The sub-pipeline may not contain tasks that are async or have side effects,
+// such as `ethtx` or async `bridge` tasks, and `values` may have at most
+// MaxForEachValues elements.
+//
+// Return types:
+//
+//	[]interface{}
+type ForEachTask struct {
+	BaseTask      `mapstructure:",squash"`
+	Values        string `json:"values"`
+	Subpipeline   string `json:"subpipeline"`
+	Concurrency   string `json:"concurrency"`
+	AllowedFaults string `json:"allowedFaults"`
+
+	runSubpipeline subpipelineRunner
+	simulate       bool
+}
+
+// subpipelineRunner executes the pipeline in source to completion, in memory,
+// as part of the current run.
+type subpipelineRunner func(ctx context.Context, source string, vars Vars, simulate bool, l logger.Logger) (Run, error)
+
+var _ Task = (*ForEachTask)(nil)
+
+func (t *ForEachTask) Type() TaskType {
+	return TaskTypeForEach
+}
+
+func (t *ForEachTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	var (
+		values        SliceParam
+		subpipeline   StringParam
+		concurrency   Uint64Param
+		allowedFaults Uint64Param
+	)
+	err := multierr.Combine(
+		errors.Wrap(ResolveParam(&values, From(VarExpr(t.Values, vars), JSONWithVarExprs(t.Values, vars, false), Input(inputs, 0))), "values"),
+		errors.Wrap(ResolveParam(&subpipeline, From(NonemptyString(t.Subpipeline))), "subpipeline"),
+		errors.Wrap(ResolveParam(&concurrency, From(NonemptyString(t.Concurrency), DefaultForEachConcurrency)), "concurrency"),
+		errors.Wrap(ResolveParam(&allowedFaults, From(NonemptyString(t.AllowedFaults), 0)), "allowedFaults"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	if concurrency == 0 {
+		return Result{Error: errors.Wrap(ErrBadInput, "concurrency: must be at least 1")}, runInfo
+	}
+	if len(values) > MaxForEachValues {
+		return Result{Error: errors.Wrapf(ErrBadInput, "values: must have at most %d elements, got %d", MaxForEachValues, len(values))}, runInfo
+	}
+
+	// Fail early, rather than once per element, if the sub-pipeline is
+	// invalid, and before anything runs if it has side effects
+	p, err := Parse(string(subpipeline))
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "subpipeline")}, runInfo
+	}
+	if err = checkForEachSubpipeline(p); err != nil {
+		return Result{Error: errors.Wrap(err, "subpipeline")}, runInfo
+	}
+
+	if int(concurrency) > len(values) {
+		concurrency = Uint64Param(len(values))
+	}
+
+	results := make([]interface{}, len(values))
+	errs := make([]error, len(values))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, element := range values {
+		i, element := i, element
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = t.runElement(ctx, lggr.With("index", i), vars, string(subpipeline), i, element)
+		}()
+	}
+	wg.Wait()
+
+	var faults uint64
+	for i, err := range errs {
+		if err != nil {
+			faults++
+			lggr.Debugw("Sub-pipeline failed for foreach element", "index", i, "err", err)
+		}
+	}
+	if faults > uint64(allowedFaults) {
+		return Result{Error: errors.Wrapf(ErrTooManyErrors, "sub-pipeline failed for %v of %v elements, more than the allowed faults %v: %v",
+			faults, len(values), allowedFaults, multierr.Combine(errs...))}, runInfo
+	}
+	return Result{Value: results}, runInfo
+}
+
+// checkForEachSubpipeline returns an error if p has async tasks or tasks with
+// side effects, which would run once per element
+func checkForEachSubpipeline(p *Pipeline) error {
+	for _, task := range p.Tasks {
+		switch task := task.(type) {
+		case *ETHTxTask:
+			return errors.Wrapf(ErrBadInput, "task %s: %s tasks are not supported in a foreach sub-pipeline", task.DotID(), task.Type())
+		case *BridgeTask:
+			if task.Async == "true" {
+				return errors.Wrapf(ErrBadInput, "task %s: async tasks are not supported in a foreach sub-pipeline", task.DotID())
+			}
+		}
+	}
+	return nil
+}
+
+func (t *ForEachTask) runElement(ctx context.Context, lggr logger.Logger, vars Vars, subpipeline string, index int, element interface{}) (interface{}, error) {
+	elementVars := vars.Copy()
+	if err := multierr.Combine(
+		elementVars.Set("element", element),
+		elementVars.Set("index", index),
+	); err != nil {
+		return nil, err
+	}
+
+	run, err := t.runSubpipeline(ctx, subpipeline, elementVars, t.simulate, lggr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "element %v", index)
+	}
+	if run.Pending {
+		return nil, errors.Errorf("element %v: async tasks are not supported in a foreach sub-pipeline", index)
+	}
+	for _, fatalErr := range run.FatalErrors {
+		if !fatalErr.IsZero() {
+			return nil, errors.Errorf("element %v: %v", index, fatalErr.String)
+		}
+	}
+
+	outputs, ok := run.Outputs.Val.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("element %v: unexpected sub-pipeline outputs of type %T", index, run.Outputs.Val)
+	}
+	if len(outputs) == 1 {
+		return outputs[0], nil
+	}
+	return outputs, nil
+}
@@ -0,0 +1,155 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bridgesMocks "github.com/smartcontractkit/chainlink/core/bridges/mocks"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestForEachTask(t *testing.T) {
+	t.Parallel()
+
+	cfg := configtest.NewTestGeneralConfig(t)
+	r, _ := newRunner(t, pgtest.NewSqlxDB(t), bridgesMocks.NewORM(t), cfg)
+	lggr := logger.TestLogger(t)
+
+	decimals := func(t *testing.T, value interface{}) (strs []string) {
+		for _, v := range value.([]interface{}) {
+			if v == nil {
+				strs = append(strs, "null")
+				continue
+			}
+			strs = append(strs, v.(decimal.Decimal).String())
+		}
+		return
+	}
+
+	tests := []struct {
+		name    string
+		source  string
+		vars    map[string]interface{}
+		check   func(t *testing.T, value interface{})
+		wantErr string
+	}{
+		{
+			"maps over the input",
+			`
+list [type=jsonparse path="list" data="$(body)"];
+each [type=foreach subpipeline="a [type=multiply input=\"$(element)\" times=10];" concurrency=2];
+list -> each;`,
+			map[string]interface{}{"body": `{"list": [1, 2, 3]}`},
+			func(t *testing.T, value interface{}) {
+				assert.Equal(t, []string{"10", "20", "30"}, decimals(t, value))
+			},
+			"",
+		},
+		{
+			"sees the index and the enclosing variables",
+			`each [type=foreach values=<["1.5", "2.5"]> subpipeline="a [type=multiply input=\"$(element)\" times=\"$(index)\"]; b [type=sum values=<[ $(a), $(base) ]>]; a -> b;"];`,
+			map[string]interface{}{"base": 100},
+			func(t *testing.T, value interface{}) {
+				assert.Equal(t, []string{"100", "102.5"}, decimals(t, value))
+			},
+			"",
+		},
+		{
+			"collects multiple final outputs",
+			`each [type=foreach values=<[2]> subpipeline="a [type=multiply input=\"$(element)\" times=2 index=0]; b [type=multiply input=\"$(element)\" times=3 index=1];"];`,
+			nil,
+			func(t *testing.T, value interface{}) {
+				require.Len(t, value, 1)
+				assert.Equal(t, []string{"4", "6"}, decimals(t, value.([]interface{})[0]))
+			},
+			"",
+		},
+		{
+			"failed elements within allowed faults are null",
+			`each [type=foreach values=<[1, 0, 4]> allowedFaults=1 subpipeline="a [type=divide input=1 divisor=\"$(element)\"];"];`,
+			nil,
+			func(t *testing.T, value interface{}) {
+				assert.Equal(t, []string{"1", "null", "0.25"}, decimals(t, value))
+			},
+			"",
+		},
+		{
+			"too many failed elements",
+			`each [type=foreach values=<[1, 0, 4]> subpipeline="a [type=divide input=1 divisor=\"$(element)\"];"];`,
+			nil,
+			nil,
+			"sub-pipeline failed for 1 of 3 elements",
+		},
+		{
+			"empty values",
+			`each [type=foreach values=<[]> subpipeline="a [type=multiply input=\"$(element)\" times=10];"];`,
+			nil,
+			func(t *testing.T, value interface{}) {
+				assert.Empty(t, value)
+			},
+			"",
+		},
+		{
+			"invalid sub-pipeline",
+			`each [type=foreach values=<[1]> subpipeline="a [type=nonexistent];"];`,
+			nil,
+			nil,
+			"subpipeline",
+		},
+		{
+			"sub-pipeline with side effects",
+			`each [type=foreach values=<[1]> subpipeline="tx [type=ethtx to=\"0x613a38AC1659769640aaE063C651F48E0250454C\" data=\"0x\"];"];`,
+			nil,
+			nil,
+			"task tx: ethtx tasks are not supported in a foreach sub-pipeline",
+		},
+		{
+			"async sub-pipeline",
+			`each [type=foreach values=<[1]> subpipeline="b [type=bridge name=\"foo\" async=\"true\"];"];`,
+			nil,
+			nil,
+			"task b: async tasks are not supported in a foreach sub-pipeline",
+		},
+		{
+			"too many values",
+			`each [type=foreach values="$(list)" subpipeline="a [type=multiply input=\"$(element)\" times=10];"];`,
+			map[string]interface{}{"list": make([]interface{}, pipeline.MaxForEachValues+1)},
+			nil,
+			"values: must have at most 1000 elements, got 1001",
+		},
+		{
+			"zero concurrency",
+			`each [type=foreach values=<[1]> concurrency=0 subpipeline="a [type=multiply input=\"$(element)\" times=10];"];`,
+			nil,
+			nil,
+			"concurrency: must be at least 1",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, trrs, err := r.ExecuteRun(testutils.Context(t), pipeline.Spec{DotDagSource: test.source}, pipeline.NewVarsFrom(test.vars), lggr)
+			require.NoError(t, err)
+
+			result, err := trrs.FinalResult(lggr).SingularResult()
+			require.NoError(t, err)
+			if test.wantErr != "" {
+				require.Error(t, result.Error)
+				assert.Contains(t, result.Error.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, result.Error)
+			test.check(t, result.Value)
+		})
+	}
+}
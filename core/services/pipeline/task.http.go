@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -14,10 +15,17 @@ import (
 	clhttp "github.com/smartcontractkit/chainlink/core/utils/http"
 )
 
+// Failed requests are retried within the run up to `retries` times (see
+// httpRetryPolicy), waiting `backoff` (default `minBackoff`) before the first
+// retry and doubling up to `maxBackoff`. `retryOn` selects which failures are
+// retried.
+//
+// If `cacheTTL` is set, the response to a request is reused by the http tasks
+// of every job that make the same request within cacheTTL (see resultCache).
 //
 // Return types:
-//     string
 //
+//	string
 type HTTPTask struct {
 	BaseTask                       `mapstructure:",squash"`
 	Method                         string
@@ -25,10 +33,14 @@ type HTTPTask struct {
 	RequestData                    string `json:"requestData"`
 	AllowUnrestrictedNetworkAccess string
 	Headers                        string
+	Backoff                        string `json:"backoff"`
+	RetryOn                        string `json:"retryOn"`
 
 	config                 Config
 	httpClient             *http.Client
 	unrestrictedHTTPClient *http.Client
+	breakers               *circuitBreakers
+	cache                  *resultCache
 }
 
 var _ Task = (*HTTPTask)(nil)
@@ -52,6 +64,10 @@ func (t *HTTPTask) Type() TaskType {
 	return TaskTypeHTTP
 }
 
+func (t *HTTPTask) retriesInRun() {}
+
+func (t *HTTPTask) cacheable() {}
+
 func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
 	_, err := CheckInputs(inputs, -1, -1, 0)
 	if err != nil {
@@ -64,6 +80,8 @@ func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, input
 		requestData                    MapParam
 		allowUnrestrictedNetworkAccess BoolParam
 		reqHeaders                     StringSliceParam
+		initialBackoff                 DurationParam
+		retryOn                        httpRetryOnParam
 	)
 	err = multierr.Combine(
 		errors.Wrap(ResolveParam(&method, From(NonemptyString(t.Method), "GET")), "method"),
@@ -74,6 +92,8 @@ func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, input
 		// You must set allowUnrestrictedNetworkAccess=true on the task to enable variable-interpolated URLs to make restricted network requests
 		errors.Wrap(ResolveParam(&allowUnrestrictedNetworkAccess, From(NonemptyString(t.AllowUnrestrictedNetworkAccess), !variableRegexp.MatchString(t.URL))), "allowUnrestrictedNetworkAccess"),
 		errors.Wrap(ResolveParam(&reqHeaders, From(NonemptyString(t.Headers), "[]")), "reqHeaders"),
+		errors.Wrap(ResolveParam(&initialBackoff, From(NonemptyString(t.Backoff), t.TaskMinBackoff())), "backoff"),
+		errors.Wrap(ResolveParam(&retryOn, From(NonemptyString(t.RetryOn), defaultHTTPRetryOn)), "retryOn"),
 	)
 	if err != nil {
 		return Result{Error: err}, runInfo
@@ -95,8 +115,14 @@ func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, input
 		"allowUnrestrictedNetworkAccess", allowUnrestrictedNetworkAccess,
 	)
 
-	requestCtx, cancel := httpRequestCtx(ctx, t, t.config)
-	defer cancel()
+	cacheKey, err := taskCacheKey(t.Type(), method, url.String(), reqHeaders, string(requestDataJSON))
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	if cached, ok := t.cache.get(ctx, lggr, t, cacheKey); ok {
+		lggr.Debugw("HTTP task: using cached response", "url", url.String(), "dotID", t.DotID())
+		return Result{Value: string(cached)}, runInfo
+	}
 
 	var client *http.Client
 	if allowUnrestrictedNetworkAccess {
@@ -104,7 +130,10 @@ func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, input
 	} else {
 		client = t.httpClient
 	}
-	responseBytes, statusCode, respHeaders, elapsed, err := makeHTTPRequest(requestCtx, lggr, method, url, reqHeaders, requestData, client, t.config.DefaultHTTPLimit())
+	policy := newHTTPRetryPolicy(t, initialBackoff, retryOn)
+	responseBytes, statusCode, respHeaders, elapsed, err := makeHTTPRequestWithRetries(ctx, lggr, t, t.config, url, policy, t.breakers, func(requestCtx context.Context) ([]byte, int, http.Header, time.Duration, error) {
+		return makeHTTPRequest(requestCtx, lggr, method, url, reqHeaders, requestData, client, t.config.DefaultHTTPLimit())
+	})
 	if err != nil {
 		if errors.Is(errors.Cause(err), clhttp.ErrDisallowedIP) {
 			err = errors.Wrap(err, `connections to local resources are disabled by default, if you are sure this is safe, you can enable on a per-task basis by setting allowUnrestrictedNetworkAccess="true" in the pipeline task spec, e.g. fetch [type="http" method=GET url="$(decode_cbor.url)" allowUnrestrictedNetworkAccess="true"]`)
@@ -122,6 +151,8 @@ func (t *HTTPTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, input
 	promHTTPFetchTime.WithLabelValues(t.DotID()).Set(float64(elapsed))
 	promHTTPResponseBodySize.WithLabelValues(t.DotID()).Set(float64(len(responseBytes)))
 
+	t.cache.put(ctx, lggr, t, cacheKey, responseBytes)
+
 	// NOTE: We always stringify the response since this is required for all current jobs.
 	// If a binary response is required we might consider adding an adapter
 	// flag such as  "BinaryMode: true" which passes through raw binary as the
@@ -2,6 +2,7 @@ package pipeline_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -14,6 +15,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/core/bridges"
@@ -24,6 +26,7 @@ import (
 	clhttptest "github.com/smartcontractkit/chainlink/core/internal/testutils/httptest"
 	"github.com/smartcontractkit/chainlink/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	clnull "github.com/smartcontractkit/chainlink/core/null"
 	"github.com/smartcontractkit/chainlink/core/services/pg"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -414,3 +417,199 @@ func TestHTTPTask_Headers(t *testing.T) {
 		assert.Equal(t, []string{"Content-Length", "38", "Content-Type", "footype", "User-Agent", "Go-http-client/1.1", "X-Header-1", "foo", "X-Header-2", "bar"}, allHeaders(headers))
 	})
 }
+
+func TestHTTPTask_Retries(t *testing.T) {
+	t.Parallel()
+
+	// failingServer responds with each of statusCodes in turn, then with 200
+	failingServer := func(t *testing.T, statusCodes ...int) (*httptest.Server, *atomic.Int32) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := int(requests.Inc())
+			w.Header().Set("Content-Type", "application/json")
+			if n <= len(statusCodes) {
+				w.WriteHeader(statusCodes[n-1])
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"result": 42}`))
+			require.NoError(t, err)
+		}))
+		t.Cleanup(server.Close)
+		return server, &requests
+	}
+
+	tests := []struct {
+		name             string
+		retries          uint32
+		retryOn          string
+		statusCodes      []int
+		expectedRequests int32
+		expectedErr      bool
+	}{
+		{"no retries", 0, "", []int{http.StatusBadGateway}, 1, true},
+		{"retries transient failures", 3, "", []int{http.StatusBadGateway, http.StatusServiceUnavailable}, 3, false},
+		{"gives up after retries", 2, "", []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}, 3, true},
+		{"does not retry client errors by default", 3, "", []int{http.StatusTooManyRequests}, 1, true},
+		{"retries specific status codes", 3, `["429"]`, []int{http.StatusTooManyRequests}, 2, false},
+		{"retries status code classes", 3, `["4xx"]`, []int{http.StatusNotFound, http.StatusConflict}, 3, false},
+		{"does not retry unlisted failures", 3, `["429"]`, []int{http.StatusBadGateway}, 1, true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, requests := failingServer(t, test.statusCodes...)
+			task := pipeline.HTTPTask{
+				BaseTask: pipeline.NewBaseTask(0, "http", nil, nil, 0),
+				Method:   "GET",
+				URL:      server.URL,
+				Backoff:  "1ms",
+				RetryOn:  test.retryOn,
+			}
+			task.Retries = clnull.Uint32From(test.retries)
+			c := clhttptest.NewTestLocalOnlyHTTPClient()
+			task.HelperSetDependencies(configtest.NewTestGeneralConfig(t), c, c)
+
+			result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+			if test.expectedErr {
+				require.Error(t, result.Error)
+				require.Nil(t, result.Value)
+			} else {
+				require.NoError(t, result.Error)
+				require.Equal(t, `{"result": 42}`, result.Value)
+			}
+			require.Equal(t, test.expectedRequests, requests.Load())
+		})
+	}
+
+	t.Run("rejects unknown retry conditions", func(t *testing.T) {
+		task := pipeline.HTTPTask{
+			Method:  "GET",
+			URL:     "http://example.com",
+			RetryOn: `["sometimes"]`,
+		}
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.Error(t, result.Error)
+		require.Equal(t, pipeline.ErrBadInput, errors.Cause(result.Error))
+	})
+
+	t.Run("circuit breaker fails fast", func(t *testing.T) {
+		server, requests := failingServer(t, http.StatusBadGateway, http.StatusBadGateway)
+		task := pipeline.HTTPTask{
+			BaseTask: pipeline.NewBaseTask(0, "http", nil, nil, 0),
+			Method:   "GET",
+			URL:      server.URL,
+			Backoff:  "1ms",
+		}
+		task.Retries = clnull.Uint32From(5)
+		c := clhttptest.NewTestLocalOnlyHTTPClient()
+		task.HelperSetDependencies(configtest.NewTestGeneralConfig(t), c, c)
+		task.HelperSetCircuitBreakers(2, time.Hour)
+
+		// the breaker trips on the second failure and the remaining retries fail fast
+		result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.ErrorIs(t, result.Error, pipeline.ErrCircuitOpen)
+		assert.True(t, runInfo.IsRetryable)
+		require.Equal(t, int32(2), requests.Load())
+
+		result, _ = task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.ErrorIs(t, result.Error, pipeline.ErrCircuitOpen)
+		require.Equal(t, int32(2), requests.Load())
+	})
+}
+
+func TestHTTPTask_Cache(t *testing.T) {
+	t.Parallel()
+
+	// countingServer responds with the number of requests it has received,
+	// failing the first `failures` of them
+	countingServer := func(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := requests.Inc()
+			if n <= failures {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, err := w.Write([]byte(fmt.Sprintf(`{"n": %d}`, n)))
+			require.NoError(t, err)
+		}))
+		t.Cleanup(server.Close)
+		return server, &requests
+	}
+
+	newTask := func(t *testing.T, url string, requestData string, cacheStore string) *pipeline.HTTPTask {
+		task := &pipeline.HTTPTask{
+			BaseTask:    pipeline.NewBaseTask(0, "http", nil, nil, 0),
+			Method:      "POST",
+			URL:         url,
+			RequestData: requestData,
+		}
+		task.CacheTTL = time.Minute
+		task.CacheStore = cacheStore
+		c := clhttptest.NewTestLocalOnlyHTTPClient()
+		task.HelperSetDependencies(configtest.NewTestGeneralConfig(t), c, c)
+		return task
+	}
+
+	run := func(t *testing.T, task *pipeline.HTTPTask) pipeline.Result {
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		return result
+	}
+
+	t.Run("reuses the response to the same request", func(t *testing.T) {
+		server, requests := countingServer(t, 0)
+		task := newTask(t, server.URL, `{"pair": "ETH/USD"}`, "")
+		task.HelperSetCache(nil)
+
+		require.Equal(t, `{"n": 1}`, run(t, task).Value)
+		require.Equal(t, `{"n": 1}`, run(t, task).Value)
+		require.Equal(t, int32(1), requests.Load())
+
+		other := newTask(t, server.URL, `{"pair": "BTC/USD"}`, "")
+		other.HelperSetCache(nil)
+		require.Equal(t, `{"n": 2}`, run(t, other).Value)
+		require.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		server, requests := countingServer(t, 1)
+		task := newTask(t, server.URL, "", "")
+		task.HelperSetCache(nil)
+
+		require.Error(t, run(t, task).Error)
+		require.Equal(t, `{"n": 2}`, run(t, task).Value)
+		require.Equal(t, `{"n": 2}`, run(t, task).Value)
+		require.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("without cacheTTL", func(t *testing.T) {
+		server, requests := countingServer(t, 0)
+		task := newTask(t, server.URL, "", "")
+		task.CacheTTL = 0
+		task.HelperSetCache(nil)
+
+		require.Equal(t, `{"n": 1}`, run(t, task).Value)
+		require.Equal(t, `{"n": 2}`, run(t, task).Value)
+		require.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("db store survives a new cache", func(t *testing.T) {
+		db := pgtest.NewSqlxDB(t)
+		cfg := configtest.NewTestGeneralConfig(t)
+		orm := pipeline.NewORM(db, logger.TestLogger(t), cfg)
+		server, requests := countingServer(t, 0)
+
+		task := newTask(t, server.URL, "", pipeline.CacheStoreDB)
+		task.HelperSetCache(orm)
+		require.Equal(t, `{"n": 1}`, run(t, task).Value)
+
+		// e.g. the node restarted
+		task.HelperSetCache(orm)
+		require.Equal(t, `{"n": 1}`, run(t, task).Value)
+		require.Equal(t, int32(1), requests.Load())
+	})
+}
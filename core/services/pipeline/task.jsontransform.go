@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// JSONTransformTask evaluates a jq expression (see jq.go for the supported
+// subset) over its input. The input may be JSON text (e.g. an http task
+// response) or an already decoded value.
+//
+// If the expression produces exactly one output, that output is returned.
+// Otherwise all outputs are returned as an array (an empty array if there
+// were none).
+//
+// Return types:
+//
+//	int64, *big.Int, float64
+//	string
+//	bool
+//	map[string]interface{}
+//	[]interface{}
+//	nil
+type JSONTransformTask struct {
+	BaseTask `mapstructure:",squash"`
+	Expr     string `json:"expr"`
+	Data     string `json:"data"`
+}
+
+var _ Task = (*JSONTransformTask)(nil)
+
+func (t *JSONTransformTask) Type() TaskType {
+	return TaskTypeJSONTransform
+}
+
+func (t *JSONTransformTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, 0, 1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var (
+		expr StringParam
+		data jqInputParam
+	)
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&expr, From(NonemptyString(t.Expr))), "expr"),
+		errors.Wrap(ResolveParam(&data, From(VarExpr(t.Data, vars), JSONWithVarExprs(t.Data, vars, false), Input(inputs, 0))), "data"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	prog, err := parseJQ(string(expr))
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "expr")}, runInfo
+	}
+
+	outputs, err := prog.run(data.val, nil, DefaultJQMaxSteps)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	if len(outputs) == 1 {
+		return Result{Value: outputs[0]}, runInfo
+	}
+	if outputs == nil {
+		outputs = []interface{}{}
+	}
+	return Result{Value: outputs}, runInfo
+}
+
+// jqInputParam accepts JSON text or any decoded value and converts it into
+// the value model used by the jq interpreter.
+type jqInputParam struct {
+	val interface{}
+}
+
+func (p *jqInputParam) UnmarshalPipelineParam(val interface{}) (err error) {
+	switch v := val.(type) {
+	case string:
+		p.val, err = jqDecode([]byte(v))
+	case []byte:
+		p.val, err = jqDecode(v)
+	default:
+		p.val, err = jqNormalize(v)
+	}
+	return err
+}
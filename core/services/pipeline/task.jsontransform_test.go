@@ -0,0 +1,259 @@
+package pipeline_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestJSONTransformTask(t *testing.T) {
+	t.Parallel()
+
+	const prices = `{"data":{"prices":[{"sym":"ETH","p":"1850.5","w":2},{"sym":"BTC","p":"27000","w":1},{"sym":"LINK","p":"6.25","w":0}]}}`
+
+	tests := []struct {
+		name           string
+		expr           string
+		data           string
+		vars           pipeline.Vars
+		inputs         []pipeline.Result
+		wantData       interface{}
+		wantErrorCause error
+	}{
+		{
+			"field lookup",
+			`.data.prices[0].sym`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			"ETH",
+			nil,
+		},
+		{
+			"negative index",
+			`.data.prices[-1].sym`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			"LINK",
+			nil,
+		},
+		{
+			"slice",
+			`.data.prices[1:] | map(.sym)`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			[]interface{}{"BTC", "LINK"},
+			nil,
+		},
+		{
+			"map and select",
+			`.data.prices | map(select(.w > 0) | .p | tonumber)`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			[]interface{}{1850.5, int64(27000)},
+			nil,
+		},
+		{
+			"arithmetic",
+			`[.data.prices[] | (.p | tonumber) * .w] | add`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			int64(30701),
+			nil,
+		},
+		{
+			"object construction",
+			`.data.prices[0] | {symbol: .sym, weight: .w}`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			map[string]interface{}{"symbol": "ETH", "weight": int64(2)},
+			nil,
+		},
+		{
+			"multiple outputs are collected",
+			`.data.prices[] | .w`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			[]interface{}{int64(2), int64(1), int64(0)},
+			nil,
+		},
+		{
+			"no outputs",
+			`.data.prices[] | select(.w > 5)`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			[]interface{}{},
+			nil,
+		},
+		{
+			"alternative operator",
+			`.missing // "default"`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			"default",
+			nil,
+		},
+		{
+			"conditional",
+			`if (.data.prices | length) > 2 then "many" else "few" end`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			"many",
+			nil,
+		},
+		{
+			"large integers keep precision",
+			`.n + 1`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: `{"n": 115792089237316195423570985008687907853269984665640564039457584007913129639934}`}},
+			mustBigInt(t, "115792089237316195423570985008687907853269984665640564039457584007913129639935"),
+			nil,
+		},
+		{
+			"decoded input from vars",
+			`.foo.bar * 2`,
+			"$(x)",
+			pipeline.NewVarsFrom(map[string]interface{}{"x": map[string]interface{}{"foo": map[string]interface{}{"bar": 21}}}),
+			nil,
+			int64(42),
+			nil,
+		},
+		{
+			"JSON data with var expressions",
+			`.a + .b`,
+			`{"a": $(x), "b": 2}`,
+			pipeline.NewVarsFrom(map[string]interface{}{"x": 40}),
+			nil,
+			int64(42),
+			nil,
+		},
+		{
+			"invalid JSON input",
+			`.`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: `{"a":`}},
+			nil,
+			pipeline.ErrBadInput,
+		},
+		{
+			"syntax error",
+			`.data[`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			nil,
+			pipeline.ErrJQSyntax,
+		},
+		{
+			"unknown function",
+			`.data | system("ls")`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			nil,
+			pipeline.ErrJQSyntax,
+		},
+		{
+			"runtime error",
+			`.data.prices[0].sym + 1`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: prices}},
+			nil,
+			pipeline.ErrJQRuntime,
+		},
+		{
+			"division by zero",
+			`1 / 0`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: `{}`}},
+			nil,
+			pipeline.ErrJQRuntime,
+		},
+		{
+			"step limit",
+			`[range(100000000)] | length`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: `{}`}},
+			nil,
+			pipeline.ErrJQStepsExhausted,
+		},
+		{
+			"input error",
+			`.`,
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Error: errors.New("foo")}},
+			nil,
+			pipeline.ErrTooManyErrors,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := pipeline.JSONTransformTask{
+				BaseTask: pipeline.NewBaseTask(0, "transform", nil, nil, 0),
+				Expr:     test.expr,
+				Data:     test.data,
+			}
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), test.vars, test.inputs)
+			assert.False(t, runInfo.IsPending)
+			assert.False(t, runInfo.IsRetryable)
+
+			if test.wantErrorCause != nil {
+				require.Equal(t, test.wantErrorCause, errors.Cause(result.Error))
+				require.Nil(t, result.Value)
+			} else {
+				require.NoError(t, result.Error)
+				require.Equal(t, test.wantData, result.Value)
+			}
+		})
+	}
+}
+
+func TestJSONTransformTask_OutputDB(t *testing.T) {
+	t.Parallel()
+
+	task := pipeline.JSONTransformTask{
+		BaseTask: pipeline.NewBaseTask(0, "transform", nil, nil, 0),
+		Expr:     `{a: .a, b: [.b[] * 2]}`,
+	}
+	result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), []pipeline.Result{{Value: `{"a": "x", "b": [1, 2.5]}`}})
+	require.NoError(t, result.Error)
+
+	bs, err := result.OutputDB().MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a": "x", "b": [2, 5]}`, string(bs))
+
+	var js pipeline.JSONSerializable
+	require.NoError(t, js.UnmarshalJSON(bs))
+	require.Equal(t, map[string]interface{}{"a": "x", "b": []interface{}{int64(2), int64(5)}}, js.Val)
+}
+
+func mustBigInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+	n, ok := new(big.Int).SetString(s, 10)
+	require.True(t, ok)
+	return n
+}
@@ -0,0 +1,144 @@
+package pipeline
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+const (
+	// OutlierFilterMethodMAD drops values further than `threshold` median
+	// absolute deviations away from the median
+	OutlierFilterMethodMAD = "mad"
+	// OutlierFilterMethodDeviation drops values deviating from the median by
+	// more than the fraction `threshold` of the median, e.g. 0.05 for 5%
+	OutlierFilterMethodDeviation = "deviation"
+
+	// DefaultOutlierFilterMADThreshold is the number of median absolute
+	// deviations beyond which a value is an outlier
+	DefaultOutlierFilterMADThreshold = 3
+)
+
+// OutlierFilterTask drops the values that are too far from the median of all
+// the values, so that a single bad source can't skew the aggregate. Outliers
+// count as faults in the same way as errored values: if there are more of them
+// together than allowedFaults, the task fails.
+//
+// The surviving values are returned in their original order, and are meant to
+// be passed on to an aggregation task, e.g. median values="$(filter)".
+//
+// Return types:
+//
+//	[]interface{} (of decimal.Decimal)
+type OutlierFilterTask struct {
+	BaseTask      `mapstructure:",squash"`
+	Values        string `json:"values"`
+	Method        string `json:"method"`
+	Threshold     string `json:"threshold"`
+	AllowedFaults string `json:"allowedFaults"`
+}
+
+var _ Task = (*OutlierFilterTask)(nil)
+
+func (t *OutlierFilterTask) Type() TaskType {
+	return TaskTypeOutlierFilter
+}
+
+func (t *OutlierFilterTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	var (
+		maybeAllowedFaults MaybeUint64Param
+		valuesAndErrs      SliceParam
+		method             StringParam
+		thresholdParam     DecimalParam
+		decimalValues      DecimalSliceParam
+		allowedFaults      int
+	)
+	err := multierr.Combine(
+		errors.Wrap(ResolveParam(&maybeAllowedFaults, From(t.AllowedFaults)), "allowedFaults"),
+		errors.Wrap(ResolveParam(&valuesAndErrs, From(VarExpr(t.Values, vars), JSONWithVarExprs(t.Values, vars, true), Inputs(inputs))), "values"),
+		errors.Wrap(ResolveParam(&method, From(NonemptyString(t.Method), OutlierFilterMethodMAD)), "method"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	switch method {
+	case OutlierFilterMethodMAD:
+		err = ResolveParam(&thresholdParam, From(VarExpr(t.Threshold, vars), NonemptyString(t.Threshold), DefaultOutlierFilterMADThreshold))
+	case OutlierFilterMethodDeviation:
+		err = ResolveParam(&thresholdParam, From(VarExpr(t.Threshold, vars), NonemptyString(t.Threshold)))
+	default:
+		return Result{Error: errors.Wrapf(ErrBadInput, "method: expected %q or %q, got %q", OutlierFilterMethodMAD, OutlierFilterMethodDeviation, method)}, runInfo
+	}
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "threshold")}, runInfo
+	}
+	threshold := thresholdParam.Decimal()
+	if threshold.IsNegative() {
+		return Result{Error: errors.Wrapf(ErrBadInput, "threshold: must not be negative, got %v", threshold)}, runInfo
+	}
+
+	if allowed, isSet := maybeAllowedFaults.Uint64(); isSet {
+		allowedFaults = int(allowed)
+	} else {
+		allowedFaults = len(valuesAndErrs) - 1
+	}
+
+	values, faults := valuesAndErrs.FilterErrors()
+	if faults > allowedFaults {
+		return Result{Error: errors.Wrapf(ErrTooManyErrors, "Number of faulty inputs %v to outlier filter task > number allowed faults %v", faults, allowedFaults)}, runInfo
+	} else if len(values) == 0 {
+		return Result{Error: errors.Wrap(ErrWrongInputCardinality, "values")}, runInfo
+	}
+
+	err = decimalValues.UnmarshalPipelineParam(values)
+	if err != nil {
+		return Result{Error: errors.Wrapf(ErrBadInput, "values: %v", err)}, runInfo
+	}
+
+	median := medianOf(decimalValues)
+	deviations := make([]decimal.Decimal, len(decimalValues))
+	for i, val := range decimalValues {
+		deviations[i] = val.Sub(median).Abs()
+	}
+
+	var maxDeviation decimal.Decimal
+	switch method {
+	case OutlierFilterMethodMAD:
+		maxDeviation = medianOf(deviations).Mul(threshold)
+	case OutlierFilterMethodDeviation:
+		maxDeviation = median.Abs().Mul(threshold)
+	}
+
+	var kept []interface{}
+	for i, val := range decimalValues {
+		if deviations[i].GreaterThan(maxDeviation) {
+			faults++
+			continue
+		}
+		kept = append(kept, val)
+	}
+	if faults > allowedFaults {
+		return Result{Error: errors.Wrapf(ErrTooManyErrors, "Number of faulty inputs and outliers %v to outlier filter task > number allowed faults %v", faults, allowedFaults)}, runInfo
+	}
+	return Result{Value: kept}, runInfo
+}
+
+// medianOf returns the median of values without reordering them
+func medianOf(values []decimal.Decimal) decimal.Decimal {
+	sorted := make([]decimal.Decimal, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+	k := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[k]
+	}
+	return sorted[k].Add(sorted[k-1]).Div(decimal.NewFromInt(2))
+}
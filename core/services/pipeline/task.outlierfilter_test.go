@@ -0,0 +1,176 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestOutlierFilterTask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		inputs        []pipeline.Result
+		method        string
+		threshold     string
+		allowedFaults string
+		want          []string
+		wantErr       error
+	}{
+		{
+			"mad by default",
+			[]pipeline.Result{{Value: "10"}, {Value: "11"}, {Value: "100"}, {Value: "12"}, {Value: "13"}},
+			"",
+			"",
+			"",
+			[]string{"10", "11", "12", "13"},
+			nil,
+		},
+		{
+			"mad with threshold",
+			[]pipeline.Result{{Value: "10"}, {Value: "11"}, {Value: "100"}, {Value: "12"}, {Value: "13"}},
+			"mad",
+			"1",
+			"",
+			[]string{"11", "12", "13"},
+			nil,
+		},
+		{
+			"mad of identical values",
+			[]pipeline.Result{{Value: "5"}, {Value: "5"}, {Value: "5"}},
+			"mad",
+			"",
+			"",
+			[]string{"5", "5", "5"},
+			nil,
+		},
+		{
+			"deviation",
+			[]pipeline.Result{{Value: "100"}, {Value: "102"}, {Value: "97"}, {Value: "120"}},
+			"deviation",
+			"0.05",
+			"",
+			[]string{"100", "102", "97"},
+			nil,
+		},
+		{
+			"outliers count as faults",
+			[]pipeline.Result{{Value: "10"}, {Value: "11"}, {Value: "100"}, {Value: "12"}, {Value: "13"}},
+			"mad",
+			"",
+			"0",
+			nil,
+			pipeline.ErrTooManyErrors,
+		},
+		{
+			"errors and outliers together exceed threshold",
+			[]pipeline.Result{{Error: errors.New("")}, {Value: "10"}, {Value: "11"}, {Value: "12"}, {Value: "100"}},
+			"mad",
+			"",
+			"1",
+			nil,
+			pipeline.ErrTooManyErrors,
+		},
+		{
+			"errors and outliers within threshold",
+			[]pipeline.Result{{Error: errors.New("")}, {Value: "10"}, {Value: "11"}, {Value: "12"}, {Value: "100"}},
+			"mad",
+			"",
+			"2",
+			[]string{"10", "11", "12"},
+			nil,
+		},
+		{
+			"zero inputs",
+			[]pipeline.Result{},
+			"mad",
+			"",
+			"0",
+			nil,
+			pipeline.ErrWrongInputCardinality,
+		},
+		{
+			"deviation requires threshold",
+			[]pipeline.Result{{Value: "1"}},
+			"deviation",
+			"",
+			"",
+			nil,
+			pipeline.ErrParameterEmpty,
+		},
+		{
+			"negative threshold",
+			[]pipeline.Result{{Value: "1"}},
+			"deviation",
+			"-0.1",
+			"",
+			nil,
+			pipeline.ErrBadInput,
+		},
+		{
+			"unknown method",
+			[]pipeline.Result{{Value: "1"}},
+			"stddev",
+			"",
+			"",
+			nil,
+			pipeline.ErrBadInput,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := pipeline.OutlierFilterTask{
+				BaseTask:      pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Method:        test.method,
+				Threshold:     test.threshold,
+				AllowedFaults: test.allowedFaults,
+			}
+			output, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), test.inputs)
+			assert.False(t, runInfo.IsPending)
+			assert.False(t, runInfo.IsRetryable)
+			if test.wantErr != nil {
+				require.Equal(t, test.wantErr, errors.Cause(output.Error))
+				require.Nil(t, output.Value)
+				return
+			}
+			require.NoError(t, output.Error)
+			var got []string
+			for _, v := range output.Value.([]interface{}) {
+				got = append(got, v.(decimal.Decimal).String())
+			}
+			require.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("feeds median", func(t *testing.T) {
+		p, err := pipeline.Parse(`
+			filter [type=outlierfilter values=<[ 10, 11, 100, 12, 13 ]>];
+			median [type=median values="$(filter)"];
+			filter -> median;
+		`)
+		require.NoError(t, err)
+
+		vars := pipeline.NewVarsFrom(nil)
+		filter := p.Tasks[0].(*pipeline.OutlierFilterTask)
+		filtered, _ := filter.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+		require.NoError(t, filtered.Error)
+		require.NoError(t, vars.Set("filter", filtered.Value))
+
+		median := p.Tasks[1].(*pipeline.MedianTask)
+		output, _ := median.Run(testutils.Context(t), logger.TestLogger(t), vars, []pipeline.Result{filtered})
+		require.NoError(t, output.Error)
+		require.Equal(t, "11.5", output.Value.(decimal.Decimal).String())
+	})
+}
@@ -0,0 +1,95 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// MaxPreviousRunsCount caps the number of runs a previousruns task may read
+const MaxPreviousRunsCount = 1000
+
+// PreviousRunsTask returns what earlier runs of the same job computed, so
+// that a pipeline can smooth its results, compute a TWAP or guard against
+// sudden changes. It returns the outputs of the `count` most recently
+// completed runs, most recent first, which can be passed on to an aggregation
+// task, e.g. mean values="$(previous)".
+//
+// By default the run outputs at `runOutputIndex` are returned. If `task` is set,
+// the outputs of that task are returned instead; note that task outputs are
+// only available if the job saves the task runs of successful runs, which
+// e.g. OCR jobs do not.
+//
+// Runs whose output is null, and runs that were pruned (see
+// JobPipeline.MaxSuccessfulRuns), are skipped, so fewer than `count` values
+// may be returned.
+//
+// Return types:
+//
+//	[]interface{}
+type PreviousRunsTask struct {
+	BaseTask       `mapstructure:",squash"`
+	Count          string `json:"count"`
+	RunOutputIndex string `json:"runOutputIndex"`
+	Task           string `json:"task"`
+
+	orm    ORM
+	specID int32
+}
+
+var _ Task = (*PreviousRunsTask)(nil)
+
+func (t *PreviousRunsTask) Type() TaskType {
+	return TaskTypePreviousRuns
+}
+
+func (t *PreviousRunsTask) Run(ctx context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, -1, -1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var (
+		count       Uint64Param
+		outputIndex Uint64Param
+		taskDotID   StringParam
+	)
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&count, From(VarExpr(t.Count, vars), NonemptyString(t.Count))), "count"),
+		errors.Wrap(ResolveParam(&outputIndex, From(VarExpr(t.RunOutputIndex, vars), NonemptyString(t.RunOutputIndex), 0)), "runOutputIndex"),
+		errors.Wrap(ResolveParam(&taskDotID, From(NonemptyString(t.Task), "")), "task"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	if count == 0 || count > MaxPreviousRunsCount {
+		return Result{Error: errors.Wrapf(ErrBadInput, "count: must be between 1 and %v, got %v", MaxPreviousRunsCount, count)}, runInfo
+	}
+
+	if t.specID == 0 {
+		// The pipeline does not belong to a saved spec, e.g. it is being
+		// simulated, so there are no previous runs
+		return Result{Value: []interface{}{}}, runInfo
+	}
+
+	var outputs []JSONSerializable
+	if taskDotID != "" {
+		outputs, err = t.orm.GetRecentTaskRunOutputs(ctx, t.specID, string(taskDotID), int(count))
+	} else {
+		outputs, err = t.orm.GetRecentRunOutputs(ctx, t.specID, int(outputIndex), int(count))
+	}
+	if err != nil {
+		return Result{Error: err}, retryableRunInfo()
+	}
+
+	values := make([]interface{}, 0, len(outputs))
+	for _, output := range outputs {
+		if output.Valid && output.Val != nil {
+			values = append(values, output.Val)
+		}
+	}
+	return Result{Value: values}, runInfo
+}
@@ -0,0 +1,167 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline/mocks"
+)
+
+func TestPreviousRunsTask(t *testing.T) {
+	t.Parallel()
+
+	const specID = int32(42)
+	outputs := []pipeline.JSONSerializable{
+		{Val: "1.5", Valid: true},
+		{},
+		{Val: int64(2), Valid: true},
+	}
+
+	tests := []struct {
+		name           string
+		count          string
+		runOutputIndex string
+		task           string
+		vars           pipeline.Vars
+		setupORM       func(orm *mocks.ORM)
+		want           []interface{}
+		wantErr        error
+		wantRetryable  bool
+	}{
+		{
+			"run outputs",
+			"3",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			func(orm *mocks.ORM) {
+				orm.On("GetRecentRunOutputs", mock.Anything, specID, 0, 3).Return(outputs, nil)
+			},
+			[]interface{}{"1.5", int64(2)},
+			nil,
+			false,
+		},
+		{
+			"run outputs at index",
+			"$(n)",
+			"$(i)",
+			"",
+			pipeline.NewVarsFrom(map[string]interface{}{"n": 5, "i": 1}),
+			func(orm *mocks.ORM) {
+				orm.On("GetRecentRunOutputs", mock.Anything, specID, 1, 5).Return(nil, nil)
+			},
+			[]interface{}{},
+			nil,
+			false,
+		},
+		{
+			"task outputs",
+			"10",
+			"",
+			"ds1_parse",
+			pipeline.NewVarsFrom(nil),
+			func(orm *mocks.ORM) {
+				orm.On("GetRecentTaskRunOutputs", mock.Anything, specID, "ds1_parse", 10).Return(outputs, nil)
+			},
+			[]interface{}{"1.5", int64(2)},
+			nil,
+			false,
+		},
+		{
+			"database error",
+			"10",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			func(orm *mocks.ORM) {
+				orm.On("GetRecentRunOutputs", mock.Anything, specID, 0, 10).Return(nil, errors.New("connection reset"))
+			},
+			nil,
+			nil,
+			true,
+		},
+		{
+			"missing count",
+			"",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			func(orm *mocks.ORM) {},
+			nil,
+			pipeline.ErrParameterEmpty,
+			false,
+		},
+		{
+			"zero count",
+			"0",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			func(orm *mocks.ORM) {},
+			nil,
+			pipeline.ErrBadInput,
+			false,
+		},
+		{
+			"count too large",
+			"1001",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			func(orm *mocks.ORM) {},
+			nil,
+			pipeline.ErrBadInput,
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			orm := mocks.NewORM(t)
+			test.setupORM(orm)
+
+			task := pipeline.PreviousRunsTask{
+				BaseTask:       pipeline.NewBaseTask(0, "previous", nil, nil, 0),
+				Count:          test.count,
+				RunOutputIndex: test.runOutputIndex,
+				Task:           test.task,
+			}
+			task.HelperSetDependencies(orm, specID)
+
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), test.vars, nil)
+			assert.False(t, runInfo.IsPending)
+			assert.Equal(t, test.wantRetryable, runInfo.IsRetryable)
+			switch {
+			case test.wantRetryable:
+				require.Error(t, result.Error)
+			case test.wantErr != nil:
+				require.Equal(t, test.wantErr, errors.Cause(result.Error))
+			default:
+				require.NoError(t, result.Error)
+				require.Equal(t, test.want, result.Value)
+			}
+		})
+	}
+
+	t.Run("without a saved spec", func(t *testing.T) {
+		task := pipeline.PreviousRunsTask{
+			BaseTask: pipeline.NewBaseTask(0, "previous", nil, nil, 0),
+			Count:    "3",
+		}
+		task.HelperSetDependencies(mocks.NewORM(t), 0)
+
+		result, _ := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+		require.NoError(t, result.Error)
+		require.Equal(t, []interface{}{}, result.Value)
+	})
+}
@@ -0,0 +1,124 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// ScriptTask runs a small deterministic script (see script.go) so that
+// per-source normalization logic can live in the job spec instead of an
+// external adapter.
+//
+// The script sees three globals:
+//
+//	inputs  the upstream task outputs, in input order (null for errored inputs)
+//	params  the decoded params attribute, which may contain $(var) expressions
+//	vars    all pipeline variables, keyed by task dot ID
+//
+// and its result is the value passed to `return`.
+//
+// Return types:
+//
+//	int64, *big.Int, float64
+//	string
+//	bool
+//	map[string]interface{}
+//	[]interface{}
+//	nil
+type ScriptTask struct {
+	BaseTask      `mapstructure:",squash"`
+	Script        string `json:"script"`
+	Params        string `json:"params"`
+	AllowedFaults string `json:"allowedFaults"`
+	MaxSteps      string `json:"maxSteps"`
+	MaxMemory     string `json:"maxMemory"`
+}
+
+var _ Task = (*ScriptTask)(nil)
+
+func (t *ScriptTask) Type() TaskType {
+	return TaskTypeScript
+}
+
+func (t *ScriptTask) Run(ctx context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	var (
+		script        StringParam
+		params        jqInputParam
+		allowedFaults Uint64Param
+		maxSteps      Uint64Param
+		maxMemory     Uint64Param
+	)
+	err := multierr.Combine(
+		errors.Wrap(ResolveParam(&script, From(NonemptyString(t.Script))), "script"),
+		errors.Wrap(ResolveParam(&params, From(VarExpr(t.Params, vars), JSONWithVarExprs(t.Params, vars, false), nil)), "params"),
+		errors.Wrap(ResolveParam(&allowedFaults, From(NonemptyString(t.AllowedFaults), 0)), "allowedFaults"),
+		errors.Wrap(ResolveParam(&maxSteps, From(NonemptyString(t.MaxSteps), DefaultScriptMaxSteps)), "maxSteps"),
+		errors.Wrap(ResolveParam(&maxMemory, From(NonemptyString(t.MaxMemory), DefaultScriptMaxMemory)), "maxMemory"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	if maxSteps > MaxScriptMaxSteps {
+		return Result{Error: errors.Wrapf(ErrBadInput, "maxSteps must be at most %d, got %d", MaxScriptMaxSteps, maxSteps)}, runInfo
+	}
+	if maxMemory > MaxScriptMaxMemory {
+		return Result{Error: errors.Wrapf(ErrBadInput, "maxMemory must be at most %d, got %d", MaxScriptMaxMemory, maxMemory)}, runInfo
+	}
+
+	if _, err = CheckInputs(inputs, -1, -1, int(allowedFaults)); err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	prog, err := parseScript(string(script))
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "script")}, runInfo
+	}
+
+	globals, err := scriptGlobals(vars, inputs, params.val)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	value, err := prog.run(ctx, globals, int(maxSteps), int(maxMemory))
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	return Result{Value: value}, runInfo
+}
+
+func scriptGlobals(vars Vars, inputs []Result, params interface{}) (map[string]interface{}, error) {
+	inputVals := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		if input.Error != nil {
+			continue
+		}
+		v, err := jqNormalize(input.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "input %d", i)
+		}
+		inputVals[i] = v
+	}
+
+	varVals := make(map[string]interface{}, len(vars.vars))
+	for k, value := range vars.vars {
+		// errored task results are not visible to the script
+		if _, isErr := value.(error); isErr {
+			continue
+		}
+		v, err := jqNormalize(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "var %s", k)
+		}
+		varVals[k] = v
+	}
+
+	return map[string]interface{}{
+		"inputs": inputVals,
+		"params": params,
+		"vars":   varVals,
+	}, nil
+}
@@ -0,0 +1,250 @@
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestScriptTask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		script         string
+		params         string
+		allowedFaults  string
+		maxSteps       string
+		maxMemory      string
+		vars           pipeline.Vars
+		inputs         []pipeline.Result
+		wantData       interface{}
+		wantErrorCause error
+	}{
+		{
+			"normalize and medianize sources",
+			`
+			let prices = [];
+			for p in inputs {
+				if p == null { continue; }
+				if type(p) == "string" { p = number(p); }
+				# source reports in cents
+				if p > 100000 { p = p / 100; }
+				prices = append(prices, p);
+			}
+			return median(prices);
+			`,
+			"",
+			"1",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: "1850.5"}, {Value: 185100}, {Value: mustDecimal(t, "1850.25")}, {Error: errors.New("timeout")}},
+			1850.5,
+			nil,
+		},
+		{
+			"params with var expressions",
+			`return params.base * params.multiplier;`,
+			`{"base": $(foo.bar), "multiplier": 3}`,
+			"",
+			"",
+			"",
+			pipeline.NewVarsFrom(map[string]interface{}{"foo": map[string]interface{}{"bar": 14}}),
+			nil,
+			int64(42),
+			nil,
+		},
+		{
+			"reads vars",
+			`if vars.ds1.volume > 0 { return vars.ds1.price; } return null;`,
+			"",
+			"",
+			"",
+			"",
+			pipeline.NewVarsFrom(map[string]interface{}{"ds1": map[string]interface{}{"price": "12.5", "volume": 10}}),
+			nil,
+			"12.5",
+			nil,
+		},
+		{
+			"errored vars are hidden",
+			`return has(vars, "ds1");`,
+			"",
+			"",
+			"",
+			"",
+			pipeline.NewVarsFrom(map[string]interface{}{"ds1": errors.New("boom")}),
+			nil,
+			false,
+			nil,
+		},
+		{
+			"builds objects",
+			`let o = {prices: [1, 2]}; o.prices[1] = 3; o.count = len(o.prices); return o;`,
+			"",
+			"",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			nil,
+			map[string]interface{}{"prices": []interface{}{int64(1), int64(3)}, "count": int64(2)},
+			nil,
+		},
+		{
+			"too many input errors",
+			`return 1;`,
+			"",
+			"0",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Error: errors.New("foo")}},
+			nil,
+			pipeline.ErrTooManyErrors,
+		},
+		{
+			"syntax error",
+			`let x = ;`,
+			"",
+			"",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			nil,
+			nil,
+			pipeline.ErrScriptSyntax,
+		},
+		{
+			"runtime error",
+			`return "a" * 2;`,
+			"",
+			"",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			nil,
+			nil,
+			pipeline.ErrScriptRuntime,
+		},
+		{
+			"explicit error",
+			`if len(inputs) == 0 { error("no inputs"); }`,
+			"",
+			"",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			nil,
+			nil,
+			pipeline.ErrScriptRuntime,
+		},
+		{
+			"step limit",
+			`let i = 0; while true { i = i + 1; }`,
+			"",
+			"",
+			"1000",
+			"",
+			pipeline.NewVarsFrom(nil),
+			nil,
+			nil,
+			pipeline.ErrScriptStepsExhausted,
+		},
+		{
+			"memory limit",
+			`let s = "0123456789"; while true { s = s + s; }`,
+			"",
+			"",
+			"",
+			"4096",
+			pipeline.NewVarsFrom(nil),
+			nil,
+			nil,
+			pipeline.ErrScriptMemoryExceeded,
+		},
+		{
+			"bad maxSteps",
+			`return 1;`,
+			"",
+			"",
+			"-1",
+			"",
+			pipeline.NewVarsFrom(nil),
+			nil,
+			nil,
+			pipeline.ErrBadInput,
+		},
+		{
+			"maxSteps above the node maximum",
+			`return 1;`,
+			"",
+			"",
+			"10000001",
+			"",
+			pipeline.NewVarsFrom(nil),
+			nil,
+			nil,
+			pipeline.ErrBadInput,
+		},
+		{
+			"maxMemory above the node maximum",
+			`return 1;`,
+			"",
+			"",
+			"",
+			"67108865",
+			pipeline.NewVarsFrom(nil),
+			nil,
+			nil,
+			pipeline.ErrBadInput,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			task := pipeline.ScriptTask{
+				BaseTask:      pipeline.NewBaseTask(0, "script", nil, nil, 0),
+				Script:        test.script,
+				Params:        test.params,
+				AllowedFaults: test.allowedFaults,
+				MaxSteps:      test.maxSteps,
+				MaxMemory:     test.maxMemory,
+			}
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), test.vars, test.inputs)
+			assert.False(t, runInfo.IsPending)
+			assert.False(t, runInfo.IsRetryable)
+
+			if test.wantErrorCause != nil {
+				require.Equal(t, test.wantErrorCause, errors.Cause(result.Error))
+				require.Nil(t, result.Value)
+			} else {
+				require.NoError(t, result.Error)
+				require.Equal(t, test.wantData, result.Value)
+			}
+		})
+	}
+}
+
+func TestScriptTask_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	task := pipeline.ScriptTask{
+		BaseTask: pipeline.NewBaseTask(0, "script", nil, nil, 0),
+		Script:   `let i = 0; while true { i = i + 1; }`,
+		MaxSteps: "10000000",
+	}
+	ctx, cancel := context.WithCancel(testutils.Context(t))
+	cancel()
+	result, _ := task.Run(ctx, logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+	require.Equal(t, context.Canceled, errors.Cause(result.Error))
+	require.Nil(t, result.Value)
+}
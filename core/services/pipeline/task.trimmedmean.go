@@ -0,0 +1,115 @@
+package pipeline
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// TrimmedMeanTask sorts its values, drops the lowest and the highest `trim`
+// fraction of them, and returns the mean of the rest. E.g. with trim="0.2" and
+// 10 values, the 2 lowest and 2 highest values are dropped.
+//
+// Return types:
+//
+//	decimal.Decimal
+type TrimmedMeanTask struct {
+	BaseTask      `mapstructure:",squash"`
+	Values        string `json:"values"`
+	Trim          string `json:"trim"`
+	AllowedFaults string `json:"allowedFaults"`
+	Precision     string `json:"precision"`
+}
+
+var _ Task = (*TrimmedMeanTask)(nil)
+
+func (t *TrimmedMeanTask) Type() TaskType {
+	return TaskTypeTrimmedMean
+}
+
+func (t *TrimmedMeanTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	value, err := trimmedMean(vars, inputs, t.Values, t.Trim, t.AllowedFaults, t.Precision, false)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	return Result{Value: value}, runInfo
+}
+
+// trimmedMean implements both the trimmedmean and the winsorize tasks, which
+// only differ in whether the values beyond the cut are dropped or clamped.
+func trimmedMean(vars Vars, inputs []Result, valuesAttr, trimAttr, allowedFaultsAttr, precisionAttr string, winsorize bool) (decimal.Decimal, error) {
+	var (
+		maybeAllowedFaults MaybeUint64Param
+		maybePrecision     MaybeInt32Param
+		valuesAndErrs      SliceParam
+		trim               DecimalParam
+		decimalValues      DecimalSliceParam
+		allowedFaults      int
+	)
+	err := multierr.Combine(
+		errors.Wrap(ResolveParam(&maybeAllowedFaults, From(allowedFaultsAttr)), "allowedFaults"),
+		errors.Wrap(ResolveParam(&maybePrecision, From(VarExpr(precisionAttr, vars), precisionAttr)), "precision"),
+		errors.Wrap(ResolveParam(&valuesAndErrs, From(VarExpr(valuesAttr, vars), JSONWithVarExprs(valuesAttr, vars, true), Inputs(inputs))), "values"),
+		errors.Wrap(ResolveParam(&trim, From(VarExpr(trimAttr, vars), NonemptyString(trimAttr))), "trim"),
+	)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	if trim.Decimal().IsNegative() || trim.Decimal().GreaterThanOrEqual(decimal.NewFromFloat(0.5)) {
+		return decimal.Decimal{}, errors.Wrapf(ErrBadInput, "trim: must be at least 0 and less than 0.5, got %v", trim.Decimal())
+	}
+
+	if allowed, isSet := maybeAllowedFaults.Uint64(); isSet {
+		allowedFaults = int(allowed)
+	} else {
+		allowedFaults = len(valuesAndErrs) - 1
+	}
+
+	taskType := TaskTypeTrimmedMean
+	if winsorize {
+		taskType = TaskTypeWinsorize
+	}
+	values, faults := valuesAndErrs.FilterErrors()
+	if faults > allowedFaults {
+		return decimal.Decimal{}, errors.Wrapf(ErrTooManyErrors, "Number of faulty inputs %v to %v task > number allowed faults %v", faults, taskType, allowedFaults)
+	} else if len(values) == 0 {
+		return decimal.Decimal{}, errors.Wrap(ErrWrongInputCardinality, "values")
+	}
+
+	err = decimalValues.UnmarshalPipelineParam(values)
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrapf(ErrBadInput, "values: %v", err)
+	}
+
+	sort.Slice(decimalValues, func(i, j int) bool {
+		return decimalValues[i].LessThan(decimalValues[j])
+	})
+
+	n := len(decimalValues)
+	// trim < 0.5, so at least one value is always kept
+	k := int(decimal.NewFromInt(int64(n)).Mul(trim.Decimal()).IntPart())
+	kept := decimalValues[k : n-k]
+
+	total := decimal.NewFromInt(0)
+	for _, val := range kept {
+		total = total.Add(val)
+	}
+	numValues := decimal.NewFromInt(int64(len(kept)))
+	if winsorize {
+		// the cut values count as the nearest kept value
+		total = total.Add(kept[0].Mul(decimal.NewFromInt(int64(k))))
+		total = total.Add(kept[len(kept)-1].Mul(decimal.NewFromInt(int64(k))))
+		numValues = decimal.NewFromInt(int64(n))
+	}
+
+	if precision, isSet := maybePrecision.Int32(); isSet {
+		return total.DivRound(numValues, precision), nil
+	}
+	return total.Div(numValues), nil
+}
@@ -0,0 +1,134 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestTrimmedMeanTask(t *testing.T) {
+	t.Parallel()
+
+	skewed := []pipeline.Result{{Value: mustDecimal(t, "5")}, {Value: mustDecimal(t, "1")}, {Value: mustDecimal(t, "100")}, {Value: mustDecimal(t, "3")}, {Value: mustDecimal(t, "2")}}
+
+	tests := []struct {
+		name          string
+		inputs        []pipeline.Result
+		trim          string
+		allowedFaults string
+		precision     string
+		want          pipeline.Result
+	}{
+		{
+			"trims both ends",
+			skewed,
+			"0.2",
+			"",
+			"2",
+			pipeline.Result{Value: mustDecimal(t, "3.33")},
+		},
+		{
+			"rounds the number of trimmed values down",
+			skewed,
+			"0.1",
+			"",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "22.2")},
+		},
+		{
+			"no trim",
+			skewed,
+			"0",
+			"",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "22.2")},
+		},
+		{
+			"keeps the middle value",
+			skewed,
+			"0.49",
+			"",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "3")},
+		},
+		{
+			"errors are dropped before trimming",
+			[]pipeline.Result{{Error: errors.New("")}, {Value: mustDecimal(t, "1")}, {Value: mustDecimal(t, "2")}, {Value: mustDecimal(t, "3")}, {Value: mustDecimal(t, "40")}},
+			"0.25",
+			"1",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "2.5")},
+		},
+		{
+			"more errors than threshold",
+			[]pipeline.Result{{Error: errors.New("")}, {Error: errors.New("")}, {Value: mustDecimal(t, "3")}},
+			"0.25",
+			"1",
+			"",
+			pipeline.Result{Error: pipeline.ErrTooManyErrors},
+		},
+		{
+			"zero inputs",
+			[]pipeline.Result{},
+			"0.25",
+			"0",
+			"",
+			pipeline.Result{Error: pipeline.ErrWrongInputCardinality},
+		},
+		{
+			"trim too large",
+			skewed,
+			"0.5",
+			"",
+			"",
+			pipeline.Result{Error: pipeline.ErrBadInput},
+		},
+		{
+			"negative trim",
+			skewed,
+			"-0.1",
+			"",
+			"",
+			pipeline.Result{Error: pipeline.ErrBadInput},
+		},
+		{
+			"missing trim",
+			skewed,
+			"",
+			"",
+			"",
+			pipeline.Result{Error: pipeline.ErrParameterEmpty},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := pipeline.TrimmedMeanTask{
+				BaseTask:      pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Trim:          test.trim,
+				AllowedFaults: test.allowedFaults,
+				Precision:     test.precision,
+			}
+			output, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), test.inputs)
+			assert.False(t, runInfo.IsPending)
+			assert.False(t, runInfo.IsRetryable)
+			if test.want.Error != nil {
+				require.Equal(t, test.want.Error, errors.Cause(output.Error))
+				require.Nil(t, output.Value)
+			} else {
+				require.NoError(t, output.Error)
+				require.Equal(t, test.want.Value.(*decimal.Decimal).String(), output.Value.(decimal.Decimal).String())
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package pipeline
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// WeightedMedianTask returns the weighted median of its values, where
+// weights[i] is the weight of values[i] (or of the i-th input). The weights of
+// errored values are dropped along with them. If the weights below a value sum
+// to exactly half of the total, the result is the mean of that value and the
+// next one.
+//
+// Return types:
+//
+//	decimal.Decimal
+type WeightedMedianTask struct {
+	BaseTask      `mapstructure:",squash"`
+	Values        string `json:"values"`
+	Weights       string `json:"weights"`
+	AllowedFaults string `json:"allowedFaults"`
+}
+
+var _ Task = (*WeightedMedianTask)(nil)
+
+func (t *WeightedMedianTask) Type() TaskType {
+	return TaskTypeWeightedMedian
+}
+
+func (t *WeightedMedianTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	var (
+		maybeAllowedFaults MaybeUint64Param
+		valuesAndErrs      SliceParam
+		weights            DecimalSliceParam
+		allowedFaults      int
+	)
+	err := multierr.Combine(
+		errors.Wrap(ResolveParam(&maybeAllowedFaults, From(t.AllowedFaults)), "allowedFaults"),
+		errors.Wrap(ResolveParam(&valuesAndErrs, From(VarExpr(t.Values, vars), JSONWithVarExprs(t.Values, vars, true), Inputs(inputs))), "values"),
+		errors.Wrap(ResolveParam(&weights, From(VarExpr(t.Weights, vars), JSONWithVarExprs(t.Weights, vars, false))), "weights"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	if len(weights) != len(valuesAndErrs) {
+		return Result{Error: errors.Wrapf(ErrBadInput, "weights: expected %v weights, one per value, got %v", len(valuesAndErrs), len(weights))}, runInfo
+	}
+
+	if allowed, isSet := maybeAllowedFaults.Uint64(); isSet {
+		allowedFaults = int(allowed)
+	} else {
+		allowedFaults = len(valuesAndErrs) - 1
+	}
+
+	type weightedValue struct {
+		value  decimal.Decimal
+		weight decimal.Decimal
+	}
+	var (
+		weighted []weightedValue
+		faults   int
+		total    = decimal.Zero
+	)
+	for i, x := range valuesAndErrs {
+		if _, is := x.(error); is {
+			faults++
+			continue
+		}
+		var d DecimalParam
+		if err = d.UnmarshalPipelineParam(x); err != nil {
+			return Result{Error: errors.Wrapf(ErrBadInput, "values: %v", err)}, runInfo
+		}
+		if weights[i].IsNegative() {
+			return Result{Error: errors.Wrapf(ErrBadInput, "weights: weight %v of value %v is negative", weights[i], i)}, runInfo
+		}
+		weighted = append(weighted, weightedValue{d.Decimal(), weights[i]})
+		total = total.Add(weights[i])
+	}
+	if faults > allowedFaults {
+		return Result{Error: errors.Wrapf(ErrTooManyErrors, "Number of faulty inputs %v to weighted median task > number allowed faults %v", faults, allowedFaults)}, runInfo
+	} else if len(weighted) == 0 {
+		return Result{Error: errors.Wrap(ErrWrongInputCardinality, "no values to medianize")}, runInfo
+	} else if !total.IsPositive() {
+		return Result{Error: errors.Wrap(ErrBadInput, "weights: the weights of the values must sum to a positive number")}, runInfo
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].value.LessThan(weighted[j].value)
+	})
+
+	half := total.Div(decimal.NewFromInt(2))
+	cumulative := decimal.Zero
+	for i, wv := range weighted {
+		cumulative = cumulative.Add(wv.weight)
+		if cumulative.LessThan(half) {
+			continue
+		}
+		if cumulative.GreaterThan(half) {
+			return Result{Value: wv.value}, runInfo
+		}
+		// Exactly half of the weight is at or below this value, so the
+		// median lies between it and the next value that has any weight
+		for _, next := range weighted[i+1:] {
+			if next.weight.IsPositive() {
+				return Result{Value: wv.value.Add(next.value).Div(decimal.NewFromInt(2))}, runInfo
+			}
+		}
+		return Result{Value: wv.value}, runInfo
+	}
+	// unreachable, the cumulative weight always reaches the total
+	return Result{Value: weighted[len(weighted)-1].value}, runInfo
+}
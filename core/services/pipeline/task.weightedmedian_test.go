@@ -0,0 +1,142 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestWeightedMedianTask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		inputs        []pipeline.Result
+		weights       string
+		allowedFaults string
+		want          pipeline.Result
+	}{
+		{
+			"equal weights, odd number of inputs",
+			[]pipeline.Result{{Value: mustDecimal(t, "1")}, {Value: mustDecimal(t, "2")}, {Value: mustDecimal(t, "3")}},
+			"[1, 1, 1]",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "2")},
+		},
+		{
+			"equal weights, even number of inputs",
+			[]pipeline.Result{{Value: mustDecimal(t, "1")}, {Value: mustDecimal(t, "2")}, {Value: mustDecimal(t, "3")}, {Value: mustDecimal(t, "4")}},
+			"[1, 1, 1, 1]",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "2.5")},
+		},
+		{
+			"heavy input dominates",
+			[]pipeline.Result{{Value: mustDecimal(t, "3")}, {Value: mustDecimal(t, "1")}, {Value: mustDecimal(t, "2")}},
+			"[5, 1, 1]",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "3")},
+		},
+		{
+			"fractional weights",
+			[]pipeline.Result{{Value: mustDecimal(t, "10")}, {Value: mustDecimal(t, "20")}, {Value: mustDecimal(t, "30")}},
+			"[0.2, 0.3, 0.5]",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "25")},
+		},
+		{
+			"zero weights are skipped when averaging",
+			[]pipeline.Result{{Value: mustDecimal(t, "1")}, {Value: mustDecimal(t, "2")}, {Value: mustDecimal(t, "3")}, {Value: mustDecimal(t, "4")}},
+			"[1, 1, 0, 2]",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "3")},
+		},
+		{
+			"errored inputs drop their weight",
+			[]pipeline.Result{{Error: errors.New("")}, {Value: mustDecimal(t, "2")}, {Value: mustDecimal(t, "3")}},
+			"[10, 1, 2]",
+			"1",
+			pipeline.Result{Value: mustDecimal(t, "3")},
+		},
+		{
+			"more errors than threshold",
+			[]pipeline.Result{{Error: errors.New("")}, {Error: errors.New("")}, {Value: mustDecimal(t, "3")}},
+			"[1, 1, 1]",
+			"1",
+			pipeline.Result{Error: pipeline.ErrTooManyErrors},
+		},
+		{
+			"zero inputs",
+			[]pipeline.Result{},
+			"[]",
+			"0",
+			pipeline.Result{Error: pipeline.ErrWrongInputCardinality},
+		},
+		{
+			"wrong number of weights",
+			[]pipeline.Result{{Value: mustDecimal(t, "1")}, {Value: mustDecimal(t, "2")}},
+			"[1, 1, 1]",
+			"",
+			pipeline.Result{Error: pipeline.ErrBadInput},
+		},
+		{
+			"negative weight",
+			[]pipeline.Result{{Value: mustDecimal(t, "1")}, {Value: mustDecimal(t, "2")}},
+			"[1, -1]",
+			"",
+			pipeline.Result{Error: pipeline.ErrBadInput},
+		},
+		{
+			"all weights zero",
+			[]pipeline.Result{{Value: mustDecimal(t, "1")}, {Value: mustDecimal(t, "2")}},
+			"[0, 0]",
+			"",
+			pipeline.Result{Error: pipeline.ErrBadInput},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := pipeline.WeightedMedianTask{
+				BaseTask:      pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Weights:       test.weights,
+				AllowedFaults: test.allowedFaults,
+			}
+			output, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), test.inputs)
+			assert.False(t, runInfo.IsPending)
+			assert.False(t, runInfo.IsRetryable)
+			if test.want.Error != nil {
+				require.Equal(t, test.want.Error, errors.Cause(output.Error))
+				require.Nil(t, output.Value)
+			} else {
+				require.NoError(t, output.Error)
+				require.Equal(t, test.want.Value.(*decimal.Decimal).String(), output.Value.(decimal.Decimal).String())
+			}
+		})
+	}
+
+	t.Run("with vars", func(t *testing.T) {
+		vars := pipeline.NewVarsFrom(map[string]interface{}{
+			"foo": map[string]interface{}{"bar": []interface{}{"1", "2", "3"}},
+			"w":   map[string]interface{}{"eth": 1, "btc": 1, "link": 5},
+		})
+		task := pipeline.WeightedMedianTask{
+			BaseTask: pipeline.NewBaseTask(0, "task", nil, nil, 0),
+			Values:   "$(foo.bar)",
+			Weights:  "[ $(w.eth), $(w.btc), $(w.link) ]",
+		}
+		output, _ := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+		require.NoError(t, output.Error)
+		require.Equal(t, "3", output.Value.(decimal.Decimal).String())
+	})
+}
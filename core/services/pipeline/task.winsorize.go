@@ -0,0 +1,36 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// WinsorizeTask returns the winsorized mean of its values: like trimmedmean,
+// but rather than being dropped, the lowest and highest `trim` fraction of
+// the values are replaced by the lowest and highest remaining value.
+//
+// Return types:
+//
+//	decimal.Decimal
+type WinsorizeTask struct {
+	BaseTask      `mapstructure:",squash"`
+	Values        string `json:"values"`
+	Trim          string `json:"trim"`
+	AllowedFaults string `json:"allowedFaults"`
+	Precision     string `json:"precision"`
+}
+
+var _ Task = (*WinsorizeTask)(nil)
+
+func (t *WinsorizeTask) Type() TaskType {
+	return TaskTypeWinsorize
+}
+
+func (t *WinsorizeTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	value, err := trimmedMean(vars, inputs, t.Values, t.Trim, t.AllowedFaults, t.Precision, true)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	return Result{Value: value}, runInfo
+}
@@ -0,0 +1,95 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+)
+
+func TestWinsorizeTask(t *testing.T) {
+	t.Parallel()
+
+	skewed := []interface{}{"5", "1", "100", "3", "2"}
+
+	tests := []struct {
+		name          string
+		values        []interface{}
+		trim          string
+		allowedFaults string
+		want          pipeline.Result
+	}{
+		{
+			"clamps both ends",
+			skewed,
+			"0.2",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "3.4")},
+		},
+		{
+			"clamps to the middle value",
+			skewed,
+			"0.4",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "3")},
+		},
+		{
+			"no trim",
+			skewed,
+			"0",
+			"",
+			pipeline.Result{Value: mustDecimal(t, "22.2")},
+		},
+		{
+			"errors are dropped before clamping",
+			[]interface{}{errors.New(""), "1", "2", "3", "40"},
+			"0.25",
+			"1",
+			pipeline.Result{Value: mustDecimal(t, "2.5")},
+		},
+		{
+			"more errors than threshold",
+			[]interface{}{errors.New(""), errors.New(""), "3"},
+			"0.25",
+			"1",
+			pipeline.Result{Error: pipeline.ErrTooManyErrors},
+		},
+		{
+			"trim too large",
+			skewed,
+			"0.5",
+			"",
+			pipeline.Result{Error: pipeline.ErrBadInput},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			vars := pipeline.NewVarsFrom(map[string]interface{}{
+				"foo": map[string]interface{}{"bar": test.values},
+			})
+			task := pipeline.WinsorizeTask{
+				BaseTask:      pipeline.NewBaseTask(0, "task", nil, nil, 0),
+				Values:        "$(foo.bar)",
+				Trim:          test.trim,
+				AllowedFaults: test.allowedFaults,
+			}
+			output, _ := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+			if test.want.Error != nil {
+				require.Equal(t, test.want.Error, errors.Cause(output.Error))
+				require.Nil(t, output.Value)
+			} else {
+				require.NoError(t, output.Error)
+				require.Equal(t, test.want.Value.(*decimal.Decimal).String(), output.Value.(decimal.Decimal).String())
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+const (
+	// DefaultTaskResultCacheSize is the number of task results kept in memory
+	DefaultTaskResultCacheSize = 1000
+	// MaxCacheTTL caps the cacheTTL of a task. Cached results older than this
+	// are pruned from the database by the run reaper.
+	MaxCacheTTL = 24 * time.Hour
+)
+
+const (
+	// CacheStoreMemory keeps cached results in memory only, so they are lost
+	// when the node restarts. This is the default.
+	CacheStoreMemory = "memory"
+	// CacheStoreDB also saves cached results in the database, so that they
+	// survive restarts.
+	CacheStoreDB = "db"
+)
+
+var (
+	promTaskCacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_task_cache_hits_total",
+		Help: "Number of task runs answered from the task result cache",
+	},
+		[]string{"task_type", "store"},
+	)
+	promTaskCacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_task_cache_misses_total",
+		Help: "Number of task runs with a cacheTTL that found no fresh cached result",
+	},
+		[]string{"task_type"},
+	)
+)
+
+// cacheableTask is implemented by the tasks that support cacheTTL. Only
+// tasks without side effects, whose result depends on nothing but their
+// rendered request, may implement it.
+type cacheableTask interface {
+	Task
+	cacheable()
+}
+
+// validateCacheAttributes checks the cacheTTL and cacheStore attributes of a
+// freshly decoded task.
+func validateCacheAttributes(task Task) error {
+	base := task.Base()
+	if base.CacheTTL == 0 && base.CacheStore == "" {
+		return nil
+	}
+	if _, ok := task.(cacheableTask); !ok {
+		return errors.Errorf("%s tasks do not support cacheTTL", task.Type())
+	}
+	if base.CacheTTL <= 0 || base.CacheTTL > MaxCacheTTL {
+		return errors.Errorf("cacheTTL must be between 0s and %v, got %v", MaxCacheTTL, base.CacheTTL)
+	}
+	switch base.CacheStore {
+	case "", CacheStoreMemory, CacheStoreDB:
+		return nil
+	default:
+		return errors.Errorf(`cacheStore must be "%s" or "%s", got "%s"`, CacheStoreMemory, CacheStoreDB, base.CacheStore)
+	}
+}
+
+// taskCacheKey identifies the result of a task by its type and its rendered
+// request, so that tasks in different jobs making the same request share
+// cached results.
+func taskCacheKey(taskType TaskType, request ...interface{}) ([]byte, error) {
+	b, err := json.Marshal(append([]interface{}{taskType}, request...))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute cache key")
+	}
+	key := sha256.Sum256(b)
+	return key[:], nil
+}
+
+type cachedResult struct {
+	value     []byte
+	fetchedAt time.Time
+}
+
+// resultCache holds the results of the tasks that set a cacheTTL, shared by
+// all the tasks of a runner. Results are kept in a fixed size in-memory LRU
+// and, for tasks with cacheStore="db", in the database too.
+//
+// Entries are not expired when they are stored; rather, each lookup only
+// accepts a result younger than the cacheTTL of the task looking it up.
+// Errors are never cached.
+type resultCache struct {
+	orm ORM
+	lru *lru.Cache
+	now func() time.Time
+	// noStore is set for simulated runs, which may use cached results but
+	// must not cache their own.
+	noStore bool
+}
+
+func newResultCache(orm ORM, size int) *resultCache {
+	cache, err := lru.New(size)
+	if err != nil {
+		// Only possible if size is not positive
+		panic(err)
+	}
+	return &resultCache{orm: orm, lru: cache, now: time.Now}
+}
+
+// readOnly returns a view of c that looks up cached results like c, but
+// never stores any.
+func (c *resultCache) readOnly() *resultCache {
+	if c == nil {
+		return nil
+	}
+	view := *c
+	view.noStore = true
+	return &view
+}
+
+// get returns the cached result for key, if the task sets a cacheTTL and
+// there is one younger than that. c may be nil, in which case nothing is
+// cached.
+func (c *resultCache) get(ctx context.Context, lggr logger.Logger, task Task, key []byte) ([]byte, bool) {
+	base := task.Base()
+	if c == nil || base.CacheTTL == 0 {
+		return nil, false
+	}
+	since := c.now().Add(-base.CacheTTL)
+
+	if v, ok := c.lru.Get(string(key)); ok {
+		if cached := v.(cachedResult); cached.fetchedAt.After(since) {
+			promTaskCacheHits.WithLabelValues(string(task.Type()), CacheStoreMemory).Inc()
+			return copyBytes(cached.value), true
+		}
+	}
+
+	if base.CacheStore == CacheStoreDB {
+		value, fetchedAt, err := c.orm.GetCachedTaskResult(ctx, key, since)
+		switch {
+		case err == nil:
+			c.lru.Add(string(key), cachedResult{value: value, fetchedAt: fetchedAt})
+			promTaskCacheHits.WithLabelValues(string(task.Type()), CacheStoreDB).Inc()
+			return value, true
+		case !errors.Is(err, sql.ErrNoRows):
+			lggr.Warnw("Failed to load cached task result", "err", err)
+		}
+	}
+
+	promTaskCacheMisses.WithLabelValues(string(task.Type())).Inc()
+	return nil, false
+}
+
+// put caches the result of a successful request, if the task sets a
+// cacheTTL.
+func (c *resultCache) put(ctx context.Context, lggr logger.Logger, task Task, key []byte, value []byte) {
+	base := task.Base()
+	if c == nil || c.noStore || base.CacheTTL == 0 {
+		return
+	}
+	fetchedAt := c.now()
+	c.lru.Add(string(key), cachedResult{value: copyBytes(value), fetchedAt: fetchedAt})
+
+	if base.CacheStore == CacheStoreDB {
+		if err := c.orm.UpsertCachedTaskResult(ctx, key, value, fetchedAt); err != nil {
+			lggr.Warnw("Failed to save cached task result", "err", err)
+		}
+	}
+}
+
+func copyBytes(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
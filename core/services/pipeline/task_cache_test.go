@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+func TestResultCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	lggr := logger.TestLogger(t)
+
+	now := time.Unix(0, 0)
+	cache := newResultCache(nil, 2)
+	cache.now = func() time.Time { return now }
+
+	task := &HTTPTask{BaseTask: NewBaseTask(0, "http", nil, nil, 0)}
+	task.CacheTTL = time.Minute
+
+	keyA, err := taskCacheKey(TaskTypeHTTP, "GET", "https://example.com/a")
+	require.NoError(t, err)
+	keyB, err := taskCacheKey(TaskTypeHTTP, "GET", "https://example.com/b")
+	require.NoError(t, err)
+	keyC, err := taskCacheKey(TaskTypeHTTP, "GET", "https://example.com/c")
+	require.NoError(t, err)
+
+	_, ok := cache.get(ctx, lggr, task, keyA)
+	require.False(t, ok)
+
+	cache.put(ctx, lggr, task, keyA, []byte("a"))
+	value, ok := cache.get(ctx, lggr, task, keyA)
+	require.True(t, ok)
+	assert.Equal(t, []byte("a"), value)
+
+	// the cacheTTL of the task looking up the result applies
+	now = now.Add(30 * time.Second)
+	shortTTL := &HTTPTask{BaseTask: NewBaseTask(0, "http", nil, nil, 0)}
+	shortTTL.CacheTTL = 10 * time.Second
+	_, ok = cache.get(ctx, lggr, shortTTL, keyA)
+	assert.False(t, ok)
+	_, ok = cache.get(ctx, lggr, task, keyA)
+	assert.True(t, ok)
+
+	now = now.Add(30 * time.Second)
+	_, ok = cache.get(ctx, lggr, task, keyA)
+	assert.False(t, ok, "result has expired")
+
+	// the least recently used result is evicted
+	cache.put(ctx, lggr, task, keyA, []byte("a"))
+	cache.put(ctx, lggr, task, keyB, []byte("b"))
+	_, ok = cache.get(ctx, lggr, task, keyA)
+	require.True(t, ok)
+	cache.put(ctx, lggr, task, keyC, []byte("c"))
+	_, ok = cache.get(ctx, lggr, task, keyB)
+	assert.False(t, ok)
+	_, ok = cache.get(ctx, lggr, task, keyA)
+	assert.True(t, ok)
+}
+
+func TestValidateCacheAttributes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		attrs    map[string]interface{}
+		taskType TaskType
+		wantErr  string
+	}{
+		{"no caching", map[string]interface{}{}, TaskTypeMultiply, ""},
+		{"http", map[string]interface{}{"cacheTTL": "30s"}, TaskTypeHTTP, ""},
+		{"ethcall in the database", map[string]interface{}{"cacheTTL": "1h", "cacheStore": "db"}, TaskTypeETHCall, ""},
+		{"unsupported task", map[string]interface{}{"cacheTTL": "30s"}, TaskTypeBridge, "bridge tasks do not support cacheTTL"},
+		{"negative ttl", map[string]interface{}{"cacheTTL": "-1s"}, TaskTypeHTTP, "cacheTTL must be between"},
+		{"ttl too long", map[string]interface{}{"cacheTTL": "25h"}, TaskTypeHTTP, "cacheTTL must be between"},
+		{"store without ttl", map[string]interface{}{"cacheStore": "memory"}, TaskTypeHTTP, "cacheTTL must be between"},
+		{"unknown store", map[string]interface{}{"cacheTTL": "30s", "cacheStore": "redis"}, TaskTypeHTTP, "cacheStore must be"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := UnmarshalTaskFromMap(test.taskType, test.attrs, 0, "task")
+			if test.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+			}
+		})
+	}
+}
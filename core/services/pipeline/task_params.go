@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
@@ -396,6 +397,30 @@ func (b *BoolParam) UnmarshalPipelineParam(val interface{}) error {
 	return errors.Wrapf(ErrBadInput, "expected true or false, got %T", val)
 }
 
+type DurationParam time.Duration
+
+func (d *DurationParam) UnmarshalPipelineParam(val interface{}) error {
+	switch v := val.(type) {
+	case string:
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Wrap(ErrBadInput, err.Error())
+		}
+		if dur < 0 {
+			return errors.Wrapf(ErrBadInput, "duration must not be negative, got %v", dur)
+		}
+		*d = DurationParam(dur)
+		return nil
+	case time.Duration:
+		if v < 0 {
+			return errors.Wrapf(ErrBadInput, "duration must not be negative, got %v", v)
+		}
+		*d = DurationParam(v)
+		return nil
+	}
+	return errors.Wrapf(ErrBadInput, "expected duration, got %T", val)
+}
+
 type DecimalParam decimal.Decimal
 
 func (d *DecimalParam) UnmarshalPipelineParam(val interface{}) error {
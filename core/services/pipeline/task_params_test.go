@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -409,6 +410,33 @@ func TestBoolParam_UnmarshalPipelineParam(t *testing.T) {
 	}
 }
 
+func TestDurationParam_UnmarshalPipelineParam(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+		err      error
+	}{
+		{"string", "1.5s", pipeline.DurationParam(1500 * time.Millisecond), nil},
+		{"string zero", "0s", pipeline.DurationParam(0), nil},
+		{"duration", 3 * time.Minute, pipeline.DurationParam(3 * time.Minute), nil},
+		{"bad string", "soon", pipeline.DurationParam(0), pipeline.ErrBadInput},
+		{"negative", "-1s", pipeline.DurationParam(0), pipeline.ErrBadInput},
+		{"int", 123, pipeline.DurationParam(0), pipeline.ErrBadInput},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var p pipeline.DurationParam
+			err := p.UnmarshalPipelineParam(test.input)
+			require.Equal(t, test.err, errors.Cause(err))
+			require.Equal(t, test.expected, p)
+		})
+	}
+}
+
 func TestDecimalParam_UnmarshalPipelineParam(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,14 @@
+-- +goose Up
+
+CREATE TABLE pipeline_task_result_cache(
+    key bytea CHECK (octet_length(key) = 32) PRIMARY KEY,
+    value bytea NOT NULL,
+    fetched_at timestamp with time zone NOT NULL
+);
+
+CREATE INDEX idx_pipeline_task_result_cache_fetched_at ON pipeline_task_result_cache (fetched_at);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_pipeline_task_result_cache_fetched_at;
+DROP TABLE pipeline_task_result_cache;
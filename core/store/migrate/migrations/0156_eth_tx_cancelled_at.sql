@@ -0,0 +1,7 @@
+-- +goose Up
+
+ALTER TABLE eth_txes ADD COLUMN cancelled_at timestamp with time zone;
+
+-- +goose Down
+
+ALTER TABLE eth_txes DROP COLUMN cancelled_at;
@@ -0,0 +1,21 @@
+-- +goose Up
+
+-- batch_forwarder_address is set on transactions that should be sent through
+-- a batching forwarder. Once such a transaction is added to a batch,
+-- batch_eth_tx_id points at the eth_tx sending the batch and batch_index is
+-- the position of its call in the batch.
+ALTER TABLE eth_txes
+    ADD COLUMN batch_forwarder_address bytea CHECK (octet_length(batch_forwarder_address) = 20),
+    ADD COLUMN batch_eth_tx_id bigint REFERENCES eth_txes (id) ON DELETE CASCADE DEFERRABLE INITIALLY IMMEDIATE,
+    ADD COLUMN batch_index integer,
+    ADD CONSTRAINT chk_eth_txes_batch CHECK ((batch_eth_tx_id IS NULL) = (batch_index IS NULL));
+
+CREATE INDEX idx_eth_txes_unbatched ON eth_txes (evm_chain_id, from_address, id) WHERE state = 'unstarted' AND batch_forwarder_address IS NOT NULL AND batch_eth_tx_id IS NULL;
+CREATE INDEX idx_eth_txes_batch_eth_tx_id ON eth_txes (batch_eth_tx_id) WHERE batch_eth_tx_id IS NOT NULL;
+
+-- +goose Down
+
+ALTER TABLE eth_txes
+    DROP COLUMN batch_forwarder_address,
+    DROP COLUMN batch_eth_tx_id,
+    DROP COLUMN batch_index;
@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- Priorities are declared from highest to lowest, so that ordering by
+-- priority sends the highest priority transactions first.
+CREATE TYPE eth_txes_priority AS ENUM ('high', 'normal', 'low');
+
+ALTER TABLE eth_txes ADD COLUMN priority eth_txes_priority NOT NULL DEFAULT 'normal';
+
+CREATE INDEX idx_eth_txes_unstarted_priority ON eth_txes (evm_chain_id, from_address, priority) WHERE state = 'unstarted'::eth_txes_state;
+
+-- +goose Down
+
+ALTER TABLE eth_txes DROP COLUMN priority;
+DROP TYPE eth_txes_priority;
@@ -0,0 +1,112 @@
+-- +goose Up
+
+-- Lifecycle events of eth_txes and eth_tx_attempts are published on the
+-- eth_tx_events channel. Notifications are only delivered once the
+-- transaction that made the change commits, so subscribers never observe a
+-- state that was rolled back. Payloads must stay under the 8000 byte limit of
+-- pg_notify, hence the truncated error.
+--
+-- Events are only published while eth_tx_events_enabled has a row, which the
+-- node maintains on start. While it is empty the triggers return right away,
+-- so that nodes without subscribers don't pay for the notifications.
+CREATE TABLE eth_tx_events_enabled (
+	enabled_at timestamptz NOT NULL
+);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION public.eth_tx_event_payload(event_type text, etx public.eth_txes, previous_state text, attempt public.eth_tx_attempts, previous_attempt_state text) RETURNS text LANGUAGE plpgsql AS $$
+BEGIN
+	RETURN json_build_object(
+		'type', event_type,
+		'evmChainID', etx.evm_chain_id::text,
+		'ethTxID', etx.id,
+		'fromAddress', '0x' || encode(etx.from_address, 'hex'),
+		'toAddress', '0x' || encode(etx.to_address, 'hex'),
+		'nonce', etx.nonce,
+		'state', etx.state,
+		'previousState', previous_state,
+		'priority', etx.priority,
+		'error', left(etx.error, 1024),
+		'pipelineTaskRunID', etx.pipeline_task_run_id,
+		'attemptID', attempt.id,
+		'hash', '0x' || encode(attempt.hash, 'hex'),
+		'attemptState', attempt.state,
+		'previousAttemptState', previous_attempt_state,
+		'gasPrice', attempt.gas_price::text,
+		'gasTipCap', attempt.gas_tip_cap::text,
+		'gasFeeCap', attempt.gas_fee_cap::text,
+		'timestamp', statement_timestamp()
+	)::text;
+END
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION public.notify_eth_tx_event() RETURNS trigger LANGUAGE plpgsql AS $$
+DECLARE
+	event_type text;
+	previous_state text;
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM public.eth_tx_events_enabled) THEN
+		RETURN NULL;
+	END IF;
+	IF TG_OP = 'INSERT' THEN
+		event_type := 'created';
+	ELSE
+		previous_state := OLD.state::text;
+		event_type := CASE
+			WHEN NEW.state = 'unconfirmed' AND OLD.state = 'in_progress' THEN 'broadcast'
+			WHEN NEW.state = 'unconfirmed' AND OLD.state = 'confirmed' THEN 'reorged'
+			WHEN NEW.state = 'fatal_error' THEN 'fatal'
+			ELSE NEW.state::text
+		END;
+	END IF;
+	PERFORM pg_notify('eth_tx_events', public.eth_tx_event_payload(event_type, NEW, previous_state, NULL, NULL));
+	RETURN NULL;
+END
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION public.notify_eth_tx_attempt_event() RETURNS trigger LANGUAGE plpgsql AS $$
+DECLARE
+	etx public.eth_txes;
+	event_type text;
+	previous_attempt_state text;
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM public.eth_tx_events_enabled) THEN
+		RETURN NULL;
+	END IF;
+	SELECT * INTO etx FROM public.eth_txes WHERE id = NEW.eth_tx_id;
+	IF TG_OP = 'UPDATE' THEN
+		previous_attempt_state := OLD.state::text;
+	END IF;
+	event_type := CASE
+		WHEN NEW.state = 'broadcast' AND EXISTS (
+			SELECT 1 FROM public.eth_tx_attempts WHERE eth_tx_id = NEW.eth_tx_id AND id <> NEW.id AND state = 'broadcast'
+		) THEN 'bumped'
+		WHEN NEW.state = 'insufficient_eth' THEN 'insufficient_eth'
+		WHEN TG_OP = 'INSERT' THEN 'attempt_created'
+		ELSE 'attempt_' || NEW.state::text
+	END;
+	PERFORM pg_notify('eth_tx_events', public.eth_tx_event_payload(event_type, etx, NULL, NEW, previous_attempt_state));
+	RETURN NULL;
+END
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER notify_eth_tx_created AFTER INSERT ON public.eth_txes FOR EACH ROW EXECUTE PROCEDURE public.notify_eth_tx_event();
+CREATE TRIGGER notify_eth_tx_state_changed AFTER UPDATE OF state ON public.eth_txes FOR EACH ROW WHEN (OLD.state IS DISTINCT FROM NEW.state) EXECUTE PROCEDURE public.notify_eth_tx_event();
+CREATE TRIGGER notify_eth_tx_attempt_created AFTER INSERT ON public.eth_tx_attempts FOR EACH ROW EXECUTE PROCEDURE public.notify_eth_tx_attempt_event();
+CREATE TRIGGER notify_eth_tx_attempt_state_changed AFTER UPDATE OF state ON public.eth_tx_attempts FOR EACH ROW WHEN (OLD.state IS DISTINCT FROM NEW.state) EXECUTE PROCEDURE public.notify_eth_tx_attempt_event();
+
+-- +goose Down
+
+DROP TRIGGER IF EXISTS notify_eth_tx_attempt_state_changed ON public.eth_tx_attempts;
+DROP TRIGGER IF EXISTS notify_eth_tx_attempt_created ON public.eth_tx_attempts;
+DROP TRIGGER IF EXISTS notify_eth_tx_state_changed ON public.eth_txes;
+DROP TRIGGER IF EXISTS notify_eth_tx_created ON public.eth_txes;
+DROP FUNCTION IF EXISTS public.notify_eth_tx_attempt_event();
+DROP FUNCTION IF EXISTS public.notify_eth_tx_event();
+DROP FUNCTION IF EXISTS public.eth_tx_event_payload(text, public.eth_txes, text, public.eth_tx_attempts, text);
+DROP TABLE IF EXISTS eth_tx_events_enabled;
@@ -0,0 +1,29 @@
+-- +goose Up
+
+-- tx_type is the EIP-2718 type requested for the attempts of a transaction.
+-- When it is NULL the type is chosen from the chain configuration.
+ALTER TABLE eth_txes
+    ADD COLUMN tx_type smallint CHECK (tx_type IN (0, 1, 2));
+
+-- Access list (0x1) attempts are priced like legacy attempts
+ALTER TABLE eth_tx_attempts
+    DROP CONSTRAINT chk_legacy_or_dynamic,
+    ADD CONSTRAINT chk_legacy_or_dynamic CHECK (
+        (tx_type IN (0, 1) AND gas_price IS NOT NULL AND gas_tip_cap IS NULL AND gas_fee_cap IS NULL)
+        OR
+        (tx_type = 2 AND gas_price IS NULL AND gas_tip_cap IS NOT NULL AND gas_fee_cap IS NOT NULL)
+    );
+
+-- +goose Down
+
+-- Existing access list attempts are not checked against the restored constraint
+ALTER TABLE eth_tx_attempts
+    DROP CONSTRAINT chk_legacy_or_dynamic,
+    ADD CONSTRAINT chk_legacy_or_dynamic CHECK (
+        (tx_type = 0 AND gas_price IS NOT NULL AND gas_tip_cap IS NULL AND gas_fee_cap IS NULL)
+        OR
+        (tx_type = 2 AND gas_price IS NULL AND gas_tip_cap IS NOT NULL AND gas_fee_cap IS NOT NULL)
+    ) NOT VALID;
+
+ALTER TABLE eth_txes
+    DROP COLUMN tx_type;
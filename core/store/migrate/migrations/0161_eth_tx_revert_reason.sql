@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- revert_reason is the decoded reason of a transaction that reverted during
+-- simulation or on-chain
+ALTER TABLE eth_txes
+    ADD COLUMN revert_reason text;
+
+-- +goose Down
+
+ALTER TABLE eth_txes
+    DROP COLUMN revert_reason;
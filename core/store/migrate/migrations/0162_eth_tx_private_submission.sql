@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- private_submission is set for transactions that are sent to the private
+-- relay of their chain instead of the public mempool. It is cleared once the
+-- transaction falls back to being broadcast publicly. private_relay_failures
+-- counts the consecutive failures of the relay to accept the transaction.
+ALTER TABLE eth_txes
+    ADD COLUMN private_submission boolean NOT NULL DEFAULT false,
+    ADD COLUMN private_relay_failures integer NOT NULL DEFAULT 0;
+
+-- +goose Down
+
+ALTER TABLE eth_txes
+    DROP COLUMN private_submission,
+    DROP COLUMN private_relay_failures;
@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- Speed up summing the gas spend of a key or a job over the last hour or day,
+-- which is checked whenever one of their transactions is created or bumped
+CREATE INDEX idx_eth_txes_from_address_created_at ON eth_txes (evm_chain_id, from_address, created_at);
+CREATE INDEX idx_eth_txes_meta_job_id_created_at ON eth_txes ((meta->>'JobID'), created_at) WHERE meta->>'JobID' IS NOT NULL;
+
+-- +goose Down
+
+DROP INDEX idx_eth_txes_meta_job_id_created_at;
+DROP INDEX idx_eth_txes_from_address_created_at;
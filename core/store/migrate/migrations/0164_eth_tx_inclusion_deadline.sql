@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- inclusion_deadline_blocks and inclusion_deadline_at are the deadline for a
+-- transaction to be included in a block by: a number of blocks after it was
+-- first broadcast, and a time. Transactions with a deadline are bumped more
+-- aggressively as it approaches.
+ALTER TABLE eth_txes
+    ADD COLUMN inclusion_deadline_blocks bigint CHECK (inclusion_deadline_blocks > 0),
+    ADD COLUMN inclusion_deadline_at timestamptz;
+
+-- +goose Down
+
+ALTER TABLE eth_txes
+    DROP COLUMN inclusion_deadline_blocks,
+    DROP COLUMN inclusion_deadline_at;
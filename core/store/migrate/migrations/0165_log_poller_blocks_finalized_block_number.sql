@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- finalized_block_number is the latest finalized block when a block was polled,
+-- either reported by the chain with the finalized block tag or FinalityDepth
+-- blocks behind the latest block. Logs at or below it can no longer be reorged.
+ALTER TABLE log_poller_blocks
+    ADD COLUMN finalized_block_number bigint NOT NULL DEFAULT 0 CHECK (finalized_block_number >= 0);
+
+-- +goose Down
+
+ALTER TABLE log_poller_blocks
+    DROP COLUMN finalized_block_number;
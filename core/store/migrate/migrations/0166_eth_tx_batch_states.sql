@@ -0,0 +1,46 @@
+-- +goose Up
+
+-- Transactions sent in a batch have no nonce of their own, but are confirmed
+-- along with their batch.
+ALTER TABLE eth_txes DROP CONSTRAINT chk_eth_txes_fsm;
+ALTER TABLE eth_txes ADD CONSTRAINT chk_eth_txes_fsm CHECK (
+    state = 'unstarted'::eth_txes_state AND nonce IS NULL AND error IS NULL AND broadcast_at IS NULL AND initial_broadcast_at IS NULL
+    OR
+    state = 'in_progress'::eth_txes_state AND nonce IS NOT NULL AND error IS NULL AND broadcast_at IS NULL AND initial_broadcast_at IS NULL
+    OR
+    state = 'fatal_error'::eth_txes_state AND nonce IS NULL AND error IS NOT NULL
+    OR
+    state = 'unconfirmed'::eth_txes_state AND nonce IS NOT NULL AND error IS NULL AND broadcast_at IS NOT NULL AND initial_broadcast_at IS NOT NULL
+    OR
+    state = 'confirmed'::eth_txes_state AND nonce IS NOT NULL AND error IS NULL AND broadcast_at IS NOT NULL AND initial_broadcast_at IS NOT NULL
+    OR
+    state = 'confirmed'::eth_txes_state AND batch_eth_tx_id IS NOT NULL AND nonce IS NULL AND error IS NULL AND broadcast_at IS NOT NULL AND initial_broadcast_at IS NOT NULL
+    OR
+    state = 'confirmed_missing_receipt'::eth_txes_state AND nonce IS NOT NULL AND error IS NULL AND broadcast_at IS NOT NULL AND initial_broadcast_at IS NOT NULL
+) NOT VALID; -- NOT VALID gives large speedup and this is a relaxing of the constraint so its safe
+
+-- The forwardBatch function of the batching forwarder is not payable, so a
+-- transaction sending value can't be batched.
+ALTER TABLE eth_txes ADD CONSTRAINT chk_eth_txes_batch_value CHECK (batch_forwarder_address IS NULL OR value = 0) NOT VALID;
+
+-- +goose Down
+
+ALTER TABLE eth_txes DROP CONSTRAINT chk_eth_txes_batch_value;
+
+-- Batched transactions were already sent in their batch, so they must not be
+-- sent again on their own.
+UPDATE eth_txes SET state = 'fatal_error', error = 'transaction was confirmed in batch eth_tx ' || batch_eth_tx_id || ' and can not be tracked after downgrading' WHERE state = 'confirmed' AND nonce IS NULL;
+ALTER TABLE eth_txes DROP CONSTRAINT chk_eth_txes_fsm;
+ALTER TABLE eth_txes ADD CONSTRAINT chk_eth_txes_fsm CHECK (
+    state = 'unstarted'::eth_txes_state AND nonce IS NULL AND error IS NULL AND broadcast_at IS NULL AND initial_broadcast_at IS NULL
+    OR
+    state = 'in_progress'::eth_txes_state AND nonce IS NOT NULL AND error IS NULL AND broadcast_at IS NULL AND initial_broadcast_at IS NULL
+    OR
+    state = 'fatal_error'::eth_txes_state AND nonce IS NULL AND error IS NOT NULL
+    OR
+    state = 'unconfirmed'::eth_txes_state AND nonce IS NOT NULL AND error IS NULL AND broadcast_at IS NOT NULL AND initial_broadcast_at IS NOT NULL
+    OR
+    state = 'confirmed'::eth_txes_state AND nonce IS NOT NULL AND error IS NULL AND broadcast_at IS NOT NULL AND initial_broadcast_at IS NOT NULL
+    OR
+    state = 'confirmed_missing_receipt'::eth_txes_state AND nonce IS NOT NULL AND error IS NULL AND broadcast_at IS NOT NULL AND initial_broadcast_at IS NOT NULL
+) NOT VALID; -- NOT VALID gives large speedup and we know data is valid because of update above
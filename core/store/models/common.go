@@ -366,6 +366,12 @@ type SendEtherRequest struct {
 	AllowHigherAmounts bool           `json:"allowHigherAmounts"`
 }
 
+// BumpEthTxRequest represents a request to replace the pending attempt of an
+// ETH transaction with one paying a higher gas price.
+type BumpEthTxRequest struct {
+	GasPrice *assets.Wei `json:"gasPrice"`
+}
+
 // AddressCollection is an array of common.Address
 // serializable to and from a database.
 type AddressCollection []common.Address
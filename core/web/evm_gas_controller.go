@@ -0,0 +1,41 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/utils"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+// EVMGasController shows what the gas estimators of EVM chains currently
+// believe gas costs.
+type EVMGasController struct {
+	App chainlink.Application
+}
+
+// Show returns the current gas prices of the EVM chain with :ID, and the bumps
+// that a pending attempt sent with them would get. Prices that could not be
+// estimated are null, and listed in errors.
+// Example:
+//  "<application>/chains/evm/:ID/gas"
+func (gc *EVMGasController) Show(c *gin.Context) {
+	var id utils.Big
+	if err := id.UnmarshalText([]byte(c.Param("ID"))); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	chain, err := gc.App.GetChains().EVM.Get(id.ToInt())
+	if err != nil {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	}
+
+	cfg := chain.Config()
+	prices, err := gas.GetPrices(c.Request.Context(), chain.TxManager().GetGasEstimator(), cfg, cfg.EvmGasLimitDefault())
+	jsonAPIResponse(c, presenters.NewEVMGasPricesResource(id.String(), prices, err), "evm_gas_prices")
+}
@@ -0,0 +1,51 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/web"
+	"github.com/smartcontractkit/chainlink/core/web/presenters"
+)
+
+func TestEVMGasController_Show(t *testing.T) {
+	t.Parallel()
+
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].GasEstimator.Mode = ptr("FixedPrice")
+	})
+	app := cltest.NewApplicationWithConfig(t, cfg)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	client := app.NewHTTPClient(cltest.APIEmailAdmin)
+
+	t.Run("not found", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/chains/evm/4242/gas")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	})
+
+	t.Run("returns the current and bumped prices", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/chains/evm/0/gas")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var r presenters.EVMGasPricesResource
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &r))
+		assert.Equal(t, "0", r.ID)
+		assert.False(t, r.EIP1559DynamicFees)
+		require.NotNil(t, r.GasPrice)
+		assert.Equal(t, "20 gwei", r.GasPrice.String())
+		require.NotNil(t, r.BumpedGasPrice)
+		assert.Equal(t, "25 gwei", r.BumpedGasPrice.String())
+		assert.Nil(t, r.FeeCap)
+		assert.Nil(t, r.BlockHistory)
+		assert.Empty(t, r.Errors)
+	})
+}
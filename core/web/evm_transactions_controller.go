@@ -1,17 +1,37 @@
 package web
 
 import (
+	"context"
 	"database/sql"
+	"io"
+	"math/big"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/smartcontractkit/chainlink/core/chains/evm"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+	"github.com/smartcontractkit/chainlink/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/pg"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/web/presenters"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
 )
 
+const (
+	// txEventsWriteWait is how long sending a message to a WebSocket client may take
+	txEventsWriteWait = 10 * time.Second
+	// txEventsPingPeriod is how often WebSocket clients are pinged to keep the connection alive
+	txEventsPingPeriod = 30 * time.Second
+)
+
+var txEventsUpgrader = websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024}
+
 // TransactionsController displays Ethereum transactions requests.
 type TransactionsController struct {
 	App chainlink.Application
@@ -46,3 +66,252 @@ func (tc *TransactionsController) Show(c *gin.Context) {
 
 	jsonAPIResponse(c, presenters.NewEthTxResourceFromAttempt(*ethTxAttempt), "transaction")
 }
+
+// Cancel replaces the Ethereum Transaction with the given attempt hash with a
+// transfer of zero ether to its sender, or discards it if it was never sent.
+// Example:
+//  "<application>/transactions/evm/:TxHash/cancel"
+func (tc *TransactionsController) Cancel(c *gin.Context) {
+	ethTxAttempt, chain, ok := tc.findAttemptChain(c)
+	if !ok {
+		return
+	}
+
+	etx, err := chain.TxManager().CancelEthTx(c.Request.Context(), ethTxAttempt.EthTxID)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, errors.Errorf("failed to cancel transaction: %v", err))
+		return
+	}
+
+	tc.App.GetAuditLogger().Audit(audit.EthTransactionCancelled, map[string]interface{}{
+		"ethTX": etx,
+	})
+
+	jsonAPIResponse(c, newEthTxResourceFromLatestAttempt(etx), "transaction")
+}
+
+// Bump replaces the pending attempt of the Ethereum Transaction with the given
+// attempt hash with one paying the requested gas price.
+// Example:
+//  "<application>/transactions/evm/:TxHash/bump"
+func (tc *TransactionsController) Bump(c *gin.Context) {
+	var br models.BumpEthTxRequest
+	if err := c.ShouldBindJSON(&br); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if br.GasPrice == nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("gasPrice is required"))
+		return
+	}
+
+	ethTxAttempt, chain, ok := tc.findAttemptChain(c)
+	if !ok {
+		return
+	}
+
+	etx, err := chain.TxManager().BumpEthTx(c.Request.Context(), ethTxAttempt.EthTxID, br.GasPrice)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, errors.Errorf("failed to bump transaction: %v", err))
+		return
+	}
+
+	tc.App.GetAuditLogger().Audit(audit.EthTransactionBumped, map[string]interface{}{
+		"ethTX":    etx,
+		"gasPrice": br.GasPrice,
+	})
+
+	jsonAPIResponse(c, newEthTxResourceFromLatestAttempt(etx), "transaction")
+}
+
+// Events streams the lifecycle events of Ethereum Transactions as
+// Server-Sent Events, or as WebSocket text messages if the request is a
+// WebSocket upgrade. The events may be filtered by chain, by the sending or
+// receiving address and by transaction with the evmChainID, address and id
+// query parameters.
+// Example:
+//  "<application>/transactions/evm/events?evmChainID=1&address=0x..."
+func (tc *TransactionsController) Events(c *gin.Context) {
+	if !tc.App.GetConfig().FeatureTxEvents() {
+		jsonAPIError(c, http.StatusMethodNotAllowed, errors.New("The transaction events feature is disabled by configuration"))
+		return
+	}
+
+	filter, err := parseEthTxEventFilter(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	sub, err := tc.App.GetEventBroadcaster().Subscribe(pg.ChannelEthTxEvents, "")
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	defer sub.Close()
+
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		tc.streamEventsWebSocket(c, sub, filter)
+		return
+	}
+	tc.streamEventsSSE(c, sub, filter)
+}
+
+// streamEventsSSE writes the events passing filter as Server-Sent Events,
+// named by their type.
+func (tc *TransactionsController) streamEventsSSE(c *gin.Context, sub pg.Subscription, filter txmgr.EthTxEventFilter) {
+	// The server drops responses that outlive its write timeout, so the
+	// stream is ended just before, letting clients reconnect cleanly.
+	ctx := c.Request.Context()
+	if timeout := tc.App.GetConfig().HTTPServerWriteTimeout(); timeout > time.Second {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout-time.Second)
+		defer cancel()
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	c.Stream(func(io.Writer) bool {
+		select {
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return false
+			}
+			if event, ok := tc.parseEthTxEvent(ev); ok && filter.Matches(event) {
+				c.SSEvent(string(event.Type), event)
+			}
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+// streamEventsWebSocket upgrades the connection and writes the events
+// passing filter as JSON text messages, until the client disconnects.
+func (tc *TransactionsController) streamEventsWebSocket(c *gin.Context, sub pg.Subscription, filter txmgr.EthTxEventFilter) {
+	conn, err := txEventsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade has already responded with the error
+		tc.App.GetLogger().Debugw("Failed to upgrade transaction events connection", "err", err)
+		return
+	}
+	defer conn.Close()
+
+	// Messages from the client are discarded, but must be read to process
+	// control frames and to notice when the connection is closed. The
+	// deadline of the server's read timeout no longer applies.
+	if err = conn.SetReadDeadline(time.Time{}); err != nil {
+		return
+	}
+	chClosed := make(chan struct{})
+	go func() {
+		defer close(chClosed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(txEventsPingPeriod)
+	defer ping.Stop()
+	for {
+		select {
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			event, ok := tc.parseEthTxEvent(ev)
+			if !ok || !filter.Matches(event) {
+				continue
+			}
+			if err = conn.SetWriteDeadline(time.Now().Add(txEventsWriteWait)); err != nil {
+				return
+			}
+			if err = conn.WriteJSON(event); err != nil {
+				tc.App.GetLogger().Debugw("Failed to write transaction event", "err", err)
+				return
+			}
+		case <-ping.C:
+			if err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(txEventsWriteWait)); err != nil {
+				return
+			}
+		case <-chClosed:
+			return
+		}
+	}
+}
+
+func (tc *TransactionsController) parseEthTxEvent(ev pg.Event) (txmgr.EthTxEvent, bool) {
+	event, err := txmgr.ParseEthTxEvent(ev.Payload)
+	if err != nil {
+		tc.App.GetLogger().Errorw("Failed to parse transaction event", "payload", ev.Payload, "err", err)
+		return event, false
+	}
+	return event, true
+}
+
+// parseEthTxEventFilter reads the filter of the Events request from its
+// query parameters.
+func parseEthTxEventFilter(c *gin.Context) (filter txmgr.EthTxEventFilter, err error) {
+	if s := c.Query("evmChainID"); s != "" {
+		chainID, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return filter, errors.Errorf("invalid evmChainID: %q", s)
+		}
+		filter.EVMChainID = chainID
+	}
+	if s := c.Query("address"); s != "" {
+		if !common.IsHexAddress(s) {
+			return filter, errors.Errorf("invalid address: %q", s)
+		}
+		address := common.HexToAddress(s)
+		filter.Address = &address
+	}
+	if s := c.Query("id"); s != "" {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return filter, errors.Errorf("invalid id: %q", s)
+		}
+		filter.EthTxID = &id
+	}
+	return filter, nil
+}
+
+// findAttemptChain finds the attempt with the :TxHash of the request and the
+// chain of its transaction, rendering an error if either doesn't exist.
+func (tc *TransactionsController) findAttemptChain(c *gin.Context) (*txmgr.EthTxAttempt, evm.Chain, bool) {
+	hash := common.HexToHash(c.Param("TxHash"))
+
+	ethTxAttempt, err := tc.App.TxmORM().FindEthTxAttempt(hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Transaction not found"))
+		return nil, nil, false
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return nil, nil, false
+	}
+
+	chain, err := tc.App.GetChains().EVM.Get(ethTxAttempt.EthTx.EVMChainID.ToInt())
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return nil, nil, false
+	}
+	return ethTxAttempt, chain, true
+}
+
+// newEthTxResourceFromLatestAttempt presents etx by its highest priced
+// attempt, if it has one.
+func newEthTxResourceFromLatestAttempt(etx txmgr.EthTx) presenters.EthTxResource {
+	if len(etx.EthTxAttempts) == 0 {
+		return presenters.NewEthTxResource(etx)
+	}
+	attempt := etx.EthTxAttempts[0]
+	attempt.EthTx = etx
+	return presenters.NewEthTxResourceFromAttempt(attempt)
+}
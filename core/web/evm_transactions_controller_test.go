@@ -1,17 +1,25 @@
 package web_test
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/pg"
+	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/smartcontractkit/chainlink/core/web"
 	"github.com/smartcontractkit/chainlink/core/web/presenters"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -124,3 +132,119 @@ func TestTransactionsController_Show_NotFound(t *testing.T) {
 	t.Cleanup(cleanup)
 	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
 }
+
+func TestTransactionsController_Cancel(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationWithKey(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+
+	borm := app.TxmORM()
+	client := app.NewHTTPClient(cltest.APIEmailAdmin)
+	_, from := cltest.MustInsertRandomKey(t, app.KeyStore.Eth(), 0)
+
+	t.Run("not found", func(t *testing.T) {
+		resp, cleanup := client.Post("/v2/transactions/evm/"+utils.NewHash().Hex()+"/cancel", nil)
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	})
+
+	t.Run("confirmed transactions can't be cancelled", func(t *testing.T) {
+		tx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, borm, 0, 1, from)
+
+		resp, cleanup := client.Post("/v2/transactions/evm/"+tx.EthTxAttempts[0].Hash.Hex()+"/cancel", nil)
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+		assert.Contains(t, string(cltest.ParseResponseBody(t, resp)), "is confirmed and can no longer be replaced")
+	})
+}
+
+func TestTransactionsController_Bump(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationWithKey(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+
+	borm := app.TxmORM()
+	client := app.NewHTTPClient(cltest.APIEmailAdmin)
+	_, from := cltest.MustInsertRandomKey(t, app.KeyStore.Eth(), 0)
+	tx := cltest.MustInsertConfirmedEthTxWithLegacyAttempt(t, borm, 0, 1, from)
+	path := "/v2/transactions/evm/" + tx.EthTxAttempts[0].Hash.Hex() + "/bump"
+
+	t.Run("missing gas price", func(t *testing.T) {
+		resp, cleanup := client.Post(path, bytes.NewBufferString(`{}`))
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("confirmed transactions can't be bumped", func(t *testing.T) {
+		resp, cleanup := client.Post(path, bytes.NewBufferString(`{"gasPrice": "100 gwei"}`))
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+		assert.Contains(t, string(cltest.ParseResponseBody(t, resp)), "is confirmed and can no longer be replaced")
+	})
+}
+
+func TestTransactionsController_Events(t *testing.T) {
+	t.Parallel()
+
+	eventBroadcaster := pg.NewNullEventBroadcaster()
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.Feature.TxEvents = ptr(true)
+	})
+	app := cltest.NewApplicationWithConfigAndKey(t, cfg, eventBroadcaster)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	client := app.NewHTTPClient(cltest.APIEmailAdmin)
+
+	t.Run("disabled", func(t *testing.T) {
+		app := cltest.NewApplicationWithKey(t)
+		require.NoError(t, app.Start(testutils.Context(t)))
+
+		client := app.NewHTTPClient(cltest.APIEmailAdmin)
+		resp, cleanup := client.Get("/v2/transactions/evm/events")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusMethodNotAllowed)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/transactions/evm/events?address=foo")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("streams the events passing the filter", func(t *testing.T) {
+		address := testutils.NewAddress()
+		resp, cleanup := client.Get("/v2/transactions/evm/events?evmChainID=42&address=" + address.Hex())
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		newEvent := func(id int64, from common.Address) pg.Event {
+			return pg.Event{
+				Channel: pg.ChannelEthTxEvents,
+				Payload: fmt.Sprintf(`{"type": "confirmed", "evmChainID": "42", "ethTxID": %d, "fromAddress": "%s", "state": "confirmed", "timestamp": "2022-10-16T12:00:00.123456+00:00"}`, id, from.Hex()),
+			}
+		}
+		eventBroadcaster.Sub.Ch <- newEvent(1, testutils.NewAddress())
+		eventBroadcaster.Sub.Ch <- newEvent(2, address)
+
+		reader := bufio.NewReader(resp.Body)
+		var name, data string
+		for data == "" {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, "event:"):
+				name = strings.TrimPrefix(line, "event:")
+			case strings.HasPrefix(line, "data:"):
+				data = strings.TrimPrefix(line, "data:")
+			}
+		}
+		assert.Equal(t, string(txmgr.EthTxEventConfirmed), name)
+		event, err := txmgr.ParseEthTxEvent(data)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), event.EthTxID)
+		assert.Equal(t, address, event.FromAddress)
+	})
+}
@@ -129,6 +129,40 @@ func (jc *JobsController) Create(c *gin.Context) {
 	jsonAPIResponse(c, presenters.NewJobResource(jb), jb.Type.String())
 }
 
+// SimulateJobRequest represents a request to simulate the pipeline of a job
+// spec without creating the job.
+type SimulateJobRequest struct {
+	TOML string                 `json:"toml"`
+	Vars map[string]interface{} `json:"vars"`
+}
+
+// Simulate validates a job spec and executes its pipeline once without
+// persisting anything, returning the result of every task. ethtx tasks return
+// the transaction they would have sent instead of sending it.
+// Example:
+// "POST <application>/jobs/simulate"
+func (jc *JobsController) Simulate(c *gin.Context) {
+	request := SimulateJobRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	jb, status, err := jc.validateJobSpec(request.TOML)
+	if err != nil {
+		jsonAPIError(c, status, err)
+		return
+	}
+
+	run, trrs, err := jc.App.SimulateJobV2(c.Request.Context(), jb, request.Vars)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewPipelineSimulationResource(run, trrs, jc.App.GetLogger()), "pipelineSimulation")
+}
+
 // Delete hard deletes a job spec.
 // Example:
 // "DELETE <application>/specs/:ID"
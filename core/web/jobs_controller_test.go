@@ -376,6 +376,55 @@ func TestJobsController_Create_WebhookSpec(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestJobsController_Simulate(t *testing.T) {
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+
+	client := app.NewHTTPClient(cltest.APIEmailAdmin)
+
+	tomlStr := `
+type            = "webhook"
+schemaVersion   = 1
+observationSource   = """
+    parse_request  [type=jsonparse path="data,result" data="$(jobRun.requestBody)"];
+    multiply       [type=multiply times="100"];
+
+    parse_request -> multiply;
+"""
+`
+	body, err := json.Marshal(web.SimulateJobRequest{
+		TOML: tomlStr,
+		Vars: map[string]interface{}{
+			"jobRun": map[string]interface{}{
+				"requestBody": `{"data": {"result": 1.23}}`,
+			},
+		},
+	})
+	require.NoError(t, err)
+	response, cleanup := client.Post("/v2/jobs/simulate", bytes.NewReader(body))
+	defer cleanup()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	resource := presenters.PipelineSimulationResource{}
+	err = web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &resource)
+	require.NoError(t, err)
+
+	require.Len(t, resource.Outputs, 1)
+	require.NotNil(t, resource.Outputs[0])
+	assert.Equal(t, `"123"`, *resource.Outputs[0])
+	require.Len(t, resource.TaskRuns, 2)
+	assert.Equal(t, "parse_request", resource.TaskRuns[0].DotID)
+	assert.Empty(t, resource.TaskRuns[0].Inputs)
+	assert.Equal(t, "multiply", resource.TaskRuns[1].DotID)
+	require.Len(t, resource.TaskRuns[1].Inputs, 1)
+	assert.Equal(t, "1.23", *resource.TaskRuns[1].Inputs[0].Value)
+
+	// nothing is persisted
+	jobs, _, err := app.JobORM().FindJobs(0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
 func TestJobsController_FailToCreate_EmptyJsonAttribute(t *testing.T) {
 	app := cltest.NewApplicationEVMDisabled(t)
 	require.NoError(t, app.Start(testutils.Context(t)))
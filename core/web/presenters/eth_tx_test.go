@@ -8,6 +8,7 @@ import (
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
@@ -26,6 +27,7 @@ func TestEthTxResource(t *testing.T) {
 		GasLimit:       uint32(5000),
 		State:          txmgr.EthTxConfirmed,
 		Value:          assets.NewEthValue(1),
+		RevertReason:   null.StringFrom("not allowed"),
 	}
 
 	r := NewEthTxResource(tx)
@@ -50,7 +52,8 @@ func TestEthTxResource(t *testing.T) {
 			"sentAt": "",
 			"to": "0x0000000000000000000000000000000000000002",
 			"value": "0.000000000000000001",
-			"evmChainID": "0"
+			"evmChainID": "0",
+			"revertReason": "not allowed"
 		  }
 		}
 	  }
@@ -96,7 +99,8 @@ func TestEthTxResource(t *testing.T) {
 			"sentAt": "300",
 			"to": "0x0000000000000000000000000000000000000002",
 			"value": "0.000000000000000001",
-			"evmChainID": "0"
+			"evmChainID": "0",
+			"revertReason": "not allowed"
 		  }
 		}
 	  }
@@ -0,0 +1,69 @@
+package presenters
+
+import (
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
+)
+
+// EVMGasPricesResource is a JSONAPI resource of the gas prices that the gas
+// estimator of an EVM chain currently uses.
+type EVMGasPricesResource struct {
+	JAID
+	EIP1559DynamicFees bool                   `json:"eip1559DynamicFees"`
+	GasPrice           *assets.Wei            `json:"gasPrice"`
+	BumpedGasPrice     *assets.Wei            `json:"bumpedGasPrice"`
+	FeeCap             *assets.Wei            `json:"feeCap"`
+	TipCap             *assets.Wei            `json:"tipCap"`
+	BumpedFeeCap       *assets.Wei            `json:"bumpedFeeCap"`
+	BumpedTipCap       *assets.Wei            `json:"bumpedTipCap"`
+	BlockHistory       *EVMBlockHistoryWindow `json:"blockHistory"`
+	Errors             []string               `json:"errors"`
+}
+
+// EVMBlockHistoryWindow is the window of blocks that the BlockHistory gas
+// estimator currently derives its prices from.
+type EVMBlockHistoryWindow struct {
+	Percentile   uint16      `json:"percentile"`
+	Size         uint16      `json:"size"`
+	BlockNumbers []int64     `json:"blockNumbers"`
+	GasPrice     *assets.Wei `json:"gasPrice"`
+	TipCap       *assets.Wei `json:"tipCap"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r EVMGasPricesResource) GetName() string {
+	return "evm_gas_prices"
+}
+
+// NewEVMGasPricesResource returns a new EVMGasPricesResource for the prices
+// of the chain with chainID, and the errors estimating them.
+func NewEVMGasPricesResource(chainID string, prices gas.Prices, err error) EVMGasPricesResource {
+	r := EVMGasPricesResource{
+		JAID:               NewJAID(chainID),
+		EIP1559DynamicFees: prices.EIP1559DynamicFees,
+		GasPrice:           prices.LegacyGasPrice,
+		BumpedGasPrice:     prices.BumpedLegacyGasPrice,
+		Errors:             []string{},
+	}
+	if fee := prices.DynamicFee; fee != nil {
+		r.FeeCap, r.TipCap = fee.FeeCap, fee.TipCap
+	}
+	if fee := prices.BumpedDynamicFee; fee != nil {
+		r.BumpedFeeCap, r.BumpedTipCap = fee.FeeCap, fee.TipCap
+	}
+	if w := prices.BlockHistory; w != nil {
+		r.BlockHistory = &EVMBlockHistoryWindow{
+			Percentile:   w.Percentile,
+			Size:         w.Size,
+			BlockNumbers: w.BlockNumbers,
+			GasPrice:     w.GasPrice,
+			TipCap:       w.TipCap,
+		}
+	}
+	for _, e := range multierr.Errors(err) {
+		r.Errors = append(r.Errors, e.Error())
+	}
+	return r
+}
@@ -1,6 +1,7 @@
 package presenters
 
 import (
+	"sort"
 	"time"
 
 	"gopkg.in/guregu/null.v4"
@@ -103,3 +104,99 @@ func NewPipelineRunResources(prs []pipeline.Run, lggr logger.Logger) []PipelineR
 
 	return out
 }
+
+// PipelineSimulationResource is the result of simulating the pipeline of a
+// job spec without creating the job
+type PipelineSimulationResource struct {
+	JAID
+	Outputs     []*string                           `json:"outputs"`
+	AllErrors   []*string                           `json:"allErrors"`
+	FatalErrors []*string                           `json:"fatalErrors"`
+	TaskRuns    []PipelineSimulationTaskRunResource `json:"taskRuns"`
+	CreatedAt   time.Time                           `json:"createdAt"`
+	FinishedAt  null.Time                           `json:"finishedAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r PipelineSimulationResource) GetName() string {
+	return "pipelineSimulation"
+}
+
+// PipelineSimulationTaskRunResource is the trace of a single task of a
+// pipeline simulation
+type PipelineSimulationTaskRunResource struct {
+	DotID      string                     `json:"dotId"`
+	Type       pipeline.TaskType          `json:"type"`
+	Inputs     []PipelineSimulationResult `json:"inputs"`
+	Output     *string                    `json:"output"`
+	Error      *string                    `json:"error"`
+	Pending    bool                       `json:"pending"`
+	Attempts   uint                       `json:"attempts"`
+	CreatedAt  time.Time                  `json:"createdAt"`
+	FinishedAt null.Time                  `json:"finishedAt"`
+}
+
+// PipelineSimulationResult is a task result, rendered as JSON
+type PipelineSimulationResult struct {
+	Value *string `json:"value"`
+	Error *string `json:"error"`
+}
+
+func NewPipelineSimulationResult(result pipeline.Result) PipelineSimulationResult {
+	var r PipelineSimulationResult
+	if output := result.OutputDB(); output.Valid {
+		outputBytes, _ := output.MarshalJSON()
+		outputStr := string(outputBytes)
+		r.Value = &outputStr
+	}
+	if errString := result.ErrorDB(); errString.Valid {
+		r.Error = &errString.String
+	}
+	return r
+}
+
+func NewPipelineSimulationResource(run pipeline.Run, trrs pipeline.TaskRunResults, lggr logger.Logger) PipelineSimulationResource {
+	lggr = lggr.Named("PipelineSimulationResource")
+
+	outputs, err := run.StringOutputs()
+	if err != nil {
+		lggr.Errorw(err.Error(), "out", run.Outputs)
+	}
+
+	// task IDs follow the topological order of the pipeline
+	sorted := make(pipeline.TaskRunResults, len(trrs))
+	copy(sorted, trrs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Task.ID() < sorted[j].Task.ID()
+	})
+
+	var trs []PipelineSimulationTaskRunResource
+	for _, trr := range sorted {
+		var inputs []PipelineSimulationResult
+		for _, input := range trrs.InputsFor(trr.Task) {
+			inputs = append(inputs, NewPipelineSimulationResult(input))
+		}
+		result := NewPipelineSimulationResult(trr.Result)
+		trs = append(trs, PipelineSimulationTaskRunResource{
+			DotID:      trr.Task.DotID(),
+			Type:       trr.Task.Type(),
+			Inputs:     inputs,
+			Output:     result.Value,
+			Error:      result.Error,
+			Pending:    trr.IsPending(),
+			Attempts:   trr.Attempts,
+			CreatedAt:  trr.CreatedAt,
+			FinishedAt: trr.FinishedAt,
+		})
+	}
+
+	return PipelineSimulationResource{
+		JAID:        NewJAID("simulation"),
+		Outputs:     outputs,
+		AllErrors:   run.StringAllErrors(),
+		FatalErrors: run.StringFatalErrors(),
+		TaskRuns:    trs,
+		CreatedAt:   run.CreatedAt,
+		FinishedAt:  run.FinishedAt,
+	}
+}
@@ -0,0 +1,161 @@
+package resolver
+
+import (
+	"github.com/graph-gophers/graphql-go"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/core/utils/stringutils"
+)
+
+// ChainGasPricesResolver resolves the ChainGasPrices type.
+type ChainGasPricesResolver struct {
+	chainID string
+	prices  gas.Prices
+	err     error
+}
+
+func NewChainGasPrices(chainID string, prices gas.Prices, err error) *ChainGasPricesResolver {
+	return &ChainGasPricesResolver{chainID: chainID, prices: prices, err: err}
+}
+
+// ChainID resolves the chain of the prices.
+func (r *ChainGasPricesResolver) ChainID() graphql.ID {
+	return graphql.ID(r.chainID)
+}
+
+// EIP1559DynamicFees resolves whether the chain uses EIP-1559 transactions.
+func (r *ChainGasPricesResolver) EIP1559DynamicFees() bool {
+	return r.prices.EIP1559DynamicFees
+}
+
+// GasPrice resolves the legacy gas price of new transactions.
+func (r *ChainGasPricesResolver) GasPrice() *string {
+	return weiString(r.prices.LegacyGasPrice)
+}
+
+// BumpedGasPrice resolves the legacy gas price of a bumped attempt.
+func (r *ChainGasPricesResolver) BumpedGasPrice() *string {
+	return weiString(r.prices.BumpedLegacyGasPrice)
+}
+
+// FeeCap resolves the EIP-1559 fee cap of new transactions.
+func (r *ChainGasPricesResolver) FeeCap() *string {
+	if r.prices.DynamicFee == nil {
+		return nil
+	}
+	return weiString(r.prices.DynamicFee.FeeCap)
+}
+
+// TipCap resolves the EIP-1559 tip cap of new transactions.
+func (r *ChainGasPricesResolver) TipCap() *string {
+	if r.prices.DynamicFee == nil {
+		return nil
+	}
+	return weiString(r.prices.DynamicFee.TipCap)
+}
+
+// BumpedFeeCap resolves the EIP-1559 fee cap of a bumped attempt.
+func (r *ChainGasPricesResolver) BumpedFeeCap() *string {
+	if r.prices.BumpedDynamicFee == nil {
+		return nil
+	}
+	return weiString(r.prices.BumpedDynamicFee.FeeCap)
+}
+
+// BumpedTipCap resolves the EIP-1559 tip cap of a bumped attempt.
+func (r *ChainGasPricesResolver) BumpedTipCap() *string {
+	if r.prices.BumpedDynamicFee == nil {
+		return nil
+	}
+	return weiString(r.prices.BumpedDynamicFee.TipCap)
+}
+
+// BlockHistory resolves the window of the BlockHistory estimator, if the chain
+// uses one.
+func (r *ChainGasPricesResolver) BlockHistory() *BlockHistoryWindowResolver {
+	if r.prices.BlockHistory == nil {
+		return nil
+	}
+	return &BlockHistoryWindowResolver{window: *r.prices.BlockHistory}
+}
+
+// Errors resolves the errors of the prices that could not be estimated.
+func (r *ChainGasPricesResolver) Errors() []string {
+	errs := []string{}
+	for _, err := range multierr.Errors(r.err) {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+// BlockHistoryWindowResolver resolves the BlockHistoryWindow type.
+type BlockHistoryWindowResolver struct {
+	window gas.BlockHistoryWindow
+}
+
+// Percentile resolves the transaction percentile of the window.
+func (r *BlockHistoryWindowResolver) Percentile() int32 {
+	return int32(r.window.Percentile)
+}
+
+// Size resolves the maximum number of blocks in the window.
+func (r *BlockHistoryWindowResolver) Size() int32 {
+	return int32(r.window.Size)
+}
+
+// BlockNumbers resolves the blocks in the window.
+func (r *BlockHistoryWindowResolver) BlockNumbers() []string {
+	nums := []string{}
+	for _, n := range r.window.BlockNumbers {
+		nums = append(nums, stringutils.FromInt64(n))
+	}
+	return nums
+}
+
+// GasPrice resolves the percentile gas price of the window.
+func (r *BlockHistoryWindowResolver) GasPrice() *string {
+	return weiString(r.window.GasPrice)
+}
+
+// TipCap resolves the percentile tip cap of the window.
+func (r *BlockHistoryWindowResolver) TipCap() *string {
+	return weiString(r.window.TipCap)
+}
+
+// -- ChainGasPrices Query --
+
+type ChainGasPricesPayloadResolver struct {
+	prices *ChainGasPricesResolver
+	NotFoundErrorUnionType
+}
+
+// NewChainGasPricesPayload returns the payload of the prices, or of err if
+// the chain could not be found.
+func NewChainGasPricesPayload(prices *ChainGasPricesResolver, err error) *ChainGasPricesPayloadResolver {
+	var e NotFoundErrorUnionType
+
+	if err != nil {
+		e = NotFoundErrorUnionType{err: err, message: err.Error(), isExpectedErrorFn: func(err error) bool {
+			return true
+		}}
+	}
+
+	return &ChainGasPricesPayloadResolver{prices: prices, NotFoundErrorUnionType: e}
+}
+
+func (r *ChainGasPricesPayloadResolver) ToChainGasPrices() (*ChainGasPricesResolver, bool) {
+	if r.err == nil {
+		return r.prices, true
+	}
+	return nil, false
+}
+
+func weiString(w *assets.Wei) *string {
+	if w == nil {
+		return nil
+	}
+	s := w.ToInt().String()
+	return &s
+}
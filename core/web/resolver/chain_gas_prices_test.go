@@ -0,0 +1,104 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
+	gasmocks "github.com/smartcontractkit/chainlink/core/chains/evm/gas/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+func TestResolver_ChainGasPrices(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chainID = utils.NewBigI(1)
+
+		query = `
+			query GetChainGasPrices {
+				chainGasPrices(id: "1") {
+					... on ChainGasPrices {
+						chainID
+						eip1559DynamicFees
+						gasPrice
+						bumpedGasPrice
+						feeCap
+						tipCap
+						bumpedFeeCap
+						bumpedTipCap
+						blockHistory {
+							percentile
+						}
+						errors
+					}
+					... on NotFoundError {
+						code
+						message
+					}
+				}
+			}`
+	)
+
+	testCases := []GQLTestCase{
+		unauthorizedTestCase(GQLTestCase{query: query}, "chainGasPrices"),
+		{
+			name:          "success",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				estimator := gasmocks.NewEstimator(t)
+				estimator.On("GetLegacyGas", mock.Anything, []byte(nil), uint32(500000), assets.GWei(100)).Return(assets.GWei(10), uint32(500000), nil)
+				estimator.On("BumpLegacyGas", mock.Anything, assets.GWei(10), uint32(500000), assets.GWei(100), []gas.PriorAttempt(nil)).Return(nil, uint32(0), gas.ErrBumpGasExceedsLimit)
+				estimator.On("GetDynamicFee", mock.Anything, uint32(500000), assets.GWei(100)).Return(gas.DynamicFee{TipCap: assets.GWei(2), FeeCap: assets.GWei(40)}, uint32(500000), nil)
+				estimator.On("BumpDynamicFee", mock.Anything, mock.Anything, uint32(500000), assets.GWei(100), []gas.PriorAttempt(nil)).Return(gas.DynamicFee{TipCap: assets.GWei(3), FeeCap: assets.GWei(48)}, uint32(500000), nil)
+
+				f.Mocks.scfg.On("EvmGasLimitDefault").Return(uint32(500000))
+				f.Mocks.scfg.On("EvmMaxGasPriceWei").Return(assets.GWei(100))
+				f.Mocks.scfg.On("EvmEIP1559DynamicFees").Return(true)
+				f.Mocks.txm.On("GetGasEstimator").Return(estimator)
+				f.Mocks.chain.On("Config").Return(f.Mocks.scfg)
+				f.Mocks.chain.On("TxManager").Return(f.Mocks.txm)
+				f.Mocks.chainSet.On("Get", chainID.ToInt()).Return(f.Mocks.chain, nil)
+				f.App.On("GetChains").Return(chainlink.Chains{EVM: f.Mocks.chainSet})
+			},
+			query: query,
+			result: `
+				{
+					"chainGasPrices": {
+						"chainID": "1",
+						"eip1559DynamicFees": true,
+						"gasPrice": "10000000000",
+						"bumpedGasPrice": null,
+						"feeCap": "40000000000",
+						"tipCap": "2000000000",
+						"bumpedFeeCap": "48000000000",
+						"bumpedTipCap": "3000000000",
+						"blockHistory": null,
+						"errors": ["failed to bump legacy gas price: gas bump exceeds limit"]
+					}
+				}`,
+		},
+		{
+			name:          "not found error",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.Mocks.chainSet.On("Get", chainID.ToInt()).Return(nil, errors.New("chain not found with id 1"))
+				f.App.On("GetChains").Return(chainlink.Chains{EVM: f.Mocks.chainSet})
+			},
+			query: query,
+			result: `
+				{
+					"chainGasPrices": {
+						"code": "NOT_FOUND",
+						"message": "chain not found with id 1"
+					}
+				}`,
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
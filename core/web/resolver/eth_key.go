@@ -7,6 +7,7 @@ import (
 	"github.com/graph-gophers/graphql-go"
 
 	"github.com/smartcontractkit/chainlink/core/chains/evm"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
 	"github.com/smartcontractkit/chainlink/core/web/loader"
 )
@@ -15,6 +16,7 @@ type ETHKey struct {
 	state ethkey.State
 	addr  ethkey.EIP55Address
 	chain evm.Chain
+	app   chainlink.Application
 }
 
 type ETHKeyResolver struct {
@@ -109,6 +111,20 @@ func (r *ETHKeyResolver) MaxGasPriceWei() *string {
 	return nil
 }
 
+// GasSpend resolves what the key spent on gas on its chain.
+func (r *ETHKeyResolver) GasSpend() (*GasSpendResolver, error) {
+	if r.key.chain == nil {
+		return nil, nil
+	}
+
+	spend, err := r.key.app.TxmORM().FindKeyGasSpend(r.key.state.Address.Address(), *r.key.state.EVMChainID.ToInt())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGasSpend(spend), nil
+}
+
 func (r *ETHKeyResolver) CreatedAt() graphql.Time {
 	return graphql.Time{Time: r.key.state.CreatedAt}
 }
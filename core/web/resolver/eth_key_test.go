@@ -11,6 +11,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/chains/evm"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/services/keystore/keys/ethkey"
@@ -112,6 +113,67 @@ func TestResolver_ETHKeys(t *testing.T) {
 					}
 				}`,
 		},
+		{
+			name:          "success with gas spend",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				states := []ethkey.State{
+					{
+						Address:    ethkey.MustEIP55Address(address.Hex()),
+						EVMChainID: *utils.NewBigI(12),
+						Disabled:   false,
+						CreatedAt:  f.Timestamp(),
+						UpdatedAt:  f.Timestamp(),
+					},
+				}
+				chainID := *utils.NewBigI(12)
+
+				f.App.On("GetConfig").Return(f.Mocks.cfg).Maybe()
+				f.Mocks.ethKs.On("GetStatesForKeys", keys).Return(states, nil)
+				f.Mocks.ethKs.On("Get", keys[0].Address.Hex()).Return(keys[0], nil)
+				f.Mocks.ethKs.On("GetAll").Return(keys, nil)
+				f.Mocks.chainSet.On("Get", states[0].EVMChainID.ToInt()).Return(f.Mocks.chain, nil)
+				f.Mocks.evmORM.PutChains(types.DBChain{ID: chainID})
+				f.Mocks.keystore.On("Eth").Return(f.Mocks.ethKs)
+				f.Mocks.txmORM.On("FindKeyGasSpend", address, *chainID.ToInt()).Return(txmgr.GasSpend{
+					EVMChainID: *chainID.ToInt(),
+					Hourly:     assets.GWei(2),
+					Daily:      assets.GWei(30),
+				}, nil)
+				f.App.On("GetKeyStore").Return(f.Mocks.keystore)
+				f.App.On("EVMORM").Return(f.Mocks.evmORM)
+				f.App.On("GetChains").Return(chainlink.Chains{EVM: f.Mocks.chainSet})
+				f.App.On("TxmORM").Return(f.Mocks.txmORM)
+			},
+			query: `
+				query GetETHKeys {
+					ethKeys {
+						results {
+							address
+							gasSpend {
+								evmChainID
+								hourly
+								daily
+							}
+						}
+					}
+				}`,
+			result: `
+				{
+					"ethKeys": {
+						"results": [
+							{
+								"address": "0x5431F5F973781809D18643b87B44921b11355d81",
+								"gasSpend": {
+									"evmChainID": "12",
+									"hourly": "2000000000",
+									"daily": "30000000000"
+								}
+							}
+						]
+					}
+				}`,
+		},
 		{
 			name:          "success with no chains",
 			authenticated: true,
@@ -144,6 +144,113 @@ func (r *EthTransactionPayloadResolver) ToEthTransaction() (*EthTransactionResol
 	return NewEthTransaction(*r.tx), true
 }
 
+// -- CancelEthTransaction Mutation --
+
+type CancelEthTransactionPayloadResolver struct {
+	tx         *txmgr.EthTx
+	replaceErr error
+	NotFoundErrorUnionType
+}
+
+func NewCancelEthTransactionPayload(tx *txmgr.EthTx, replaceErr error, err error) *CancelEthTransactionPayloadResolver {
+	e := NotFoundErrorUnionType{err: err, message: "transaction not found", isExpectedErrorFn: nil}
+
+	return &CancelEthTransactionPayloadResolver{tx: tx, replaceErr: replaceErr, NotFoundErrorUnionType: e}
+}
+
+func (r *CancelEthTransactionPayloadResolver) ToCancelEthTransactionSuccess() (*CancelEthTransactionSuccessResolver, bool) {
+	if r.tx == nil {
+		return nil, false
+	}
+
+	return &CancelEthTransactionSuccessResolver{tx: *r.tx}, true
+}
+
+func (r *CancelEthTransactionPayloadResolver) ToEthTransactionCannotReplaceError() (*EthTransactionCannotReplaceErrorResolver, bool) {
+	if r.replaceErr == nil {
+		return nil, false
+	}
+
+	return NewEthTransactionCannotReplaceError(r.replaceErr), true
+}
+
+type CancelEthTransactionSuccessResolver struct {
+	tx txmgr.EthTx
+}
+
+func (r *CancelEthTransactionSuccessResolver) Transaction() *EthTransactionResolver {
+	return NewEthTransaction(r.tx)
+}
+
+// -- BumpEthTransaction Mutation --
+
+type BumpEthTransactionPayloadResolver struct {
+	tx         *txmgr.EthTx
+	inputErrs  map[string]string
+	replaceErr error
+	NotFoundErrorUnionType
+}
+
+func NewBumpEthTransactionPayload(tx *txmgr.EthTx, inputErrs map[string]string, replaceErr error, err error) *BumpEthTransactionPayloadResolver {
+	e := NotFoundErrorUnionType{err: err, message: "transaction not found", isExpectedErrorFn: nil}
+
+	return &BumpEthTransactionPayloadResolver{tx: tx, inputErrs: inputErrs, replaceErr: replaceErr, NotFoundErrorUnionType: e}
+}
+
+func (r *BumpEthTransactionPayloadResolver) ToBumpEthTransactionSuccess() (*BumpEthTransactionSuccessResolver, bool) {
+	if r.tx == nil {
+		return nil, false
+	}
+
+	return &BumpEthTransactionSuccessResolver{tx: *r.tx}, true
+}
+
+func (r *BumpEthTransactionPayloadResolver) ToInputErrors() (*InputErrorsResolver, bool) {
+	if r.inputErrs == nil {
+		return nil, false
+	}
+
+	var errs []*InputErrorResolver
+	for path, message := range r.inputErrs {
+		errs = append(errs, NewInputError(path, message))
+	}
+
+	return NewInputErrors(errs), true
+}
+
+func (r *BumpEthTransactionPayloadResolver) ToEthTransactionCannotReplaceError() (*EthTransactionCannotReplaceErrorResolver, bool) {
+	if r.replaceErr == nil {
+		return nil, false
+	}
+
+	return NewEthTransactionCannotReplaceError(r.replaceErr), true
+}
+
+type BumpEthTransactionSuccessResolver struct {
+	tx txmgr.EthTx
+}
+
+func (r *BumpEthTransactionSuccessResolver) Transaction() *EthTransactionResolver {
+	return NewEthTransaction(r.tx)
+}
+
+type EthTransactionCannotReplaceErrorResolver struct {
+	message string
+	code    ErrorCode
+}
+
+func NewEthTransactionCannotReplaceError(err error) *EthTransactionCannotReplaceErrorResolver {
+	return &EthTransactionCannotReplaceErrorResolver{message: err.Error(), code: ErrorCodeUnprocessable}
+}
+
+func (r *EthTransactionCannotReplaceErrorResolver) Code() ErrorCode {
+	return r.code
+}
+
+func (r *EthTransactionCannotReplaceErrorResolver) Message() string {
+	return r.message
+}
+
 // -- EthTransactions Query --
 
 type EthTransactionsPayloadResolver struct {
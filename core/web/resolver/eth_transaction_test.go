@@ -3,14 +3,17 @@ package resolver
 import (
 	"database/sql"
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
 	"github.com/smartcontractkit/chainlink/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
@@ -419,3 +422,178 @@ func TestResolver_EthTransactionsAttempts(t *testing.T) {
 
 	RunGQLTests(t, testCases)
 }
+
+func TestResolver_CancelEthTransaction(t *testing.T) {
+	t.Parallel()
+
+	mutation := `
+		mutation CancelEthTransaction($hash: ID!) {
+			cancelEthTransaction(hash: $hash) {
+				... on CancelEthTransactionSuccess {
+					transaction {
+						state
+						evmChainID
+					}
+				}
+				... on NotFoundError {
+					code
+					message
+				}
+				... on EthTransactionCannotReplaceError {
+					code
+					message
+				}
+			}
+		}`
+	variables := map[string]interface{}{
+		"hash": "0x5431F5F973781809D18643b87B44921b11355d81",
+	}
+	hash := common.HexToHash("0x5431F5F973781809D18643b87B44921b11355d81")
+	etx := txmgr.EthTx{ID: 1, State: txmgr.EthTxUnconfirmed, EVMChainID: *utils.NewBigI(22)}
+
+	before := func(f *gqlTestFramework) {
+		f.Mocks.txmORM.On("FindEthTxByHash", hash).Return(&etx, nil)
+		f.App.On("TxmORM").Return(f.Mocks.txmORM)
+		f.Mocks.chain.On("TxManager").Return(f.Mocks.txm)
+		f.Mocks.chainSet.On("Get", big.NewInt(22)).Return(f.Mocks.chain, nil)
+		f.App.On("GetChains").Return(chainlink.Chains{EVM: f.Mocks.chainSet})
+	}
+
+	testCases := []GQLTestCase{
+		unauthorizedTestCase(GQLTestCase{query: mutation, variables: variables}, "cancelEthTransaction"),
+		{
+			name:          "success",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				before(f)
+				f.Mocks.txm.On("CancelEthTx", mock.Anything, int64(1)).Return(etx, nil)
+			},
+			query:     mutation,
+			variables: variables,
+			result: `
+				{
+					"cancelEthTransaction": {
+						"transaction": {
+							"state": "unconfirmed",
+							"evmChainID": "22"
+						}
+					}
+				}`,
+		},
+		{
+			name:          "not found",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.Mocks.txmORM.On("FindEthTxByHash", hash).Return(nil, sql.ErrNoRows)
+				f.App.On("TxmORM").Return(f.Mocks.txmORM)
+			},
+			query:     mutation,
+			variables: variables,
+			result: `
+				{
+					"cancelEthTransaction": {
+						"code": "NOT_FOUND",
+						"message": "transaction not found"
+					}
+				}`,
+		},
+		{
+			name:          "cannot replace",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				before(f)
+				f.Mocks.txm.On("CancelEthTx", mock.Anything, int64(1)).Return(txmgr.EthTx{}, errors.New("eth_tx 1 is confirmed and can no longer be replaced"))
+			},
+			query:     mutation,
+			variables: variables,
+			result: `
+				{
+					"cancelEthTransaction": {
+						"code": "UNPROCESSABLE",
+						"message": "eth_tx 1 is confirmed and can no longer be replaced"
+					}
+				}`,
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
+
+func TestResolver_BumpEthTransaction(t *testing.T) {
+	t.Parallel()
+
+	mutation := `
+		mutation BumpEthTransaction($hash: ID!, $input: BumpEthTransactionInput!) {
+			bumpEthTransaction(hash: $hash, input: $input) {
+				... on BumpEthTransactionSuccess {
+					transaction {
+						state
+					}
+				}
+				... on InputErrors {
+					errors {
+						path
+						message
+						code
+					}
+				}
+				... on EthTransactionCannotReplaceError {
+					code
+					message
+				}
+			}
+		}`
+	variables := map[string]interface{}{
+		"hash":  "0x5431F5F973781809D18643b87B44921b11355d81",
+		"input": map[string]interface{}{"gasPrice": "100 gwei"},
+	}
+	hash := common.HexToHash("0x5431F5F973781809D18643b87B44921b11355d81")
+	etx := txmgr.EthTx{ID: 1, State: txmgr.EthTxUnconfirmed, EVMChainID: *utils.NewBigI(22)}
+
+	testCases := []GQLTestCase{
+		unauthorizedTestCase(GQLTestCase{query: mutation, variables: variables}, "bumpEthTransaction"),
+		{
+			name:          "success",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.Mocks.txmORM.On("FindEthTxByHash", hash).Return(&etx, nil)
+				f.App.On("TxmORM").Return(f.Mocks.txmORM)
+				f.Mocks.chain.On("TxManager").Return(f.Mocks.txm)
+				f.Mocks.chainSet.On("Get", big.NewInt(22)).Return(f.Mocks.chain, nil)
+				f.App.On("GetChains").Return(chainlink.Chains{EVM: f.Mocks.chainSet})
+				f.Mocks.txm.On("BumpEthTx", mock.Anything, int64(1), assets.GWei(100)).Return(etx, nil)
+			},
+			query:     mutation,
+			variables: variables,
+			result: `
+				{
+					"bumpEthTransaction": {
+						"transaction": {
+							"state": "unconfirmed"
+						}
+					}
+				}`,
+		},
+		{
+			name:          "invalid gas price",
+			authenticated: true,
+			query:         mutation,
+			variables: map[string]interface{}{
+				"hash":  "0x5431F5F973781809D18643b87B44921b11355d81",
+				"input": map[string]interface{}{"gasPrice": "lots"},
+			},
+			result: `
+				{
+					"bumpEthTransaction": {
+						"errors": [{
+							"path": "gasPrice",
+							"message": "invalid gas price",
+							"code": "INVALID_INPUT"
+						}]
+					}
+				}`,
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
@@ -56,6 +56,8 @@ type GasEstimatorMode string
 const (
 	GasEstimatorModeBlockHistory GasEstimatorMode = "BLOCK_HISTORY"
 	GasEstimatorModeFixedPrice   GasEstimatorMode = "FIXED_PRICE"
+	GasEstimatorModeFeeHistory   GasEstimatorMode = "FEE_HISTORY"
+	GasEstimatorModeComposite    GasEstimatorMode = "COMPOSITE"
 	GasEstimatorModeOptimism2    GasEstimatorMode = "OPTIMISM2"
 	GasEstimatorModeL2Suggested  GasEstimatorMode = "L2_SUGGESTED"
 )
@@ -66,6 +68,10 @@ func ToGasEstimatorMode(s string) (GasEstimatorMode, error) {
 		return GasEstimatorModeBlockHistory, nil
 	case "FixedPrice":
 		return GasEstimatorModeFixedPrice, nil
+	case "FeeHistory":
+		return GasEstimatorModeFeeHistory, nil
+	case "Composite":
+		return GasEstimatorModeComposite, nil
 	case "Optimism2":
 		return GasEstimatorModeOptimism2, nil
 	case "L2Suggested":
@@ -81,6 +87,10 @@ func FromGasEstimatorMode(gsm GasEstimatorMode) string {
 		return "BlockHistory"
 	case GasEstimatorModeFixedPrice:
 		return "FixedPrice"
+	case GasEstimatorModeFeeHistory:
+		return "FeeHistory"
+	case GasEstimatorModeComposite:
+		return "Composite"
 	case GasEstimatorModeOptimism2:
 		return "Optimism2"
 	case GasEstimatorModeL2Suggested:
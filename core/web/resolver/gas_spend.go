@@ -0,0 +1,40 @@
+package resolver
+
+import (
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
+)
+
+// GasSpendResolver resolves the GasSpend type.
+type GasSpendResolver struct {
+	spend txmgr.GasSpend
+}
+
+func NewGasSpend(spend txmgr.GasSpend) *GasSpendResolver {
+	return &GasSpendResolver{spend: spend}
+}
+
+func NewGasSpends(spends []txmgr.GasSpend) []*GasSpendResolver {
+	var resolvers []*GasSpendResolver
+	for _, s := range spends {
+		resolvers = append(resolvers, NewGasSpend(s))
+	}
+
+	return resolvers
+}
+
+// EVMChainID resolves the chain of the spend.
+func (r *GasSpendResolver) EVMChainID() graphql.ID {
+	return graphql.ID(r.spend.EVMChainID.String())
+}
+
+// Hourly resolves the spend over the last hour, in wei.
+func (r *GasSpendResolver) Hourly() string {
+	return r.spend.Hourly.ToInt().String()
+}
+
+// Daily resolves the spend over the last day, in wei.
+func (r *GasSpendResolver) Daily() string {
+	return r.spend.Daily.ToInt().String()
+}
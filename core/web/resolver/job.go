@@ -49,6 +49,16 @@ func (r *JobResolver) Errors(ctx context.Context) ([]*JobErrorResolver, error) {
 	return NewJobErrors(specErrs), nil
 }
 
+// GasSpend resolves what the job spent on gas on each of its chains.
+func (r *JobResolver) GasSpend() ([]*GasSpendResolver, error) {
+	spends, err := r.app.TxmORM().FindJobGasSpends(r.j.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGasSpends(spends), nil
+}
+
 // ExternalJobID resolves the job's external job id.
 func (r *JobResolver) ExternalJobID() string {
 	return r.j.ExternalJobID.String()
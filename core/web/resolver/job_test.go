@@ -3,6 +3,7 @@ package resolver
 import (
 	"database/sql"
 	"encoding/json"
+	"math/big"
 	"testing"
 	"time"
 
@@ -13,6 +14,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"gopkg.in/guregu/null.v4"
 
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/txmgr"
 	clnull "github.com/smartcontractkit/chainlink/core/null"
 	"github.com/smartcontractkit/chainlink/core/services/directrequest"
 	"github.com/smartcontractkit/chainlink/core/services/job"
@@ -232,6 +235,57 @@ func TestResolver_Job(t *testing.T) {
 				}
 			`,
 		},
+		{
+			name:          "success with gas spend",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On("JobORM").Return(f.Mocks.jobORM)
+				f.Mocks.jobORM.On("FindJobWithoutSpecErrors", id).Return(job.Job{
+					ID:            1,
+					ExternalJobID: externalJobID,
+					Type:          job.OffchainReporting,
+					OCROracleSpec: &job.OCROracleSpec{},
+				}, nil)
+				f.App.On("TxmORM").Return(f.Mocks.txmORM)
+				f.Mocks.txmORM.On("FindJobGasSpends", id).Return([]txmgr.GasSpend{
+					{EVMChainID: *big.NewInt(1), Hourly: assets.NewWeiI(0), Daily: assets.GWei(5)},
+					{EVMChainID: *big.NewInt(137), Hourly: assets.GWei(40), Daily: assets.GWei(700)},
+				}, nil)
+			},
+			query: `
+				query GetJob {
+					job(id: "1") {
+						... on Job {
+							id
+							gasSpend {
+								evmChainID
+								hourly
+								daily
+							}
+						}
+					}
+				}
+			`,
+			result: `
+				{
+					"job": {
+						"id": "1",
+						"gasSpend": [
+							{
+								"evmChainID": "1",
+								"hourly": "0",
+								"daily": "5000000000"
+							},
+							{
+								"evmChainID": "137",
+								"hourly": "40000000000",
+								"daily": "700000000000"
+							}
+						]
+					}
+				}
+			`,
+		},
 		{
 			name:          "not found",
 			authenticated: true,
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap/zapcore"
@@ -1422,3 +1423,73 @@ func (r *Resolver) DeleteOCR2KeyBundle(ctx context.Context, args struct {
 	r.App.GetAuditLogger().Audit(audit.OCR2KeyBundleDeleted, map[string]interface{}{"id": id})
 	return NewDeleteOCR2KeyBundlePayloadResolver(&key, nil), nil
 }
+
+func (r *Resolver) CancelEthTransaction(ctx context.Context, args struct {
+	Hash graphql.ID
+}) (*CancelEthTransactionPayloadResolver, error) {
+	if err := authenticateUserIsAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	etx, err := r.App.TxmORM().FindEthTxByHash(common.HexToHash(string(args.Hash)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return NewCancelEthTransactionPayload(nil, nil, err), nil
+		}
+
+		return nil, err
+	}
+
+	chain, err := r.App.GetChains().EVM.Get(etx.EVMChainID.ToInt())
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := chain.TxManager().CancelEthTx(ctx, etx.ID)
+	if err != nil {
+		return NewCancelEthTransactionPayload(nil, err, nil), nil
+	}
+
+	r.App.GetAuditLogger().Audit(audit.EthTransactionCancelled, map[string]interface{}{"ethTX": cancelled})
+	return NewCancelEthTransactionPayload(&cancelled, nil, nil), nil
+}
+
+func (r *Resolver) BumpEthTransaction(ctx context.Context, args struct {
+	Hash  graphql.ID
+	Input struct {
+		GasPrice string
+	}
+}) (*BumpEthTransactionPayloadResolver, error) {
+	if err := authenticateUserIsAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	gasPrice := new(assets.Wei)
+	if err := gasPrice.UnmarshalText([]byte(args.Input.GasPrice)); err != nil {
+		return NewBumpEthTransactionPayload(nil, map[string]string{
+			"gasPrice": "invalid gas price",
+		}, nil, nil), nil
+	}
+
+	etx, err := r.App.TxmORM().FindEthTxByHash(common.HexToHash(string(args.Hash)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return NewBumpEthTransactionPayload(nil, nil, nil, err), nil
+		}
+
+		return nil, err
+	}
+
+	chain, err := r.App.GetChains().EVM.Get(etx.EVMChainID.ToInt())
+	if err != nil {
+		return nil, err
+	}
+
+	bumped, err := chain.TxManager().BumpEthTx(ctx, etx.ID, gasPrice)
+	if err != nil {
+		return NewBumpEthTransactionPayload(nil, nil, err, nil), nil
+	}
+
+	r.App.GetAuditLogger().Audit(audit.EthTransactionBumped, map[string]interface{}{"ethTX": bumped, "gasPrice": gasPrice})
+	return NewBumpEthTransactionPayload(&bumped, nil, nil, nil), nil
+}
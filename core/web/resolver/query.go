@@ -12,6 +12,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/bridges"
 	"github.com/smartcontractkit/chainlink/core/chains/evm"
+	"github.com/smartcontractkit/chainlink/core/chains/evm/gas"
 	"github.com/smartcontractkit/chainlink/core/config"
 	config2 "github.com/smartcontractkit/chainlink/core/config/v2"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
@@ -88,6 +89,30 @@ func (r *Resolver) Chain(ctx context.Context, args struct{ ID graphql.ID }) (*Ch
 	return NewChainPayload(chain, nil), nil
 }
 
+// ChainGasPrices retrieves what the gas estimator of an EVM chain currently
+// believes gas costs.
+func (r *Resolver) ChainGasPrices(ctx context.Context, args struct{ ID graphql.ID }) (*ChainGasPricesPayloadResolver, error) {
+	if err := authenticateUser(ctx); err != nil {
+		return nil, err
+	}
+
+	id := utils.Big{}
+	err := id.UnmarshalText([]byte(args.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := r.App.GetChains().EVM.Get(id.ToInt())
+	if err != nil {
+		return NewChainGasPricesPayload(nil, err), nil
+	}
+
+	cfg := chain.Config()
+	prices, err := gas.GetPrices(ctx, chain.TxManager().GetGasEstimator(), cfg, cfg.EvmGasLimitDefault())
+
+	return NewChainGasPricesPayload(NewChainGasPrices(id.String(), prices, err), nil), nil
+}
+
 // Chains retrieves a paginated list of chains.
 func (r *Resolver) Chains(ctx context.Context, args struct {
 	Offset *int32
@@ -417,6 +442,7 @@ func (r *Resolver) ETHKeys(ctx context.Context) (*ETHKeysPayloadResolver, error)
 			addr:  k.EIP55Address,
 			state: state,
 			chain: chain,
+			app:   r.App,
 		})
 	}
 	// Put disabled keys to the end
@@ -54,6 +54,7 @@ type mocks struct {
 	eIMgr       *webhookmocks.ExternalInitiatorManager
 	balM        *evmORMMocks.BalanceMonitor
 	txmORM      *txmgrMocks.ORM
+	txm         *txmgrMocks.TxManager
 	auditLogger *audit.AuditLoggerService
 }
 
@@ -112,6 +113,7 @@ func setupFramework(t *testing.T) *gqlTestFramework {
 		eIMgr:       webhookmocks.NewExternalInitiatorManager(t),
 		balM:        evmORMMocks.NewBalanceMonitor(t),
 		txmORM:      txmgrMocks.NewORM(t),
+		txm:         txmgrMocks.NewTxManager(t),
 		auditLogger: &audit.AuditLoggerService{},
 	}
 